@@ -0,0 +1,63 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ClusterImageMirrorSetSpecApplyConfiguration represents a declarative configuration of the ClusterImageMirrorSetSpec type for use
+// with apply.
+//
+// ClusterImageMirrorSetSpec defines the image mirrors to use.
+type ClusterImageMirrorSetSpecApplyConfiguration struct {
+	// Selects the namespaces of Packages to use the mirrors for.
+	// Mirrors are used for all Packages and ClusterPackages if unset.
+	// ClusterPackages are only selected, if no selector is set.
+	NamespaceSelector *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	// Image prefix overrides applied to package images and images used by packages.
+	// The most specific matching override is applied.
+	ImagePrefixOverrides []PackageImagePrefixOverrideApplyConfiguration `json:"imagePrefixOverrides,omitempty"`
+	// Registry host overrides applied when pulling package images.
+	RegistryHostOverrides []RegistryHostOverrideApplyConfiguration `json:"registryHostOverrides,omitempty"`
+}
+
+// ClusterImageMirrorSetSpecApplyConfiguration constructs a declarative configuration of the ClusterImageMirrorSetSpec type for use with
+// apply.
+func ClusterImageMirrorSetSpec() *ClusterImageMirrorSetSpecApplyConfiguration {
+	return &ClusterImageMirrorSetSpecApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ClusterImageMirrorSetSpecApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *ClusterImageMirrorSetSpecApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithImagePrefixOverrides adds the given value to the ImagePrefixOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePrefixOverrides field.
+func (b *ClusterImageMirrorSetSpecApplyConfiguration) WithImagePrefixOverrides(values ...*PackageImagePrefixOverrideApplyConfiguration) *ClusterImageMirrorSetSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithImagePrefixOverrides")
+		}
+		b.ImagePrefixOverrides = append(b.ImagePrefixOverrides, *values[i])
+	}
+	return b
+}
+
+// WithRegistryHostOverrides adds the given value to the RegistryHostOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RegistryHostOverrides field.
+func (b *ClusterImageMirrorSetSpecApplyConfiguration) WithRegistryHostOverrides(values ...*RegistryHostOverrideApplyConfiguration) *ClusterImageMirrorSetSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRegistryHostOverrides")
+		}
+		b.RegistryHostOverrides = append(b.RegistryHostOverrides, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,50 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ClusterImageVerificationPolicySpecApplyConfiguration represents a declarative configuration of the ClusterImageVerificationPolicySpec type for use
+// with apply.
+//
+// ClusterImageVerificationPolicySpec defines the keys package images have to be signed with.
+type ClusterImageVerificationPolicySpecApplyConfiguration struct {
+	// Selects the namespaces of Packages that have to be verified.
+	// Namespaces opt in to verification by matching the selector.
+	// All Packages and ClusterPackages are verified if unset.
+	// ClusterPackages are only selected, if no selector is set.
+	NamespaceSelector *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	// Trusted public keys.
+	// A package image is accepted, if it is signed by any of the keys
+	// of the policies selecting the Package.
+	PublicKeys []PublicKeySecretReferenceApplyConfiguration `json:"publicKeys,omitempty"`
+}
+
+// ClusterImageVerificationPolicySpecApplyConfiguration constructs a declarative configuration of the ClusterImageVerificationPolicySpec type for use with
+// apply.
+func ClusterImageVerificationPolicySpec() *ClusterImageVerificationPolicySpecApplyConfiguration {
+	return &ClusterImageVerificationPolicySpecApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ClusterImageVerificationPolicySpecApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *ClusterImageVerificationPolicySpecApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithPublicKeys adds the given value to the PublicKeys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PublicKeys field.
+func (b *ClusterImageVerificationPolicySpecApplyConfiguration) WithPublicKeys(values ...*PublicKeySecretReferenceApplyConfiguration) *ClusterImageVerificationPolicySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPublicKeys")
+		}
+		b.PublicKeys = append(b.PublicKeys, *values[i])
+	}
+	return b
+}
@@ -19,6 +19,9 @@ type ClusterObjectDeploymentSpecApplyConfiguration struct {
 	Template *ObjectSetTemplateApplyConfiguration `json:"template,omitempty"`
 	// If Paused is true, the object and its children will not be reconciled.
 	Paused *bool `json:"paused,omitempty"`
+	// ServiceAccount to impersonate when reconciling objects of new ObjectSets.
+	// Objects are reconciled with the identity of Package Operator, if unset.
+	ServiceAccount *ServiceAccountReferenceApplyConfiguration `json:"serviceAccount,omitempty"`
 }
 
 // ClusterObjectDeploymentSpecApplyConfiguration constructs a declarative configuration of the ClusterObjectDeploymentSpec type for use with
@@ -58,3 +61,11 @@ func (b *ClusterObjectDeploymentSpecApplyConfiguration) WithPaused(value bool) *
 	b.Paused = &value
 	return b
 }
+
+// WithServiceAccount sets the ServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccount field is set to the value of the last call.
+func (b *ClusterObjectDeploymentSpecApplyConfiguration) WithServiceAccount(value *ServiceAccountReferenceApplyConfiguration) *ClusterObjectDeploymentSpecApplyConfiguration {
+	b.ServiceAccount = value
+	return b
+}
@@ -13,7 +13,7 @@ import (
 //
 // ClusterObjectTemplate contain a go template of a Kubernetes manifest. The manifest is then templated with the
 // sources provided in the .Spec.Sources. The sources can come from objects from any namespace or cluster scoped
-// objects.
+// objects. With a namespace selector, the manifest is templated into every matching namespace.
 type ClusterObjectTemplateApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
@@ -0,0 +1,42 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ClusterSetPackageConfigOverlayApplyConfiguration represents a declarative configuration of the ClusterSetPackageConfigOverlay type for use
+// with apply.
+//
+// ClusterSetPackageConfigOverlay adds config to the Packages of a group of clusters,
+// e.g. region- or tier-specific values.
+type ClusterSetPackageConfigOverlayApplyConfiguration struct {
+	// ClusterSelector is a label query matching kubeconfig Secrets of clusters the overlay applies to.
+	ClusterSelector *v1.LabelSelectorApplyConfiguration `json:"clusterSelector,omitempty"`
+	// Config merged into the Package config from the template as JSON merge patch (RFC 7386).
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// ClusterSetPackageConfigOverlayApplyConfiguration constructs a declarative configuration of the ClusterSetPackageConfigOverlay type for use with
+// apply.
+func ClusterSetPackageConfigOverlay() *ClusterSetPackageConfigOverlayApplyConfiguration {
+	return &ClusterSetPackageConfigOverlayApplyConfiguration{}
+}
+
+// WithClusterSelector sets the ClusterSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterSelector field is set to the value of the last call.
+func (b *ClusterSetPackageConfigOverlayApplyConfiguration) WithClusterSelector(value *v1.LabelSelectorApplyConfiguration) *ClusterSetPackageConfigOverlayApplyConfiguration {
+	b.ClusterSelector = value
+	return b
+}
+
+// WithConfig sets the Config field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Config field is set to the value of the last call.
+func (b *ClusterSetPackageConfigOverlayApplyConfiguration) WithConfig(value runtime.RawExtension) *ClusterSetPackageConfigOverlayApplyConfiguration {
+	b.Config = &value
+	return b
+}
@@ -0,0 +1,91 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ClusterSetPackageSpecApplyConfiguration represents a declarative configuration of the ClusterSetPackageSpec type for use
+// with apply.
+//
+// ClusterSetPackageSpec is the description of a ClusterSetPackage.
+type ClusterSetPackageSpecApplyConfiguration struct {
+	Strategy *HostedClusterPackageStrategyApplyConfiguration `json:"strategy,omitempty"`
+	// ClusterSelector is a label query matching kubeconfig Secrets of clusters that the Package should be rolled out to.
+	// Only Secrets in the Package Operator namespace with the
+	// "package-operator.run/cluster-kubeconfig" label are considered.
+	ClusterSelector *v1.LabelSelectorApplyConfiguration `json:"clusterSelector,omitempty"`
+	// Namespace on the target clusters to create the Package in.
+	// The namespace is created, if it does not exist.
+	Namespace *string `json:"namespace,omitempty"`
+	// Template describes the Package that should be created when new
+	// clusters matching the clusterSelector are registered.
+	Template *PackageTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	// Partition clusters by the label value of their kubeconfig Secret.
+	// All packages in the same partition will have to be upgraded
+	// before progressing to the next partition.
+	Partition *HostedClusterPackagePartitionSpecApplyConfiguration `json:"partition,omitempty"`
+	// ConfigOverlays are merged into the config of Packages for clusters matching their selector.
+	// Overlays are applied in order, so later overlays take precedence.
+	ConfigOverlays []ClusterSetPackageConfigOverlayApplyConfiguration `json:"configOverlays,omitempty"`
+}
+
+// ClusterSetPackageSpecApplyConfiguration constructs a declarative configuration of the ClusterSetPackageSpec type for use with
+// apply.
+func ClusterSetPackageSpec() *ClusterSetPackageSpecApplyConfiguration {
+	return &ClusterSetPackageSpecApplyConfiguration{}
+}
+
+// WithStrategy sets the Strategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Strategy field is set to the value of the last call.
+func (b *ClusterSetPackageSpecApplyConfiguration) WithStrategy(value *HostedClusterPackageStrategyApplyConfiguration) *ClusterSetPackageSpecApplyConfiguration {
+	b.Strategy = value
+	return b
+}
+
+// WithClusterSelector sets the ClusterSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterSelector field is set to the value of the last call.
+func (b *ClusterSetPackageSpecApplyConfiguration) WithClusterSelector(value *v1.LabelSelectorApplyConfiguration) *ClusterSetPackageSpecApplyConfiguration {
+	b.ClusterSelector = value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ClusterSetPackageSpecApplyConfiguration) WithNamespace(value string) *ClusterSetPackageSpecApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *ClusterSetPackageSpecApplyConfiguration) WithTemplate(value *PackageTemplateSpecApplyConfiguration) *ClusterSetPackageSpecApplyConfiguration {
+	b.Template = value
+	return b
+}
+
+// WithPartition sets the Partition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Partition field is set to the value of the last call.
+func (b *ClusterSetPackageSpecApplyConfiguration) WithPartition(value *HostedClusterPackagePartitionSpecApplyConfiguration) *ClusterSetPackageSpecApplyConfiguration {
+	b.Partition = value
+	return b
+}
+
+// WithConfigOverlays adds the given value to the ConfigOverlays field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConfigOverlays field.
+func (b *ClusterSetPackageSpecApplyConfiguration) WithConfigOverlays(values ...*ClusterSetPackageConfigOverlayApplyConfiguration) *ClusterSetPackageSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConfigOverlays")
+		}
+		b.ConfigOverlays = append(b.ConfigOverlays, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,42 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// HostedClusterPackageConfigOverlayApplyConfiguration represents a declarative configuration of the HostedClusterPackageConfigOverlay type for use
+// with apply.
+//
+// HostedClusterPackageConfigOverlay adds config to the Packages of a group of HostedClusters,
+// e.g. region- or tier-specific values.
+type HostedClusterPackageConfigOverlayApplyConfiguration struct {
+	// HostedClusterSelector is a label query matching HostedClusters the overlay applies to.
+	HostedClusterSelector *v1.LabelSelectorApplyConfiguration `json:"hostedClusterSelector,omitempty"`
+	// Config merged into the Package config from the template as JSON merge patch (RFC 7386).
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// HostedClusterPackageConfigOverlayApplyConfiguration constructs a declarative configuration of the HostedClusterPackageConfigOverlay type for use with
+// apply.
+func HostedClusterPackageConfigOverlay() *HostedClusterPackageConfigOverlayApplyConfiguration {
+	return &HostedClusterPackageConfigOverlayApplyConfiguration{}
+}
+
+// WithHostedClusterSelector sets the HostedClusterSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostedClusterSelector field is set to the value of the last call.
+func (b *HostedClusterPackageConfigOverlayApplyConfiguration) WithHostedClusterSelector(value *v1.LabelSelectorApplyConfiguration) *HostedClusterPackageConfigOverlayApplyConfiguration {
+	b.HostedClusterSelector = value
+	return b
+}
+
+// WithConfig sets the Config field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Config field is set to the value of the last call.
+func (b *HostedClusterPackageConfigOverlayApplyConfiguration) WithConfig(value runtime.RawExtension) *HostedClusterPackageConfigOverlayApplyConfiguration {
+	b.Config = &value
+	return b
+}
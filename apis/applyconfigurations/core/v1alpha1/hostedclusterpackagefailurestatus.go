@@ -0,0 +1,60 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HostedClusterPackageFailureStatusApplyConfiguration represents a declarative configuration of the HostedClusterPackageFailureStatus type for use
+// with apply.
+//
+// HostedClusterPackageFailureStatus describes a HostedCluster with a Package that failed to become Available.
+type HostedClusterPackageFailureStatusApplyConfiguration struct {
+	// Name of the HostedCluster.
+	Name *string `json:"name,omitempty"`
+	// Namespace of the HostedCluster.
+	Namespace *string `json:"namespace,omitempty"`
+	// Time the Package was updated.
+	UpdatedAt *v1.Time `json:"updatedAt,omitempty"`
+	// Message of the Available condition of the Package.
+	Message *string `json:"message,omitempty"`
+}
+
+// HostedClusterPackageFailureStatusApplyConfiguration constructs a declarative configuration of the HostedClusterPackageFailureStatus type for use with
+// apply.
+func HostedClusterPackageFailureStatus() *HostedClusterPackageFailureStatusApplyConfiguration {
+	return &HostedClusterPackageFailureStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *HostedClusterPackageFailureStatusApplyConfiguration) WithName(value string) *HostedClusterPackageFailureStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *HostedClusterPackageFailureStatusApplyConfiguration) WithNamespace(value string) *HostedClusterPackageFailureStatusApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithUpdatedAt sets the UpdatedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdatedAt field is set to the value of the last call.
+func (b *HostedClusterPackageFailureStatusApplyConfiguration) WithUpdatedAt(value v1.Time) *HostedClusterPackageFailureStatusApplyConfiguration {
+	b.UpdatedAt = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *HostedClusterPackageFailureStatusApplyConfiguration) WithMessage(value string) *HostedClusterPackageFailureStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,28 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// HostedClusterPackagePartitionCanarySpecApplyConfiguration represents a declarative configuration of the HostedClusterPackagePartitionCanarySpec type for use
+// with apply.
+//
+// HostedClusterPackagePartitionCanarySpec describes the canary partition.
+// The canary partition is split off the first partition and is upgraded before any other partition.
+type HostedClusterPackagePartitionCanarySpecApplyConfiguration struct {
+	// Number of HostedClusters in the canary partition.
+	// HostedClusters are picked from the first partition ordered by namespace and name.
+	HostedClusters *int32 `json:"hostedClusters,omitempty"`
+}
+
+// HostedClusterPackagePartitionCanarySpecApplyConfiguration constructs a declarative configuration of the HostedClusterPackagePartitionCanarySpec type for use with
+// apply.
+func HostedClusterPackagePartitionCanarySpec() *HostedClusterPackagePartitionCanarySpecApplyConfiguration {
+	return &HostedClusterPackagePartitionCanarySpecApplyConfiguration{}
+}
+
+// WithHostedClusters sets the HostedClusters field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostedClusters field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionCanarySpecApplyConfiguration) WithHostedClusters(value int32) *HostedClusterPackagePartitionCanarySpecApplyConfiguration {
+	b.HostedClusters = &value
+	return b
+}
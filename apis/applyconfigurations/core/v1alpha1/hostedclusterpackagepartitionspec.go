@@ -16,6 +16,11 @@ type HostedClusterPackagePartitionSpecApplyConfiguration struct {
 	// Controls how partitions are ordered.
 	// By default items will be sorted AlphaNumeric ascending.
 	Order *HostedClusterPackagePartitionOrderSpecApplyConfiguration `json:"order,omitempty"`
+	// SoakSeconds is the time all Packages of a partition have to be updated and Available,
+	// before upgrades in the next partition start.
+	SoakSeconds *int32 `json:"soakSeconds,omitempty"`
+	// Canary upgrades a few HostedClusters of the first partition before all others.
+	Canary *HostedClusterPackagePartitionCanarySpecApplyConfiguration `json:"canary,omitempty"`
 }
 
 // HostedClusterPackagePartitionSpecApplyConfiguration constructs a declarative configuration of the HostedClusterPackagePartitionSpec type for use with
@@ -39,3 +44,19 @@ func (b *HostedClusterPackagePartitionSpecApplyConfiguration) WithOrder(value *H
 	b.Order = value
 	return b
 }
+
+// WithSoakSeconds sets the SoakSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SoakSeconds field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionSpecApplyConfiguration) WithSoakSeconds(value int32) *HostedClusterPackagePartitionSpecApplyConfiguration {
+	b.SoakSeconds = &value
+	return b
+}
+
+// WithCanary sets the Canary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Canary field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionSpecApplyConfiguration) WithCanary(value *HostedClusterPackagePartitionCanarySpecApplyConfiguration) *HostedClusterPackagePartitionSpecApplyConfiguration {
+	b.Canary = value
+	return b
+}
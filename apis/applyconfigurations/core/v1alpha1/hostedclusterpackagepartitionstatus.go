@@ -2,6 +2,10 @@
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // HostedClusterPackagePartitionStatusApplyConfiguration represents a declarative configuration of the HostedClusterPackagePartitionStatus type for use
 // with apply.
 //
@@ -9,7 +13,20 @@ package v1alpha1
 type HostedClusterPackagePartitionStatusApplyConfiguration struct {
 	HostedClusterPackageCountsStatusApplyConfiguration `json:",inline"`
 	// Name of the partition.
+	// The partition of HostedClusters without label is named "*".
 	Name *string `json:"name,omitempty"`
+	// Canary is true for the canary partition split off the named partition.
+	Canary *bool `json:"canary,omitempty"`
+	// Members of the canary partition, picked when upgrades of a generation start
+	// and kept until the next generation, even if other targets come and go.
+	Members []HostedClusterPackageRefStatusApplyConfiguration `json:"members,omitempty"`
+	// Time the first Package of the partition was updated.
+	UpdateStartedAt *v1.Time `json:"updateStartedAt,omitempty"`
+	// Time since all Packages of the partition are updated and Available.
+	AvailableSince *v1.Time `json:"availableSince,omitempty"`
+	// Time the partition finished soaking.
+	// Upgrades in the next partition start afterwards.
+	SoakedAt *v1.Time `json:"soakedAt,omitempty"`
 }
 
 // HostedClusterPackagePartitionStatusApplyConfiguration constructs a declarative configuration of the HostedClusterPackagePartitionStatus type for use with
@@ -65,3 +82,48 @@ func (b *HostedClusterPackagePartitionStatusApplyConfiguration) WithName(value s
 	b.Name = &value
 	return b
 }
+
+// WithCanary sets the Canary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Canary field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionStatusApplyConfiguration) WithCanary(value bool) *HostedClusterPackagePartitionStatusApplyConfiguration {
+	b.Canary = &value
+	return b
+}
+
+// WithMembers adds the given value to the Members field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Members field.
+func (b *HostedClusterPackagePartitionStatusApplyConfiguration) WithMembers(values ...*HostedClusterPackageRefStatusApplyConfiguration) *HostedClusterPackagePartitionStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMembers")
+		}
+		b.Members = append(b.Members, *values[i])
+	}
+	return b
+}
+
+// WithUpdateStartedAt sets the UpdateStartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateStartedAt field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionStatusApplyConfiguration) WithUpdateStartedAt(value v1.Time) *HostedClusterPackagePartitionStatusApplyConfiguration {
+	b.UpdateStartedAt = &value
+	return b
+}
+
+// WithAvailableSince sets the AvailableSince field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AvailableSince field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionStatusApplyConfiguration) WithAvailableSince(value v1.Time) *HostedClusterPackagePartitionStatusApplyConfiguration {
+	b.AvailableSince = &value
+	return b
+}
+
+// WithSoakedAt sets the SoakedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SoakedAt field is set to the value of the last call.
+func (b *HostedClusterPackagePartitionStatusApplyConfiguration) WithSoakedAt(value v1.Time) *HostedClusterPackagePartitionStatusApplyConfiguration {
+	b.SoakedAt = &value
+	return b
+}
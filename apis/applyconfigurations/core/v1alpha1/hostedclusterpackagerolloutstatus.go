@@ -0,0 +1,87 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// HostedClusterPackageRolloutStatusApplyConfiguration represents a declarative configuration of the HostedClusterPackageRolloutStatus type for use
+// with apply.
+//
+// HostedClusterPackageRolloutStatus describes the state of a rolling upgrade.
+type HostedClusterPackageRolloutStatusApplyConfiguration struct {
+	// Generation of the HostedClusterPackage the rollout applies to.
+	// Any change to the spec starts a new rollout.
+	Generation *int64 `json:"generation,omitempty"`
+	// State of the rollout.
+	State *corev1alpha1.HostedClusterPackageRolloutState `json:"state,omitempty"`
+	// Only Packages updated after the rollout was resumed count towards the failure threshold.
+	ResumedAt *v1.Time `json:"resumedAt,omitempty"`
+	// Value of the resume-rollout annotation handled last.
+	LastHandledResume *string `json:"lastHandledResume,omitempty"`
+	// Value of the abort-rollout annotation handled last.
+	LastHandledAbort *string `json:"lastHandledAbort,omitempty"`
+	// HostedClusters with updated Packages that did not become Available within the progress deadline.
+	Failed []HostedClusterPackageFailureStatusApplyConfiguration `json:"failed,omitempty"`
+}
+
+// HostedClusterPackageRolloutStatusApplyConfiguration constructs a declarative configuration of the HostedClusterPackageRolloutStatus type for use with
+// apply.
+func HostedClusterPackageRolloutStatus() *HostedClusterPackageRolloutStatusApplyConfiguration {
+	return &HostedClusterPackageRolloutStatusApplyConfiguration{}
+}
+
+// WithGeneration sets the Generation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Generation field is set to the value of the last call.
+func (b *HostedClusterPackageRolloutStatusApplyConfiguration) WithGeneration(value int64) *HostedClusterPackageRolloutStatusApplyConfiguration {
+	b.Generation = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *HostedClusterPackageRolloutStatusApplyConfiguration) WithState(value corev1alpha1.HostedClusterPackageRolloutState) *HostedClusterPackageRolloutStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithResumedAt sets the ResumedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResumedAt field is set to the value of the last call.
+func (b *HostedClusterPackageRolloutStatusApplyConfiguration) WithResumedAt(value v1.Time) *HostedClusterPackageRolloutStatusApplyConfiguration {
+	b.ResumedAt = &value
+	return b
+}
+
+// WithLastHandledResume sets the LastHandledResume field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastHandledResume field is set to the value of the last call.
+func (b *HostedClusterPackageRolloutStatusApplyConfiguration) WithLastHandledResume(value string) *HostedClusterPackageRolloutStatusApplyConfiguration {
+	b.LastHandledResume = &value
+	return b
+}
+
+// WithLastHandledAbort sets the LastHandledAbort field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastHandledAbort field is set to the value of the last call.
+func (b *HostedClusterPackageRolloutStatusApplyConfiguration) WithLastHandledAbort(value string) *HostedClusterPackageRolloutStatusApplyConfiguration {
+	b.LastHandledAbort = &value
+	return b
+}
+
+// WithFailed adds the given value to the Failed field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Failed field.
+func (b *HostedClusterPackageRolloutStatusApplyConfiguration) WithFailed(values ...*HostedClusterPackageFailureStatusApplyConfiguration) *HostedClusterPackageRolloutStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFailed")
+		}
+		b.Failed = append(b.Failed, *values[i])
+	}
+	return b
+}
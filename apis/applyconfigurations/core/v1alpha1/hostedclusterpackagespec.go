@@ -21,6 +21,9 @@ type HostedClusterPackageSpecApplyConfiguration struct {
 	// All packages in the same partition will have to be upgraded
 	// before progressing to the next partition.
 	Partition *HostedClusterPackagePartitionSpecApplyConfiguration `json:"partition,omitempty"`
+	// ConfigOverlays are merged into the config of Packages for HostedClusters matching their selector.
+	// Overlays are applied in order, so later overlays take precedence.
+	ConfigOverlays []HostedClusterPackageConfigOverlayApplyConfiguration `json:"configOverlays,omitempty"`
 }
 
 // HostedClusterPackageSpecApplyConfiguration constructs a declarative configuration of the HostedClusterPackageSpec type for use with
@@ -60,3 +63,16 @@ func (b *HostedClusterPackageSpecApplyConfiguration) WithPartition(value *Hosted
 	b.Partition = value
 	return b
 }
+
+// WithConfigOverlays adds the given value to the ConfigOverlays field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConfigOverlays field.
+func (b *HostedClusterPackageSpecApplyConfiguration) WithConfigOverlays(values ...*HostedClusterPackageConfigOverlayApplyConfiguration) *HostedClusterPackageSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConfigOverlays")
+		}
+		b.ConfigOverlays = append(b.ConfigOverlays, *values[i])
+	}
+	return b
+}
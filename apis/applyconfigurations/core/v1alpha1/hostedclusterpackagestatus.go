@@ -18,6 +18,8 @@ type HostedClusterPackageStatusApplyConfiguration struct {
 	Partitions []HostedClusterPackagePartitionStatusApplyConfiguration `json:"partitions,omitempty"`
 	// Processing set of packages during upgrade.
 	Processing []HostedClusterPackageRefStatusApplyConfiguration `json:"processing,omitempty"`
+	// Rollout describes the state of a rolling upgrade.
+	Rollout *HostedClusterPackageRolloutStatusApplyConfiguration `json:"rollout,omitempty"`
 }
 
 // HostedClusterPackageStatusApplyConfiguration constructs a declarative configuration of the HostedClusterPackageStatus type for use with
@@ -104,3 +106,11 @@ func (b *HostedClusterPackageStatusApplyConfiguration) WithProcessing(values ...
 	}
 	return b
 }
+
+// WithRollout sets the Rollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollout field is set to the value of the last call.
+func (b *HostedClusterPackageStatusApplyConfiguration) WithRollout(value *HostedClusterPackageRolloutStatusApplyConfiguration) *HostedClusterPackageStatusApplyConfiguration {
+	b.Rollout = value
+	return b
+}
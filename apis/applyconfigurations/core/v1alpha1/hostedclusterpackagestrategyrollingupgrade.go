@@ -2,6 +2,10 @@
 
 package v1alpha1
 
+import (
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
 // HostedClusterPackageStrategyRollingUpgradeApplyConfiguration represents a declarative configuration of the HostedClusterPackageStrategyRollingUpgrade type for use
 // with apply.
 //
@@ -11,6 +15,13 @@ type HostedClusterPackageStrategyRollingUpgradeApplyConfiguration struct {
 	// MaxUnavailable defines how many Packages may become unavailable during upgrade at the same time.
 	// Cannot be below 1, because we cannot surge to create more instances.
 	MaxUnavailable *int `json:"maxUnavailable,omitempty"`
+	// FailureThreshold is the number or percentage of updated Packages that may fail,
+	// before the rollout is halted. Percentages are relative to the number of targeted HostedClusters.
+	// The rollout is never halted automatically, if unset.
+	FailureThreshold *intstr.IntOrString `json:"failureThreshold,omitempty"`
+	// ProgressDeadlineSeconds is the time an updated Package has to become Available,
+	// before it is considered failed.
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
 }
 
 // HostedClusterPackageStrategyRollingUpgradeApplyConfiguration constructs a declarative configuration of the HostedClusterPackageStrategyRollingUpgrade type for use with
@@ -26,3 +37,19 @@ func (b *HostedClusterPackageStrategyRollingUpgradeApplyConfiguration) WithMaxUn
 	b.MaxUnavailable = &value
 	return b
 }
+
+// WithFailureThreshold sets the FailureThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureThreshold field is set to the value of the last call.
+func (b *HostedClusterPackageStrategyRollingUpgradeApplyConfiguration) WithFailureThreshold(value intstr.IntOrString) *HostedClusterPackageStrategyRollingUpgradeApplyConfiguration {
+	b.FailureThreshold = &value
+	return b
+}
+
+// WithProgressDeadlineSeconds sets the ProgressDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProgressDeadlineSeconds field is set to the value of the last call.
+func (b *HostedClusterPackageStrategyRollingUpgradeApplyConfiguration) WithProgressDeadlineSeconds(value int32) *HostedClusterPackageStrategyRollingUpgradeApplyConfiguration {
+	b.ProgressDeadlineSeconds = &value
+	return b
+}
@@ -18,6 +18,9 @@ type ObjectSetSpecApplyConfiguration struct {
 	Previous []PreviousRevisionReferenceApplyConfiguration `json:"previous,omitempty"`
 	// Computed revision number, monotonically increasing.
 	Revision *int64 `json:"revision,omitempty"`
+	// ServiceAccount to impersonate when reconciling objects.
+	// Objects are reconciled with the identity of Package Operator, if unset.
+	ServiceAccount *ServiceAccountReferenceApplyConfiguration `json:"serviceAccount,omitempty"`
 }
 
 // ObjectSetSpecApplyConfiguration constructs a declarative configuration of the ObjectSetSpec type for use with
@@ -88,3 +91,11 @@ func (b *ObjectSetSpecApplyConfiguration) WithRevision(value int64) *ObjectSetSp
 	b.Revision = &value
 	return b
 }
+
+// WithServiceAccount sets the ServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccount field is set to the value of the last call.
+func (b *ObjectSetSpecApplyConfiguration) WithServiceAccount(value *ServiceAccountReferenceApplyConfiguration) *ObjectSetSpecApplyConfiguration {
+	b.ServiceAccount = value
+	return b
+}
@@ -13,8 +13,11 @@ type ObjectSetTemplatePhaseApplyConfiguration struct {
 	// to another controller, by creating an ObjectSetPhase object. If set to the
 	// string "default" the built-in Package Operator ObjectSetPhase controller
 	// will reconcile the object in the same way the ObjectSet would. If set to
-	// any other string, an out-of-tree controller needs to be present to handle
-	// ObjectSetPhase objects.
+	// the string "kubeconfig-secret" the built-in controller will reconcile the
+	// objects against the cluster referenced by the
+	// "package-operator.run/target-cluster-kubeconfig-secret" annotation.
+	// If set to any other string, an out-of-tree controller needs to be present
+	// to handle ObjectSetPhase objects.
 	Class *string `json:"class,omitempty"`
 	// Objects belonging to this phase.
 	Objects []ObjectSetObjectApplyConfiguration `json:"objects,omitempty"`
@@ -0,0 +1,36 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ObjectTemplateNamespaceFailureApplyConfiguration represents a declarative configuration of the ObjectTemplateNamespaceFailure type for use
+// with apply.
+//
+// ObjectTemplateNamespaceFailure describes why objects could not be templated into a namespace.
+type ObjectTemplateNamespaceFailureApplyConfiguration struct {
+	// Name of the namespace.
+	Name *string `json:"name,omitempty"`
+	// Message describing the failure.
+	Message *string `json:"message,omitempty"`
+}
+
+// ObjectTemplateNamespaceFailureApplyConfiguration constructs a declarative configuration of the ObjectTemplateNamespaceFailure type for use with
+// apply.
+func ObjectTemplateNamespaceFailure() *ObjectTemplateNamespaceFailureApplyConfiguration {
+	return &ObjectTemplateNamespaceFailureApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ObjectTemplateNamespaceFailureApplyConfiguration) WithName(value string) *ObjectTemplateNamespaceFailureApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ObjectTemplateNamespaceFailureApplyConfiguration) WithMessage(value string) *ObjectTemplateNamespaceFailureApplyConfiguration {
+	b.Message = &value
+	return b
+}
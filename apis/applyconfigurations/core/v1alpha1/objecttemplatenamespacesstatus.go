@@ -0,0 +1,51 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ObjectTemplateNamespacesStatusApplyConfiguration represents a declarative configuration of the ObjectTemplateNamespacesStatus type for use
+// with apply.
+//
+// ObjectTemplateNamespacesStatus summarizes the namespaces objects are templated into.
+type ObjectTemplateNamespacesStatusApplyConfiguration struct {
+	// Number of namespaces matching the namespaceSelector.
+	Selected *int32 `json:"selected,omitempty"`
+	// Number of namespaces all objects have been templated into.
+	Ready *int32 `json:"ready,omitempty"`
+	// Namespaces objects could not be templated into.
+	Failed []ObjectTemplateNamespaceFailureApplyConfiguration `json:"failed,omitempty"`
+}
+
+// ObjectTemplateNamespacesStatusApplyConfiguration constructs a declarative configuration of the ObjectTemplateNamespacesStatus type for use with
+// apply.
+func ObjectTemplateNamespacesStatus() *ObjectTemplateNamespacesStatusApplyConfiguration {
+	return &ObjectTemplateNamespacesStatusApplyConfiguration{}
+}
+
+// WithSelected sets the Selected field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selected field is set to the value of the last call.
+func (b *ObjectTemplateNamespacesStatusApplyConfiguration) WithSelected(value int32) *ObjectTemplateNamespacesStatusApplyConfiguration {
+	b.Selected = &value
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *ObjectTemplateNamespacesStatusApplyConfiguration) WithReady(value int32) *ObjectTemplateNamespacesStatusApplyConfiguration {
+	b.Ready = &value
+	return b
+}
+
+// WithFailed adds the given value to the Failed field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Failed field.
+func (b *ObjectTemplateNamespacesStatusApplyConfiguration) WithFailed(values ...*ObjectTemplateNamespaceFailureApplyConfiguration) *ObjectTemplateNamespacesStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFailed")
+		}
+		b.Failed = append(b.Failed, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// ObjectTemplateObservedSourceApplyConfiguration represents a declarative configuration of the ObjectTemplateObservedSource type for use
+// with apply.
+//
+// ObjectTemplateObservedSource references a source object and the version of it used to render the template.
+type ObjectTemplateObservedSourceApplyConfiguration struct {
+	// API version of the source object.
+	APIVersion *string `json:"apiVersion,omitempty"`
+	// Kind of the source object.
+	Kind *string `json:"kind,omitempty"`
+	// Namespace of the source object.
+	Namespace *string `json:"namespace,omitempty"`
+	// Name of the source object.
+	Name *string `json:"name,omitempty"`
+	// UID of the source object.
+	UID *types.UID `json:"uid,omitempty"`
+	// ResourceVersion of the source object.
+	ResourceVersion *string `json:"resourceVersion,omitempty"`
+}
+
+// ObjectTemplateObservedSourceApplyConfiguration constructs a declarative configuration of the ObjectTemplateObservedSource type for use with
+// apply.
+func ObjectTemplateObservedSource() *ObjectTemplateObservedSourceApplyConfiguration {
+	return &ObjectTemplateObservedSourceApplyConfiguration{}
+}
+
+// WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIVersion field is set to the value of the last call.
+func (b *ObjectTemplateObservedSourceApplyConfiguration) WithAPIVersion(value string) *ObjectTemplateObservedSourceApplyConfiguration {
+	b.APIVersion = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *ObjectTemplateObservedSourceApplyConfiguration) WithKind(value string) *ObjectTemplateObservedSourceApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ObjectTemplateObservedSourceApplyConfiguration) WithNamespace(value string) *ObjectTemplateObservedSourceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ObjectTemplateObservedSourceApplyConfiguration) WithName(value string) *ObjectTemplateObservedSourceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *ObjectTemplateObservedSourceApplyConfiguration) WithUID(value types.UID) *ObjectTemplateObservedSourceApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithResourceVersion sets the ResourceVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceVersion field is set to the value of the last call.
+func (b *ObjectTemplateObservedSourceApplyConfiguration) WithResourceVersion(value string) *ObjectTemplateObservedSourceApplyConfiguration {
+	b.ResourceVersion = &value
+	return b
+}
@@ -2,19 +2,42 @@
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
 // ObjectTemplateSourceApplyConfiguration represents a declarative configuration of the ObjectTemplateSource type for use
 // with apply.
 //
 // ObjectTemplateSource defines a source for a template.
+// Sources either reference a single object by name or select a list of objects by labels.
 type ObjectTemplateSourceApplyConfiguration struct {
-	APIVersion *string                                      `json:"apiVersion,omitempty"`
-	Kind       *string                                      `json:"kind,omitempty"`
-	Namespace  *string                                      `json:"namespace,omitempty"`
-	Name       *string                                      `json:"name,omitempty"`
-	Items      []ObjectTemplateSourceItemApplyConfiguration `json:"items,omitempty"`
+	APIVersion *string `json:"apiVersion,omitempty"`
+	Kind       *string `json:"kind,omitempty"`
+	Namespace  *string `json:"namespace,omitempty"`
+	// Name of the source object.
+	Name *string `json:"name,omitempty"`
+	// Selects all objects matching the label selector instead of a single object by name.
+	// Matched objects are stored at destination, ordered by namespace and name.
+	// Items are copied from each matched object into its own entry,
+	// the whole object is stored if no items are given.
+	Selector *v1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+	// JSONPath to destination in which to store the objects matched by selector
+	// or the result of expression.
+	Destination *string `json:"destination,omitempty"`
+	// Format of the objects matched by selector at destination,
+	// either a List or a Map keyed by object name.
+	Format *corev1alpha1.ObjectTemplateSourceFormat `json:"format,omitempty"`
+	// Values to copy from the source object into the template context.
+	Items []ObjectTemplateSourceItemApplyConfiguration `json:"items,omitempty"`
+	// CEL expression evaluated with the source object as `object`.
+	// The result is stored at destination, or as the entry of each object matched by selector.
+	Expression *string `json:"expression,omitempty"`
 	// Marks this source as optional.
 	// The templated object will still be applied if optional sources are not found.
 	// If the source object is created later on, it will be eventually picked up.
+	// Has no effect on sources with a selector, which may match no objects.
 	Optional *bool `json:"optional,omitempty"`
 }
 
@@ -56,6 +79,30 @@ func (b *ObjectTemplateSourceApplyConfiguration) WithName(value string) *ObjectT
 	return b
 }
 
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *ObjectTemplateSourceApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *ObjectTemplateSourceApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithDestination sets the Destination field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Destination field is set to the value of the last call.
+func (b *ObjectTemplateSourceApplyConfiguration) WithDestination(value string) *ObjectTemplateSourceApplyConfiguration {
+	b.Destination = &value
+	return b
+}
+
+// WithFormat sets the Format field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Format field is set to the value of the last call.
+func (b *ObjectTemplateSourceApplyConfiguration) WithFormat(value corev1alpha1.ObjectTemplateSourceFormat) *ObjectTemplateSourceApplyConfiguration {
+	b.Format = &value
+	return b
+}
+
 // WithItems adds the given value to the Items field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Items field.
@@ -69,6 +116,14 @@ func (b *ObjectTemplateSourceApplyConfiguration) WithItems(values ...*ObjectTemp
 	return b
 }
 
+// WithExpression sets the Expression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expression field is set to the value of the last call.
+func (b *ObjectTemplateSourceApplyConfiguration) WithExpression(value string) *ObjectTemplateSourceApplyConfiguration {
+	b.Expression = &value
+	return b
+}
+
 // WithOptional sets the Optional field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Optional field is set to the value of the last call.
@@ -9,6 +9,9 @@ package v1alpha1
 type ObjectTemplateSourceItemApplyConfiguration struct {
 	// JSONPath to value in source object.
 	Key *string `json:"key,omitempty"`
+	// CEL expression evaluated with the source object as `object`,
+	// e.g. `string(base64.decode(object.data.password))`.
+	Expression *string `json:"expression,omitempty"`
 	// JSONPath to destination in which to store copy of the source value.
 	Destination *string `json:"destination,omitempty"`
 }
@@ -27,6 +30,14 @@ func (b *ObjectTemplateSourceItemApplyConfiguration) WithKey(value string) *Obje
 	return b
 }
 
+// WithExpression sets the Expression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expression field is set to the value of the last call.
+func (b *ObjectTemplateSourceItemApplyConfiguration) WithExpression(value string) *ObjectTemplateSourceItemApplyConfiguration {
+	b.Expression = &value
+	return b
+}
+
 // WithDestination sets the Destination field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Destination field is set to the value of the last call.
@@ -2,15 +2,25 @@
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // ObjectTemplateSpecApplyConfiguration represents a declarative configuration of the ObjectTemplateSpec type for use
 // with apply.
 //
 // ObjectTemplateSpec specification.
 type ObjectTemplateSpecApplyConfiguration struct {
-	// Go template of a Kubernetes manifest
+	// Go template of one or more Kubernetes manifests,
+	// multiple objects are separated as YAML documents by "---".
 	Template *string `json:"template,omitempty"`
 	// Objects in which configuration parameters are fetched
 	Sources []ObjectTemplateSourceApplyConfiguration `json:"sources,omitempty"`
+	// Templates objects into every namespace matching the label selector.
+	// The selected Namespace is available as .namespace in the template context
+	// and objects without a namespace are placed into it.
+	// Only supported by ClusterObjectTemplates.
+	NamespaceSelector *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
 }
 
 // ObjectTemplateSpecApplyConfiguration constructs a declarative configuration of the ObjectTemplateSpec type for use with
@@ -39,3 +49,11 @@ func (b *ObjectTemplateSpecApplyConfiguration) WithSources(values ...*ObjectTemp
 	}
 	return b
 }
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ObjectTemplateSpecApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *ObjectTemplateSpecApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
@@ -13,8 +13,17 @@ import (
 type ObjectTemplateStatusApplyConfiguration struct {
 	// Conditions is a list of status conditions the templated object is in.
 	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
-	// ControllerOf references the templated object.
+	// ControllerOf references the first templated object.
+	// Kept for compatibility, use ControlledObjects instead.
 	ControllerOf *ControlledObjectReferenceApplyConfiguration `json:"controllerOf,omitempty"`
+	// ControlledObjects references all templated objects.
+	ControlledObjects []ControlledObjectReferenceApplyConfiguration `json:"controlledObjects,omitempty"`
+	// Namespaces summarizes the namespaces selected by namespaceSelector.
+	Namespaces *ObjectTemplateNamespacesStatusApplyConfiguration `json:"namespaces,omitempty"`
+	// Sources observed while rendering the template.
+	Sources []ObjectTemplateObservedSourceApplyConfiguration `json:"sources,omitempty"`
+	// SHA256 hash of the rendered objects.
+	RenderedHash *string `json:"renderedHash,omitempty"`
 }
 
 // ObjectTemplateStatusApplyConfiguration constructs a declarative configuration of the ObjectTemplateStatus type for use with
@@ -43,3 +52,45 @@ func (b *ObjectTemplateStatusApplyConfiguration) WithControllerOf(value *Control
 	b.ControllerOf = value
 	return b
 }
+
+// WithControlledObjects adds the given value to the ControlledObjects field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ControlledObjects field.
+func (b *ObjectTemplateStatusApplyConfiguration) WithControlledObjects(values ...*ControlledObjectReferenceApplyConfiguration) *ObjectTemplateStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithControlledObjects")
+		}
+		b.ControlledObjects = append(b.ControlledObjects, *values[i])
+	}
+	return b
+}
+
+// WithNamespaces sets the Namespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespaces field is set to the value of the last call.
+func (b *ObjectTemplateStatusApplyConfiguration) WithNamespaces(value *ObjectTemplateNamespacesStatusApplyConfiguration) *ObjectTemplateStatusApplyConfiguration {
+	b.Namespaces = value
+	return b
+}
+
+// WithSources adds the given value to the Sources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sources field.
+func (b *ObjectTemplateStatusApplyConfiguration) WithSources(values ...*ObjectTemplateObservedSourceApplyConfiguration) *ObjectTemplateStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSources")
+		}
+		b.Sources = append(b.Sources, *values[i])
+	}
+	return b
+}
+
+// WithRenderedHash sets the RenderedHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RenderedHash field is set to the value of the last call.
+func (b *ObjectTemplateStatusApplyConfiguration) WithRenderedHash(value string) *ObjectTemplateStatusApplyConfiguration {
+	b.RenderedHash = &value
+	return b
+}
@@ -0,0 +1,36 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PackageImageOverrideApplyConfiguration represents a declarative configuration of the PackageImageOverride type for use
+// with apply.
+//
+// PackageImageOverride replaces a single image declared in the PackageManifest.
+type PackageImageOverrideApplyConfiguration struct {
+	// Name of the image as declared in the PackageManifest.
+	Name *string `json:"name,omitempty"`
+	// Image reference pinned by digest.
+	Image *string `json:"image,omitempty"`
+}
+
+// PackageImageOverrideApplyConfiguration constructs a declarative configuration of the PackageImageOverride type for use with
+// apply.
+func PackageImageOverride() *PackageImageOverrideApplyConfiguration {
+	return &PackageImageOverrideApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PackageImageOverrideApplyConfiguration) WithName(value string) *PackageImageOverrideApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *PackageImageOverrideApplyConfiguration) WithImage(value string) *PackageImageOverrideApplyConfiguration {
+	b.Image = &value
+	return b
+}
@@ -0,0 +1,37 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PackageImagePrefixOverrideApplyConfiguration represents a declarative configuration of the PackageImagePrefixOverride type for use
+// with apply.
+//
+// PackageImagePrefixOverride rewrites the prefix of images.
+// The most specific matching override is applied.
+type PackageImagePrefixOverrideApplyConfiguration struct {
+	// Image prefix to replace, e.g. quay.io/example/.
+	From *string `json:"from,omitempty"`
+	// Replacement of the image prefix, e.g. mirror.example.com/example/.
+	To *string `json:"to,omitempty"`
+}
+
+// PackageImagePrefixOverrideApplyConfiguration constructs a declarative configuration of the PackageImagePrefixOverride type for use with
+// apply.
+func PackageImagePrefixOverride() *PackageImagePrefixOverrideApplyConfiguration {
+	return &PackageImagePrefixOverrideApplyConfiguration{}
+}
+
+// WithFrom sets the From field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the From field is set to the value of the last call.
+func (b *PackageImagePrefixOverrideApplyConfiguration) WithFrom(value string) *PackageImagePrefixOverrideApplyConfiguration {
+	b.From = &value
+	return b
+}
+
+// WithTo sets the To field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the To field is set to the value of the last call.
+func (b *PackageImagePrefixOverrideApplyConfiguration) WithTo(value string) *PackageImagePrefixOverrideApplyConfiguration {
+	b.To = &value
+	return b
+}
@@ -0,0 +1,53 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// PackagePatchApplyConfiguration represents a declarative configuration of the PackagePatch type for use
+// with apply.
+//
+// PackagePatch modifies rendered objects of a package.
+type PackagePatchApplyConfiguration struct {
+	// Selects the objects to patch.
+	// All objects of the package are patched, if no target is set.
+	Target *PackagePatchTargetApplyConfiguration `json:"target,omitempty"`
+	// Type of the patch.
+	Type *corev1alpha1.PackagePatchType `json:"type,omitempty"`
+	// Patch document as YAML or JSON.
+	// A partial object for StrategicMerge patches
+	// or a list of operations for JSON6902 patches.
+	Patch *string `json:"patch,omitempty"`
+}
+
+// PackagePatchApplyConfiguration constructs a declarative configuration of the PackagePatch type for use with
+// apply.
+func PackagePatch() *PackagePatchApplyConfiguration {
+	return &PackagePatchApplyConfiguration{}
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *PackagePatchApplyConfiguration) WithTarget(value *PackagePatchTargetApplyConfiguration) *PackagePatchApplyConfiguration {
+	b.Target = value
+	return b
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *PackagePatchApplyConfiguration) WithType(value corev1alpha1.PackagePatchType) *PackagePatchApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithPatch sets the Patch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Patch field is set to the value of the last call.
+func (b *PackagePatchApplyConfiguration) WithPatch(value string) *PackagePatchApplyConfiguration {
+	b.Patch = &value
+	return b
+}
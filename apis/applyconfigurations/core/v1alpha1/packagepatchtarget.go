@@ -0,0 +1,61 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// PackagePatchTargetApplyConfiguration represents a declarative configuration of the PackagePatchTarget type for use
+// with apply.
+//
+// PackagePatchTarget selects objects to patch.
+// Objects have to match all given fields, unset fields match any object.
+type PackagePatchTargetApplyConfiguration struct {
+	// API group of the object.
+	Group *string `json:"group,omitempty"`
+	// Kind of the object.
+	Kind *string `json:"kind,omitempty"`
+	// Name of the object.
+	Name *string `json:"name,omitempty"`
+	// Selects objects by labels.
+	LabelSelector *v1.LabelSelectorApplyConfiguration `json:"labelSelector,omitempty"`
+}
+
+// PackagePatchTargetApplyConfiguration constructs a declarative configuration of the PackagePatchTarget type for use with
+// apply.
+func PackagePatchTarget() *PackagePatchTargetApplyConfiguration {
+	return &PackagePatchTargetApplyConfiguration{}
+}
+
+// WithGroup sets the Group field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Group field is set to the value of the last call.
+func (b *PackagePatchTargetApplyConfiguration) WithGroup(value string) *PackagePatchTargetApplyConfiguration {
+	b.Group = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *PackagePatchTargetApplyConfiguration) WithKind(value string) *PackagePatchTargetApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PackagePatchTargetApplyConfiguration) WithName(value string) *PackagePatchTargetApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithLabelSelector sets the LabelSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LabelSelector field is set to the value of the last call.
+func (b *PackagePatchTargetApplyConfiguration) WithLabelSelector(value *v1.LabelSelectorApplyConfiguration) *PackagePatchTargetApplyConfiguration {
+	b.LabelSelector = value
+	return b
+}
@@ -21,6 +21,23 @@ type PackageSpecApplyConfiguration struct {
 	Component *string `json:"component,omitempty"`
 	// If Paused is true, the package and its children will not be reconciled.
 	Paused *bool `json:"paused,omitempty"`
+	// Patches applied to the rendered objects of the package,
+	// before they are handed to the ObjectDeployment.
+	// Patches are applied in order.
+	Patches []PackagePatchApplyConfiguration `json:"patches,omitempty"`
+	// Labels added to all rendered objects of the package.
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+	// Annotations added to all rendered objects of the package.
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+	// Replaces images declared in the PackageManifest by name.
+	// Images have to be declared in the PackageManifestLock and overrides have to reference a digest.
+	ImageOverrides []PackageImageOverrideApplyConfiguration `json:"imageOverrides,omitempty"`
+	// Image prefix overrides for images of this package.
+	// Applied before image prefix overrides configured for Package Operator and ClusterImageMirrorSets.
+	ImagePrefixOverrides []PackageImagePrefixOverrideApplyConfiguration `json:"imagePrefixOverrides,omitempty"`
+	// ServiceAccount to impersonate when reconciling objects of the package.
+	// Objects are reconciled with the identity of Package Operator, if unset.
+	ServiceAccount *ServiceAccountReferenceApplyConfiguration `json:"serviceAccount,omitempty"`
 }
 
 // PackageSpecApplyConfiguration constructs a declarative configuration of the PackageSpec type for use with
@@ -60,3 +77,78 @@ func (b *PackageSpecApplyConfiguration) WithPaused(value bool) *PackageSpecApply
 	b.Paused = &value
 	return b
 }
+
+// WithPatches adds the given value to the Patches field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Patches field.
+func (b *PackageSpecApplyConfiguration) WithPatches(values ...*PackagePatchApplyConfiguration) *PackageSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPatches")
+		}
+		b.Patches = append(b.Patches, *values[i])
+	}
+	return b
+}
+
+// WithCommonLabels puts the entries into the CommonLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the CommonLabels field,
+// overwriting an existing map entries in CommonLabels field with the same key.
+func (b *PackageSpecApplyConfiguration) WithCommonLabels(entries map[string]string) *PackageSpecApplyConfiguration {
+	if b.CommonLabels == nil && len(entries) > 0 {
+		b.CommonLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.CommonLabels[k] = v
+	}
+	return b
+}
+
+// WithCommonAnnotations puts the entries into the CommonAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the CommonAnnotations field,
+// overwriting an existing map entries in CommonAnnotations field with the same key.
+func (b *PackageSpecApplyConfiguration) WithCommonAnnotations(entries map[string]string) *PackageSpecApplyConfiguration {
+	if b.CommonAnnotations == nil && len(entries) > 0 {
+		b.CommonAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.CommonAnnotations[k] = v
+	}
+	return b
+}
+
+// WithImageOverrides adds the given value to the ImageOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImageOverrides field.
+func (b *PackageSpecApplyConfiguration) WithImageOverrides(values ...*PackageImageOverrideApplyConfiguration) *PackageSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithImageOverrides")
+		}
+		b.ImageOverrides = append(b.ImageOverrides, *values[i])
+	}
+	return b
+}
+
+// WithImagePrefixOverrides adds the given value to the ImagePrefixOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePrefixOverrides field.
+func (b *PackageSpecApplyConfiguration) WithImagePrefixOverrides(values ...*PackageImagePrefixOverrideApplyConfiguration) *PackageSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithImagePrefixOverrides")
+		}
+		b.ImagePrefixOverrides = append(b.ImagePrefixOverrides, *values[i])
+	}
+	return b
+}
+
+// WithServiceAccount sets the ServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccount field is set to the value of the last call.
+func (b *PackageSpecApplyConfiguration) WithServiceAccount(value *ServiceAccountReferenceApplyConfiguration) *PackageSpecApplyConfiguration {
+	b.ServiceAccount = value
+	return b
+}
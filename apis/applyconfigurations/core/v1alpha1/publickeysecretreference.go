@@ -0,0 +1,46 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PublicKeySecretReferenceApplyConfiguration represents a declarative configuration of the PublicKeySecretReference type for use
+// with apply.
+//
+// PublicKeySecretReference references a PEM encoded public key stored in a Secret.
+type PublicKeySecretReferenceApplyConfiguration struct {
+	// Name of the Secret.
+	Name *string `json:"name,omitempty"`
+	// Namespace of the Secret.
+	Namespace *string `json:"namespace,omitempty"`
+	// Key of the public key in the Secret data.
+	Key *string `json:"key,omitempty"`
+}
+
+// PublicKeySecretReferenceApplyConfiguration constructs a declarative configuration of the PublicKeySecretReference type for use with
+// apply.
+func PublicKeySecretReference() *PublicKeySecretReferenceApplyConfiguration {
+	return &PublicKeySecretReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PublicKeySecretReferenceApplyConfiguration) WithName(value string) *PublicKeySecretReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PublicKeySecretReferenceApplyConfiguration) WithNamespace(value string) *PublicKeySecretReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *PublicKeySecretReferenceApplyConfiguration) WithKey(value string) *PublicKeySecretReferenceApplyConfiguration {
+	b.Key = &value
+	return b
+}
@@ -0,0 +1,36 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RegistryHostOverrideApplyConfiguration represents a declarative configuration of the RegistryHostOverride type for use
+// with apply.
+//
+// RegistryHostOverride replaces the registry host of images.
+type RegistryHostOverrideApplyConfiguration struct {
+	// Registry host to replace, e.g. quay.io.
+	From *string `json:"from,omitempty"`
+	// Replacement registry host, e.g. mirror.example.com:5000.
+	To *string `json:"to,omitempty"`
+}
+
+// RegistryHostOverrideApplyConfiguration constructs a declarative configuration of the RegistryHostOverride type for use with
+// apply.
+func RegistryHostOverride() *RegistryHostOverrideApplyConfiguration {
+	return &RegistryHostOverrideApplyConfiguration{}
+}
+
+// WithFrom sets the From field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the From field is set to the value of the last call.
+func (b *RegistryHostOverrideApplyConfiguration) WithFrom(value string) *RegistryHostOverrideApplyConfiguration {
+	b.From = &value
+	return b
+}
+
+// WithTo sets the To field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the To field is set to the value of the last call.
+func (b *RegistryHostOverrideApplyConfiguration) WithTo(value string) *RegistryHostOverrideApplyConfiguration {
+	b.To = &value
+	return b
+}
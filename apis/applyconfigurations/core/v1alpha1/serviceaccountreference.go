@@ -0,0 +1,39 @@
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ServiceAccountReferenceApplyConfiguration represents a declarative configuration of the ServiceAccountReference type for use
+// with apply.
+//
+// ServiceAccountReference references a ServiceAccount to impersonate.
+type ServiceAccountReferenceApplyConfiguration struct {
+	// Name of the ServiceAccount.
+	Name *string `json:"name,omitempty"`
+	// Namespace of the ServiceAccount.
+	// Defaults to the namespace of the referencing object and
+	// must be set when referenced from cluster-scoped objects.
+	// Namespaced objects can only reference ServiceAccounts in their own namespace.
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ServiceAccountReferenceApplyConfiguration constructs a declarative configuration of the ServiceAccountReference type for use with
+// apply.
+func ServiceAccountReference() *ServiceAccountReferenceApplyConfiguration {
+	return &ServiceAccountReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ServiceAccountReferenceApplyConfiguration) WithName(value string) *ServiceAccountReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ServiceAccountReferenceApplyConfiguration) WithNamespace(value string) *ServiceAccountReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
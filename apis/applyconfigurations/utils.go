@@ -16,6 +16,14 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=package-operator.run, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterImageMirrorSet"):
+		return &corev1alpha1.ClusterImageMirrorSetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterImageMirrorSetSpec"):
+		return &corev1alpha1.ClusterImageMirrorSetSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterImageVerificationPolicy"):
+		return &corev1alpha1.ClusterImageVerificationPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterImageVerificationPolicySpec"):
+		return &corev1alpha1.ClusterImageVerificationPolicySpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterObjectDeployment"):
 		return &corev1alpha1.ClusterObjectDeploymentApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterObjectDeploymentSpec"):
@@ -40,14 +48,26 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1alpha1.ClusterObjectTemplateApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterPackage"):
 		return &corev1alpha1.ClusterPackageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterSetPackage"):
+		return &corev1alpha1.ClusterSetPackageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterSetPackageConfigOverlay"):
+		return &corev1alpha1.ClusterSetPackageConfigOverlayApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterSetPackageSpec"):
+		return &corev1alpha1.ClusterSetPackageSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ConditionMapping"):
 		return &corev1alpha1.ConditionMappingApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ControlledObjectReference"):
 		return &corev1alpha1.ControlledObjectReferenceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackage"):
 		return &corev1alpha1.HostedClusterPackageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageConfigOverlay"):
+		return &corev1alpha1.HostedClusterPackageConfigOverlayApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageCountsStatus"):
 		return &corev1alpha1.HostedClusterPackageCountsStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageFailureStatus"):
+		return &corev1alpha1.HostedClusterPackageFailureStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackagePartitionCanarySpec"):
+		return &corev1alpha1.HostedClusterPackagePartitionCanarySpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackagePartitionOrderSpec"):
 		return &corev1alpha1.HostedClusterPackagePartitionOrderSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackagePartitionSpec"):
@@ -56,6 +76,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1alpha1.HostedClusterPackagePartitionStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageRefStatus"):
 		return &corev1alpha1.HostedClusterPackageRefStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageRolloutStatus"):
+		return &corev1alpha1.HostedClusterPackageRolloutStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageSpec"):
 		return &corev1alpha1.HostedClusterPackageSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HostedClusterPackageStatus"):
@@ -94,6 +116,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1alpha1.ObjectSetTemplateSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ObjectTemplate"):
 		return &corev1alpha1.ObjectTemplateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ObjectTemplateNamespaceFailure"):
+		return &corev1alpha1.ObjectTemplateNamespaceFailureApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ObjectTemplateNamespacesStatus"):
+		return &corev1alpha1.ObjectTemplateNamespacesStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ObjectTemplateObservedSource"):
+		return &corev1alpha1.ObjectTemplateObservedSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ObjectTemplateSource"):
 		return &corev1alpha1.ObjectTemplateSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ObjectTemplateSourceItem"):
@@ -104,6 +132,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1alpha1.ObjectTemplateStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Package"):
 		return &corev1alpha1.PackageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PackageImageOverride"):
+		return &corev1alpha1.PackageImageOverrideApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PackageImagePrefixOverride"):
+		return &corev1alpha1.PackageImagePrefixOverrideApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PackagePatch"):
+		return &corev1alpha1.PackagePatchApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PackagePatchTarget"):
+		return &corev1alpha1.PackagePatchTargetApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PackageProbeKindSpec"):
 		return &corev1alpha1.PackageProbeKindSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PackageSpec"):
@@ -124,8 +160,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1alpha1.ProbeFieldsEqualSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ProbeSelector"):
 		return &corev1alpha1.ProbeSelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PublicKeySecretReference"):
+		return &corev1alpha1.PublicKeySecretReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RegistryHostOverride"):
+		return &corev1alpha1.RegistryHostOverrideApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RemotePhaseReference"):
 		return &corev1alpha1.RemotePhaseReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ServiceAccountReference"):
+		return &corev1alpha1.ServiceAccountReferenceApplyConfiguration{}
 
 	}
 	return nil
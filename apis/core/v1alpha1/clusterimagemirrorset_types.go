@@ -0,0 +1,55 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ClusterImageMirrorSet configures image mirrors for Packages and ClusterPackages,
+// in addition to the image overrides configured for Package Operator.
+// Changes are picked up without restarting Package Operator.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName={"imirror","cims"}
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterImageMirrorSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterImageMirrorSetSpec `json:"spec,omitempty"`
+}
+
+// ClusterImageMirrorSetList contains a list of ClusterImageMirrorSets.
+// +kubebuilder:object:root=true
+type ClusterImageMirrorSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterImageMirrorSet `json:"items"`
+}
+
+// ClusterImageMirrorSetSpec defines the image mirrors to use.
+type ClusterImageMirrorSetSpec struct {
+	// Selects the namespaces of Packages to use the mirrors for.
+	// Mirrors are used for all Packages and ClusterPackages if unset.
+	// ClusterPackages are only selected, if no selector is set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Image prefix overrides applied to package images and images used by packages.
+	// The most specific matching override is applied.
+	// +optional
+	ImagePrefixOverrides []PackageImagePrefixOverride `json:"imagePrefixOverrides,omitempty"`
+	// Registry host overrides applied when pulling package images.
+	// +optional
+	// +listType=map
+	// +listMapKey=from
+	RegistryHostOverrides []RegistryHostOverride `json:"registryHostOverrides,omitempty"`
+}
+
+// RegistryHostOverride replaces the registry host of images.
+type RegistryHostOverride struct {
+	// Registry host to replace, e.g. quay.io.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// Replacement registry host, e.g. mirror.example.com:5000.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+}
+
+func init() { register(&ClusterImageMirrorSet{}, &ClusterImageMirrorSetList{}) }
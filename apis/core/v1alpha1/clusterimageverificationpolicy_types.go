@@ -0,0 +1,56 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ClusterImageVerificationPolicy requires package images to be signed by a trusted key,
+// before Packages and ClusterPackages are unpacked.
+// Signatures are expected in the cosign format next to the package image in the same repository.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName={"imgverify","civp"}
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterImageVerificationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterImageVerificationPolicySpec `json:"spec,omitempty"`
+}
+
+// ClusterImageVerificationPolicyList contains a list of ClusterImageVerificationPolicies.
+// +kubebuilder:object:root=true
+type ClusterImageVerificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterImageVerificationPolicy `json:"items"`
+}
+
+// ClusterImageVerificationPolicySpec defines the keys package images have to be signed with.
+type ClusterImageVerificationPolicySpec struct {
+	// Selects the namespaces of Packages that have to be verified.
+	// Namespaces opt in to verification by matching the selector.
+	// All Packages and ClusterPackages are verified if unset.
+	// ClusterPackages are only selected, if no selector is set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Trusted public keys.
+	// A package image is accepted, if it is signed by any of the keys
+	// of the policies selecting the Package.
+	// +kubebuilder:validation:MinItems=1
+	PublicKeys []PublicKeySecretReference `json:"publicKeys"`
+}
+
+// PublicKeySecretReference references a PEM encoded public key stored in a Secret.
+type PublicKeySecretReference struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Key of the public key in the Secret data.
+	// +kubebuilder:default=cosign.pub
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+func init() { register(&ClusterImageVerificationPolicy{}, &ClusterImageVerificationPolicyList{}) }
@@ -16,6 +16,10 @@ type ClusterObjectDeploymentSpec struct {
 	Template ObjectSetTemplate `json:"template"`
 	// If Paused is true, the object and its children will not be reconciled.
 	Paused bool `json:"paused,omitempty"`
+	// ServiceAccount to impersonate when reconciling objects of new ObjectSets.
+	// Objects are reconciled with the identity of Package Operator, if unset.
+	// +optional
+	ServiceAccount *ServiceAccountReference `json:"serviceAccount,omitempty"`
 }
 
 // ClusterObjectDeploymentStatus defines the observed state of a ClusterObjectDeployment.
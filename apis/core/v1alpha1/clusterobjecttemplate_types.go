@@ -4,11 +4,12 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // ClusterObjectTemplate contain a go template of a Kubernetes manifest. The manifest is then templated with the
 // sources provided in the .Spec.Sources. The sources can come from objects from any namespace or cluster scoped
-// objects.
+// objects. With a namespace selector, the manifest is templated into every matching namespace.
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,shortName={"clobjtmpl","cot"}
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Invalid",type=string,JSONPath=`.status.conditions[?(@.type=="package-operator.run/Invalid")].status`
+// +kubebuilder:printcolumn:name="Namespaces",type=integer,JSONPath=`.status.namespaces.ready`,priority=1
 type ClusterObjectTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
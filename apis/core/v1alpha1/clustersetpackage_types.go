@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ClusterSetPackage defines a package to be rolled out on every cluster
+// registered via a kubeconfig Secret matching the clusterSelector.
+// Package Operator has to be installed on the target clusters to reconcile the Packages.
+// Experimental: Subject to change.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cspkg
+// +kubebuilder:printcolumn:name="Available",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].status`
+// +kubebuilder:printcolumn:name="Progressing",type=string,JSONPath=`.status.conditions[?(@.type=="Progressing")].status`
+// +kubebuilder:printcolumn:name="HasPausedPackage",type=string,JSONPath=`.status.conditions[?(@.type=="HasPausedPackage")].status`
+// +kubebuilder:printcolumn:name="ObservedGeneration",type=string,JSONPath=`.status.observedGeneration`
+// +kubebuilder:printcolumn:name="Image",type=string,priority=1,JSONPath=`.spec.template.spec.image`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterSetPackage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSetPackageSpec      `json:"spec,omitempty"`
+	Status HostedClusterPackageStatus `json:"status,omitempty"`
+}
+
+// ClusterSetPackageSpec is the description of a ClusterSetPackage.
+type ClusterSetPackageSpec struct {
+	// +kubebuilder:default={instant: {}}
+	Strategy HostedClusterPackageStrategy `json:"strategy"`
+	// ClusterSelector is a label query matching kubeconfig Secrets of clusters that the Package should be rolled out to.
+	// Only Secrets in the Package Operator namespace with the
+	// "package-operator.run/cluster-kubeconfig" label are considered.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Namespace on the target clusters to create the Package in.
+	// The namespace is created, if it does not exist.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Template describes the Package that should be created when new
+	// clusters matching the clusterSelector are registered.
+	Template PackageTemplateSpec `json:"template"`
+	// Partition clusters by the label value of their kubeconfig Secret.
+	// All packages in the same partition will have to be upgraded
+	// before progressing to the next partition.
+	Partition *HostedClusterPackagePartitionSpec `json:"partition,omitempty"`
+	// ConfigOverlays are merged into the config of Packages for clusters matching their selector.
+	// Overlays are applied in order, so later overlays take precedence.
+	// +optional
+	ConfigOverlays []ClusterSetPackageConfigOverlay `json:"configOverlays,omitempty"`
+}
+
+// ClusterSetPackageConfigOverlay adds config to the Packages of a group of clusters,
+// e.g. region- or tier-specific values.
+type ClusterSetPackageConfigOverlay struct {
+	// ClusterSelector is a label query matching kubeconfig Secrets of clusters the overlay applies to.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+	// Config merged into the Package config from the template as JSON merge patch (RFC 7386).
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config runtime.RawExtension `json:"config"`
+}
+
+const (
+	// ClusterKubeconfigSecretLabel registers a Secret as kubeconfig of a target cluster for ClusterSetPackages.
+	ClusterKubeconfigSecretLabel = "package-operator.run/cluster-kubeconfig"
+	// ClusterKubeconfigSecretKey is the key of the kubeconfig in registered Secrets.
+	ClusterKubeconfigSecretKey = "kubeconfig"
+	// ClusterSetPackageLabel is set on Packages created by a ClusterSetPackage on target clusters.
+	ClusterSetPackageLabel = "package-operator.run/cluster-set-package"
+)
+
+// ClusterSetPackageList contains a list of ClusterSetPackage.
+// +kubebuilder:object:root=true
+type ClusterSetPackageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterSetPackage `json:"items"`
+}
+
+func init() { register(&ClusterSetPackage{}, &ClusterSetPackageList{}) }
@@ -46,6 +46,19 @@ type ObjectSetTemplateSpec struct {
 	SuccessDelaySeconds int32 `json:"successDelaySeconds,omitempty"`
 }
 
+// ServiceAccountReference references a ServiceAccount to impersonate.
+type ServiceAccountReference struct {
+	// Name of the ServiceAccount.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the ServiceAccount.
+	// Defaults to the namespace of the referencing object and
+	// must be set when referenced from cluster-scoped objects.
+	// Namespaced objects can only reference ServiceAccounts in their own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // ObjectSetTemplatePhase configures the reconcile phase of ObjectSets.
 type ObjectSetTemplatePhase struct {
 	// Name of the reconcile phase. Must be unique within a ObjectSet.
@@ -54,8 +67,11 @@ type ObjectSetTemplatePhase struct {
 	// to another controller, by creating an ObjectSetPhase object. If set to the
 	// string "default" the built-in Package Operator ObjectSetPhase controller
 	// will reconcile the object in the same way the ObjectSet would. If set to
-	// any other string, an out-of-tree controller needs to be present to handle
-	// ObjectSetPhase objects.
+	// the string "kubeconfig-secret" the built-in controller will reconcile the
+	// objects against the cluster referenced by the
+	// "package-operator.run/target-cluster-kubeconfig-secret" annotation.
+	// If set to any other string, an out-of-tree controller needs to be present
+	// to handle ObjectSetPhase objects.
 	Class string `json:"class,omitempty"`
 	// Objects belonging to this phase.
 	Objects []ObjectSetObject `json:"objects,omitempty"`
@@ -117,6 +133,9 @@ const (
 	// InTransition condition is True when the ObjectSet is not in control of all objects defined in spec.
 	// This holds true during rollout of the first instance or while handing over objects between two ObjectSets.
 	ObjectSetInTransition = "InTransition"
+	// PermissionsVerified is True when access reviews confirmed
+	// that all objects of the observed generation can be managed.
+	ObjectSetPermissionsVerified = "PermissionsVerified"
 )
 
 // ObjectSetProbe define how ObjectSets check their children for their status.
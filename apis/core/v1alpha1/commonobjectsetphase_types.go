@@ -10,3 +10,15 @@ const (
 
 // ObjectSetPhaseClassLabel is the label key for the phase class.
 const ObjectSetPhaseClassLabel = "package-operator.run/phase-class"
+
+// KubeconfigSecretObjectSetPhaseClass is the phase class reconciled by the built-in controller
+// against the cluster described by the kubeconfig Secret referenced via
+// ObjectSetPhaseTargetClusterSecretAnnotation.
+const KubeconfigSecretObjectSetPhaseClass = "kubeconfig-secret"
+
+// ObjectSetPhaseTargetClusterSecretAnnotation references the Secret holding the kubeconfig
+// of the cluster to reconcile phases of class KubeconfigSecretObjectSetPhaseClass against.
+// The kubeconfig is read from the ClusterKubeconfigSecretKey key and may only contain inline credentials.
+// ObjectSetPhases use the annotation of their Namespace and reference Secrets in the same namespace.
+// ClusterObjectSetPhases use their own annotation and reference Secrets in the namespace of Package Operator.
+const ObjectSetPhaseTargetClusterSecretAnnotation = "package-operator.run/target-cluster-kubeconfig-secret"
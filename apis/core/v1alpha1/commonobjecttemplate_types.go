@@ -1,33 +1,90 @@
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
 
 // ObjectTemplateSpec specification.
 type ObjectTemplateSpec struct {
-	// Go template of a Kubernetes manifest
+	// Go template of one or more Kubernetes manifests,
+	// multiple objects are separated as YAML documents by "---".
 	Template string `json:"template"`
 
 	// Objects in which configuration parameters are fetched
 	Sources []ObjectTemplateSource `json:"sources"`
+
+	// Templates objects into every namespace matching the label selector.
+	// The selected Namespace is available as .namespace in the template context
+	// and objects without a namespace are placed into it.
+	// Only supported by ClusterObjectTemplates.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 // ObjectTemplateSource defines a source for a template.
+// Sources either reference a single object by name or select a list of objects by labels.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.selector)", message="exactly one of name or selector must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.selector) || has(self.destination)", message="destination is required with selector"
+// +kubebuilder:validation:XValidation:rule="!has(self.expression) || has(self.destination)", message="destination is required with expression"
+// +kubebuilder:validation:XValidation:rule="!has(self.expression) || !has(self.items)", message="expression and items are mutually exclusive"
 type ObjectTemplateSource struct {
-	APIVersion string                     `json:"apiVersion"`
-	Kind       string                     `json:"kind"`
-	Namespace  string                     `json:"namespace,omitempty"`
-	Name       string                     `json:"name"`
-	Items      []ObjectTemplateSourceItem `json:"items"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Name of the source object.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selects all objects matching the label selector instead of a single object by name.
+	// Matched objects are stored at destination, ordered by namespace and name.
+	// Items are copied from each matched object into its own entry,
+	// the whole object is stored if no items are given.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// JSONPath to destination in which to store the objects matched by selector
+	// or the result of expression.
+	// +optional
+	Destination string `json:"destination,omitempty"`
+	// Format of the objects matched by selector at destination,
+	// either a List or a Map keyed by object name.
+	// +kubebuilder:default=List
+	// +kubebuilder:validation:Enum=List;Map
+	// +optional
+	Format ObjectTemplateSourceFormat `json:"format,omitempty"`
+	// Values to copy from the source object into the template context.
+	// +optional
+	Items []ObjectTemplateSourceItem `json:"items,omitempty"`
+	// CEL expression evaluated with the source object as `object`.
+	// The result is stored at destination, or as the entry of each object matched by selector.
+	// +optional
+	Expression string `json:"expression,omitempty"`
 	// Marks this source as optional.
 	// The templated object will still be applied if optional sources are not found.
 	// If the source object is created later on, it will be eventually picked up.
+	// Has no effect on sources with a selector, which may match no objects.
 	Optional bool `json:"optional,omitempty"`
 }
 
+// ObjectTemplateSourceFormat defines how objects matched by a source selector are stored.
+type ObjectTemplateSourceFormat string
+
+const (
+	// List of objects.
+	ObjectTemplateSourceFormatList ObjectTemplateSourceFormat = "List"
+	// Map of objects keyed by object name.
+	ObjectTemplateSourceFormatMap ObjectTemplateSourceFormat = "Map"
+)
+
 // ObjectTemplateSourceItem defines a source item for an object template.
+// +kubebuilder:validation:XValidation:rule="has(self.key) != has(self.expression)", message="exactly one of key or expression must be set"
 type ObjectTemplateSourceItem struct {
 	// JSONPath to value in source object.
-	Key string `json:"key"`
+	// +optional
+	Key string `json:"key,omitempty"`
+	// CEL expression evaluated with the source object as `object`,
+	// e.g. `string(base64.decode(object.data.password))`.
+	// +optional
+	Expression string `json:"expression,omitempty"`
 	// JSONPath to destination in which to store copy of the source value.
 	Destination string `json:"destination"`
 }
@@ -37,14 +94,64 @@ type ObjectTemplateStatus struct {
 	// Conditions is a list of status conditions the templated object is in.
 	// +example=[{type: "Available", status: "True", reason: "Available",  message: "Latest Revision is Available."}]
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
-	// ControllerOf references the templated object.
+	// ControllerOf references the first templated object.
+	// Kept for compatibility, use ControlledObjects instead.
 	ControllerOf ControlledObjectReference `json:"controllerOf,omitempty"`
+	// ControlledObjects references all templated objects.
+	ControlledObjects []ControlledObjectReference `json:"controlledObjects,omitempty"`
+	// Namespaces summarizes the namespaces selected by namespaceSelector.
+	// +optional
+	Namespaces *ObjectTemplateNamespacesStatus `json:"namespaces,omitempty"`
+	// Sources observed while rendering the template.
+	// +optional
+	Sources []ObjectTemplateObservedSource `json:"sources,omitempty"`
+	// SHA256 hash of the rendered objects.
+	// +optional
+	RenderedHash string `json:"renderedHash,omitempty"`
+}
+
+// ObjectTemplateObservedSource references a source object and the version of it used to render the template.
+type ObjectTemplateObservedSource struct {
+	// API version of the source object.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the source object.
+	Kind string `json:"kind"`
+	// Namespace of the source object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the source object.
+	Name string `json:"name"`
+	// UID of the source object.
+	UID types.UID `json:"uid"`
+	// ResourceVersion of the source object.
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// ObjectTemplateNamespacesStatus summarizes the namespaces objects are templated into.
+type ObjectTemplateNamespacesStatus struct {
+	// Number of namespaces matching the namespaceSelector.
+	Selected int32 `json:"selected"`
+	// Number of namespaces all objects have been templated into.
+	Ready int32 `json:"ready"`
+	// Namespaces objects could not be templated into.
+	// +optional
+	Failed []ObjectTemplateNamespaceFailure `json:"failed,omitempty"`
+}
+
+// ObjectTemplateNamespaceFailure describes why objects could not be templated into a namespace.
+type ObjectTemplateNamespaceFailure struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+	// Message describing the failure.
+	Message string `json:"message"`
 }
 
 // ObjectTemplate condition types.
 const (
 	// Invalid indicates an issue with the ObjectTemplates own configuration.
 	ObjectTemplateInvalid = "package-operator.run/Invalid"
+	// SourcesAvailable indicates whether all sources and keys referenced by the ObjectTemplate could be found.
+	ObjectTemplateSourcesAvailable = "package-operator.run/SourcesAvailable"
 )
 
 // ObjectTemplateStatusPhase defines the status phase of an object template.
@@ -66,6 +66,97 @@ type PackageSpec struct {
 	Component string `json:"component,omitempty"`
 	// If Paused is true, the package and its children will not be reconciled.
 	Paused bool `json:"paused,omitempty"`
+	// Patches applied to the rendered objects of the package,
+	// before they are handed to the ObjectDeployment.
+	// Patches are applied in order.
+	// +optional
+	Patches []PackagePatch `json:"patches,omitempty"`
+	// Labels added to all rendered objects of the package.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+	// Annotations added to all rendered objects of the package.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+	// Replaces images declared in the PackageManifest by name.
+	// Images have to be declared in the PackageManifestLock and overrides have to reference a digest.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ImageOverrides []PackageImageOverride `json:"imageOverrides,omitempty"`
+	// Image prefix overrides for images of this package.
+	// Applied before image prefix overrides configured for Package Operator and ClusterImageMirrorSets.
+	// +optional
+	ImagePrefixOverrides []PackageImagePrefixOverride `json:"imagePrefixOverrides,omitempty"`
+	// ServiceAccount to impersonate when reconciling objects of the package.
+	// Objects are reconciled with the identity of Package Operator, if unset.
+	// +optional
+	ServiceAccount *ServiceAccountReference `json:"serviceAccount,omitempty"`
+}
+
+// PackageImageOverride replaces a single image declared in the PackageManifest.
+type PackageImageOverride struct {
+	// Name of the image as declared in the PackageManifest.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Image reference pinned by digest.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+}
+
+// PackageImagePrefixOverride rewrites the prefix of images.
+// The most specific matching override is applied.
+type PackageImagePrefixOverride struct {
+	// Image prefix to replace, e.g. quay.io/example/.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// Replacement of the image prefix, e.g. mirror.example.com/example/.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+}
+
+// PackagePatch modifies rendered objects of a package.
+type PackagePatch struct {
+	// Selects the objects to patch.
+	// All objects of the package are patched, if no target is set.
+	// +optional
+	Target *PackagePatchTarget `json:"target,omitempty"`
+	// Type of the patch.
+	// +kubebuilder:default=StrategicMerge
+	// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+	Type PackagePatchType `json:"type,omitempty"`
+	// Patch document as YAML or JSON.
+	// A partial object for StrategicMerge patches
+	// or a list of operations for JSON6902 patches.
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// PackagePatchType defines how a patch is applied to an object.
+type PackagePatchType string
+
+const (
+	// Strategic merge patch, as used by kubectl patch.
+	// Falls back to a JSON merge patch for types without strategic merge metadata, e.g. custom resources.
+	PackagePatchTypeStrategicMerge PackagePatchType = "StrategicMerge"
+	// JSON patch as specified in RFC 6902.
+	PackagePatchTypeJSON6902 PackagePatchType = "JSON6902"
+)
+
+// PackagePatchTarget selects objects to patch.
+// Objects have to match all given fields, unset fields match any object.
+type PackagePatchTarget struct {
+	// API group of the object.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Kind of the object.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name of the object.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selects objects by labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 }
 
 // PackageTemplateSpec describes the data a package should have when created from a template.
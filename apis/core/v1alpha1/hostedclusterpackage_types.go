@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // HostedClusterPackage defines package to be rolled out on every HyperShift HostedCluster.
@@ -37,6 +39,20 @@ type HostedClusterPackageSpec struct {
 	// All packages in the same partition will have to be upgraded
 	// before progressing to the next partition.
 	Partition *HostedClusterPackagePartitionSpec `json:"partition,omitempty"`
+	// ConfigOverlays are merged into the config of Packages for HostedClusters matching their selector.
+	// Overlays are applied in order, so later overlays take precedence.
+	// +optional
+	ConfigOverlays []HostedClusterPackageConfigOverlay `json:"configOverlays,omitempty"`
+}
+
+// HostedClusterPackageConfigOverlay adds config to the Packages of a group of HostedClusters,
+// e.g. region- or tier-specific values.
+type HostedClusterPackageConfigOverlay struct {
+	// HostedClusterSelector is a label query matching HostedClusters the overlay applies to.
+	HostedClusterSelector metav1.LabelSelector `json:"hostedClusterSelector"`
+	// Config merged into the Package config from the template as JSON merge patch (RFC 7386).
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config runtime.RawExtension `json:"config"`
 }
 
 // HostedClusterPackageStrategy describes the rollout strategy for a HostedClusterPackage.
@@ -59,6 +75,18 @@ type HostedClusterPackageStrategyRollingUpgrade struct {
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Minimum=1
 	MaxUnavailable int `json:"maxUnavailable"`
+	// FailureThreshold is the number or percentage of updated Packages that may fail,
+	// before the rollout is halted. Percentages are relative to the number of targeted HostedClusters.
+	// The rollout is never halted automatically, if unset.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	FailureThreshold *intstr.IntOrString `json:"failureThreshold,omitempty"`
+	// ProgressDeadlineSeconds is the time an updated Package has to become Available,
+	// before it is considered failed.
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds int32 `json:"progressDeadlineSeconds,omitempty"`
 }
 
 // HostedClusterPackagePartitionSpec describes settings to partition HostedClusters into groups for upgrades.
@@ -73,6 +101,23 @@ type HostedClusterPackagePartitionSpec struct {
 	// Controls how partitions are ordered.
 	// By default items will be sorted AlphaNumeric ascending.
 	Order *HostedClusterPackagePartitionOrderSpec `json:"order,omitempty"`
+	// SoakSeconds is the time all Packages of a partition have to be updated and Available,
+	// before upgrades in the next partition start.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SoakSeconds int32 `json:"soakSeconds,omitempty"`
+	// Canary upgrades a few HostedClusters of the first partition before all others.
+	// +optional
+	Canary *HostedClusterPackagePartitionCanarySpec `json:"canary,omitempty"`
+}
+
+// HostedClusterPackagePartitionCanarySpec describes the canary partition.
+// The canary partition is split off the first partition and is upgraded before any other partition.
+type HostedClusterPackagePartitionCanarySpec struct {
+	// Number of HostedClusters in the canary partition.
+	// HostedClusters are picked from the first partition ordered by namespace and name.
+	// +kubebuilder:validation:Minimum=1
+	HostedClusters int32 `json:"hostedClusters"`
 }
 
 // HostedClusterPackagePartitionOrderAlphanumericAsc describes the alphanumeric
@@ -109,6 +154,56 @@ type HostedClusterPackageStatus struct {
 	Partitions []HostedClusterPackagePartitionStatus `json:"partitions,omitempty"`
 	// Processing set of packages during upgrade.
 	Processing []HostedClusterPackageRefStatus `json:"processing,omitempty"`
+	// Rollout describes the state of a rolling upgrade.
+	// +optional
+	Rollout *HostedClusterPackageRolloutStatus `json:"rollout,omitempty"`
+}
+
+// HostedClusterPackageRolloutStatus describes the state of a rolling upgrade.
+type HostedClusterPackageRolloutStatus struct {
+	// Generation of the HostedClusterPackage the rollout applies to.
+	// Any change to the spec starts a new rollout.
+	Generation int64 `json:"generation"`
+	// State of the rollout.
+	// +kubebuilder:validation:Enum=Progressing;Halted;Aborted
+	State HostedClusterPackageRolloutState `json:"state"`
+	// Only Packages updated after the rollout was resumed count towards the failure threshold.
+	// +optional
+	ResumedAt *metav1.Time `json:"resumedAt,omitempty"`
+	// Value of the resume-rollout annotation handled last.
+	// +optional
+	LastHandledResume string `json:"lastHandledResume,omitempty"`
+	// Value of the abort-rollout annotation handled last.
+	// +optional
+	LastHandledAbort string `json:"lastHandledAbort,omitempty"`
+	// HostedClusters with updated Packages that did not become Available within the progress deadline.
+	// +optional
+	Failed []HostedClusterPackageFailureStatus `json:"failed,omitempty"`
+}
+
+// HostedClusterPackageRolloutState describes the state of a rolling upgrade.
+type HostedClusterPackageRolloutState string
+
+const (
+	// Packages are updated.
+	HostedClusterPackageRolloutStateProgressing HostedClusterPackageRolloutState = "Progressing"
+	// Too many Packages failed, Packages are not updated until the rollout is resumed.
+	HostedClusterPackageRolloutStateHalted HostedClusterPackageRolloutState = "Halted"
+	// Rollout was aborted, Packages are not updated until the rollout is resumed.
+	HostedClusterPackageRolloutStateAborted HostedClusterPackageRolloutState = "Aborted"
+)
+
+// HostedClusterPackageFailureStatus describes a HostedCluster with a Package that failed to become Available.
+type HostedClusterPackageFailureStatus struct {
+	// Name of the HostedCluster or kubeconfig Secret of the target cluster.
+	Name string `json:"name"`
+	// Namespace of the HostedCluster or kubeconfig Secret of the target cluster.
+	Namespace string `json:"namespace"`
+	// Time the Package was updated.
+	UpdatedAt metav1.Time `json:"updatedAt"`
+	// Message of the Available condition of the Package.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 const (
@@ -123,6 +218,18 @@ const (
 	// This means that a rollout can get stuck because this Package will not successfully progress
 	// until it has been unpaused again.
 	HostedClusterPackageHasPausedPackage = "HasPausedPackage"
+	// HostedClusterPackageRolloutHalted indicates that a rolling upgrade was halted,
+	// because too many Packages failed or the rollout was aborted.
+	HostedClusterPackageRolloutHalted = "RolloutHalted"
+)
+
+const (
+	// HostedClusterPackageResumeRolloutAnnotation resumes a halted or aborted rollout,
+	// whenever its value changes, e.g. to the current timestamp.
+	HostedClusterPackageResumeRolloutAnnotation = "package-operator.run/resume-rollout"
+	// HostedClusterPackageAbortRolloutAnnotation aborts a rollout,
+	// whenever its value changes, e.g. to the current timestamp.
+	HostedClusterPackageAbortRolloutAnnotation = "package-operator.run/abort-rollout"
 )
 
 // HostedClusterPackagePartitionStatus describes the status of a partition.
@@ -130,7 +237,25 @@ type HostedClusterPackagePartitionStatus struct {
 	HostedClusterPackageCountsStatus `json:",inline"`
 
 	// Name of the partition.
+	// The partition of HostedClusters without label is named "*".
 	Name string `json:"name"`
+	// Canary is true for the canary partition split off the named partition.
+	// +optional
+	Canary bool `json:"canary,omitempty"`
+	// Members of the canary partition, picked when upgrades of a generation start
+	// and kept until the next generation, even if other targets come and go.
+	// +optional
+	Members []HostedClusterPackageRefStatus `json:"members,omitempty"`
+	// Time the first Package of the partition was updated.
+	// +optional
+	UpdateStartedAt *metav1.Time `json:"updateStartedAt,omitempty"`
+	// Time since all Packages of the partition are updated and Available.
+	// +optional
+	AvailableSince *metav1.Time `json:"availableSince,omitempty"`
+	// Time the partition finished soaking.
+	// Upgrades in the next partition start afterwards.
+	// +optional
+	SoakedAt *metav1.Time `json:"soakedAt,omitempty"`
 }
 
 // HostedClusterPackageCountsStatus counts the status of Packages.
@@ -46,6 +46,7 @@ type ObjectSetList struct {
 // +kubebuilder:validation:XValidation:rule="(has(self.availabilityProbes) == has(oldSelf.availabilityProbes)) && (!has(self.availabilityProbes) || (self.availabilityProbes == oldSelf.availabilityProbes))", message="availabilityProbes is immutable"
 // +kubebuilder:validation:XValidation:rule="(has(self.successDelaySeconds) == has(oldSelf.successDelaySeconds)) && (!has(self.successDelaySeconds) || (self.successDelaySeconds == oldSelf.successDelaySeconds))", message="successDelaySeconds is immutable"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.revision) || (self.revision == oldSelf.revision)", message="revision is immutable"
+// +kubebuilder:validation:XValidation:rule="(has(self.serviceAccount) == has(oldSelf.serviceAccount)) && (!has(self.serviceAccount) || (self.serviceAccount == oldSelf.serviceAccount))", message="serviceAccount is immutable"
 type ObjectSetSpec struct {
 	ObjectSetTemplateSpec `json:",inline"`
 
@@ -59,6 +60,11 @@ type ObjectSetSpec struct {
 
 	// Computed revision number, monotonically increasing.
 	Revision int64 `json:"revision,omitempty"`
+
+	// ServiceAccount to impersonate when reconciling objects.
+	// Objects are reconciled with the identity of Package Operator, if unset.
+	// +optional
+	ServiceAccount *ServiceAccountReference `json:"serviceAccount,omitempty"`
 }
 
 // ObjectSetStatus defines the observed state of a ObjectSet.
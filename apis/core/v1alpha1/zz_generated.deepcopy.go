@@ -7,8 +7,180 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageMirrorSet) DeepCopyInto(out *ClusterImageMirrorSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageMirrorSet.
+func (in *ClusterImageMirrorSet) DeepCopy() *ClusterImageMirrorSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageMirrorSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageMirrorSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageMirrorSetList) DeepCopyInto(out *ClusterImageMirrorSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterImageMirrorSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageMirrorSetList.
+func (in *ClusterImageMirrorSetList) DeepCopy() *ClusterImageMirrorSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageMirrorSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageMirrorSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageMirrorSetSpec) DeepCopyInto(out *ClusterImageMirrorSetSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePrefixOverrides != nil {
+		in, out := &in.ImagePrefixOverrides, &out.ImagePrefixOverrides
+		*out = make([]PackageImagePrefixOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegistryHostOverrides != nil {
+		in, out := &in.RegistryHostOverrides, &out.RegistryHostOverrides
+		*out = make([]RegistryHostOverride, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageMirrorSetSpec.
+func (in *ClusterImageMirrorSetSpec) DeepCopy() *ClusterImageMirrorSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageMirrorSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageVerificationPolicy) DeepCopyInto(out *ClusterImageVerificationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageVerificationPolicy.
+func (in *ClusterImageVerificationPolicy) DeepCopy() *ClusterImageVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageVerificationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageVerificationPolicyList) DeepCopyInto(out *ClusterImageVerificationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterImageVerificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageVerificationPolicyList.
+func (in *ClusterImageVerificationPolicyList) DeepCopy() *ClusterImageVerificationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageVerificationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageVerificationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageVerificationPolicySpec) DeepCopyInto(out *ClusterImageVerificationPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]PublicKeySecretReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageVerificationPolicySpec.
+func (in *ClusterImageVerificationPolicySpec) DeepCopy() *ClusterImageVerificationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageVerificationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterObjectDeployment) DeepCopyInto(out *ClusterObjectDeployment) {
 	*out = *in
@@ -78,6 +250,11 @@ func (in *ClusterObjectDeploymentSpec) DeepCopyInto(out *ClusterObjectDeployment
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.Template.DeepCopyInto(&out.Template)
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectDeploymentSpec.
@@ -310,6 +487,11 @@ func (in *ClusterObjectSetSpec) DeepCopyInto(out *ClusterObjectSetSpec) {
 		*out = make([]PreviousRevisionReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectSetSpec.
@@ -536,6 +718,112 @@ func (in *ClusterPackageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetPackage) DeepCopyInto(out *ClusterSetPackage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetPackage.
+func (in *ClusterSetPackage) DeepCopy() *ClusterSetPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSetPackage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetPackageConfigOverlay) DeepCopyInto(out *ClusterSetPackageConfigOverlay) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetPackageConfigOverlay.
+func (in *ClusterSetPackageConfigOverlay) DeepCopy() *ClusterSetPackageConfigOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetPackageConfigOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetPackageList) DeepCopyInto(out *ClusterSetPackageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSetPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetPackageList.
+func (in *ClusterSetPackageList) DeepCopy() *ClusterSetPackageList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetPackageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSetPackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetPackageSpec) DeepCopyInto(out *ClusterSetPackageSpec) {
+	*out = *in
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(HostedClusterPackagePartitionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigOverlays != nil {
+		in, out := &in.ConfigOverlays, &out.ConfigOverlays
+		*out = make([]ClusterSetPackageConfigOverlay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetPackageSpec.
+func (in *ClusterSetPackageSpec) DeepCopy() *ClusterSetPackageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetPackageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionMapping) DeepCopyInto(out *ConditionMapping) {
 	*out = *in
@@ -593,6 +881,23 @@ func (in *HostedClusterPackage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterPackageConfigOverlay) DeepCopyInto(out *HostedClusterPackageConfigOverlay) {
+	*out = *in
+	in.HostedClusterSelector.DeepCopyInto(&out.HostedClusterSelector)
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackageConfigOverlay.
+func (in *HostedClusterPackageConfigOverlay) DeepCopy() *HostedClusterPackageConfigOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterPackageConfigOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedClusterPackageCountsStatus) DeepCopyInto(out *HostedClusterPackageCountsStatus) {
 	*out = *in
@@ -608,6 +913,22 @@ func (in *HostedClusterPackageCountsStatus) DeepCopy() *HostedClusterPackageCoun
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterPackageFailureStatus) DeepCopyInto(out *HostedClusterPackageFailureStatus) {
+	*out = *in
+	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackageFailureStatus.
+func (in *HostedClusterPackageFailureStatus) DeepCopy() *HostedClusterPackageFailureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterPackageFailureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedClusterPackageList) DeepCopyInto(out *HostedClusterPackageList) {
 	*out = *in
@@ -640,6 +961,21 @@ func (in *HostedClusterPackageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterPackagePartitionCanarySpec) DeepCopyInto(out *HostedClusterPackagePartitionCanarySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackagePartitionCanarySpec.
+func (in *HostedClusterPackagePartitionCanarySpec) DeepCopy() *HostedClusterPackagePartitionCanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterPackagePartitionCanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedClusterPackagePartitionOrderAlphanumericAsc) DeepCopyInto(out *HostedClusterPackagePartitionOrderAlphanumericAsc) {
 	*out = *in
@@ -688,6 +1024,11 @@ func (in *HostedClusterPackagePartitionSpec) DeepCopyInto(out *HostedClusterPack
 		*out = new(HostedClusterPackagePartitionOrderSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(HostedClusterPackagePartitionCanarySpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackagePartitionSpec.
@@ -704,6 +1045,23 @@ func (in *HostedClusterPackagePartitionSpec) DeepCopy() *HostedClusterPackagePar
 func (in *HostedClusterPackagePartitionStatus) DeepCopyInto(out *HostedClusterPackagePartitionStatus) {
 	*out = *in
 	out.HostedClusterPackageCountsStatus = in.HostedClusterPackageCountsStatus
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]HostedClusterPackageRefStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdateStartedAt != nil {
+		in, out := &in.UpdateStartedAt, &out.UpdateStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.AvailableSince != nil {
+		in, out := &in.AvailableSince, &out.AvailableSince
+		*out = (*in).DeepCopy()
+	}
+	if in.SoakedAt != nil {
+		in, out := &in.SoakedAt, &out.SoakedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackagePartitionStatus.
@@ -731,6 +1089,32 @@ func (in *HostedClusterPackageRefStatus) DeepCopy() *HostedClusterPackageRefStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterPackageRolloutStatus) DeepCopyInto(out *HostedClusterPackageRolloutStatus) {
+	*out = *in
+	if in.ResumedAt != nil {
+		in, out := &in.ResumedAt, &out.ResumedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]HostedClusterPackageFailureStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackageRolloutStatus.
+func (in *HostedClusterPackageRolloutStatus) DeepCopy() *HostedClusterPackageRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterPackageRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedClusterPackageSpec) DeepCopyInto(out *HostedClusterPackageSpec) {
 	*out = *in
@@ -742,6 +1126,13 @@ func (in *HostedClusterPackageSpec) DeepCopyInto(out *HostedClusterPackageSpec)
 		*out = new(HostedClusterPackagePartitionSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConfigOverlays != nil {
+		in, out := &in.ConfigOverlays, &out.ConfigOverlays
+		*out = make([]HostedClusterPackageConfigOverlay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackageSpec.
@@ -768,13 +1159,20 @@ func (in *HostedClusterPackageStatus) DeepCopyInto(out *HostedClusterPackageStat
 	if in.Partitions != nil {
 		in, out := &in.Partitions, &out.Partitions
 		*out = make([]HostedClusterPackagePartitionStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Processing != nil {
 		in, out := &in.Processing, &out.Processing
 		*out = make([]HostedClusterPackageRefStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(HostedClusterPackageRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackageStatus.
@@ -798,7 +1196,7 @@ func (in *HostedClusterPackageStrategy) DeepCopyInto(out *HostedClusterPackageSt
 	if in.RollingUpgrade != nil {
 		in, out := &in.RollingUpgrade, &out.RollingUpgrade
 		*out = new(HostedClusterPackageStrategyRollingUpgrade)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -830,6 +1228,11 @@ func (in *HostedClusterPackageStrategyInstant) DeepCopy() *HostedClusterPackageS
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedClusterPackageStrategyRollingUpgrade) DeepCopyInto(out *HostedClusterPackageStrategyRollingUpgrade) {
 	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterPackageStrategyRollingUpgrade.
@@ -911,6 +1314,11 @@ func (in *ObjectDeploymentSpec) DeepCopyInto(out *ObjectDeploymentSpec) {
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.Template.DeepCopyInto(&out.Template)
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDeploymentSpec.
@@ -1187,6 +1595,11 @@ func (in *ObjectSetSpec) DeepCopyInto(out *ObjectSetSpec) {
 		*out = make([]PreviousRevisionReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetSpec.
@@ -1427,9 +1840,64 @@ func (in *ObjectTemplateList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateNamespaceFailure) DeepCopyInto(out *ObjectTemplateNamespaceFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateNamespaceFailure.
+func (in *ObjectTemplateNamespaceFailure) DeepCopy() *ObjectTemplateNamespaceFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateNamespaceFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateNamespacesStatus) DeepCopyInto(out *ObjectTemplateNamespacesStatus) {
+	*out = *in
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]ObjectTemplateNamespaceFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateNamespacesStatus.
+func (in *ObjectTemplateNamespacesStatus) DeepCopy() *ObjectTemplateNamespacesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateNamespacesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateObservedSource) DeepCopyInto(out *ObjectTemplateObservedSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateObservedSource.
+func (in *ObjectTemplateObservedSource) DeepCopy() *ObjectTemplateObservedSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateObservedSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTemplateSource) DeepCopyInto(out *ObjectTemplateSource) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]ObjectTemplateSourceItem, len(*in))
@@ -1472,6 +1940,11 @@ func (in *ObjectTemplateSpec) DeepCopyInto(out *ObjectTemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSpec.
@@ -1495,6 +1968,21 @@ func (in *ObjectTemplateStatus) DeepCopyInto(out *ObjectTemplateStatus) {
 		}
 	}
 	out.ControllerOf = in.ControllerOf
+	if in.ControlledObjects != nil {
+		in, out := &in.ControlledObjects, &out.ControlledObjects
+		*out = make([]ControlledObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(ObjectTemplateNamespacesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ObjectTemplateObservedSource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateStatus.
@@ -1534,6 +2022,36 @@ func (in *Package) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageImageOverride) DeepCopyInto(out *PackageImageOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageImageOverride.
+func (in *PackageImageOverride) DeepCopy() *PackageImageOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageImageOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageImagePrefixOverride) DeepCopyInto(out *PackageImagePrefixOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageImagePrefixOverride.
+func (in *PackageImagePrefixOverride) DeepCopy() *PackageImagePrefixOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageImagePrefixOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageList) DeepCopyInto(out *PackageList) {
 	*out = *in
@@ -1566,6 +2084,46 @@ func (in *PackageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePatch) DeepCopyInto(out *PackagePatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(PackagePatchTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePatch.
+func (in *PackagePatch) DeepCopy() *PackagePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePatchTarget) DeepCopyInto(out *PackagePatchTarget) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePatchTarget.
+func (in *PackagePatchTarget) DeepCopy() *PackagePatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageProbeKindSpec) DeepCopyInto(out *PackageProbeKindSpec) {
 	*out = *in
@@ -1589,6 +2147,42 @@ func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]PackagePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImageOverrides != nil {
+		in, out := &in.ImageOverrides, &out.ImageOverrides
+		*out = make([]PackageImageOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePrefixOverrides != nil {
+		in, out := &in.ImagePrefixOverrides, &out.ImagePrefixOverrides
+		*out = make([]PackageImagePrefixOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSpec.
@@ -1755,6 +2349,36 @@ func (in *ProbeSelector) DeepCopy() *ProbeSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicKeySecretReference) DeepCopyInto(out *PublicKeySecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeySecretReference.
+func (in *PublicKeySecretReference) DeepCopy() *PublicKeySecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicKeySecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryHostOverride) DeepCopyInto(out *RegistryHostOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryHostOverride.
+func (in *RegistryHostOverride) DeepCopy() *RegistryHostOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryHostOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemotePhaseReference) DeepCopyInto(out *RemotePhaseReference) {
 	*out = *in
@@ -1769,3 +2393,18 @@ func (in *RemotePhaseReference) DeepCopy() *RemotePhaseReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -30,6 +30,9 @@ const (
 	PackageSourceImageAnnotation = "package-operator.run/package-source-image"
 	// PackageConfigAnnotation contains the configuration for this object.
 	PackageConfigAnnotation = "package-operator.run/package-config"
+	// PackageImagesAnnotation lists the images used by the package as JSON array,
+	// before image prefix overrides configured for Package Operator and ClusterImageMirrorSets are applied.
+	PackageImagesAnnotation = "package-operator.run/package-images"
 	// PackageInstanceLabel contains the name of the Package instance.
 	PackageInstanceLabel = "package-operator.run/instance"
 )
@@ -240,6 +243,8 @@ type PackageManifestTest struct {
 	// Template testing configuration.
 	Template    []PackageManifestTestCaseTemplate `json:"template,omitempty"`
 	Kubeconform *PackageManifestTestKubeconform   `json:"kubeconform,omitempty"`
+	// Lint rule configuration.
+	Lint *PackageManifestTestLint `json:"lint,omitempty"`
 }
 
 // PackageManifestTestCaseTemplate template testing configuration.
@@ -264,6 +269,17 @@ type PackageManifestTestKubeconform struct {
 	SchemaLocations []string `json:"schemaLocations,omitempty"`
 }
 
+// PackageManifestTestLint configures which lint rules to run against the rendered output of template test cases.
+type PackageManifestTestLint struct {
+	// Names of lint rules to run in addition to the rules enabled by default.
+	// +example=[resource-requests]
+	Enable []string `json:"enable,omitempty"`
+	// Names of lint rules to skip.
+	// Takes precedence over enable.
+	// +example=[availability-probes]
+	Disable []string `json:"disable,omitempty"`
+}
+
 // TemplateContext is available within the package templating process.
 type TemplateContext struct {
 	// Package object.
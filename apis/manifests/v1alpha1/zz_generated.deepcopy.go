@@ -555,6 +555,11 @@ func (in *PackageManifestTest) DeepCopyInto(out *PackageManifestTest) {
 		*out = new(PackageManifestTestKubeconform)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Lint != nil {
+		in, out := &in.Lint, &out.Lint
+		*out = new(PackageManifestTestLint)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestTest.
@@ -603,6 +608,31 @@ func (in *PackageManifestTestKubeconform) DeepCopy() *PackageManifestTestKubecon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageManifestTestLint) DeepCopyInto(out *PackageManifestTestLint) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestTestLint.
+func (in *PackageManifestTestLint) DeepCopy() *PackageManifestTestLint {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageManifestTestLint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageManifestUniqueInScopeConstraint) DeepCopyInto(out *PackageManifestUniqueInScopeConstraint) {
 	*out = *in
@@ -3,15 +3,24 @@ package buildcmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
 )
 
+// Environment variable holding the timestamp recorded in SBOMs as seconds since the unix epoch.
+// See https://reproducible-builds.org/specs/source-date-epoch/.
+const sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
 type BuilderFactory interface {
 	Builder() Builder
 }
@@ -22,10 +31,11 @@ type Builder interface {
 
 func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 	const (
-		buildUse   = "build source_path [--tag tag]... [--output output_path] [--push]"
+		buildUse   = "build source_path [--tag tag]... [--output output_path] [--push] [--sbom format]"
 		buildShort = "build an PKO package image using manifests at the given path"
 		buildLong  = "builds and optionally pushes an OCI image in the Package Operator" +
-			" package format from the specified build context directory."
+			" package format from the specified build context directory." +
+			" SBOMs record the time given by the " + sourceDateEpochEnv + " environment variable, if set."
 		buildSuccessMessage = "Package built successfully!"
 	)
 
@@ -53,52 +63,86 @@ func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 				return fmt.Errorf("invalid tag specified as parameter %s: %w", ref, err)
 			}
 		}
+		if opts.SBOM != "" && !slices.Contains(packages.SBOMFormats, opts.SBOM) {
+			return fmt.Errorf("%w: unknown SBOM format: %s", internalcmd.ErrInvalidArgs, opts.SBOM)
+		}
 		for label, value := range opts.Labels {
 			if value == "" {
 				return fmt.Errorf("parsing label %q, value is empty", label)
 			}
 		}
+		sbomCreated, err := sourceDateEpoch()
+		if err != nil {
+			return err
+		}
 		factoryOpts := []internalcmd.BuildFromSourceOption{
 			internalcmd.WithInsecure(opts.Insecure),
 			internalcmd.WithOutputPath(opts.OutputPath),
 			internalcmd.WithPush(opts.Push),
 			internalcmd.WithTags(opts.Tags),
 			internalcmd.WithLabels(opts.Labels),
+			internalcmd.WithSBOMCreated(sbomCreated),
+			internalcmd.WithSBOMFormat(opts.SBOM),
 		}
 
 		switch opts.OutputFormat {
 		case "":
-		case internalcmd.OutputFormatHuman, internalcmd.OutputFormatDigest:
+		case internalcmd.OutputFormatHuman, internalcmd.OutputFormatDigest,
+			internalcmd.OutputFormatJSON, internalcmd.OutputFormatSARIF:
 			factoryOpts = append(factoryOpts, internalcmd.WithOutputFormat(opts.OutputFormat))
 		default:
 			return fmt.Errorf("unknown output format: %s", opts.OutputFormat)
 		}
 
-		if err := builderFactory.Builder().BuildFromSource(cmd.Context(), src, factoryOpts...); err != nil {
-			return fmt.Errorf("building from source: %w", err)
-		}
+		buildErr := builderFactory.Builder().BuildFromSource(cmd.Context(), src, factoryOpts...)
 
 		switch opts.OutputFormat {
 		case "", internalcmd.OutputFormatHuman:
+			if buildErr != nil {
+				break
+			}
 			if _, err := fmt.Fprint(cmd.OutOrStdout(), buildSuccessMessage); err != nil {
 				panic(err)
 			}
 		case internalcmd.OutputFormatDigest:
+		case internalcmd.OutputFormatJSON, internalcmd.OutputFormatSARIF:
+			report := internalcmd.NewViolationReport(cmd.Context(), src, buildErr)
+			if err := report.Write(cmd.OutOrStdout(), opts.OutputFormat, src); err != nil {
+				return fmt.Errorf("writing report: %w", err)
+			}
 		default:
 			panic(opts.OutputFormat)
 		}
 
+		if buildErr != nil {
+			return fmt.Errorf("building from source: %w", buildErr)
+		}
+
 		return nil
 	}
 
 	return cmd
 }
 
+// Returns the time given by SOURCE_DATE_EPOCH or the zero time if it is not set.
+func sourceDateEpoch() (time.Time, error) {
+	value := os.Getenv(sourceDateEpochEnv)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: parsing %s: %w", internalcmd.ErrInvalidArgs, sourceDateEpochEnv, err)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
 type options struct {
 	Insecure     bool
 	OutputPath   string
 	OutputFormat string
 	Push         bool
+	SBOM         string
 	Tags         []string
 	Labels       map[string]string
 }
@@ -137,8 +181,18 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 	)
 	flags.StringVar(&o.OutputFormat, "output-format", internalcmd.OutputFormatHuman,
 		strings.Join([]string{
-			"Either `human` for regular stdout output or `digest` for only printing ",
+			"Either `human` for regular stdout output, `digest` for only printing",
 			"the image digest of the pushed package image",
+			"or `json`/`sarif` for a machine readable report of validation violations and errors",
+		}, " "),
+	)
+	flags.StringVar(
+		&o.SBOM,
+		"sbom",
+		o.SBOM,
+		strings.Join([]string{
+			"Attach a software bill of materials listing all locked images, dependencies and file digests",
+			"to the created image. Either `spdx` or `cyclonedx`. Defaults to none.",
 		}, " "),
 	)
 	flags.StringToStringVarP(
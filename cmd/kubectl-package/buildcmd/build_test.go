@@ -5,12 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
 )
 
 func TestBuildOutput(t *testing.T) {
@@ -46,6 +48,96 @@ func TestBuildOutput(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBuildOutputSBOM(t *testing.T) {
+	t.Parallel()
+
+	output := filepath.Join(t.TempDir(), "pkg.tar")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	packagePath := filepath.Join(wd, "testdata")
+
+	factory := &builderFactoryMock{}
+	factory.On("Builder").Return(internalcmd.NewBuild())
+
+	cmd := NewCmd(factory)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{packagePath, "--tag", "chicken:oldest", "--output", output, "--sbom", "spdx"})
+
+	require.NoError(t, cmd.Execute())
+
+	i, err := tarball.ImageFromPath(output, nil)
+	require.NoError(t, err)
+	sbom, err := packages.SBOMFromOCI(i)
+	require.NoError(t, err)
+	require.Equal(t, packages.SBOMFormatSPDX, sbom.Format)
+	require.Contains(t, string(sbom.Document), "deployment.yaml.gotmpl")
+}
+
+func TestBuildOutputSBOM_reproducible(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	packagePath := filepath.Join(wd, "testdata")
+
+	digests := make([]string, 2)
+	for i := range digests {
+		output := filepath.Join(t.TempDir(), "pkg.tar")
+
+		factory := &builderFactoryMock{}
+		factory.On("Builder").Return(internalcmd.NewBuild())
+
+		cmd := NewCmd(factory)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{packagePath, "--tag", "chicken:oldest", "--output", output, "--sbom", "cyclonedx"})
+		require.NoError(t, cmd.Execute())
+
+		image, err := tarball.ImageFromPath(output, nil)
+		require.NoError(t, err)
+		digest, err := image.Digest()
+		require.NoError(t, err)
+		digests[i] = digest.String()
+	}
+	require.Equal(t, digests[0], digests[1])
+}
+
+//nolint:paralleltest
+//nolint:nolintlint // directive `//nolint:paralleltest` is unused for linter "paralleltest" (nolintlint)
+func Test_sourceDateEpoch(t *testing.T) {
+	// Uses t.Setenv, which cannot be combined with t.Parallel.
+
+	t.Setenv(sourceDateEpochEnv, "")
+	created, err := sourceDateEpoch()
+	require.NoError(t, err)
+	require.True(t, created.IsZero())
+
+	t.Setenv(sourceDateEpochEnv, "1700000000")
+	created, err = sourceDateEpoch()
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1700000000, 0).UTC(), created)
+
+	t.Setenv(sourceDateEpochEnv, "yesterday")
+	_, err = sourceDateEpoch()
+	require.ErrorIs(t, err, internalcmd.ErrInvalidArgs)
+}
+
+func TestBuildInvalidSBOMFormat(t *testing.T) {
+	t.Parallel()
+
+	factory := &builderFactoryMock{}
+	factory.On("Builder").Return(internalcmd.NewBuild())
+
+	cmd := NewCmd(factory)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"testdata", "--sbom", "banana"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
+
 func TestBuildEmptySource(t *testing.T) {
 	t.Parallel()
 
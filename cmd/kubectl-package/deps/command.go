@@ -9,14 +9,19 @@ import (
 
 	"package-operator.run/cmd/kubectl-package/buildcmd"
 	"package-operator.run/cmd/kubectl-package/clustertreecmd"
+	"package-operator.run/cmd/kubectl-package/inspectcmd"
 	"package-operator.run/cmd/kubectl-package/kickstartcmd"
+	"package-operator.run/cmd/kubectl-package/lintcmd"
+	"package-operator.run/cmd/kubectl-package/mirrorcmd"
 	"package-operator.run/cmd/kubectl-package/pausecmd"
 	"package-operator.run/cmd/kubectl-package/repocmd"
 	"package-operator.run/cmd/kubectl-package/rolloutcmd"
 	"package-operator.run/cmd/kubectl-package/rootcmd"
+	"package-operator.run/cmd/kubectl-package/signcmd"
 	"package-operator.run/cmd/kubectl-package/treecmd"
 	"package-operator.run/cmd/kubectl-package/updatecmd"
 	"package-operator.run/cmd/kubectl-package/validatecmd"
+	"package-operator.run/cmd/kubectl-package/verifycmd"
 	"package-operator.run/cmd/kubectl-package/versioncmd"
 	internalcmd "package-operator.run/internal/cmd"
 )
@@ -102,6 +107,86 @@ func ProvideValidator(scheme *runtime.Scheme) validatecmd.Validator {
 	return internalcmd.NewValidate(scheme)
 }
 
+func ProvideInspectCmd(inspector inspectcmd.Inspector) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: inspectcmd.NewCmd(
+			inspector,
+		),
+	}
+}
+
+func ProvideInspector(f LogFactory) inspectcmd.Inspector {
+	return internalcmd.NewInspect(
+		internalcmd.WithLog{
+			Log: f.Logger(),
+		},
+	)
+}
+
+func ProvideMirrorCmd(mirrorer mirrorcmd.Mirrorer) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: mirrorcmd.NewCmd(
+			mirrorer,
+		),
+	}
+}
+
+func ProvideMirrorer(f LogFactory) mirrorcmd.Mirrorer {
+	return internalcmd.NewMirror(
+		internalcmd.WithLog{
+			Log: f.Logger(),
+		},
+	)
+}
+
+func ProvideSignCmd(signer signcmd.Signer) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: signcmd.NewCmd(
+			signer,
+		),
+	}
+}
+
+func ProvideSigner(f LogFactory) signcmd.Signer {
+	return internalcmd.NewSign(
+		internalcmd.WithLog{
+			Log: f.Logger(),
+		},
+	)
+}
+
+func ProvideVerifyCmd(verifier verifycmd.Verifier) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: verifycmd.NewCmd(
+			verifier,
+		),
+	}
+}
+
+func ProvideVerifier(f LogFactory) verifycmd.Verifier {
+	return internalcmd.NewSign(
+		internalcmd.WithLog{
+			Log: f.Logger(),
+		},
+	)
+}
+
+func ProvideLintCmd(linter lintcmd.Linter) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: lintcmd.NewCmd(
+			linter,
+		),
+	}
+}
+
+func ProvideLinter(f LogFactory) lintcmd.Linter {
+	return internalcmd.NewLint(
+		internalcmd.WithLog{
+			Log: f.Logger(),
+		},
+	)
+}
+
 func ProvideBuildCmd(builderFactory buildcmd.BuilderFactory) RootSubCommandResult {
 	return RootSubCommandResult{
 		SubCommand: buildcmd.NewCmd(
@@ -27,6 +27,11 @@ func constructors() []any {
 		ProvideClusterTreeCmd,
 		ProvideUpdateCmd,
 		ProvideValidateCmd,
+		ProvideLintCmd,
+		ProvideInspectCmd,
+		ProvideMirrorCmd,
+		ProvideSignCmd,
+		ProvideVerifyCmd,
 		ProvideBuildCmd,
 		ProvideVersionCmd,
 		ProvideLogFactory,
@@ -36,6 +41,11 @@ func constructors() []any {
 		ProvideUpdater,
 		ProvideBuilderFactory,
 		ProvideValidator,
+		ProvideLinter,
+		ProvideInspector,
+		ProvideMirrorer,
+		ProvideSigner,
+		ProvideVerifier,
 		ProvideRendererFactory,
 		ProvideRolloutCmd,
 		ProvideClientFactory,
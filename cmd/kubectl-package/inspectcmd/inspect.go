@@ -0,0 +1,107 @@
+package inspectcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+)
+
+type Inspector interface {
+	InspectPackage(
+		ctx context.Context, target string, opts ...internalcmd.InspectPackageOption,
+	) (*internalcmd.PackageInspection, error)
+	InspectSBOM(ctx context.Context, target string, opts ...internalcmd.InspectPackageOption) (*packages.SBOM, error)
+}
+
+func NewCmd(inspector Inspector) *cobra.Command {
+	const (
+		inspectUse   = "inspect [--insecure] [--sbom] [--output format] target"
+		inspectShort = "inspect a package."
+		inspectLong  = "inspect a package and print its metadata, scopes, phases, config schema, images, " +
+			"dependencies, constraints, components and image labels. " +
+			"With --sbom, print the software bill of materials attached to the image by build --sbom instead. " +
+			"Target may be an image reference, an image tar created by build --output or a package source folder."
+	)
+
+	cmd := &cobra.Command{
+		Use:   inspectUse,
+		Short: inspectShort,
+		Long:  inspectLong,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	var opts options
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		target := args[0]
+		if target == "" {
+			return fmt.Errorf("%w: 'target' must not be empty", internalcmd.ErrInvalidArgs)
+		}
+
+		switch opts.Output {
+		case internalcmd.OutputFormatHuman, internalcmd.OutputFormatJSON, internalcmd.OutputFormatYAML:
+		default:
+			return fmt.Errorf("%w: unknown output format: %s", internalcmd.ErrInvalidArgs, opts.Output)
+		}
+
+		if opts.SBOM {
+			sbom, err := inspector.InspectSBOM(cmd.Context(), target, internalcmd.WithInsecure(opts.Insecure))
+			if err != nil {
+				return fmt.Errorf("inspecting package: %w", err)
+			}
+
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(sbom.Document)); err != nil {
+				panic(err)
+			}
+			return nil
+		}
+
+		inspection, err := inspector.InspectPackage(cmd.Context(), target, internalcmd.WithInsecure(opts.Insecure))
+		if err != nil {
+			return fmt.Errorf("inspecting package: %w", err)
+		}
+
+		if err := inspection.Write(cmd.OutOrStdout(), opts.Output); err != nil {
+			return fmt.Errorf("writing inspection: %w", err)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+type options struct {
+	Insecure bool
+	Output   string
+	SBOM     bool
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Insecure,
+		"insecure",
+		o.Insecure,
+		"Allows pulling images without TLS or using TLS with unverified certificates.",
+	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		internalcmd.OutputFormatHuman,
+		"Output format, either `human`, `json` or `yaml`",
+	)
+	flags.BoolVar(
+		&o.SBOM,
+		"sbom",
+		o.SBOM,
+		"print the software bill of materials attached to the package image",
+	)
+}
@@ -0,0 +1,107 @@
+package inspectcmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+)
+
+type inspectorMock struct {
+	inspection *internalcmd.PackageInspection
+	sbom       *packages.SBOM
+	err        error
+}
+
+func (m inspectorMock) InspectPackage(
+	context.Context, string, ...internalcmd.InspectPackageOption,
+) (*internalcmd.PackageInspection, error) {
+	return m.inspection, m.err
+}
+
+func (m inspectorMock) InspectSBOM(
+	context.Context, string, ...internalcmd.InspectPackageOption,
+) (*packages.SBOM, error) {
+	return m.sbom, m.err
+}
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	inspection := &internalcmd.PackageInspection{
+		Name:   "test",
+		Scopes: []string{"Namespaced"},
+		Phases: []internalcmd.InspectedPhase{{Name: "deploy"}},
+	}
+
+	for name, tc := range map[string]struct {
+		args     []string
+		expected string
+	}{
+		"human": {
+			expected: "test\n└── Scopes: Namespaced\n└── Phases\n    └── deploy\n",
+		},
+		"json": {
+			args: []string{"-o", "json"},
+			expected: "{\n  \"name\": \"test\",\n  \"scopes\": [\n    \"Namespaced\"\n  ],\n" +
+				"  \"phases\": [\n    {\n      \"name\": \"deploy\"\n    }\n  ]\n}\n",
+		},
+		"yaml": {
+			args:     []string{"-o", "yaml"},
+			expected: "name: test\nphases:\n- name: deploy\nscopes:\n- Namespaced\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := NewCmd(inspectorMock{inspection: inspection})
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetArgs(append(tc.args, "quay.io/package-operator/test:v1"))
+
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tc.expected, stdout.String())
+		})
+	}
+}
+
+func TestInspect_InvalidOutput(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(inspectorMock{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"-o", "banana", "quay.io/package-operator/test:v1"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
+
+func TestInspect_SBOM(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(inspectorMock{sbom: &packages.SBOM{
+		Format: packages.SBOMFormatSPDX, Document: []byte(`{"spdxVersion":"SPDX-2.3"}`),
+	}})
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"--sbom", "quay.io/package-operator/test:v1"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "{\"spdxVersion\":\"SPDX-2.3\"}\n", stdout.String())
+}
+
+func TestInspect_NoSBOM(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(inspectorMock{err: packages.ErrNoSBOM})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--sbom", "quay.io/package-operator/test:v1"})
+
+	require.ErrorIs(t, cmd.Execute(), packages.ErrNoSBOM)
+}
@@ -59,7 +59,8 @@ type options struct {
 func (o *options) AddFlags(flags *pflag.FlagSet) {
 	const (
 		inputUse = "Files or urls to load objects from. " +
-			`Supports glob and "-" to read from stdin. Can be supplied multiple times.`
+			`Supports glob and "-" to read from stdin. Can be supplied multiple times. ` +
+			"Files ending in .jsonnet are evaluated."
 		olmBundleUse = "OLM Bundle OCI to import. e.g. quay.io/xx/xxx:tag. " +
 			"Overrides the output package name with the bundle's name."
 		parametrizeUse = "Parametrize flags: e.g. replicas."
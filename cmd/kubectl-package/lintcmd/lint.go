@@ -0,0 +1,51 @@
+package lintcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+type Linter interface {
+	LintPackage(ctx context.Context, path string) error
+}
+
+func NewCmd(linter Linter) *cobra.Command {
+	const (
+		lintUse   = "lint source_path"
+		lintShort = "lint a package for best practice violations."
+		lintLong  = "lint a package for best practice violations. " +
+			"Rules run against the rendered output of every template test case " +
+			"and can be enabled or disabled via .test.lint in the PackageManifest."
+		lintSuccessMessage = "Package linted successfully!"
+	)
+
+	cmd := &cobra.Command{
+		Use:   lintUse,
+		Short: lintShort,
+		Long:  lintLong,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		src := args[0]
+		if src == "" {
+			return fmt.Errorf("%w: 'source_path' must not be empty", internalcmd.ErrInvalidArgs)
+		}
+
+		if err := linter.LintPackage(cmd.Context(), src); err != nil {
+			return fmt.Errorf("linting package: %w", err)
+		}
+
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), lintSuccessMessage); err != nil {
+			panic(err)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
@@ -0,0 +1,38 @@
+package lintcmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func TestLintFolder(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(internalcmd.NewLint())
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{"testdata"})
+
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Package linted successfully!", stdout.String())
+	require.Empty(t, stderr.String())
+}
+
+func TestLint_NoPath(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(internalcmd.NewLint())
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	require.Error(t, cmd.Execute())
+	require.NotEmpty(t, stderr.String())
+}
@@ -0,0 +1,151 @@
+package mirrorcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+const (
+	// Comma separated overrides as accepted by --image-prefix-overrides of package-operator-manager.
+	outputFlag = "flag"
+	// ClusterImageMirrorSet manifest.
+	outputYAML = "yaml"
+)
+
+type Mirrorer interface {
+	MirrorPackage(
+		ctx context.Context, image, target string, opts ...internalcmd.MirrorPackageOption,
+	) (*internalcmd.MirrorResult, error)
+}
+
+func NewCmd(mirrorer Mirrorer) *cobra.Command {
+	const (
+		mirrorUse   = "mirror [--layout] [--insecure] [--output format] image target"
+		mirrorShort = "mirror a package and all its images."
+		mirrorLong  = "mirror a package image, every image in its lock file and all dependency packages " +
+			"into the target registry prefix or an OCI layout directory if --layout is set. " +
+			"Prints the image prefix overrides to configure Package Operator with, " +
+			"either as value for --image-prefix-overrides or as ClusterImageMirrorSet."
+	)
+
+	cmd := &cobra.Command{
+		Use:   mirrorUse,
+		Short: mirrorShort,
+		Long:  mirrorLong,
+		Args:  cobra.ExactArgs(2),
+	}
+
+	var opts options
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		image, target := args[0], args[1]
+		if image == "" || target == "" {
+			return fmt.Errorf("%w: 'image' and 'target' must not be empty", internalcmd.ErrInvalidArgs)
+		}
+		switch opts.Output {
+		case outputFlag, outputYAML:
+		default:
+			return fmt.Errorf("%w: unknown output format: %s", internalcmd.ErrInvalidArgs, opts.Output)
+		}
+
+		res, err := mirrorer.MirrorPackage(cmd.Context(), image, target,
+			internalcmd.WithInsecure(opts.Insecure),
+			internalcmd.WithLayout(opts.Layout),
+		)
+		if err != nil {
+			return fmt.Errorf("mirroring package: %w", err)
+		}
+
+		if opts.Layout {
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Mirrored %d images to %s\n", len(res.Images), target); err != nil {
+				panic(err)
+			}
+			return nil
+		}
+
+		out, err := formatOverrides(res, opts)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), out); err != nil {
+			panic(err)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+func formatOverrides(res *internalcmd.MirrorResult, opts options) (string, error) {
+	if opts.Output == outputFlag {
+		overrides := make([]string, 0, len(res.Overrides))
+		for _, o := range res.Overrides {
+			overrides = append(overrides, o.From+"="+o.To)
+		}
+		return strings.Join(overrides, ","), nil
+	}
+
+	mirrorSet := &corev1alpha1.ClusterImageMirrorSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.GroupVersion.String(),
+			Kind:       "ClusterImageMirrorSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.Name},
+	}
+	for _, o := range res.Overrides {
+		mirrorSet.Spec.ImagePrefixOverrides = append(mirrorSet.Spec.ImagePrefixOverrides,
+			corev1alpha1.PackageImagePrefixOverride{From: o.From, To: o.To})
+	}
+	b, err := yaml.Marshal(mirrorSet)
+	if err != nil {
+		return "", fmt.Errorf("marshalling ClusterImageMirrorSet: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+type options struct {
+	Insecure bool
+	Layout   bool
+	Name     string
+	Output   string
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Insecure,
+		"insecure",
+		o.Insecure,
+		"Allows pulling and pushing images without TLS or using TLS with unverified certificates.",
+	)
+	flags.BoolVar(
+		&o.Layout,
+		"layout",
+		o.Layout,
+		"treat target as OCI layout directory instead of a registry prefix",
+	)
+	flags.StringVar(
+		&o.Name,
+		"name",
+		"package-mirror",
+		"Name of the ClusterImageMirrorSet printed with --output yaml.",
+	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		outputFlag,
+		"Output format of the image prefix overrides, either `flag` or `yaml`",
+	)
+}
@@ -0,0 +1,97 @@
+package mirrorcmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/imageprefix"
+)
+
+type mirrorerMock struct {
+	cfg internalcmd.MirrorPackageConfig
+	res *internalcmd.MirrorResult
+}
+
+func (m *mirrorerMock) MirrorPackage(
+	_ context.Context, _, _ string, opts ...internalcmd.MirrorPackageOption,
+) (*internalcmd.MirrorResult, error) {
+	m.cfg.Option(opts...)
+	return m.res, nil
+}
+
+var testResult = &internalcmd.MirrorResult{
+	Images: []internalcmd.MirroredImage{
+		{Source: "quay.io/example/pkg@sha256:1234", Target: "mirror.example.com/example/pkg@sha256:1234"},
+		{Source: "quay.io/example/nginx@sha256:5678", Target: "mirror.example.com/example/nginx@sha256:5678"},
+	},
+	Overrides: []imageprefix.Override{
+		{From: "quay.io/example/nginx", To: "mirror.example.com/example/nginx"},
+		{From: "quay.io/example/pkg", To: "mirror.example.com/example/pkg"},
+	},
+}
+
+func TestMirror(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(&mirrorerMock{res: testResult})
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"quay.io/example/pkg:v1", "mirror.example.com"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t,
+		"quay.io/example/nginx=mirror.example.com/example/nginx,quay.io/example/pkg=mirror.example.com/example/pkg\n",
+		stdout.String())
+}
+
+func TestMirror_YAML(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(&mirrorerMock{res: testResult})
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"quay.io/example/pkg:v1", "mirror.example.com", "-o", "yaml", "--name", "test"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, `apiVersion: package-operator.run/v1alpha1
+kind: ClusterImageMirrorSet
+metadata:
+  name: test
+spec:
+  imagePrefixOverrides:
+  - from: quay.io/example/nginx
+    to: mirror.example.com/example/nginx
+  - from: quay.io/example/pkg
+    to: mirror.example.com/example/pkg
+`, stdout.String())
+}
+
+func TestMirror_Layout(t *testing.T) {
+	t.Parallel()
+
+	mirrorer := &mirrorerMock{res: testResult}
+	cmd := NewCmd(mirrorer)
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"quay.io/example/pkg:v1", "dir", "--layout"})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, mirrorer.cfg.Layout)
+	assert.Equal(t, "Mirrored 2 images to dir\n", stdout.String())
+}
+
+func TestMirror_InvalidOutput(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(&mirrorerMock{res: testResult})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"quay.io/example/pkg:v1", "mirror.example.com", "-o", "banana"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
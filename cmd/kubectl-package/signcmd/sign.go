@@ -0,0 +1,96 @@
+package signcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+// Environment variable holding the password of encrypted private keys, same as used by cosign.
+const keyPasswordEnv = "COSIGN_PASSWORD"
+
+type Signer interface {
+	SignPackage(ctx context.Context, target string, opts ...internalcmd.SignPackageOption) (string, error)
+}
+
+func NewCmd(signer Signer) *cobra.Command {
+	const (
+		signUse   = "sign [--layout] [--insecure] --key path target"
+		signShort = "sign a package image."
+		signLong  = "sign a package image with a local private key. " +
+			"Target may be an image reference in a registry or an OCI layout directory if --layout is set. " +
+			"The signature is stored in the cosign format next to the image. " +
+			"The password of encrypted keys is read from the " + keyPasswordEnv + " environment variable."
+	)
+
+	cmd := &cobra.Command{
+		Use:   signUse,
+		Short: signShort,
+		Long:  signLong,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	var opts options
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		target := args[0]
+		if target == "" {
+			return fmt.Errorf("%w: 'target' must not be empty", internalcmd.ErrInvalidArgs)
+		}
+		if opts.Key == "" {
+			return fmt.Errorf("%w: '--key' must be provided", internalcmd.ErrInvalidArgs)
+		}
+
+		digest, err := signer.SignPackage(cmd.Context(), target,
+			internalcmd.WithInsecure(opts.Insecure),
+			internalcmd.WithKeyPassword(os.Getenv(keyPasswordEnv)),
+			internalcmd.WithKeyPath(opts.Key),
+			internalcmd.WithLayout(opts.Layout),
+		)
+		if err != nil {
+			return fmt.Errorf("signing package: %w", err)
+		}
+
+		if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Signed %s\n", digest); err != nil {
+			panic(err)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+type options struct {
+	Insecure bool
+	Key      string
+	Layout   bool
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Insecure,
+		"insecure",
+		o.Insecure,
+		"Allows pushing signatures without TLS or using TLS with unverified certificates.",
+	)
+	flags.StringVar(
+		&o.Key,
+		"key",
+		o.Key,
+		"Path to the PEM encoded private key to sign with.",
+	)
+	flags.BoolVar(
+		&o.Layout,
+		"layout",
+		o.Layout,
+		"treat target as OCI layout directory instead of an image reference",
+	)
+}
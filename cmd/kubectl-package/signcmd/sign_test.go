@@ -0,0 +1,67 @@
+package signcmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+type signerMock struct {
+	cfg internalcmd.SignPackageConfig
+	err error
+}
+
+func (m *signerMock) SignPackage(
+	_ context.Context, _ string, opts ...internalcmd.SignPackageOption,
+) (string, error) {
+	m.cfg.Option(opts...)
+	if m.err != nil {
+		return "", m.err
+	}
+	return "quay.io/package-operator/test@sha256:1234", nil
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	signer := &signerMock{}
+	cmd := NewCmd(signer)
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"--key", "cosign.key", "--insecure", "quay.io/package-operator/test:v1"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Signed quay.io/package-operator/test@sha256:1234\n", stdout.String())
+	assert.Equal(t, "cosign.key", signer.cfg.KeyPath)
+	assert.True(t, signer.cfg.Insecure)
+	assert.False(t, signer.cfg.Layout)
+}
+
+func TestSign_NoKey(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(&signerMock{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"quay.io/package-operator/test:v1"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
+
+func TestSign_Error(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test")
+	cmd := NewCmd(&signerMock{err: errTest})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--key", "cosign.key", "--layout", "dir"})
+
+	require.ErrorIs(t, cmd.Execute(), errTest)
+}
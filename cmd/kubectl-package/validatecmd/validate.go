@@ -16,7 +16,7 @@ type Validator interface {
 
 func NewCmd(validator Validator) *cobra.Command {
 	const (
-		validateUse   = "validate [--pull] target"
+		validateUse   = "validate [--pull] [--output format] target"
 		validateShort = "validate a package."
 		validateLong  = "validate a package. Target may be a source directory, " +
 			"a package in a tar[.gz] or a fully qualified tag if --pull is set."
@@ -39,9 +39,16 @@ func NewCmd(validator Validator) *cobra.Command {
 		if src == "" {
 			return fmt.Errorf("%w: 'target' must not be empty", internalcmd.ErrInvalidArgs)
 		}
+		switch opts.Output {
+		case internalcmd.OutputFormatHuman, internalcmd.OutputFormatJSON, internalcmd.OutputFormatSARIF:
+		default:
+			return fmt.Errorf("%w: unknown output format: %s", internalcmd.ErrInvalidArgs, opts.Output)
+		}
 
 		validateOptions := []internalcmd.ValidatePackageOption{
 			internalcmd.WithInsecure(opts.Insecure),
+			internalcmd.WithKubeVersion(opts.KubeVersion),
+			internalcmd.WithRejectDeprecatedAPIs(opts.RejectDeprecatedAPIs),
 		}
 
 		if opts.Pull {
@@ -50,12 +57,29 @@ func NewCmd(validator Validator) *cobra.Command {
 			validateOptions = append(validateOptions, internalcmd.WithPath(src))
 		}
 
-		if err := validator.ValidatePackage(cmd.Context(), validateOptions...); err != nil {
-			return fmt.Errorf("validating package: %w", err)
+		validateErr := validator.ValidatePackage(cmd.Context(), validateOptions...)
+
+		switch opts.Output {
+		case internalcmd.OutputFormatHuman:
+			if validateErr != nil {
+				break
+			}
+			if _, err := fmt.Fprint(cmd.OutOrStdout(), validationSuccessMessage); err != nil {
+				panic(err)
+			}
+		case internalcmd.OutputFormatJSON, internalcmd.OutputFormatSARIF:
+			var srcPath string
+			if !opts.Pull {
+				srcPath = src
+			}
+			report := internalcmd.NewViolationReport(cmd.Context(), srcPath, validateErr)
+			if err := report.Write(cmd.OutOrStdout(), opts.Output, srcPath); err != nil {
+				return fmt.Errorf("writing report: %w", err)
+			}
 		}
 
-		if _, err := fmt.Fprint(cmd.OutOrStdout(), validationSuccessMessage); err != nil {
-			panic(err)
+		if validateErr != nil {
+			return fmt.Errorf("validating package: %w", validateErr)
 		}
 
 		return nil
@@ -65,8 +89,11 @@ func NewCmd(validator Validator) *cobra.Command {
 }
 
 type options struct {
-	Insecure bool
-	Pull     bool
+	Insecure             bool
+	KubeVersion          string
+	Output               string
+	Pull                 bool
+	RejectDeprecatedAPIs bool
 }
 
 func (o *options) AddFlags(flags *pflag.FlagSet) {
@@ -76,6 +103,27 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		o.Insecure,
 		"Allows pulling images without TLS or using TLS with unverified certificates.",
 	)
+	flags.StringVar(
+		&o.KubeVersion,
+		"kube-version",
+		o.KubeVersion,
+		"Kubernetes version to check for deprecated and removed APIs, "+
+			"defaults to the environment of each template test case, not supported with --pull",
+	)
+	flags.BoolVar(
+		&o.RejectDeprecatedAPIs,
+		"reject-deprecated-apis",
+		o.RejectDeprecatedAPIs,
+		"Also reject APIs that are deprecated, but still served in the Kubernetes version, "+
+			"by default only removed APIs are rejected, not supported with --pull",
+	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		internalcmd.OutputFormatHuman,
+		"Output format, either `human`, `json` or `sarif`",
+	)
 	flags.BoolVar(
 		&o.Pull,
 		"pull",
@@ -2,11 +2,15 @@ package validatecmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
 )
 
 func TestValidateFolder(t *testing.T) {
@@ -59,3 +63,43 @@ func TestValidate_InvalidPath(t *testing.T) {
 	require.Error(t, cmd.Execute())
 	require.NotEmpty(t, stderr.String())
 }
+
+type validatorMock struct{ err error }
+
+func (m validatorMock) ValidatePackage(context.Context, ...internalcmd.ValidatePackageOption) error {
+	return m.err
+}
+
+func TestValidate_OutputJSON(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(validatorMock{err: packages.ViolationError{
+		Reason: packages.ViolationReasonMissingPhaseAnnotation,
+		Path:   "cel-conditionals.yaml",
+		Index:  new(0),
+	}})
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--output", "json", "testdata"})
+
+	require.Error(t, cmd.Execute())
+
+	var report internalcmd.ViolationReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	require.Len(t, report.Violations, 1)
+	assert.Equal(t, "missing-phase-annotation", report.Violations[0].RuleID)
+	assert.Equal(t, "error", report.Violations[0].Severity)
+	assert.Equal(t, "cel-conditionals.yaml", report.Violations[0].Path)
+}
+
+func TestValidate_UnknownOutput(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(validatorMock{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--output", "xml", "testdata"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
@@ -0,0 +1,89 @@
+package verifycmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+type Verifier interface {
+	VerifyPackage(ctx context.Context, target string, opts ...internalcmd.VerifyPackageOption) (string, error)
+}
+
+func NewCmd(verifier Verifier) *cobra.Command {
+	const (
+		verifyUse   = "verify [--layout] [--insecure] --key path [--key path...] target"
+		verifyShort = "verify the signature of a package image."
+		verifyLong  = "verify that a package image is signed by any of the given public keys. " +
+			"Target may be an image reference in a registry or an OCI layout directory if --layout is set."
+	)
+
+	cmd := &cobra.Command{
+		Use:   verifyUse,
+		Short: verifyShort,
+		Long:  verifyLong,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	var opts options
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		target := args[0]
+		if target == "" {
+			return fmt.Errorf("%w: 'target' must not be empty", internalcmd.ErrInvalidArgs)
+		}
+		if len(opts.Keys) == 0 {
+			return fmt.Errorf("%w: '--key' must be provided", internalcmd.ErrInvalidArgs)
+		}
+
+		digest, err := verifier.VerifyPackage(cmd.Context(), target,
+			internalcmd.WithInsecure(opts.Insecure),
+			internalcmd.WithKeyPaths(opts.Keys),
+			internalcmd.WithLayout(opts.Layout),
+		)
+		if err != nil {
+			return fmt.Errorf("verifying package: %w", err)
+		}
+
+		if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Verified %s\n", digest); err != nil {
+			panic(err)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+type options struct {
+	Insecure bool
+	Keys     []string
+	Layout   bool
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Insecure,
+		"insecure",
+		o.Insecure,
+		"Allows pulling images without TLS or using TLS with unverified certificates.",
+	)
+	flags.StringArrayVar(
+		&o.Keys,
+		"key",
+		o.Keys,
+		"Path to a PEM encoded public key to verify with, may be given multiple times.",
+	)
+	flags.BoolVar(
+		&o.Layout,
+		"layout",
+		o.Layout,
+		"treat target as OCI layout directory instead of an image reference",
+	)
+}
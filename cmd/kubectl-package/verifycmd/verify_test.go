@@ -0,0 +1,66 @@
+package verifycmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+type verifierMock struct {
+	cfg internalcmd.VerifyPackageConfig
+	err error
+}
+
+func (m *verifierMock) VerifyPackage(
+	_ context.Context, _ string, opts ...internalcmd.VerifyPackageOption,
+) (string, error) {
+	m.cfg.Option(opts...)
+	if m.err != nil {
+		return "", m.err
+	}
+	return "sha256:1234", nil
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	verifier := &verifierMock{}
+	cmd := NewCmd(verifier)
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"--key", "a.pub", "--key", "b.pub", "--layout", "dir"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Verified sha256:1234\n", stdout.String())
+	assert.Equal(t, []string{"a.pub", "b.pub"}, verifier.cfg.KeyPaths)
+	assert.True(t, verifier.cfg.Layout)
+}
+
+func TestVerify_NoKey(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmd(&verifierMock{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"quay.io/package-operator/test:v1"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
+
+func TestVerify_Error(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test")
+	cmd := NewCmd(&verifierMock{err: errTest})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--key", "a.pub", "quay.io/package-operator/test:v1"})
+
+	require.ErrorIs(t, cmd.Execute(), errTest)
+}
@@ -39,9 +39,13 @@ func NewBootstrapper(
 	opts components.Options,
 ) (*Bootstrapper, error) {
 	c := uncachedClient
+	// Package Operator itself is installed as a ClusterPackage.
+	pullImage := func(ctx context.Context, image string) (*packages.RawPackage, error) {
+		return registry.Pull(ctx, "", image)
+	}
 	init := newInitializer(
 		c, scheme, &packageObjectLoad{},
-		registry.Pull, opts.Namespace, opts.SelfBootstrap, opts.SelfBootstrapConfig, opts.ImagePrefixOverrides,
+		pullImage, opts.Namespace, opts.SelfBootstrap, opts.SelfBootstrapConfig, opts.ImagePrefixOverrides,
 	)
 	fixer := newFixer(c, log, opts.Namespace)
 
@@ -12,7 +12,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
+	"package-operator.run/internal/autoimpersonation"
 	"package-operator.run/internal/constants"
+	"package-operator.run/internal/controllers/objecttemplate"
 	pkometrics "package-operator.run/internal/metrics"
 
 	"pkg.package-operator.run/boxcutter/managedcache"
@@ -25,10 +27,13 @@ func ProvideAccessManager(
 	scheme *runtime.Scheme,
 ) managedcache.ObjectBoundAccessManager[client.Object] {
 	mapper := func(
-		_ context.Context, _ client.Object,
+		_ context.Context, owner client.Object,
 		c *rest.Config, o cache.Options,
 	) (*rest.Config, cache.Options, error) {
-		return c, o, nil
+		// Caches owned by a ServiceAccount impersonate it
+		// and only watch its namespace, if it was referenced by a namespaced owner.
+		return autoimpersonation.RESTConfigFor(owner, c),
+			autoimpersonation.MapCacheOptions(owner, objecttemplate.MapCacheOptions(owner, o)), nil
 	}
 
 	accessManager := managedcache.NewObjectBoundAccessManager(
@@ -0,0 +1,24 @@
+package components
+
+import (
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"package-operator.run/internal/controllers/hostedclusterpackages"
+)
+
+// Type alias for dependency injector.
+type ClusterSetPackageController struct{ controller }
+
+func ProvideClusterSetPackageController(
+	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient, options Options,
+) ClusterSetPackageController {
+	return ClusterSetPackageController{
+		hostedclusterpackages.NewClusterSetPackageController(
+			mgr.GetClient(), uncachedClient,
+			log.WithName("controllers").WithName("ClusterSetPackage"),
+			mgr.GetScheme(), options.Namespace,
+		),
+	}
+}
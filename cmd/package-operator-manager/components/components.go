@@ -43,6 +43,7 @@ func NewComponents() (*dig.Container, error) {
 		ProvideObjectSetController, ProvideClusterObjectSetController,
 		// ObjectSetPhase
 		ProvideObjectSetPhaseController, ProvideClusterObjectSetPhaseController,
+		ProvideKubeconfigSecretObjectSetPhaseController, ProvideKubeconfigSecretClusterObjectSetPhaseController,
 		// ObjectDeployment
 		ProvideObjectDeploymentController, ProvideClusterObjectDeploymentController,
 		// Package
@@ -55,6 +56,9 @@ func NewComponents() (*dig.Container, error) {
 
 		// HostedClusterPackage
 		ProvideHostedClusterPackageController,
+
+		// ClusterSetPackage
+		ProvideClusterSetPackageController,
 	}
 	for _, p := range providers {
 		if err := container.Provide(p); err != nil {
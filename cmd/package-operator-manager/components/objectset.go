@@ -13,8 +13,8 @@ import (
 // Type alias for dependency injector to differentiate
 // Cluster and non-cluster scoped *Generic<>Controllers.
 type (
-	ObjectSetController        struct{ controller }
-	ClusterObjectSetController struct{ controller }
+	ObjectSetController        struct{ controllerAndEnvSinker }
+	ClusterObjectSetController struct{ controllerAndEnvSinker }
 )
 
 func ProvideObjectSetController(
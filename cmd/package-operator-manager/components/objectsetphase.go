@@ -7,6 +7,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/controllers/objectsetphases"
 )
 
@@ -15,6 +16,9 @@ import (
 type (
 	ObjectSetPhaseController        struct{ controller }
 	ClusterObjectSetPhaseController struct{ controller }
+
+	KubeconfigSecretObjectSetPhaseController        struct{ controller }
+	KubeconfigSecretClusterObjectSetPhaseController struct{ controller }
 )
 
 const defaultObjectSetPhaseClass = "default"
@@ -50,3 +54,31 @@ func ProvideClusterObjectSetPhaseController(
 		),
 	}
 }
+
+func ProvideKubeconfigSecretObjectSetPhaseController(
+	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient,
+) KubeconfigSecretObjectSetPhaseController {
+	return KubeconfigSecretObjectSetPhaseController{
+		objectsetphases.NewKubeconfigSecretObjectSetPhaseController(
+			log.WithName("controllers").WithName("KubeconfigSecretObjectSetPhase"),
+			mgr.GetScheme(),
+			corev1alpha1.KubeconfigSecretObjectSetPhaseClass, mgr.GetClient(),
+			uncachedClient,
+		),
+	}
+}
+
+func ProvideKubeconfigSecretClusterObjectSetPhaseController(
+	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient, options Options,
+) KubeconfigSecretClusterObjectSetPhaseController {
+	return KubeconfigSecretClusterObjectSetPhaseController{
+		objectsetphases.NewKubeconfigSecretClusterObjectSetPhaseController(
+			log.WithName("controllers").WithName("KubeconfigSecretClusterObjectSetPhase"),
+			mgr.GetScheme(),
+			corev1alpha1.KubeconfigSecretObjectSetPhaseClass, mgr.GetClient(),
+			uncachedClient, options.Namespace,
+		),
+	}
+}
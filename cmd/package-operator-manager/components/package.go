@@ -8,7 +8,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	controllerspackages "package-operator.run/internal/controllers/packages"
+	"package-operator.run/internal/imagemirror"
 	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
 )
@@ -26,8 +28,8 @@ type (
 
 func ProvideRequestManager(log logr.Logger, uncachedClient UncachedClient, opts Options) *packages.RequestManager {
 	return packages.NewRequestManager(
-		prepareRegistryHostOverrides(log, opts.RegistryHostOverrides),
-		prepareImagePrefixOverrides(log, opts.ImagePrefixOverrides),
+		imagemirror.NewResolver(uncachedClient.Client, prepareImageMirrors(log, opts)),
+		imageverify.NewPolicyResolver(uncachedClient.Client),
 		uncachedClient.Client,
 		types.NamespacedName{
 			Namespace: opts.ServiceAccountNamespace,
@@ -36,6 +38,14 @@ func ProvideRequestManager(log logr.Logger, uncachedClient UncachedClient, opts
 	)
 }
 
+// Overrides configured via flags apply to all packages, ClusterImageMirrorSets are added on top.
+func prepareImageMirrors(log logr.Logger, opts Options) imagemirror.Overrides {
+	return imagemirror.Overrides{
+		ImagePrefixOverrides:  prepareImagePrefixOverrides(log, opts.ImagePrefixOverrides),
+		RegistryHostOverrides: prepareRegistryHostOverrides(log, opts.RegistryHostOverrides),
+	}
+}
+
 func prepareRegistryHostOverrides(log logr.Logger, flag string) map[string]string {
 	if len(flag) == 0 {
 		return nil
@@ -85,7 +95,8 @@ func ProvidePackageController(
 			log.WithName("controllers").WithName("Package"),
 			mgr.GetScheme(),
 			requestManager, recorder, opts.PackageHashModifier,
-			prepareImagePrefixOverrides(log, opts.ImagePrefixOverrides),
+			imagemirror.NewResolver(mgr.GetClient(), prepareImageMirrors(log, opts)),
+			imageverify.NewPolicyResolver(mgr.GetClient()),
 		),
 	}
 }
@@ -103,7 +114,8 @@ func ProvideClusterPackageController(
 			log.WithName("controllers").WithName("ClusterPackage"),
 			mgr.GetScheme(),
 			requestManager, recorder, opts.PackageHashModifier,
-			prepareImagePrefixOverrides(log, opts.ImagePrefixOverrides),
+			imagemirror.NewResolver(mgr.GetClient(), prepareImageMirrors(log, opts)),
+			imageverify.NewPolicyResolver(mgr.GetClient()),
 		),
 	}
 }
@@ -44,6 +44,9 @@ type AllControllers struct {
 	ObjectSetPhase        ObjectSetPhaseController
 	ClusterObjectSetPhase ClusterObjectSetPhaseController
 
+	KubeconfigSecretObjectSetPhase        KubeconfigSecretObjectSetPhaseController
+	KubeconfigSecretClusterObjectSetPhase KubeconfigSecretClusterObjectSetPhaseController
+
 	ObjectDeployment        ObjectDeploymentController
 	ClusterObjectDeployment ClusterObjectDeploymentController
 
@@ -52,15 +55,19 @@ type AllControllers struct {
 
 	ObjectTemplate        ObjectTemplateController
 	ClusterObjectTemplate ClusterObjectTemplateController
+
+	ClusterSetPackage ClusterSetPackageController
 }
 
 func (ac AllControllers) List() []any {
 	return []any{
 		ac.ObjectSet, ac.ClusterObjectSet,
 		ac.ObjectSetPhase, ac.ClusterObjectSetPhase,
+		ac.KubeconfigSecretObjectSetPhase, ac.KubeconfigSecretClusterObjectSetPhase,
 		ac.ObjectDeployment, ac.ClusterObjectDeployment,
 		ac.Package, ac.ClusterPackage,
 		ac.ObjectTemplate, ac.ClusterObjectTemplate,
+		ac.ClusterSetPackage,
 	}
 }
 
@@ -82,6 +89,14 @@ func (ac AllControllers) SetupWithManager(mgr ctrl.Manager) error {
 			name:       "ClusterObjectSetPhase",
 			controller: ac.ClusterObjectSetPhase,
 		},
+		{
+			name:       "KubeconfigSecretObjectSetPhase",
+			controller: ac.KubeconfigSecretObjectSetPhase,
+		},
+		{
+			name:       "KubeconfigSecretClusterObjectSetPhase",
+			controller: ac.KubeconfigSecretClusterObjectSetPhase,
+		},
 		{
 			name:       "ObjectDeployment",
 			controller: ac.ObjectDeployment,
@@ -106,6 +121,10 @@ func (ac AllControllers) SetupWithManager(mgr ctrl.Manager) error {
 			name:       "ClusterObjectTemplate",
 			controller: ac.ClusterObjectTemplate,
 		},
+		{
+			name:       "ClusterSetPackage",
+			controller: ac.ClusterSetPackage,
+		},
 	})
 }
 
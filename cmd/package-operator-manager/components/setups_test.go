@@ -59,12 +59,15 @@ func TestAllControllers(t *testing.T) {
 		cos    = newMock()
 		osp    = newMock()
 		cosp   = newMock()
+		ksosp  = newMock()
+		kscosp = newMock()
 		od     = newMock()
 		cod    = newMock()
 		pkg    = newMock()
 		cpkg   = newMock()
 		otmpl  = newMock()
 		cotmpl = newMock()
+		cspkg  = newMock()
 	)
 	all := AllControllers{
 		ObjectSet:        ObjectSetController{os},
@@ -73,6 +76,9 @@ func TestAllControllers(t *testing.T) {
 		ObjectSetPhase:        ObjectSetPhaseController{osp},
 		ClusterObjectSetPhase: ClusterObjectSetPhaseController{cosp},
 
+		KubeconfigSecretObjectSetPhase:        KubeconfigSecretObjectSetPhaseController{ksosp},
+		KubeconfigSecretClusterObjectSetPhase: KubeconfigSecretClusterObjectSetPhaseController{kscosp},
+
 		ObjectDeployment:        ObjectDeploymentController{od},
 		ClusterObjectDeployment: ClusterObjectDeploymentController{cod},
 
@@ -81,6 +87,8 @@ func TestAllControllers(t *testing.T) {
 
 		ObjectTemplate:        ObjectTemplateController{otmpl},
 		ClusterObjectTemplate: ClusterObjectTemplateController{cotmpl},
+
+		ClusterSetPackage: ClusterSetPackageController{cspkg},
 	}
 	err := all.SetupWithManager(nil)
 	require.NoError(t, err)
@@ -88,7 +96,7 @@ func TestAllControllers(t *testing.T) {
 	for _, m := range mocks {
 		m.AssertExpectations(t)
 	}
-	assert.Len(t, all.List(), 10)
+	assert.Len(t, all.List(), 13)
 }
 
 func TestBootstrapControllers(t *testing.T) {
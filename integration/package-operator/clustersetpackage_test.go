@@ -0,0 +1,79 @@
+//go:build integration
+
+package packageoperator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Rolls out a Package to the test cluster itself, registered via kubeconfig Secret.
+func TestClusterSetPackage_InstantRollout(t *testing.T) {
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	// Kubeconfig of the test cluster reachable from within the cluster, created by the test setup.
+	adminKubeconfig := &corev1.Secret{}
+	requireClientGet(ctx, t, "service-network-admin-kubeconfig", "default", adminKubeconfig)
+
+	clusterSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cspkg-self",
+			Namespace: PackageOperatorNamespace,
+			Labels: map[string]string{
+				corev1alpha1.ClusterKubeconfigSecretLabel: "True",
+				"cspkg-enable": "True",
+			},
+		},
+		Data: map[string][]byte{
+			corev1alpha1.ClusterKubeconfigSecretKey: adminKubeconfig.Data["kubeconfig"],
+		},
+	}
+	require.NoError(t, Client.Create(ctx, clusterSecret))
+	cleanupOnSuccess(ctx, t, clusterSecret)
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cspkg",
+		},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				Instant: &corev1alpha1.HostedClusterPackageStrategyInstant{},
+			},
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"cspkg-enable": "True",
+				},
+			},
+			Namespace: "test-cspkg",
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{
+					Image: SuccessTestPackageImage,
+					Config: &runtime.RawExtension{
+						Raw: fmt.Appendf(nil, `{"testStubImage": "%s"}`, TestStubImage),
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, Client.Create(ctx, cspkg))
+	cleanupOnSuccess(ctx, t, cspkg)
+
+	requireCondition(ctx, t, cspkg, corev1alpha1.HostedClusterPackageAvailable, metav1.ConditionTrue)
+	requireCondition(ctx, t, cspkg, corev1alpha1.HostedClusterPackageProgressing, metav1.ConditionFalse)
+
+	pkg := &corev1alpha1.Package{}
+	requireClientGet(ctx, t, cspkg.Name, cspkg.Spec.Namespace, pkg)
+	assert.Equal(t, cspkg.Name, pkg.Labels[corev1alpha1.ClusterSetPackageLabel])
+	requireCondition(ctx, t, pkg, corev1alpha1.PackageAvailable, metav1.ConditionTrue)
+}
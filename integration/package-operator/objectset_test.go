@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -398,3 +399,72 @@ func TestObjectSet_invalidPreviousReference(t *testing.T) {
 		assert.Equal(t, prev.Spec.Revision+1, clusterObjectSet.Spec.Revision)
 	})
 }
+
+// Objects of an ObjectSet are reconciled as its ServiceAccount,
+// which only needs access to the namespace of the ObjectSet.
+func TestObjectSet_namespacedServiceAccount(t *testing.T) {
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-namespaced-sa",
+			Namespace: "default",
+		},
+	}
+	require.NoError(t, Client.Create(ctx, sa))
+	cleanupOnSuccess(ctx, t, sa)
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-namespaced-sa",
+			Namespace: "default",
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		}},
+	}
+	require.NoError(t, Client.Create(ctx, role))
+	cleanupOnSuccess(ctx, t, role)
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-namespaced-sa",
+			Namespace: "default",
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		}},
+	}
+	require.NoError(t, Client.Create(ctx, roleBinding))
+	cleanupOnSuccess(ctx, t, roleBinding)
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-namespaced-sa",
+			Namespace: "default",
+		},
+		Data: map[string]string{"banana": "bread"},
+	}
+	objectSet, err := simpleObjectSet(configMap, "default", "")
+	require.NoError(t, err)
+	objectSet.Name = "test-namespaced-sa"
+	objectSet.Spec.ServiceAccount = &corev1alpha1.ServiceAccountReference{Name: sa.Name}
+
+	require.NoError(t, Client.Create(ctx, objectSet))
+	cleanupOnSuccess(ctx, t, objectSet)
+	requireCondition(ctx, t, objectSet, corev1alpha1.ObjectSetAvailable, metav1.ConditionTrue)
+
+	actualConfigMap := &corev1.ConfigMap{}
+	requireClientGet(ctx, t, configMap.Name, configMap.Namespace, actualConfigMap)
+	assert.Equal(t, "bread", actualConfigMap.Data["banana"])
+}
@@ -23,6 +23,8 @@ type ObjectDeploymentAccessor interface {
 	GetSpecRevisionHistoryLimit() *int32
 	GetSpecSelector() metav1.LabelSelector
 	SetSpecSelector(labels map[string]string)
+	GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference
+	SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference)
 	SetSpecTemplateSpec(corev1alpha1.ObjectSetTemplateSpec)
 	GetSpecTemplateSpec() corev1alpha1.ObjectSetTemplateSpec
 
@@ -167,6 +169,14 @@ func (a *ObjectDeployment) SetSpecPaused(paused bool) {
 	a.Spec.Paused = paused
 }
 
+func (a *ObjectDeployment) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	return a.Spec.ServiceAccount
+}
+
+func (a *ObjectDeployment) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	a.Spec.ServiceAccount = serviceAccount
+}
+
 type ClusterObjectDeployment struct {
 	corev1alpha1.ClusterObjectDeployment
 }
@@ -259,3 +269,11 @@ func (a *ClusterObjectDeployment) GetSpecPaused() bool {
 func (a *ClusterObjectDeployment) SetSpecPaused(paused bool) {
 	a.Spec.Paused = paused
 }
+
+func (a *ClusterObjectDeployment) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	return a.Spec.ServiceAccount
+}
+
+func (a *ClusterObjectDeployment) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	a.Spec.ServiceAccount = serviceAccount
+}
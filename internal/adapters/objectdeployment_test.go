@@ -58,6 +58,10 @@ func TestObjectDeployment(t *testing.T) {
 	deploy.SetSpecPaused(false)
 	assert.False(t, deploy.GetSpecPaused())
 
+	serviceAccount := &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+	deploy.SetSpecServiceAccount(serviceAccount)
+	assert.Same(t, serviceAccount, deploy.GetSpecServiceAccount())
+
 	condition := metav1.Condition{
 		Type: "test-condition",
 	}
@@ -114,6 +118,10 @@ func TestClusterObjectDeployment(t *testing.T) {
 	deploy.SetSpecPaused(false)
 	assert.False(t, deploy.GetSpecPaused())
 
+	serviceAccount := &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+	deploy.SetSpecServiceAccount(serviceAccount)
+	assert.Same(t, serviceAccount, deploy.GetSpecServiceAccount())
+
 	condition := metav1.Condition{
 		Type: "test-condition",
 	}
@@ -45,6 +45,8 @@ type ObjectSetAccessor interface {
 	GetSpecSuccessDelaySeconds() int32
 	SetSpecRevision(int64)
 	GetSpecRevision() int64
+	GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference
+	SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference)
 
 	IsStatusPaused() bool
 	// Deprecated: use GetSpecRevision instead
@@ -205,6 +207,14 @@ func (a *ObjectSetAdapter) GetSpecRevision() int64 {
 	return a.Spec.Revision
 }
 
+func (a *ObjectSetAdapter) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	return a.Spec.ServiceAccount
+}
+
+func (a *ObjectSetAdapter) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	a.Spec.ServiceAccount = serviceAccount
+}
+
 func (a *ObjectSetAdapter) GetStatusRemotePhases() []corev1alpha1.RemotePhaseReference {
 	return a.Status.RemotePhases
 }
@@ -338,6 +348,14 @@ func (a *ClusterObjectSetAdapter) GetSpecRevision() int64 {
 	return a.Spec.Revision
 }
 
+func (a *ClusterObjectSetAdapter) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	return a.Spec.ServiceAccount
+}
+
+func (a *ClusterObjectSetAdapter) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	a.Spec.ServiceAccount = serviceAccount
+}
+
 func (a *ClusterObjectSetAdapter) GetStatusRemotePhases() []corev1alpha1.RemotePhaseReference {
 	return a.Status.RemotePhases
 }
@@ -42,6 +42,10 @@ func TestObjectSet(t *testing.T) {
 	objectSet.SetSpecRevision(revision)
 	assert.Equal(t, revision, objectSet.GetSpecRevision())
 
+	serviceAccount := &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+	objectSet.SetSpecServiceAccount(serviceAccount)
+	assert.Same(t, serviceAccount, objectSet.GetSpecServiceAccount())
+
 	objectSet.Spec.Previous = []corev1alpha1.PreviousRevisionReference{
 		{},
 	}
@@ -112,6 +116,10 @@ func TestClusterObjectSet(t *testing.T) {
 	objectSet.SetSpecRevision(revision)
 	assert.Equal(t, revision, objectSet.GetSpecRevision())
 
+	serviceAccount := &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+	objectSet.SetSpecServiceAccount(serviceAccount)
+	assert.Same(t, serviceAccount, objectSet.GetSpecServiceAccount())
+
 	objectSet.Spec.Previous = []corev1alpha1.PreviousRevisionReference{
 		{},
 	}
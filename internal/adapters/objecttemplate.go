@@ -21,10 +21,14 @@ type ObjectTemplateAccessor interface {
 	ClientObject() client.Object
 	GetTemplate() string
 	GetSources() []corev1alpha1.ObjectTemplateSource
+	GetNamespaceSelector() *metav1.LabelSelector
 	GetStatusConditions() *[]metav1.Condition
 	GetGeneration() int64
-	SetStatusControllerOf(corev1alpha1.ControlledObjectReference)
-	GetStatusControllerOf() corev1alpha1.ControlledObjectReference
+	SetStatusControllerOf([]corev1alpha1.ControlledObjectReference)
+	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
+	SetStatusNamespaces(*corev1alpha1.ObjectTemplateNamespacesStatus)
+	SetStatusSources([]corev1alpha1.ObjectTemplateObservedSource)
+	SetStatusRenderedHash(string)
 }
 
 type GenericObjectTemplateFactory func(scheme *runtime.Scheme) ObjectTemplateAccessor
@@ -67,6 +71,10 @@ func (t *GenericObjectTemplate) GetSources() []corev1alpha1.ObjectTemplateSource
 	return t.Spec.Sources
 }
 
+func (t *GenericObjectTemplate) GetNamespaceSelector() *metav1.LabelSelector {
+	return t.Spec.NamespaceSelector
+}
+
 func (t *GenericObjectTemplate) GetStatusConditions() *[]metav1.Condition {
 	return &t.Status.Conditions
 }
@@ -75,12 +83,24 @@ func (t *GenericObjectTemplate) GetGeneration() int64 {
 	return t.Generation
 }
 
-func (t *GenericObjectTemplate) SetStatusControllerOf(controllerOf corev1alpha1.ControlledObjectReference) {
-	t.Status.ControllerOf = controllerOf
+func (t *GenericObjectTemplate) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
+	setObjectTemplateStatusControllerOf(&t.Status, controllerOf)
+}
+
+func (t *GenericObjectTemplate) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return getObjectTemplateStatusControllerOf(&t.Status)
+}
+
+func (t *GenericObjectTemplate) SetStatusNamespaces(namespaces *corev1alpha1.ObjectTemplateNamespacesStatus) {
+	t.Status.Namespaces = namespaces
 }
 
-func (t *GenericObjectTemplate) GetStatusControllerOf() corev1alpha1.ControlledObjectReference {
-	return t.Status.ControllerOf
+func (t *GenericObjectTemplate) SetStatusSources(sources []corev1alpha1.ObjectTemplateObservedSource) {
+	t.Status.Sources = sources
+}
+
+func (t *GenericObjectTemplate) SetStatusRenderedHash(hash string) {
+	t.Status.RenderedHash = hash
 }
 
 type GenericClusterObjectTemplate struct {
@@ -95,6 +115,10 @@ func (t *GenericClusterObjectTemplate) GetSources() []corev1alpha1.ObjectTemplat
 	return t.Spec.Sources
 }
 
+func (t *GenericClusterObjectTemplate) GetNamespaceSelector() *metav1.LabelSelector {
+	return t.Spec.NamespaceSelector
+}
+
 func (t *GenericClusterObjectTemplate) GetStatusConditions() *[]metav1.Condition {
 	return &t.Status.Conditions
 }
@@ -107,10 +131,43 @@ func (t *GenericClusterObjectTemplate) GetGeneration() int64 {
 	return t.Generation
 }
 
-func (t *GenericClusterObjectTemplate) SetStatusControllerOf(controllerOf corev1alpha1.ControlledObjectReference) {
-	t.Status.ControllerOf = controllerOf
+func (t *GenericClusterObjectTemplate) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
+	setObjectTemplateStatusControllerOf(&t.Status, controllerOf)
+}
+
+func (t *GenericClusterObjectTemplate) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return getObjectTemplateStatusControllerOf(&t.Status)
+}
+
+func (t *GenericClusterObjectTemplate) SetStatusNamespaces(namespaces *corev1alpha1.ObjectTemplateNamespacesStatus) {
+	t.Status.Namespaces = namespaces
 }
 
-func (t *GenericClusterObjectTemplate) GetStatusControllerOf() corev1alpha1.ControlledObjectReference {
-	return t.Status.ControllerOf
+func (t *GenericClusterObjectTemplate) SetStatusSources(sources []corev1alpha1.ObjectTemplateObservedSource) {
+	t.Status.Sources = sources
+}
+
+func (t *GenericClusterObjectTemplate) SetStatusRenderedHash(hash string) {
+	t.Status.RenderedHash = hash
+}
+
+func setObjectTemplateStatusControllerOf(
+	status *corev1alpha1.ObjectTemplateStatus, controllerOf []corev1alpha1.ControlledObjectReference,
+) {
+	status.ControlledObjects = controllerOf
+	// Keep the deprecated single reference pointing to the first object.
+	status.ControllerOf = corev1alpha1.ControlledObjectReference{}
+	if len(controllerOf) > 0 {
+		status.ControllerOf = controllerOf[0]
+	}
+}
+
+func getObjectTemplateStatusControllerOf(
+	status *corev1alpha1.ObjectTemplateStatus,
+) []corev1alpha1.ControlledObjectReference {
+	if len(status.ControlledObjects) == 0 && status.ControllerOf != (corev1alpha1.ControlledObjectReference{}) {
+		// Status written before templates could render multiple objects.
+		return []corev1alpha1.ControlledObjectReference{status.ControllerOf}
+	}
+	return status.ControlledObjects
 }
@@ -21,9 +21,13 @@ func TestGenericObjectTemplate(t *testing.T) {
 	ot.Generation = generation
 	assert.Equal(t, generation, ot.GetGeneration())
 
-	controlledObj := corev1alpha1.ControlledObjectReference{}
-	ot.SetStatusControllerOf(controlledObj)
-	assert.Equal(t, controlledObj, ot.GetStatusControllerOf())
+	controlledObjs := []corev1alpha1.ControlledObjectReference{
+		{Kind: "ConfigMap", Name: "a"},
+		{Kind: "ConfigMap", Name: "b"},
+	}
+	ot.SetStatusControllerOf(controlledObjs)
+	assert.Equal(t, controlledObjs, ot.GetStatusControllerOf())
+	assert.Equal(t, controlledObjs[0], ot.Status.ControllerOf)
 
 	ot.Status.Conditions = []metav1.Condition{}
 	assert.Equal(t, ot.Status.Conditions, *ot.GetStatusConditions())
@@ -32,6 +36,21 @@ func TestGenericObjectTemplate(t *testing.T) {
 	ot.Spec.Sources = sources
 	assert.Equal(t, sources, ot.GetSources())
 
+	selector := &metav1.LabelSelector{}
+	ot.Spec.NamespaceSelector = selector
+	assert.Equal(t, selector, ot.GetNamespaceSelector())
+
+	namespaces := &corev1alpha1.ObjectTemplateNamespacesStatus{Selected: 1}
+	ot.SetStatusNamespaces(namespaces)
+	assert.Equal(t, namespaces, ot.Status.Namespaces)
+
+	observed := []corev1alpha1.ObjectTemplateObservedSource{{Kind: "ConfigMap", Name: "a", ResourceVersion: "1"}}
+	ot.SetStatusSources(observed)
+	assert.Equal(t, observed, ot.Status.Sources)
+
+	ot.SetStatusRenderedHash("abc")
+	assert.Equal(t, "abc", ot.Status.RenderedHash)
+
 	ot.Spec.Template = ""
 	assert.Equal(t, ot.Spec.Template, ot.GetTemplate())
 }
@@ -48,9 +67,13 @@ func TestGenericClusterObjectTemplate(t *testing.T) {
 	ot.Generation = generation
 	assert.Equal(t, generation, ot.GetGeneration())
 
-	controlledObj := corev1alpha1.ControlledObjectReference{}
-	ot.SetStatusControllerOf(controlledObj)
-	assert.Equal(t, controlledObj, ot.GetStatusControllerOf())
+	controlledObjs := []corev1alpha1.ControlledObjectReference{
+		{Kind: "ConfigMap", Name: "a"},
+		{Kind: "ConfigMap", Name: "b"},
+	}
+	ot.SetStatusControllerOf(controlledObjs)
+	assert.Equal(t, controlledObjs, ot.GetStatusControllerOf())
+	assert.Equal(t, controlledObjs[0], ot.Status.ControllerOf)
 
 	ot.Status.Conditions = []metav1.Condition{}
 	assert.Equal(t, ot.Status.Conditions, *ot.GetStatusConditions())
@@ -59,6 +82,35 @@ func TestGenericClusterObjectTemplate(t *testing.T) {
 	ot.Spec.Sources = sources
 	assert.Equal(t, sources, ot.GetSources())
 
+	selector := &metav1.LabelSelector{}
+	ot.Spec.NamespaceSelector = selector
+	assert.Equal(t, selector, ot.GetNamespaceSelector())
+
+	namespaces := &corev1alpha1.ObjectTemplateNamespacesStatus{Selected: 1}
+	ot.SetStatusNamespaces(namespaces)
+	assert.Equal(t, namespaces, ot.Status.Namespaces)
+
+	observed := []corev1alpha1.ObjectTemplateObservedSource{{Kind: "ConfigMap", Name: "a", ResourceVersion: "1"}}
+	ot.SetStatusSources(observed)
+	assert.Equal(t, observed, ot.Status.Sources)
+
+	ot.SetStatusRenderedHash("abc")
+	assert.Equal(t, "abc", ot.Status.RenderedHash)
+
 	ot.Spec.Template = ""
 	assert.Equal(t, ot.Spec.Template, ot.GetTemplate())
 }
+
+func TestGenericObjectTemplate_legacyControllerOf(t *testing.T) {
+	t.Parallel()
+
+	ot := NewGenericObjectTemplate(testScheme).(*GenericObjectTemplate)
+	assert.Empty(t, ot.GetStatusControllerOf())
+
+	controlledObj := corev1alpha1.ControlledObjectReference{Kind: "ConfigMap", Name: "a"}
+	ot.Status.ControllerOf = controlledObj
+	assert.Equal(t, []corev1alpha1.ControlledObjectReference{controlledObj}, ot.GetStatusControllerOf())
+
+	ot.SetStatusControllerOf(nil)
+	assert.Empty(t, ot.GetStatusControllerOf())
+}
@@ -28,6 +28,12 @@ type PackageAccessor interface {
 	GetSpecPaused() bool
 	SetSpecPaused(paused bool)
 	GetSpecTemplateContext() manifests.TemplateContext
+	GetSpecPatches() []corev1alpha1.PackagePatch
+	GetSpecCommonLabels() map[string]string
+	GetSpecCommonAnnotations() map[string]string
+	GetSpecImageOverrides() []corev1alpha1.PackageImageOverride
+	GetSpecImagePrefixOverrides() []corev1alpha1.PackageImagePrefixOverride
+	GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference
 
 	GetStatusConditions() *[]metav1.Condition
 	GetStatusRevision() int64
@@ -81,6 +87,30 @@ func (a *GenericPackage) GetSpecImage() string {
 	return a.Spec.Image
 }
 
+func (a *GenericPackage) GetSpecPatches() []corev1alpha1.PackagePatch {
+	return a.Spec.Patches
+}
+
+func (a *GenericPackage) GetSpecCommonLabels() map[string]string {
+	return a.Spec.CommonLabels
+}
+
+func (a *GenericPackage) GetSpecCommonAnnotations() map[string]string {
+	return a.Spec.CommonAnnotations
+}
+
+func (a *GenericPackage) GetSpecImageOverrides() []corev1alpha1.PackageImageOverride {
+	return a.Spec.ImageOverrides
+}
+
+func (a *GenericPackage) GetSpecImagePrefixOverrides() []corev1alpha1.PackageImagePrefixOverride {
+	return a.Spec.ImagePrefixOverrides
+}
+
+func (a *GenericPackage) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	return a.Spec.ServiceAccount
+}
+
 func (a *GenericPackage) GetSpecHash(packageHashModifier *int32) string {
 	return utils.ComputeSHA256Hash(a.Spec, packageHashModifier)
 }
@@ -139,6 +169,30 @@ func (a *GenericClusterPackage) GetSpecImage() string {
 	return a.Spec.Image
 }
 
+func (a *GenericClusterPackage) GetSpecPatches() []corev1alpha1.PackagePatch {
+	return a.Spec.Patches
+}
+
+func (a *GenericClusterPackage) GetSpecCommonLabels() map[string]string {
+	return a.Spec.CommonLabels
+}
+
+func (a *GenericClusterPackage) GetSpecCommonAnnotations() map[string]string {
+	return a.Spec.CommonAnnotations
+}
+
+func (a *GenericClusterPackage) GetSpecImageOverrides() []corev1alpha1.PackageImageOverride {
+	return a.Spec.ImageOverrides
+}
+
+func (a *GenericClusterPackage) GetSpecImagePrefixOverrides() []corev1alpha1.PackageImagePrefixOverride {
+	return a.Spec.ImagePrefixOverrides
+}
+
+func (a *GenericClusterPackage) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	return a.Spec.ServiceAccount
+}
+
 func (a *GenericClusterPackage) GetSpecHash(packageHashModifier *int32) string {
 	return utils.ComputeSHA256Hash(a.Spec, packageHashModifier)
 }
@@ -32,6 +32,19 @@ func TestGenericPackage(t *testing.T) {
 	p.Spec.Component = "test_component"
 	assert.Equal(t, p.Spec.Component, pkg.GetSpecComponent())
 
+	p.Spec.Patches = []corev1alpha1.PackagePatch{{Patch: "metadata: {}"}}
+	assert.Equal(t, p.Spec.Patches, pkg.GetSpecPatches())
+	p.Spec.CommonLabels = map[string]string{"l": "l"}
+	assert.Equal(t, p.Spec.CommonLabels, pkg.GetSpecCommonLabels())
+	p.Spec.CommonAnnotations = map[string]string{"a": "a"}
+	assert.Equal(t, p.Spec.CommonAnnotations, pkg.GetSpecCommonAnnotations())
+	p.Spec.ImageOverrides = []corev1alpha1.PackageImageOverride{{Name: "app", Image: "quay.io/app@sha256:123"}}
+	assert.Equal(t, p.Spec.ImageOverrides, pkg.GetSpecImageOverrides())
+	p.Spec.ImagePrefixOverrides = []corev1alpha1.PackageImagePrefixOverride{{From: "quay.io/", To: "mirror/"}}
+	assert.Equal(t, p.Spec.ImagePrefixOverrides, pkg.GetSpecImagePrefixOverrides())
+	p.Spec.ServiceAccount = &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+	assert.Same(t, p.Spec.ServiceAccount, pkg.GetSpecServiceAccount())
+
 	assert.Empty(t, pkg.GetStatusConditions())
 	p.Status.Conditions = []metav1.Condition{
 		{
@@ -74,6 +87,19 @@ func TestGenericClusterPackage(t *testing.T) {
 	p.Spec.Component = "test_component"
 	assert.Equal(t, p.Spec.Component, pkg.GetSpecComponent())
 
+	p.Spec.Patches = []corev1alpha1.PackagePatch{{Patch: "metadata: {}"}}
+	assert.Equal(t, p.Spec.Patches, pkg.GetSpecPatches())
+	p.Spec.CommonLabels = map[string]string{"l": "l"}
+	assert.Equal(t, p.Spec.CommonLabels, pkg.GetSpecCommonLabels())
+	p.Spec.CommonAnnotations = map[string]string{"a": "a"}
+	assert.Equal(t, p.Spec.CommonAnnotations, pkg.GetSpecCommonAnnotations())
+	p.Spec.ImageOverrides = []corev1alpha1.PackageImageOverride{{Name: "app", Image: "quay.io/app@sha256:123"}}
+	assert.Equal(t, p.Spec.ImageOverrides, pkg.GetSpecImageOverrides())
+	p.Spec.ImagePrefixOverrides = []corev1alpha1.PackageImagePrefixOverride{{From: "quay.io/", To: "mirror/"}}
+	assert.Equal(t, p.Spec.ImagePrefixOverrides, pkg.GetSpecImagePrefixOverrides())
+	p.Spec.ServiceAccount = &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+	assert.Same(t, p.Spec.ServiceAccount, pkg.GetSpecServiceAccount())
+
 	assert.Empty(t, pkg.GetStatusConditions())
 	p.Status.Conditions = []metav1.Condition{
 		{
@@ -0,0 +1,139 @@
+// Package apideprecation knows about deprecated and removed upstream Kubernetes APIs.
+package apideprecation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// API describes a Kubernetes API that has been deprecated and/or removed.
+type API struct {
+	// Kubernetes minor version the API was deprecated in.
+	DeprecatedIn *version.Version
+	// Kubernetes minor version the API is no longer served in.
+	// nil if no removal has been scheduled yet.
+	RemovedIn *version.Version
+	// Replacement GroupVersion to migrate to.
+	// Empty if the API is removed without a replacement.
+	Replacement string
+}
+
+// APIs lists well known deprecated and removed upstream Kubernetes APIs.
+var APIs = map[schema.GroupVersionKind]API{
+	// Removed in v1.16
+	{Group: "extensions", Version: "v1beta1", Kind: "Deployment"}:    deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "extensions", Version: "v1beta1", Kind: "DaemonSet"}:     deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "extensions", Version: "v1beta1", Kind: "ReplicaSet"}:    deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "extensions", Version: "v1beta1", Kind: "NetworkPolicy"}: deprecatedAPI("1.9", "1.16", "networking.k8s.io/v1"),
+	{Group: "extensions", Version: "v1beta1", Kind: "PodSecurityPolicy"}: deprecatedAPI(
+		"1.10", "1.16", "policy/v1beta1"),
+	{Group: "apps", Version: "v1beta1", Kind: "Deployment"}:  deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "apps", Version: "v1beta1", Kind: "StatefulSet"}: deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "apps", Version: "v1beta2", Kind: "Deployment"}:  deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "apps", Version: "v1beta2", Kind: "StatefulSet"}: deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "apps", Version: "v1beta2", Kind: "DaemonSet"}:   deprecatedAPI("1.9", "1.16", "apps/v1"),
+	{Group: "apps", Version: "v1beta2", Kind: "ReplicaSet"}:  deprecatedAPI("1.9", "1.16", "apps/v1"),
+
+	// Removed in v1.22
+	{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}: deprecatedAPI("1.14", "1.22", "networking.k8s.io/v1"),
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress"}: deprecatedAPI(
+		"1.19", "1.22", "networking.k8s.io/v1"),
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "IngressClass"}: deprecatedAPI(
+		"1.19", "1.22", "networking.k8s.io/v1"),
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingWebhookConfiguration"}: deprecatedAPI(
+		"1.16", "1.22", "admissionregistration.k8s.io/v1"),
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingWebhookConfiguration"}: deprecatedAPI(
+		"1.16", "1.22", "admissionregistration.k8s.io/v1"),
+	{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"}: deprecatedAPI(
+		"1.16", "1.22", "apiextensions.k8s.io/v1"),
+	{Group: "apiregistration.k8s.io", Version: "v1beta1", Kind: "APIService"}: deprecatedAPI(
+		"1.19", "1.22", "apiregistration.k8s.io/v1"),
+	{Group: "certificates.k8s.io", Version: "v1beta1", Kind: "CertificateSigningRequest"}: deprecatedAPI(
+		"1.19", "1.22", "certificates.k8s.io/v1"),
+	{Group: "coordination.k8s.io", Version: "v1beta1", Kind: "Lease"}: deprecatedAPI(
+		"1.14", "1.22", "coordination.k8s.io/v1"),
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRole"}: deprecatedAPI(
+		"1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRoleBinding"}: deprecatedAPI(
+		"1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "Role"}: deprecatedAPI(
+		"1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "RoleBinding"}: deprecatedAPI(
+		"1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	{Group: "scheduling.k8s.io", Version: "v1beta1", Kind: "PriorityClass"}: deprecatedAPI(
+		"1.14", "1.22", "scheduling.k8s.io/v1"),
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSIDriver"}: deprecatedAPI(
+		"1.19", "1.22", "storage.k8s.io/v1"),
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSINode"}: deprecatedAPI(
+		"1.17", "1.22", "storage.k8s.io/v1"),
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "StorageClass"}: deprecatedAPI(
+		"1.6", "1.22", "storage.k8s.io/v1"),
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "VolumeAttachment"}: deprecatedAPI(
+		"1.13", "1.22", "storage.k8s.io/v1"),
+
+	// Removed in v1.25
+	{Group: "batch", Version: "v1beta1", Kind: "CronJob"}: deprecatedAPI("1.21", "1.25", "batch/v1"),
+	{Group: "discovery.k8s.io", Version: "v1beta1", Kind: "EndpointSlice"}: deprecatedAPI(
+		"1.21", "1.25", "discovery.k8s.io/v1"),
+	{Group: "events.k8s.io", Version: "v1beta1", Kind: "Event"}: deprecatedAPI("1.19", "1.25", "events.k8s.io/v1"),
+	{Group: "autoscaling", Version: "v2beta1", Kind: "HorizontalPodAutoscaler"}: deprecatedAPI(
+		"1.23", "1.25", "autoscaling/v2"),
+	{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"}: deprecatedAPI("1.21", "1.25", "policy/v1"),
+	{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy"}:   deprecatedAPI("1.21", "1.25", ""),
+	{Group: "node.k8s.io", Version: "v1beta1", Kind: "RuntimeClass"}:   deprecatedAPI("1.20", "1.25", "node.k8s.io/v1"),
+
+	// Removed in v1.26
+	{Group: "autoscaling", Version: "v2beta2", Kind: "HorizontalPodAutoscaler"}: deprecatedAPI(
+		"1.23", "1.26", "autoscaling/v2"),
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Kind: "FlowSchema"}: deprecatedAPI(
+		"1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"),
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Kind: "PriorityLevelConfiguration"}: deprecatedAPI(
+		"1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"),
+
+	// Removed in v1.27
+	{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSIStorageCapacity"}: deprecatedAPI(
+		"1.24", "1.27", "storage.k8s.io/v1"),
+
+	// Removed in v1.29
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "FlowSchema"}: deprecatedAPI(
+		"1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"),
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "PriorityLevelConfiguration"}: deprecatedAPI(
+		"1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"),
+
+	// Removed in v1.32
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema"}: deprecatedAPI(
+		"1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"),
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "PriorityLevelConfiguration"}: deprecatedAPI(
+		"1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"),
+}
+
+func deprecatedAPI(deprecatedIn, removedIn, replacement string) API {
+	api := API{
+		DeprecatedIn: version.MustParseMajorMinor(deprecatedIn),
+		Replacement:  replacement,
+	}
+	if len(removedIn) > 0 {
+		api.RemovedIn = version.MustParseMajorMinor(removedIn)
+	}
+	return api
+}
+
+// IsRemovedIn returns true if the API is no longer served in kubeVersion.
+func (api API) IsRemovedIn(kubeVersion *version.Version) bool {
+	return api.RemovedIn != nil && kubeVersion.AtLeast(api.RemovedIn)
+}
+
+// Describe returns a human readable summary of the deprecation of gvk and how to migrate.
+func (api API) Describe(gvk schema.GroupVersionKind) string {
+	gv := gvk.GroupVersion().String()
+	msg := fmt.Sprintf("%s %s deprecated in v%s", gv, gvk.Kind, api.DeprecatedIn)
+	if api.RemovedIn != nil {
+		msg += fmt.Sprintf(", removed in v%s", api.RemovedIn)
+	}
+	if len(api.Replacement) > 0 {
+		return msg + fmt.Sprintf(", migrate to %s", api.Replacement)
+	}
+	return msg + ", no replacement available"
+}
@@ -198,6 +198,8 @@ type PackageManifestTest struct {
 	// Template testing configuration.
 	Template    []PackageManifestTestCaseTemplate
 	Kubeconform *PackageManifestTestKubeconform
+	// Lint rule configuration.
+	Lint *PackageManifestTestLint
 }
 
 // PackageManifestTestCaseTemplate template testing configuration.
@@ -219,6 +221,15 @@ type PackageManifestTestKubeconform struct {
 	SchemaLocations []string
 }
 
+// PackageManifestTestLint configures which lint rules to run against the rendered output of template test cases.
+type PackageManifestTestLint struct {
+	// Names of lint rules to run in addition to the rules enabled by default.
+	Enable []string
+	// Names of lint rules to skip.
+	// Takes precedence over Enable.
+	Disable []string
+}
+
 // TemplateContext is available within the package templating process.
 type TemplateContext struct {
 	// Package object.
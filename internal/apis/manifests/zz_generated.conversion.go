@@ -303,6 +303,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PackageManifestTestLint)(nil), (*v1alpha1.PackageManifestTestLint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_manifests_PackageManifestTestLint_To_v1alpha1_PackageManifestTestLint(a.(*PackageManifestTestLint), b.(*v1alpha1.PackageManifestTestLint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PackageManifestTestLint)(nil), (*PackageManifestTestLint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageManifestTestLint_To_manifests_PackageManifestTestLint(a.(*v1alpha1.PackageManifestTestLint), b.(*PackageManifestTestLint), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PackageManifestUniqueInScopeConstraint)(nil), (*v1alpha1.PackageManifestUniqueInScopeConstraint)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_manifests_PackageManifestUniqueInScopeConstraint_To_v1alpha1_PackageManifestUniqueInScopeConstraint(a.(*PackageManifestUniqueInScopeConstraint), b.(*v1alpha1.PackageManifestUniqueInScopeConstraint), scope)
 	}); err != nil {
@@ -1000,6 +1010,7 @@ func Convert_v1alpha1_PackageManifestSpecConfig_To_manifests_PackageManifestSpec
 func autoConvert_manifests_PackageManifestTest_To_v1alpha1_PackageManifestTest(in *PackageManifestTest, out *v1alpha1.PackageManifestTest, s conversion.Scope) error {
 	out.Template = *(*[]v1alpha1.PackageManifestTestCaseTemplate)(unsafe.Pointer(&in.Template))
 	out.Kubeconform = (*v1alpha1.PackageManifestTestKubeconform)(unsafe.Pointer(in.Kubeconform))
+	out.Lint = (*v1alpha1.PackageManifestTestLint)(unsafe.Pointer(in.Lint))
 	return nil
 }
 
@@ -1011,6 +1022,7 @@ func Convert_manifests_PackageManifestTest_To_v1alpha1_PackageManifestTest(in *P
 func autoConvert_v1alpha1_PackageManifestTest_To_manifests_PackageManifestTest(in *v1alpha1.PackageManifestTest, out *PackageManifestTest, s conversion.Scope) error {
 	out.Template = *(*[]PackageManifestTestCaseTemplate)(unsafe.Pointer(&in.Template))
 	out.Kubeconform = (*PackageManifestTestKubeconform)(unsafe.Pointer(in.Kubeconform))
+	out.Lint = (*PackageManifestTestLint)(unsafe.Pointer(in.Lint))
 	return nil
 }
 
@@ -1067,6 +1079,28 @@ func Convert_v1alpha1_PackageManifestTestKubeconform_To_manifests_PackageManifes
 	return autoConvert_v1alpha1_PackageManifestTestKubeconform_To_manifests_PackageManifestTestKubeconform(in, out, s)
 }
 
+func autoConvert_manifests_PackageManifestTestLint_To_v1alpha1_PackageManifestTestLint(in *PackageManifestTestLint, out *v1alpha1.PackageManifestTestLint, s conversion.Scope) error {
+	out.Enable = *(*[]string)(unsafe.Pointer(&in.Enable))
+	out.Disable = *(*[]string)(unsafe.Pointer(&in.Disable))
+	return nil
+}
+
+// Convert_manifests_PackageManifestTestLint_To_v1alpha1_PackageManifestTestLint is an autogenerated conversion function.
+func Convert_manifests_PackageManifestTestLint_To_v1alpha1_PackageManifestTestLint(in *PackageManifestTestLint, out *v1alpha1.PackageManifestTestLint, s conversion.Scope) error {
+	return autoConvert_manifests_PackageManifestTestLint_To_v1alpha1_PackageManifestTestLint(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageManifestTestLint_To_manifests_PackageManifestTestLint(in *v1alpha1.PackageManifestTestLint, out *PackageManifestTestLint, s conversion.Scope) error {
+	out.Enable = *(*[]string)(unsafe.Pointer(&in.Enable))
+	out.Disable = *(*[]string)(unsafe.Pointer(&in.Disable))
+	return nil
+}
+
+// Convert_v1alpha1_PackageManifestTestLint_To_manifests_PackageManifestTestLint is an autogenerated conversion function.
+func Convert_v1alpha1_PackageManifestTestLint_To_manifests_PackageManifestTestLint(in *v1alpha1.PackageManifestTestLint, out *PackageManifestTestLint, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageManifestTestLint_To_manifests_PackageManifestTestLint(in, out, s)
+}
+
 func autoConvert_manifests_PackageManifestUniqueInScopeConstraint_To_v1alpha1_PackageManifestUniqueInScopeConstraint(in *PackageManifestUniqueInScopeConstraint, out *v1alpha1.PackageManifestUniqueInScopeConstraint, s conversion.Scope) error {
 	return nil
 }
@@ -0,0 +1,256 @@
+// Package autoimpersonation resolves the ServiceAccount objects of a Package,
+// ObjectDeployment or ObjectSet tree are reconciled as.
+package autoimpersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/autoimpersonation/ownership"
+	"package-operator.run/internal/constants"
+)
+
+var ErrInvalidServiceAccountReference = errors.New("invalid ServiceAccount reference")
+
+// Marks cache owners of namespaced owners, which have a cache scoped to the ServiceAccount namespace.
+const (
+	cacheScopeAnnotation = "package-operator.run/cache-scope"
+	cacheScopeNamespace  = "Namespace"
+)
+
+// Resolver looks up the ServiceAccount to impersonate when reconciling objects of an owner.
+//
+// The ServiceAccount named by the owner itself takes precedence.
+// Owners not naming a ServiceAccount inherit it from their controller,
+// but only if ownership is acknowledged both ways,
+// so objects can't hijack the identity of another tree by just adding an owner reference.
+type Resolver struct {
+	client client.Reader
+	scheme *runtime.Scheme
+}
+
+func NewResolver(client client.Reader, scheme *runtime.Scheme) *Resolver {
+	return &Resolver{
+		client: client,
+		scheme: scheme,
+	}
+}
+
+// ServiceAccountFor returns the ServiceAccount to impersonate when reconciling objects of the given owner.
+// Returns nil if objects are to be reconciled with the identity of Package Operator.
+func (r *Resolver) ServiceAccountFor(
+	ctx context.Context, owner client.Object,
+) (*types.NamespacedName, error) {
+	ref, supported := serviceAccountReference(owner)
+	switch {
+	case ref != nil:
+		return serviceAccountKey(owner, ref)
+	case !supported:
+		return nil, nil //nolint:nilnil
+	}
+
+	controller, verified, err := r.verifiedController(ctx, owner)
+	if err != nil || !verified {
+		return nil, err
+	}
+	return r.ServiceAccountFor(ctx, controller)
+}
+
+// CacheOwner returns the owner of the cache to reconcile objects of the given owner with.
+// Owners reconciled as the same ServiceAccount share a cache impersonating it,
+// all other owners share the cache running with the identity of Package Operator.
+func (r *Resolver) CacheOwner(
+	ctx context.Context, owner client.Object,
+) (client.Object, error) {
+	sa, err := r.ServiceAccountFor(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("resolving ServiceAccount to impersonate: %w", err)
+	}
+	if sa == nil {
+		return constants.StaticCacheOwner(), nil
+	}
+	if len(owner.GetNamespace()) == 0 {
+		return &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sa.Name,
+				Namespace: sa.Namespace,
+				// Not a real UID, just a unique key for the cache of this ServiceAccount.
+				UID: types.UID("cluster-serviceaccount:" + sa.Namespace + ":" + sa.Name),
+			},
+		}, nil
+	}
+	// Namespaced owners only reconcile objects in their own namespace,
+	// so their ServiceAccount may only have access to that namespace.
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+			UID:       types.UID("serviceaccount:" + sa.Namespace + ":" + sa.Name),
+			Annotations: map[string]string{
+				cacheScopeAnnotation: cacheScopeNamespace,
+			},
+		},
+	}, nil
+}
+
+// MapCacheOptions restricts the cache of the given cache owner to the namespace of its ServiceAccount,
+// if it was returned by CacheOwner for a namespaced owner.
+// Options of all other caches are returned unchanged.
+func MapCacheOptions(cacheOwner client.Object, opts cache.Options) cache.Options {
+	sa, ok := cacheOwner.(*corev1.ServiceAccount)
+	if !ok || sa.Annotations[cacheScopeAnnotation] != cacheScopeNamespace {
+		return opts
+	}
+
+	opts.DefaultNamespaces = map[string]cache.Config{
+		sa.Namespace: {},
+	}
+	return opts
+}
+
+// RESTConfigFor returns a copy of the given config impersonating the ServiceAccount
+// the given cache owner was returned for by CacheOwner.
+// The config is returned unchanged for all other cache owners.
+func RESTConfigFor(cacheOwner client.Object, cfg *rest.Config) *rest.Config {
+	sa, ok := cacheOwner.(*corev1.ServiceAccount)
+	if !ok {
+		return cfg
+	}
+
+	key := types.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}
+	impersonatingCfg := rest.CopyConfig(cfg)
+	impersonatingCfg.Impersonate = rest.ImpersonationConfig{
+		UserName: Username(key),
+		Groups:   Groups(key),
+	}
+	return impersonatingCfg
+}
+
+// Username returns the name of the user the API server authenticates the given ServiceAccount as.
+func Username(sa types.NamespacedName) string {
+	return serviceaccount.MakeUsername(sa.Namespace, sa.Name)
+}
+
+// Groups returns the groups the API server adds to users of the given ServiceAccount.
+func Groups(sa types.NamespacedName) []string {
+	return append(serviceaccount.MakeGroupNames(sa.Namespace), user.AllAuthenticated)
+}
+
+// verifiedController returns the controller of the given object,
+// if it is a Package Operator object that acknowledges controlling it.
+func (r *Resolver) verifiedController(
+	ctx context.Context, obj client.Object,
+) (controller client.Object, ok bool, err error) {
+	controllerRef := metav1.GetControllerOf(obj)
+	if controllerRef == nil || controllerRef.APIVersion != corev1alpha1.GroupVersion.String() {
+		return nil, false, nil
+	}
+	newController, ok := controllerKinds[controllerRef.Kind]
+	if !ok {
+		return nil, false, nil
+	}
+
+	controller = newController()
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Name:      controllerRef.Name,
+		Namespace: obj.GetNamespace(),
+	}, controller); apimachineryerrors.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("getting controller %s %s: %w", controllerRef.Kind, controllerRef.Name, err)
+	}
+	if controller.GetUID() != controllerRef.UID {
+		return nil, false, nil
+	}
+
+	// Ownership verification relies on TypeMeta being set.
+	objWithGVK, err := r.withGVK(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	controllerWithGVK, err := r.withGVK(controller)
+	if err != nil {
+		return nil, false, err
+	}
+	verified, err := ownership.VerifyOwnership(objWithGVK, controllerWithGVK)
+	if err != nil || !verified {
+		return nil, false, err
+	}
+	return controller, true, nil
+}
+
+func (r *Resolver) withGVK(obj client.Object) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return nil, err
+	}
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return obj, nil
+}
+
+// Owners that may pass on their ServiceAccount to objects they control.
+var controllerKinds = map[string]func() client.Object{
+	"ObjectSet":               func() client.Object { return &corev1alpha1.ObjectSet{} },
+	"ClusterObjectSet":        func() client.Object { return &corev1alpha1.ClusterObjectSet{} },
+	"ObjectDeployment":        func() client.Object { return &corev1alpha1.ObjectDeployment{} },
+	"ClusterObjectDeployment": func() client.Object { return &corev1alpha1.ClusterObjectDeployment{} },
+	"Package":                 func() client.Object { return &corev1alpha1.Package{} },
+	"ClusterPackage":          func() client.Object { return &corev1alpha1.ClusterPackage{} },
+}
+
+// serviceAccountReference returns the ServiceAccount referenced by the given object
+// and whether objects of its kind can reference ServiceAccounts at all.
+func serviceAccountReference(obj client.Object) (*corev1alpha1.ServiceAccountReference, bool) {
+	switch o := obj.(type) {
+	case *corev1alpha1.ObjectSet:
+		return o.Spec.ServiceAccount, true
+	case *corev1alpha1.ClusterObjectSet:
+		return o.Spec.ServiceAccount, true
+	case *corev1alpha1.ObjectDeployment:
+		return o.Spec.ServiceAccount, true
+	case *corev1alpha1.ClusterObjectDeployment:
+		return o.Spec.ServiceAccount, true
+	case *corev1alpha1.Package:
+		return o.Spec.ServiceAccount, true
+	case *corev1alpha1.ClusterPackage:
+		return o.Spec.ServiceAccount, true
+	case *corev1alpha1.ObjectSetPhase, *corev1alpha1.ClusterObjectSetPhase:
+		// Always inherited from the ObjectSet delegating the phase.
+		return nil, true
+	}
+	return nil, false
+}
+
+func serviceAccountKey(
+	obj client.Object, ref *corev1alpha1.ServiceAccountReference,
+) (*types.NamespacedName, error) {
+	key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	switch {
+	case len(obj.GetNamespace()) == 0 && len(key.Namespace) == 0:
+		return nil, fmt.Errorf(
+			"%w: namespace must be set when referenced from cluster-scoped objects",
+			ErrInvalidServiceAccountReference)
+	case len(key.Namespace) == 0:
+		key.Namespace = obj.GetNamespace()
+	case len(obj.GetNamespace()) > 0 && key.Namespace != obj.GetNamespace():
+		return nil, fmt.Errorf(
+			"%w: ServiceAccount %s must be in namespace %q",
+			ErrInvalidServiceAccountReference, key, obj.GetNamespace())
+	}
+	return &key, nil
+}
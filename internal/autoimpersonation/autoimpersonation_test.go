@@ -0,0 +1,329 @@
+package autoimpersonation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"package-operator.run/apis"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+var testScheme = runtime.NewScheme()
+
+func init() {
+	if err := apis.AddToScheme(testScheme); err != nil {
+		panic(err)
+	}
+}
+
+func newControllerRef(kind, name string, uid types.UID) []metav1.OwnerReference {
+	t := true
+	return []metav1.OwnerReference{{
+		APIVersion: corev1alpha1.GroupVersion.String(),
+		Kind:       kind,
+		Name:       name,
+		UID:        uid,
+		Controller: &t,
+	}}
+}
+
+func TestResolver_ServiceAccountFor(t *testing.T) {
+	t.Parallel()
+
+	tenantObjectDeployment := &corev1alpha1.ObjectDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "test", UID: "tenant-uid"},
+		Spec: corev1alpha1.ObjectDeploymentSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "tenant"},
+		},
+		Status: corev1alpha1.ObjectDeploymentStatus{
+			ControllerOf: []corev1alpha1.ControlledObjectReference{{
+				Kind:      "ObjectSet",
+				Group:     corev1alpha1.GroupVersion.Group,
+				Name:      "tenant-1",
+				Namespace: "test",
+			}},
+		},
+	}
+	tenantObjectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-1", Namespace: "test", UID: "tenant-1-uid"},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "tenant"},
+		},
+		Status: corev1alpha1.ObjectSetStatus{
+			RemotePhases: []corev1alpha1.RemotePhaseReference{{
+				Name: "tenant-1-phase", UID: "tenant-1-phase-uid",
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(tenantObjectDeployment, tenantObjectSet).
+		Build()
+	r := NewResolver(c, testScheme)
+
+	tests := []struct {
+		name        string
+		owner       client.Object
+		expected    *types.NamespacedName
+		expectedErr error
+	}{
+		{
+			name: "own ServiceAccount",
+			owner: &corev1alpha1.ObjectSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+				Spec: corev1alpha1.ObjectSetSpec{
+					ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa"},
+				},
+			},
+			expected: &types.NamespacedName{Namespace: "test", Name: "sa"},
+		},
+		{
+			name: "ServiceAccount in other namespace",
+			owner: &corev1alpha1.ObjectSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+				Spec: corev1alpha1.ObjectSetSpec{
+					ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa", Namespace: "kube-system"},
+				},
+			},
+			expectedErr: ErrInvalidServiceAccountReference,
+		},
+		{
+			name: "cluster-scoped",
+			owner: &corev1alpha1.ClusterObjectSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "os"},
+				Spec: corev1alpha1.ClusterObjectSetSpec{
+					ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa", Namespace: "kube-system"},
+				},
+			},
+			expected: &types.NamespacedName{Namespace: "kube-system", Name: "sa"},
+		},
+		{
+			name: "cluster-scoped without namespace",
+			owner: &corev1alpha1.ClusterObjectSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "os"},
+				Spec: corev1alpha1.ClusterObjectSetSpec{
+					ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa"},
+				},
+			},
+			expectedErr: ErrInvalidServiceAccountReference,
+		},
+		{
+			name: "inherited from verified controller",
+			owner: &corev1alpha1.ObjectSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "tenant-1", Namespace: "test",
+					OwnerReferences: newControllerRef("ObjectDeployment", "tenant", "tenant-uid"),
+				},
+			},
+			expected: &types.NamespacedName{Namespace: "test", Name: "tenant"},
+		},
+		{
+			name: "not acknowledged by controller",
+			owner: &corev1alpha1.ObjectSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "hijacker", Namespace: "test",
+					OwnerReferences: newControllerRef("ObjectDeployment", "tenant", "tenant-uid"),
+				},
+			},
+		},
+		{
+			name: "controller UID mismatch",
+			owner: &corev1alpha1.ObjectSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "tenant-1", Namespace: "test",
+					OwnerReferences: newControllerRef("ObjectDeployment", "tenant", "other-uid"),
+				},
+			},
+		},
+		{
+			name: "controller not found",
+			owner: &corev1alpha1.ObjectSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "tenant-1", Namespace: "test",
+					OwnerReferences: newControllerRef("ObjectDeployment", "missing", "missing-uid"),
+				},
+			},
+		},
+		{
+			name: "ObjectSetPhase delegated by ObjectSet",
+			owner: &corev1alpha1.ObjectSetPhase{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "tenant-1-phase", Namespace: "test", UID: "tenant-1-phase-uid",
+					OwnerReferences: newControllerRef("ObjectSet", "tenant-1", "tenant-1-uid"),
+				},
+			},
+			expected: &types.NamespacedName{Namespace: "test", Name: "tenant"},
+		},
+		{
+			name: "ObjectSetPhase not delegated by ObjectSet",
+			owner: &corev1alpha1.ObjectSetPhase{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "hijacker", Namespace: "test", UID: "hijacker-uid",
+					OwnerReferences: newControllerRef("ObjectSet", "tenant-1", "tenant-1-uid"),
+				},
+			},
+		},
+		{
+			name: "unsupported kind",
+			owner: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cm", Namespace: "test",
+					OwnerReferences: newControllerRef("ObjectDeployment", "tenant", "tenant-uid"),
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			sa, err := r.ServiceAccountFor(context.Background(), test.owner)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, sa)
+		})
+	}
+}
+
+func TestResolver_CacheOwner(t *testing.T) {
+	t.Parallel()
+
+	r := NewResolver(fake.NewClientBuilder().WithScheme(testScheme).Build(), testScheme)
+
+	owner, err := r.CacheOwner(context.Background(), &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, constants.StaticCacheOwner(), owner)
+
+	tenantOwner := func(name string) client.Object {
+		t.Helper()
+		owner, err := r.CacheOwner(context.Background(), &corev1alpha1.ObjectSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: corev1alpha1.ObjectSetSpec{
+				ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa"},
+			},
+		})
+		require.NoError(t, err)
+		return owner
+	}
+	// ObjectSets reconciled as the same ServiceAccount share a cache.
+	assert.Equal(t, tenantOwner("os-1").GetUID(), tenantOwner("os-2").GetUID())
+	assert.NotEqual(t, constants.StaticCacheOwner().GetUID(), tenantOwner("os-1").GetUID())
+
+	_, err = r.CacheOwner(context.Background(), &corev1alpha1.ClusterObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os"},
+		Spec: corev1alpha1.ClusterObjectSetSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa"},
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidServiceAccountReference)
+}
+
+func TestMapCacheOptions(t *testing.T) {
+	t.Parallel()
+
+	r := NewResolver(fake.NewClientBuilder().WithScheme(testScheme).Build(), testScheme)
+	cacheOptionsFor := func(owner client.Object) cache.Options {
+		t.Helper()
+		cacheOwner, err := r.CacheOwner(context.Background(), owner)
+		require.NoError(t, err)
+		return MapCacheOptions(cacheOwner, cache.Options{})
+	}
+
+	// Namespaced owners may only grant their ServiceAccount access to their namespace.
+	assert.Equal(t, map[string]cache.Config{"test": {}}, cacheOptionsFor(&corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa"},
+		},
+	}).DefaultNamespaces)
+
+	assert.Empty(t, cacheOptionsFor(&corev1alpha1.ClusterObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os"},
+		Spec: corev1alpha1.ClusterObjectSetSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa", Namespace: "kube-system"},
+		},
+	}).DefaultNamespaces)
+
+	assert.Empty(t, cacheOptionsFor(&corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+	}).DefaultNamespaces)
+}
+
+func TestRESTConfigFor(t *testing.T) {
+	t.Parallel()
+
+	type identity struct {
+		user   string
+		groups []string
+	}
+	requests := make(chan identity, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests <- identity{
+			user:   req.Header.Get("Impersonate-User"),
+			groups: req.Header.Values("Impersonate-Group"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"test"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	r := NewResolver(fake.NewClientBuilder().WithScheme(testScheme).Build(), testScheme)
+	cfg := &rest.Config{Host: server.URL}
+
+	getAs := func(owner client.Object) identity {
+		t.Helper()
+		cacheOwner, err := r.CacheOwner(context.Background(), owner)
+		require.NoError(t, err)
+
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+		c, err := client.New(RESTConfigFor(cacheOwner, cfg), client.Options{
+			Scheme: clientgoscheme.Scheme,
+			Mapper: mapper,
+		})
+		require.NoError(t, err)
+		require.NoError(t, c.Get(context.Background(),
+			client.ObjectKey{Name: "cm", Namespace: "test"}, &corev1.ConfigMap{}))
+		return <-requests
+	}
+
+	// Package Operator identity.
+	assert.Equal(t, identity{}, getAs(&corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+	}))
+
+	// ServiceAccount identity.
+	assert.Equal(t, identity{
+		user: "system:serviceaccount:test:sa",
+		groups: []string{
+			"system:serviceaccounts", "system:serviceaccounts:test", "system:authenticated",
+		},
+	}, getAs(&corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "os", Namespace: "test"},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "sa"},
+		},
+	}))
+	assert.Empty(t, cfg.Impersonate.UserName, "original config must not be modified")
+}
@@ -52,28 +52,38 @@ func verifyObjectDeployment(obj, owner client.Object) bool {
 }
 
 func verifyClusterObjectDeployment(obj, owner client.Object) bool {
-	objectDeployment := owner.(*v1alpha1.ObjectDeployment)
+	objectDeployment := owner.(*v1alpha1.ClusterObjectDeployment)
 	return verifyTwoWayOwnership(obj, owner, objectDeployment.Status.ControllerOf)
 }
 
 func verifyObjectSet(obj, owner client.Object) bool {
 	objectSet := owner.(*v1alpha1.ObjectSet)
+	if obj.GetObjectKind().GroupVersionKind().Kind == "ObjectSetPhase" {
+		return findOwnerReference(obj, owner) && findRemotePhase(obj, objectSet.Status.RemotePhases)
+	}
 	return verifyTwoWayOwnership(obj, owner, objectSet.Status.ControllerOf)
 }
 
 func verifyClusterObjectSet(obj, owner client.Object) bool {
 	objectSet := owner.(*v1alpha1.ClusterObjectSet)
+	if obj.GetObjectKind().GroupVersionKind().Kind == "ClusterObjectSetPhase" {
+		return findOwnerReference(obj, owner) && findRemotePhase(obj, objectSet.Status.RemotePhases)
+	}
 	return verifyTwoWayOwnership(obj, owner, objectSet.Status.ControllerOf)
 }
 
 func verifyObjectTemplate(obj, owner client.Object) bool {
 	objectTemplate := owner.(*v1alpha1.ObjectTemplate)
-	return verifyTwoWayOwnership(obj, owner, []v1alpha1.ControlledObjectReference{objectTemplate.Status.ControllerOf})
+	return verifyTwoWayOwnership(obj, owner, append(
+		[]v1alpha1.ControlledObjectReference{objectTemplate.Status.ControllerOf},
+		objectTemplate.Status.ControlledObjects...))
 }
 
 func verifyClusterObjectTemplate(obj, owner client.Object) bool {
 	objectTemplate := owner.(*v1alpha1.ClusterObjectTemplate)
-	return verifyTwoWayOwnership(obj, owner, []v1alpha1.ControlledObjectReference{objectTemplate.Status.ControllerOf})
+	return verifyTwoWayOwnership(obj, owner, append(
+		[]v1alpha1.ControlledObjectReference{objectTemplate.Status.ControllerOf},
+		objectTemplate.Status.ControlledObjects...))
 }
 
 func verifyTwoWayOwnership(
@@ -92,6 +102,15 @@ func findOwnerReference(obj, owner client.Object) bool {
 	return false
 }
 
+func findRemotePhase(obj client.Object, remotePhases []v1alpha1.RemotePhaseReference) bool {
+	for _, ref := range remotePhases {
+		if ref.Name == obj.GetName() && ref.UID == obj.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
 func findControllerOf(obj client.Object, controllerOf []v1alpha1.ControlledObjectReference) bool {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	objRef := v1alpha1.ControlledObjectReference{
@@ -165,7 +165,9 @@ func TestVerifyOwnership_ObjectTemplate(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, isOwner)
 
-	objectTemplate.SetStatusControllerOf(newControlledObjectReference(&cm))
+	objectTemplate.SetStatusControllerOf([]v1alpha1.ControlledObjectReference{
+		newControlledObjectReference(&cm),
+	})
 
 	// Two-way ownership established
 	isOwner, err = VerifyOwnership(&cm, objectTemplate.ClientObject())
@@ -251,6 +253,49 @@ func TestVerifyOwnership_ObjectDeployment(t *testing.T) {
 	}
 }
 
+func TestVerifyOwnership_ClusterObjectDeployment(t *testing.T) {
+	t.Parallel()
+
+	objectDeployment := adapters.ClusterObjectDeployment{
+		ClusterObjectDeployment: v1alpha1.ClusterObjectDeployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ClusterObjectDeployment",
+				APIVersion: "package-operator.run/v1alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-od",
+				UID:  "test-od-uid",
+			},
+		},
+	}
+
+	objectSet := adapters.ClusterObjectSetAdapter{
+		ClusterObjectSet: v1alpha1.ClusterObjectSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ClusterObjectSet",
+				APIVersion: "package-operator.run/v1alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-os",
+				UID:  "test-os-uid",
+			},
+		},
+	}
+	objectSet.SetOwnerReferences(newOwnerReferences(objectDeployment.ClientObject()))
+
+	isOwner, err := VerifyOwnership(objectSet.ClientObject(), objectDeployment.ClientObject())
+	require.NoError(t, err)
+	assert.False(t, isOwner)
+
+	objectDeployment.SetStatusControllerOf([]v1alpha1.ControlledObjectReference{
+		newControlledObjectReference(objectSet.ClientObject()),
+	})
+
+	isOwner, err = VerifyOwnership(objectSet.ClientObject(), objectDeployment.ClientObject())
+	require.NoError(t, err)
+	assert.True(t, isOwner)
+}
+
 func TestVerifyOwnership_ObjectSet(t *testing.T) {
 	t.Parallel()
 
@@ -301,6 +346,51 @@ func TestVerifyOwnership_ObjectSet(t *testing.T) {
 	assert.True(t, isOwner)
 }
 
+func TestVerifyOwnership_ObjectSetRemotePhase(t *testing.T) {
+	t.Parallel()
+
+	objectSet := adapters.ObjectSetAdapter{
+		ObjectSet: v1alpha1.ObjectSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ObjectSet",
+				APIVersion: "package-operator.run/v1alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-os",
+				Namespace: "test-os-ns",
+				UID:       "test-os-uid",
+			},
+		},
+	}
+
+	phase := v1alpha1.ObjectSetPhase{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ObjectSetPhase",
+			APIVersion: "package-operator.run/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-os-phase",
+			Namespace:       "test-os-ns",
+			UID:             "test-os-phase-uid",
+			OwnerReferences: newOwnerReferences(objectSet.ClientObject()),
+		},
+	}
+
+	// one-way ownership
+	isOwner, err := VerifyOwnership(&phase, objectSet.ClientObject())
+	require.NoError(t, err)
+	assert.False(t, isOwner)
+
+	// two-way ownership
+	objectSet.SetStatusRemotePhases([]v1alpha1.RemotePhaseReference{
+		{Name: phase.Name, UID: phase.UID},
+	})
+
+	isOwner, err = VerifyOwnership(&phase, objectSet.ClientObject())
+	require.NoError(t, err)
+	assert.True(t, isOwner)
+}
+
 func newOwnerReferences(o client.Object) []metav1.OwnerReference {
 	t := true
 	APIVersion, kind := o.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
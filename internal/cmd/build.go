@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/crane"
@@ -68,7 +69,7 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 	switch cfg.OutputFormat {
 	case OutputFormatHuman:
 		log = b.cfg.Log
-	case OutputFormatDigest:
+	case OutputFormatDigest, OutputFormatJSON, OutputFormatSARIF:
 		log = logr.Discard()
 	default:
 		panic("unknown output format: " + cfg.OutputFormat)
@@ -107,16 +108,34 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 		return fmt.Errorf("loading package from files: %w", err)
 	}
 
+	image, err := packages.ToOCI(rawPkg)
+	if err != nil {
+		return fmt.Errorf("exporting package to image: %w", err)
+	}
+
+	if cfg.SBOMFormat != "" {
+		log.Info("attaching SBOM", "format", cfg.SBOMFormat)
+
+		sbom, err := packages.EncodeSBOM(packages.NewBillOfMaterials(pkg, rawPkg), cfg.SBOMFormat, cfg.SBOMCreated)
+		if err != nil {
+			return fmt.Errorf("generating SBOM: %w", err)
+		}
+		image, err = packages.AttachSBOM(image, sbom)
+		if err != nil {
+			return fmt.Errorf("attaching SBOM: %w", err)
+		}
+	}
+
 	if cfg.OutputPath != "" {
 		log.Info("writing tagged image to disk", "path", cfg.OutputPath)
 
-		if err := packages.ToOCIFile(cfg.OutputPath, cfg.Tags, rawPkg); err != nil {
+		if err := packages.ImageToOCIFile(cfg.OutputPath, cfg.Tags, image); err != nil {
 			return fmt.Errorf("exporting package to file: %w", err)
 		}
 	}
 
 	if cfg.Push {
-		digest, err := packages.ToPushedOCI(ctx, cfg.Tags, rawPkg, craneOpts...)
+		digest, err := packages.ImageToPushedOCI(ctx, cfg.Tags, image, craneOpts...)
 		if err != nil {
 			return fmt.Errorf("exporting package to image: %w", err)
 		}
@@ -136,6 +155,10 @@ type BuildFromSourceConfig struct {
 	Tags         []string
 	Labels       map[string]string
 	Push         bool
+	SBOMFormat   string
+	// Creation time recorded in the SBOM.
+	// Defaults to the zero time, so building the same source twice yields the same image.
+	SBOMCreated time.Time
 }
 
 func (c *BuildFromSourceConfig) Option(opts ...BuildFromSourceOption) {
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"package-operator.run/internal/packages"
+)
+
+func NewInspect(opts ...InspectOption) *Inspect {
+	var cfg InspectConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Inspect{
+		cfg: cfg,
+	}
+}
+
+type Inspect struct {
+	cfg InspectConfig
+}
+
+type InspectConfig struct {
+	Log logr.Logger
+}
+
+func (c *InspectConfig) Option(opts ...InspectOption) {
+	for _, opt := range opts {
+		opt.ConfigureInspect(c)
+	}
+}
+
+func (c *InspectConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+type InspectOption interface {
+	ConfigureInspect(*InspectConfig)
+}
+
+// InspectSBOM returns the SBOM attached to the package image during build.
+// Target is an image reference or the path to an image tar created by build.
+func (i *Inspect) InspectSBOM(
+	ctx context.Context, target string, opts ...InspectPackageOption,
+) (*packages.SBOM, error) {
+	var cfg InspectPackageConfig
+
+	cfg.Option(opts...)
+
+	image, err := i.loadImage(ctx, target, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sbom, err := packages.SBOMFromOCI(image)
+	if err != nil {
+		return nil, fmt.Errorf("reading SBOM: %w", err)
+	}
+
+	return sbom, nil
+}
+
+// InspectPackage loads the package at target and summarizes its manifests.
+// Target is an image reference, the path to an image tar created by build or a package source folder.
+func (i *Inspect) InspectPackage(
+	ctx context.Context, target string, opts ...InspectPackageOption,
+) (*PackageInspection, error) {
+	var cfg InspectPackageConfig
+
+	cfg.Option(opts...)
+
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		i.cfg.Log.Info("loading source from disk", "path", target)
+
+		rawPkg, err := packages.FromFolder(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("loading package contents from folder: %w", err)
+		}
+		return i.inspectRawPackage(ctx, rawPkg)
+	}
+
+	image, err := i.loadImage(ctx, target, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rawPkg, err := packages.FromOCI(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("importing package from image: %w", err)
+	}
+	inspection, err := i.inspectRawPackage(ctx, rawPkg)
+	if err != nil {
+		return nil, err
+	}
+
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("reading image config: %w", err)
+	}
+	inspection.ImageLabels = configFile.Config.Labels
+
+	sbom, err := packages.SBOMFromOCI(image)
+	switch {
+	case err == nil:
+		inspection.SBOMFormat = sbom.Format
+	case !errors.Is(err, packages.ErrNoSBOM):
+		return nil, fmt.Errorf("reading SBOM: %w", err)
+	}
+
+	return inspection, nil
+}
+
+func (i *Inspect) inspectRawPackage(ctx context.Context, rawPkg *packages.RawPackage) (*PackageInspection, error) {
+	pkg, err := packages.DefaultStructuralLoader.Load(ctx, rawPkg)
+	if err != nil {
+		return nil, fmt.Errorf("parsing package contents: %w", err)
+	}
+
+	return NewPackageInspection(pkg), nil
+}
+
+func (i *Inspect) loadImage(
+	ctx context.Context, target string, cfg InspectPackageConfig,
+) (containerregistrypkgv1.Image, error) {
+	if _, err := os.Stat(target); err == nil {
+		i.cfg.Log.Info("loading package image from file", "path", target)
+
+		image, err := crane.Load(target)
+		if err != nil {
+			return nil, fmt.Errorf("loading package image from file: %w", err)
+		}
+		return image, nil
+	}
+
+	i.cfg.Log.Info("pulling package image", "image", target)
+
+	opts := append(craneOptions(cfg.Insecure), crane.WithContext(ctx))
+	image, err := crane.Pull(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("pulling package image: %w", err)
+	}
+	return image, nil
+}
+
+type InspectPackageConfig struct {
+	Insecure bool
+}
+
+func (c *InspectPackageConfig) Option(opts ...InspectPackageOption) {
+	for _, opt := range opts {
+		opt.ConfigureInspectPackage(c)
+	}
+}
+
+type InspectPackageOption interface {
+	ConfigureInspectPackage(*InspectPackageConfig)
+}
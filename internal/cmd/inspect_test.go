@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/packages"
+)
+
+func TestInspect_InspectSBOM(t *testing.T) {
+	t.Parallel()
+
+	image, err := packages.ToOCI(&packages.RawPackage{
+		Files: packages.Files{"manifest.yaml": []byte("test")},
+	})
+	require.NoError(t, err)
+	sbom := &packages.SBOM{Format: packages.SBOMFormatSPDX, Document: []byte("{}")}
+	image, err = packages.AttachSBOM(image, sbom)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	ref := strings.TrimPrefix(srv.URL, "http://") + "/pkg:v1"
+	require.NoError(t, crane.Push(image, ref, crane.Insecure))
+
+	path := filepath.Join(t.TempDir(), "pkg.tar")
+	require.NoError(t, packages.ImageToOCIFile(path, []string{"pkg:v1"}, image))
+
+	i := NewInspect()
+	ctx := context.Background()
+
+	for _, target := range []string{ref, path} {
+		read, err := i.InspectSBOM(ctx, target, WithInsecure(true))
+		require.NoError(t, err)
+		assert.Equal(t, sbom, read)
+	}
+}
+
+func TestInspect_InspectSBOM_NoSBOM(t *testing.T) {
+	t.Parallel()
+
+	image, err := packages.ToOCI(&packages.RawPackage{
+		Files: packages.Files{"manifest.yaml": []byte("test")},
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "pkg.tar")
+	require.NoError(t, packages.ImageToOCIFile(path, []string{"pkg:v1"}, image))
+
+	_, err = NewInspect().InspectSBOM(context.Background(), path)
+	require.ErrorIs(t, err, packages.ErrNoSBOM)
+}
+
+const inspectTestManifest = `apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifest
+metadata:
+  name: test
+spec:
+  scopes:
+  - Namespaced
+  phases:
+  - name: deploy
+  availabilityProbes: []
+`
+
+func TestInspect_InspectPackage(t *testing.T) {
+	t.Parallel()
+
+	rawPkg := &packages.RawPackage{
+		Files: packages.Files{"manifest.yaml": []byte(inspectTestManifest)},
+	}
+	image, err := packages.ToOCI(rawPkg)
+	require.NoError(t, err)
+	image, err = packages.AttachSBOM(image, &packages.SBOM{Format: packages.SBOMFormatCycloneDX, Document: []byte("{}")})
+	require.NoError(t, err)
+	configFile, err := image.ConfigFile()
+	require.NoError(t, err)
+	configFile.Config.Labels = map[string]string{"org.opencontainers.image.version": "v1"}
+	image, err = mutate.ConfigFile(image, configFile)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "pkg.tar")
+	require.NoError(t, packages.ImageToOCIFile(path, []string{"pkg:v1"}, image))
+
+	srcPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcPath, "manifest.yaml"), []byte(inspectTestManifest), 0o600))
+
+	i := NewInspect()
+	ctx := context.Background()
+
+	in, err := i.InspectPackage(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, &PackageInspection{
+		Name:        "test",
+		Scopes:      []string{"Namespaced"},
+		Phases:      []InspectedPhase{{Name: "deploy"}},
+		ImageLabels: map[string]string{"org.opencontainers.image.version": "v1"},
+		SBOMFormat:  packages.SBOMFormatCycloneDX,
+	}, in)
+
+	in, err = i.InspectPackage(ctx, srcPath)
+	require.NoError(t, err)
+	assert.Equal(t, &PackageInspection{
+		Name:   "test",
+		Scopes: []string{"Namespaced"},
+		Phases: []InspectedPhase{{Name: "deploy"}},
+	}, in)
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/disiqueira/gotree"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"sigs.k8s.io/yaml"
+
+	"package-operator.run/internal/packages"
+)
+
+// PackageInspection summarizes the manifests of a package.
+type PackageInspection struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Scopes      []string          `json:"scopes"`
+	Phases      []InspectedPhase  `json:"phases"`
+	// Fields of the config OpenAPI schema, recursively.
+	Config       []InspectedConfigField `json:"config,omitempty"`
+	Images       []InspectedImage       `json:"images,omitempty"`
+	Dependencies []InspectedDependency  `json:"dependencies,omitempty"`
+	Constraints  []InspectedConstraint  `json:"constraints,omitempty"`
+	Components   []PackageInspection    `json:"components,omitempty"`
+	// Labels of the package image, empty when inspecting a source folder.
+	ImageLabels map[string]string `json:"imageLabels,omitempty"`
+	// Format of the SBOM attached to the package image, if any.
+	SBOMFormat string `json:"sbomFormat,omitempty"`
+}
+
+type InspectedPhase struct {
+	Name  string `json:"name"`
+	Class string `json:"class,omitempty"`
+}
+
+type InspectedConfigField struct {
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	Default     any                    `json:"default,omitempty"`
+	Enum        []any                  `json:"enum,omitempty"`
+	Fields      []InspectedConfigField `json:"fields,omitempty"`
+}
+
+type InspectedImage struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	// Digest from the lock file, empty if the package is not locked.
+	Digest string `json:"digest,omitempty"`
+}
+
+type InspectedDependency struct {
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	Range   string `json:"range,omitempty"`
+	// Resolved image, digest and version from the lock file, empty if the package is not locked.
+	Image   string `json:"image,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type InspectedConstraint struct {
+	Platform        []string                  `json:"platform,omitempty"`
+	PlatformVersion *InspectedPlatformVersion `json:"platformVersion,omitempty"`
+	UniqueInScope   bool                      `json:"uniqueInScope,omitempty"`
+}
+
+type InspectedPlatformVersion struct {
+	Name  string `json:"name"`
+	Range string `json:"range"`
+}
+
+// NewPackageInspection summarizes the given package and its components.
+func NewPackageInspection(pkg *packages.Package) *PackageInspection {
+	manifest := pkg.Manifest
+	in := &PackageInspection{
+		Name:        manifest.Name,
+		Labels:      manifest.Labels,
+		Annotations: manifest.Annotations,
+		Scopes:      []string{},
+		Phases:      []InspectedPhase{},
+	}
+
+	for _, scope := range manifest.Spec.Scopes {
+		in.Scopes = append(in.Scopes, string(scope))
+	}
+	for _, phase := range manifest.Spec.Phases {
+		in.Phases = append(in.Phases, InspectedPhase{Name: phase.Name, Class: phase.Class})
+	}
+	if schema := manifest.Spec.Config.OpenAPIV3Schema; schema != nil {
+		in.Config = inspectConfigFields(schema)
+	}
+
+	var (
+		lockedImages = map[string]InspectedImage{}
+		lockedDeps   = map[string]InspectedDependency{}
+	)
+	if pkg.ManifestLock != nil {
+		for _, img := range pkg.ManifestLock.Spec.Images {
+			lockedImages[img.Name] = InspectedImage{Image: img.Image, Digest: img.Digest}
+		}
+		for _, dep := range pkg.ManifestLock.Spec.Dependencies {
+			lockedDeps[dep.Name] = InspectedDependency{Image: dep.Image, Digest: dep.Digest, Version: dep.Version}
+		}
+	}
+
+	for _, img := range manifest.Spec.Images {
+		inspected := InspectedImage{Name: img.Name, Image: img.Image}
+		if locked, ok := lockedImages[img.Name]; ok {
+			inspected.Image, inspected.Digest = locked.Image, locked.Digest
+		}
+		in.Images = append(in.Images, inspected)
+	}
+	for _, dep := range manifest.Spec.Dependencies {
+		if dep.Image == nil {
+			continue
+		}
+		inspected := lockedDeps[dep.Image.Name]
+		inspected.Name, inspected.Package, inspected.Range = dep.Image.Name, dep.Image.Package, dep.Image.Range
+		in.Dependencies = append(in.Dependencies, inspected)
+	}
+
+	for _, c := range manifest.Spec.Constraints {
+		var inspected InspectedConstraint
+		for _, p := range c.Platform {
+			inspected.Platform = append(inspected.Platform, string(p))
+		}
+		if c.PlatformVersion != nil {
+			inspected.PlatformVersion = &InspectedPlatformVersion{
+				Name: string(c.PlatformVersion.Name), Range: c.PlatformVersion.Range,
+			}
+		}
+		inspected.UniqueInScope = c.UniqueInScope != nil
+		in.Constraints = append(in.Constraints, inspected)
+	}
+
+	for i := range pkg.Components {
+		in.Components = append(in.Components, *NewPackageInspection(&pkg.Components[i]))
+	}
+	// Components are loaded in random order.
+	slices.SortFunc(in.Components, func(a, b PackageInspection) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return in
+}
+
+func inspectConfigFields(schema *apiextensions.JSONSchemaProps) []InspectedConfigField {
+	var fields []InspectedConfigField
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		prop := schema.Properties[name]
+		fields = append(fields, inspectConfigField(name, &prop, slices.Contains(schema.Required, name)))
+	}
+
+	// Describe elements of arrays and values of maps as nested fields.
+	if schema.Items != nil && schema.Items.Schema != nil {
+		fields = append(fields, inspectConfigField("[]", schema.Items.Schema, false))
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		fields = append(fields, inspectConfigField("*", schema.AdditionalProperties.Schema, false))
+	}
+	return fields
+}
+
+func inspectConfigField(name string, schema *apiextensions.JSONSchemaProps, required bool) InspectedConfigField {
+	field := InspectedConfigField{
+		Name:        name,
+		Type:        schema.Type,
+		Description: schema.Description,
+		Required:    required,
+		Fields:      inspectConfigFields(schema),
+	}
+	if schema.Default != nil {
+		field.Default = *schema.Default
+	}
+	for _, e := range schema.Enum {
+		field.Enum = append(field.Enum, e)
+	}
+	return field
+}
+
+// Write the inspection in the given output format.
+func (in *PackageInspection) Write(w io.Writer, format string) error {
+	var (
+		out []byte
+		err error
+	)
+	switch format {
+	case OutputFormatHuman:
+		out = []byte(in.tree().Print())
+	case OutputFormatJSON:
+		out, err = json.MarshalIndent(in, "", "  ")
+		out = append(out, '\n')
+	case OutputFormatYAML:
+		out, err = yaml.Marshal(in)
+	default:
+		return fmt.Errorf("%w: unknown output format: %s", ErrInvalidArgs, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+func (in *PackageInspection) tree() gotree.Tree {
+	root := gotree.New(in.Name)
+
+	if len(in.Scopes) > 0 {
+		root.Add("Scopes: " + strings.Join(in.Scopes, ", "))
+	}
+	if in.SBOMFormat != "" {
+		root.Add("SBOM: " + in.SBOMFormat)
+	}
+	addKeyValues(root, "Labels", in.Labels)
+	addKeyValues(root, "Annotations", in.Annotations)
+	addKeyValues(root, "Image Labels", in.ImageLabels)
+
+	if len(in.Phases) > 0 {
+		phases := root.Add("Phases")
+		for _, p := range in.Phases {
+			if p.Class != "" {
+				phases.Add(fmt.Sprintf("%s (class: %s)", p.Name, p.Class))
+				continue
+			}
+			phases.Add(p.Name)
+		}
+	}
+
+	if len(in.Config) > 0 {
+		addConfigFields(root.Add("Config"), in.Config)
+	}
+
+	if len(in.Images) > 0 {
+		images := root.Add("Images")
+		for _, img := range in.Images {
+			ref := img.Image
+			if img.Digest != "" {
+				ref += "@" + img.Digest
+			}
+			images.Add(img.Name + ": " + ref)
+		}
+	}
+
+	if len(in.Dependencies) > 0 {
+		deps := root.Add("Dependencies")
+		for _, dep := range in.Dependencies {
+			text := dep.Name + ": " + dep.Package
+			if dep.Range != "" {
+				text += " " + dep.Range
+			}
+			d := deps.Add(text)
+			if dep.Digest != "" {
+				d.Add(fmt.Sprintf("locked: %s@%s (%s)", dep.Image, dep.Digest, dep.Version))
+			}
+		}
+	}
+
+	if len(in.Constraints) > 0 {
+		constraints := root.Add("Constraints")
+		for _, c := range in.Constraints {
+			if len(c.Platform) > 0 {
+				constraints.Add("Platform: " + strings.Join(c.Platform, ", "))
+			}
+			if c.PlatformVersion != nil {
+				constraints.Add(fmt.Sprintf("Platform version: %s %s", c.PlatformVersion.Name, c.PlatformVersion.Range))
+			}
+			if c.UniqueInScope {
+				constraints.Add("Unique in scope")
+			}
+		}
+	}
+
+	if len(in.Components) > 0 {
+		components := root.Add("Components")
+		for _, c := range in.Components {
+			components.AddTree(c.tree())
+		}
+	}
+
+	return root
+}
+
+func addKeyValues(parent gotree.Tree, title string, kv map[string]string) {
+	if len(kv) == 0 {
+		return
+	}
+
+	t := parent.Add(title)
+	for _, k := range slices.Sorted(maps.Keys(kv)) {
+		t.Add(k + "=" + kv[k])
+	}
+}
+
+func addConfigFields(parent gotree.Tree, fields []InspectedConfigField) {
+	for _, f := range fields {
+		var attrs []string
+		if f.Type != "" {
+			attrs = append(attrs, f.Type)
+		}
+		if f.Required {
+			attrs = append(attrs, "required")
+		}
+		if f.Default != nil {
+			attrs = append(attrs, fmt.Sprintf("default: %v", f.Default))
+		}
+		if len(f.Enum) > 0 {
+			attrs = append(attrs, fmt.Sprintf("enum: %v", f.Enum))
+		}
+
+		text := f.Name
+		if len(attrs) > 0 {
+			text += " (" + strings.Join(attrs, ", ") + ")"
+		}
+		if f.Description != "" {
+			// Only the first line, to keep the tree readable.
+			description, _, _ := strings.Cut(f.Description, "\n")
+			text += ": " + description
+		}
+		addConfigFields(parent.Add(text), f.Fields)
+	}
+}
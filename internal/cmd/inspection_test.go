@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+)
+
+const inspectionTestDigest = "sha256:00e48c32b3cdcf9e2c66467f2beb0ef33b43b54e2b56415db4ee431512c406ea"
+
+func inspectionTestPackage() *packages.Package {
+	var replicas apiextensions.JSON = float64(1)
+
+	return &packages.Package{
+		Manifest: &manifests.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: manifests.PackageManifestSpec{
+				Scopes: []manifests.PackageManifestScope{manifests.PackageManifestScopeNamespaced},
+				Phases: []manifests.PackageManifestPhase{
+					{Name: "deploy"},
+					{Name: "remote", Class: "hosted-cluster"},
+				},
+				Config: manifests.PackageManifestSpecConfig{
+					OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"database"},
+						Properties: map[string]apiextensions.JSONSchemaProps{
+							"replicas": {Type: "integer", Default: &replicas},
+							"database": {
+								Type: "object",
+								Properties: map[string]apiextensions.JSONSchemaProps{
+									"host": {Type: "string", Description: "Hostname of the database.\nMore details."},
+								},
+							},
+							"tags": {
+								Type:  "array",
+								Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+							},
+						},
+					},
+				},
+				Images: []manifests.PackageManifestImage{
+					{Name: "nginx", Image: "quay.io/example/nginx:1.25"},
+				},
+				Dependencies: []manifests.PackageManifestDependency{
+					{Image: &manifests.PackageManifestDependencyImage{
+						Name: "dep", Package: "example.com/dep", Range: ">=1.0.0",
+					}},
+				},
+				Constraints: []manifests.PackageManifestConstraint{
+					{PlatformVersion: &manifests.PackageManifestPlatformVersionConstraint{
+						Name: manifests.Kubernetes, Range: ">=1.29",
+					}},
+					{UniqueInScope: &manifests.PackageManifestUniqueInScopeConstraint{}},
+				},
+			},
+		},
+		ManifestLock: &manifests.PackageManifestLock{
+			Spec: manifests.PackageManifestLockSpec{
+				Images: []manifests.PackageManifestLockImage{
+					{Name: "nginx", Image: "quay.io/example/nginx:1.25", Digest: inspectionTestDigest},
+				},
+				Dependencies: []manifests.PackageManifestLockDependency{
+					{Name: "dep", Image: "quay.io/example/dep", Digest: inspectionTestDigest, Version: "v1.2.0"},
+				},
+			},
+		},
+		Components: []packages.Package{
+			{Manifest: &manifests.PackageManifest{ObjectMeta: metav1.ObjectMeta{Name: "b"}}},
+			{Manifest: &manifests.PackageManifest{ObjectMeta: metav1.ObjectMeta{Name: "a"}}},
+		},
+	}
+}
+
+func TestNewPackageInspection(t *testing.T) {
+	t.Parallel()
+
+	in := NewPackageInspection(inspectionTestPackage())
+
+	assert.Equal(t, "test", in.Name)
+	assert.Equal(t, []string{"Namespaced"}, in.Scopes)
+	assert.Equal(t, []InspectedPhase{{Name: "deploy"}, {Name: "remote", Class: "hosted-cluster"}}, in.Phases)
+	assert.Equal(t, []InspectedConfigField{
+		{Name: "database", Type: "object", Required: true, Fields: []InspectedConfigField{
+			{Name: "host", Type: "string", Description: "Hostname of the database.\nMore details."},
+		}},
+		{Name: "replicas", Type: "integer", Default: float64(1)},
+		{Name: "tags", Type: "array", Fields: []InspectedConfigField{
+			{Name: "[]", Type: "string"},
+		}},
+	}, in.Config)
+	assert.Equal(t, []InspectedImage{
+		{Name: "nginx", Image: "quay.io/example/nginx:1.25", Digest: inspectionTestDigest},
+	}, in.Images)
+	assert.Equal(t, []InspectedDependency{{
+		Name: "dep", Package: "example.com/dep", Range: ">=1.0.0",
+		Image: "quay.io/example/dep", Digest: inspectionTestDigest, Version: "v1.2.0",
+	}}, in.Dependencies)
+	assert.Equal(t, []InspectedConstraint{
+		{PlatformVersion: &InspectedPlatformVersion{Name: "Kubernetes", Range: ">=1.29"}},
+		{UniqueInScope: true},
+	}, in.Constraints)
+	if assert.Len(t, in.Components, 2) {
+		assert.Equal(t, "a", in.Components[0].Name)
+		assert.Equal(t, "b", in.Components[1].Name)
+	}
+}
+
+func TestPackageInspection_Write(t *testing.T) {
+	t.Parallel()
+
+	in := NewPackageInspection(inspectionTestPackage())
+	in.ImageLabels = map[string]string{"org.opencontainers.image.source": "https://example.com"}
+	in.SBOMFormat = packages.SBOMFormatSPDX
+
+	out := &bytes.Buffer{}
+	require.NoError(t, in.Write(out, OutputFormatHuman))
+	assert.Equal(t, `test
+└── Scopes: Namespaced
+└── SBOM: spdx
+└── Image Labels
+│   ├── org.opencontainers.image.source=https://example.com
+└── Phases
+│   ├── deploy
+│   ├── remote (class: hosted-cluster)
+└── Config
+│   ├── database (object, required)
+│   │   ├── host (string): Hostname of the database.
+│   ├── replicas (integer, default: 1)
+│   ├── tags (array)
+│       └── [] (string)
+└── Images
+│   ├── nginx: quay.io/example/nginx:1.25@`+inspectionTestDigest+`
+└── Dependencies
+│   ├── dep: example.com/dep >=1.0.0
+│       └── locked: quay.io/example/dep@`+inspectionTestDigest+` (v1.2.0)
+└── Constraints
+│   ├── Platform version: Kubernetes >=1.29
+│   ├── Unique in scope
+└── Components
+    └── a
+    └── b
+`, out.String())
+
+	require.ErrorIs(t, in.Write(out, "banana"), ErrInvalidArgs)
+}
@@ -161,9 +161,12 @@ func (k *Kickstarter) getInput(ctx context.Context, input string) (
 				return nil, fmt.Errorf("accessing: %w", err)
 			}
 			var matchObjs []unstructured.Unstructured
-			if i.IsDir() {
+			switch {
+			case i.IsDir():
 				matchObjs, err = kubemanifests.LoadKubernetesObjectsFromFolder(match)
-			} else {
+			case packages.IsJsonnetFile(match):
+				matchObjs, err = loadKubernetesObjectsFromJsonnet(match)
+			default:
 				matchObjs, err = kubemanifests.LoadKubernetesObjectsFromFile(match)
 			}
 			if err != nil {
@@ -181,6 +184,15 @@ func (k *Kickstarter) getInput(ctx context.Context, input string) (
 	return kubemanifests.LoadKubernetesObjectsFromBytes(content)
 }
 
+// Evaluates a Jsonnet program and loads the objects it produces.
+func loadKubernetesObjectsFromJsonnet(path string) ([]unstructured.Unstructured, error) {
+	content, err := packages.EvaluateJsonnetFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating jsonnet: %w", err)
+	}
+	return kubemanifests.LoadKubernetesObjectsFromBytes(content)
+}
+
 // expandIfFilePattern returns all the filenames that match the input pattern
 // or the filename if it is a specific filename and not a pattern.
 // If the input is a pattern and it yields no result it will result in an error.
@@ -28,3 +28,20 @@ func TestKickstart(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, kickstartMessage, msg)
 }
+
+func TestKickstart_Jsonnet(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if err := os.RemoveAll("my-jsonnet-pkg"); err != nil {
+			panic(err)
+		}
+	}()
+
+	ctx := context.Background()
+	k := NewKickstarter(nil)
+	msg, err := k.Kickstart(ctx, "my-jsonnet-pkg", []string{"testdata/objects.jsonnet"}, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `Kickstarted the "my-jsonnet-pkg" package with 2 objects.`, msg)
+	assert.FileExists(t, "my-jsonnet-pkg/deploy/a.configmap.yaml")
+	assert.FileExists(t, "my-jsonnet-pkg/namespaces/my-ns.namespace.yaml")
+}
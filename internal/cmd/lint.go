@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"package-operator.run/internal/packages"
+)
+
+func NewLint(opts ...LintOption) *Lint {
+	var cfg LintConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Lint{
+		cfg: cfg,
+	}
+}
+
+type Lint struct {
+	cfg LintConfig
+}
+
+type LintConfig struct {
+	Log logr.Logger
+}
+
+func (c *LintConfig) Option(opts ...LintOption) {
+	for _, opt := range opts {
+		opt.ConfigureLint(c)
+	}
+}
+
+func (c *LintConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+type LintOption interface {
+	ConfigureLint(*LintConfig)
+}
+
+// LintPackage runs all lint rules enabled in the PackageManifest
+// against the rendered output of the package source at path.
+func (l *Lint) LintPackage(ctx context.Context, path string) error {
+	ctx = logr.NewContext(ctx, l.cfg.Log)
+
+	rawPkg, err := getPackageFromPath(ctx, path)
+	if err != nil {
+		return fmt.Errorf("getting package from path: %w", err)
+	}
+
+	pkg, err := packages.DefaultStructuralLoader.Load(ctx, rawPkg)
+	if err != nil {
+		return err
+	}
+
+	return packages.NewLinter().ValidatePackage(ctx, pkg)
+}
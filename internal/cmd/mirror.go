@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imageverify"
+	"package-operator.run/internal/packages"
+)
+
+// OCI annotation naming images in an OCI layout.
+const ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+func NewMirror(opts ...MirrorOption) *Mirror {
+	var cfg MirrorConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Mirror{
+		cfg: cfg,
+	}
+}
+
+type Mirror struct {
+	cfg MirrorConfig
+}
+
+type MirrorConfig struct {
+	Log logr.Logger
+	// Options used for all registry requests.
+	CraneOptions []crane.Option
+}
+
+func (c *MirrorConfig) Option(opts ...MirrorOption) {
+	for _, opt := range opts {
+		opt.ConfigureMirror(c)
+	}
+}
+
+func (c *MirrorConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+type MirrorOption interface {
+	ConfigureMirror(*MirrorConfig)
+}
+
+// MirrorResult lists the images copied while mirroring a package.
+type MirrorResult struct {
+	// Copied images by digest and their cosign signatures by tag.
+	Images []MirroredImage
+	// Image prefix overrides redirecting all mirrored repositories to the target.
+	// Empty when mirroring into an OCI layout.
+	Overrides []imageprefix.Override
+}
+
+// MirroredImage is an image copied while mirroring a package.
+type MirroredImage struct {
+	Source string
+	Target string
+}
+
+// MirrorPackage copies the package image at image, every image in its lock file and
+// all dependency packages, recursively, into the target registry prefix.
+// With WithLayout, target is the path of an OCI layout directory instead, which is created if needed.
+func (m *Mirror) MirrorPackage(
+	ctx context.Context, image, target string, opts ...MirrorPackageOption,
+) (*MirrorResult, error) {
+	var cfg MirrorPackageConfig
+
+	cfg.Option(opts...)
+
+	craneOpts := append(slices.Clone(m.cfg.CraneOptions), crane.WithContext(ctx))
+	if cfg.Insecure {
+		craneOpts = append(craneOpts, crane.Insecure)
+	}
+
+	w := &mirrorWalker{
+		log:       m.cfg.Log,
+		craneOpts: craneOpts,
+		copied:    map[string]string{},
+		result:    &MirrorResult{},
+	}
+	if cfg.Layout {
+		p, err := openOrCreateLayout(target)
+		if err != nil {
+			return nil, err
+		}
+		w.copy = w.layoutCopier(p)
+	} else {
+		w.copy = w.registryCopier(strings.TrimSuffix(target, "/"))
+	}
+
+	if err := w.mirrorPackage(ctx, image, image); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(w.result.Overrides, func(a, b imageprefix.Override) int {
+		return strings.Compare(a.From, b.From)
+	})
+	return w.result, nil
+}
+
+type mirrorWalker struct {
+	log       logr.Logger
+	craneOpts []crane.Option
+	// Copies the image at the given digest or signature tag reference.
+	// Returns the target repository to override the source repository with, if any.
+	copy func(ref name.Reference) (string, error)
+	// Target repositories of digest references already copied.
+	copied map[string]string
+	result *MirrorResult
+}
+
+// Mirrors the package image at ref and everything it references.
+// source is the image as referenced by the user or the dependent package.
+func (w *mirrorWalker) mirrorPackage(ctx context.Context, ref, source string) error {
+	digest, err := w.mirrorImage(ref, source)
+	if err != nil {
+		return err
+	}
+
+	rawPkg, err := packages.FromRegistry(ctx, digest.String(), w.craneOpts...)
+	if err != nil {
+		return fmt.Errorf("importing package %s: %w", source, err)
+	}
+	pkg, err := packages.DefaultStructuralLoader.Load(ctx, rawPkg)
+	if err != nil {
+		return fmt.Errorf("loading package %s: %w", source, err)
+	}
+
+	for _, p := range append([]packages.Package{*pkg}, pkg.Components...) {
+		if p.ManifestLock == nil {
+			continue
+		}
+		for _, img := range p.ManifestLock.Spec.Images {
+			ref, err := packages.ImageWithDigest(img.Image, img.Digest)
+			if err != nil {
+				return err
+			}
+			if _, err := w.mirrorImage(ref, img.Image); err != nil {
+				return err
+			}
+		}
+		for _, dep := range p.ManifestLock.Spec.Dependencies {
+			ref, err := packages.ImageWithDigest(dep.Image, dep.Digest)
+			if err != nil {
+				return err
+			}
+			if err := w.mirrorPackage(ctx, ref, dep.Image); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Copies the image at ref, unless it was already copied and returns its digest reference.
+// source is the image as referenced by the user or package.
+func (w *mirrorWalker) mirrorImage(image, source string) (name.Digest, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("parsing image reference %s: %w", image, err)
+	}
+
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		d, err := crane.Digest(image, w.craneOpts...)
+		if err != nil {
+			return name.Digest{}, fmt.Errorf("resolving digest of %s: %w", image, err)
+		}
+		digest = ref.Context().Digest(d)
+	}
+
+	targetRepo, copied := w.copied[digest.String()]
+	if !copied {
+		w.log.Info("copying image", "image", digest.String())
+		targetRepo, err = w.copy(digest)
+		if err != nil {
+			return name.Digest{}, fmt.Errorf("copying %s: %w", digest, err)
+		}
+		w.copied[digest.String()] = targetRepo
+
+		if err := w.mirrorSignature(digest); err != nil {
+			return name.Digest{}, err
+		}
+	}
+
+	if targetRepo == "" {
+		return digest, nil
+	}
+	// Overrides end at the tag or digest separator,
+	// so they don't redirect other repositories sharing the same prefix.
+	// The same image may be referenced differently by multiple packages.
+	repo := repositoryOf(source)
+	for _, override := range []imageprefix.Override{
+		{From: repo + ":", To: targetRepo + ":"},
+		{From: repo + "@", To: targetRepo + "@"},
+	} {
+		if !slices.Contains(w.result.Overrides, override) {
+			w.result.Overrides = append(w.result.Overrides, override)
+		}
+	}
+	return digest, nil
+}
+
+// Copies the cosign signature of the image at digest, if it is signed,
+// so signature verification keeps working against the mirror.
+func (w *mirrorWalker) mirrorSignature(digest name.Digest) error {
+	sigTag := imageverify.SignatureTag(digest)
+	if _, err := crane.Digest(sigTag.String(), w.craneOpts...); err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("looking up signature of %s: %w", digest, err)
+	}
+
+	w.log.Info("copying signature", "image", digest.String(), "signature", sigTag.String())
+	if _, err := w.copy(sigTag); err != nil {
+		return fmt.Errorf("copying signature %s: %w", sigTag, err)
+	}
+	return nil
+}
+
+func (w *mirrorWalker) registryCopier(target string) func(name.Reference) (string, error) {
+	return func(ref name.Reference) (string, error) {
+		// Keep the registry host, so repositories of different registries don't collide in the target.
+		targetRepo := target + "/" + ref.Context().Name()
+		targetRef := targetRepo + "@" + ref.Identifier()
+		if _, ok := ref.(name.Tag); ok {
+			targetRef = targetRepo + ":" + ref.Identifier()
+		}
+		if err := crane.Copy(ref.String(), targetRef, w.craneOpts...); err != nil {
+			return "", err
+		}
+
+		w.result.Images = append(w.result.Images, MirroredImage{
+			Source: ref.String(), Target: targetRef,
+		})
+		return targetRepo, nil
+	}
+}
+
+func (w *mirrorWalker) layoutCopier(p layout.Path) func(name.Reference) (string, error) {
+	return func(source name.Reference) (string, error) {
+		desc, err := remote.Get(source, crane.GetOptions(w.craneOpts...).Remote...)
+		if err != nil {
+			return "", err
+		}
+		// Replace instead of append, so mirroring into the same layout again is idempotent.
+		ref := source.String()
+		annotations := layout.WithAnnotations(map[string]string{ociRefNameAnnotation: ref})
+		matcher := match.Annotation(ociRefNameAnnotation, ref)
+
+		if desc.MediaType.IsIndex() {
+			idx, err := desc.ImageIndex()
+			if err != nil {
+				return "", err
+			}
+			if err := p.ReplaceIndex(idx, matcher, annotations); err != nil {
+				return "", err
+			}
+		} else {
+			img, err := desc.Image()
+			if err != nil {
+				return "", err
+			}
+			if err := p.ReplaceImage(img, matcher, annotations); err != nil {
+				return "", err
+			}
+		}
+
+		w.result.Images = append(w.result.Images, MirroredImage{
+			Source: ref, Target: ref,
+		})
+		// Images in layouts are not addressable by PKO, so there is nothing to override.
+		return "", nil
+	}
+}
+
+// Opens the OCI layout at path, initializing it if the path does not exist or is an empty directory.
+func openOrCreateLayout(path string) (layout.Path, error) {
+	if _, err := os.Stat(filepath.Join(path, "index.json")); err == nil {
+		p, err := layout.FromPath(path)
+		if err != nil {
+			return "", fmt.Errorf("reading OCI layout: %w", err)
+		}
+		return p, nil
+	}
+
+	p, err := layout.Write(path, empty.Index)
+	if err != nil {
+		return "", fmt.Errorf("creating OCI layout: %w", err)
+	}
+	return p, nil
+}
+
+// Strips tag and digest from the image reference as written,
+// so overrides match the image exactly how it is referenced by packages.
+func repositoryOf(image string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+type MirrorPackageConfig struct {
+	Insecure bool
+	Layout   bool
+}
+
+func (c *MirrorPackageConfig) Option(opts ...MirrorPackageOption) {
+	for _, opt := range opts {
+		opt.ConfigureMirrorPackage(c)
+	}
+}
+
+type MirrorPackageOption interface {
+	ConfigureMirrorPackage(*MirrorPackageConfig)
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imageverify"
+	"package-operator.run/internal/packages"
+	"package-operator.run/internal/testutil"
+)
+
+const mirrorTestManifest = `apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifest
+metadata:
+  name: %s
+spec:
+  scopes:
+  - Namespaced
+  phases:
+  - name: deploy
+  availabilityProbes: []
+`
+
+const mirrorTestLock = `apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifestLock
+spec:
+  images:
+  - name: %s
+    image: %s
+    digest: %s
+  dependencies: %s
+`
+
+// Pushes a root package depending on a dependency package, each locking one image.
+func pushMirrorTestPackages(t *testing.T, reg *testutil.InMemoryRegistry) {
+	t.Helper()
+
+	pushRandom := func(ref string) string {
+		t.Helper()
+
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+		require.NoError(t, crane.Push(img, ref, reg.CraneOpt))
+		d, err := img.Digest()
+		require.NoError(t, err)
+		return d.String()
+	}
+	pushPackage := func(ref, name, lock string) string {
+		t.Helper()
+
+		img, err := packages.ToOCI(&packages.RawPackage{Files: packages.Files{
+			"manifest.yaml":      []byte(fmt.Sprintf(mirrorTestManifest, name)),
+			"manifest.lock.yaml": []byte(lock),
+		}})
+		require.NoError(t, err)
+		require.NoError(t, crane.Push(img, ref, reg.CraneOpt))
+		d, err := img.Digest()
+		require.NoError(t, err)
+		return d.String()
+	}
+
+	redisDigest := pushRandom("quay.io/example/redis:7")
+	depDigest := pushPackage("quay.io/example/dep-pkg:v1", "dep",
+		fmt.Sprintf(mirrorTestLock, "redis", "quay.io/example/redis:7", redisDigest, "[]"))
+	nginxDigest := pushRandom("docker.io/library/nginx:1.25")
+	pushPackage("quay.io/example/pkg:v1", "pkg",
+		fmt.Sprintf(mirrorTestLock, "nginx", "nginx:1.25", nginxDigest, fmt.Sprintf(
+			"[{name: dep, image: quay.io/example/dep-pkg, digest: %q, version: v1.0.0}]", depDigest)))
+}
+
+func TestMirror_MirrorPackage(t *testing.T) {
+	t.Parallel()
+
+	reg := testutil.NewInMemoryRegistry()
+	pushMirrorTestPackages(t, reg)
+
+	m := NewMirror(WithCraneOptions{reg.CraneOpt})
+	res, err := m.MirrorPackage(context.Background(), "quay.io/example/pkg:v1", "mirror.example.com/pko/")
+	require.NoError(t, err)
+
+	assert.Equal(t, []imageprefix.Override{
+		{From: "nginx:", To: "mirror.example.com/pko/index.docker.io/library/nginx:"},
+		{From: "nginx@", To: "mirror.example.com/pko/index.docker.io/library/nginx@"},
+		{From: "quay.io/example/dep-pkg:", To: "mirror.example.com/pko/quay.io/example/dep-pkg:"},
+		{From: "quay.io/example/dep-pkg@", To: "mirror.example.com/pko/quay.io/example/dep-pkg@"},
+		{From: "quay.io/example/pkg:", To: "mirror.example.com/pko/quay.io/example/pkg:"},
+		{From: "quay.io/example/pkg@", To: "mirror.example.com/pko/quay.io/example/pkg@"},
+		{From: "quay.io/example/redis:", To: "mirror.example.com/pko/quay.io/example/redis:"},
+		{From: "quay.io/example/redis@", To: "mirror.example.com/pko/quay.io/example/redis@"},
+	}, res.Overrides)
+	// Overrides must not redirect repositories only sharing a prefix with a mirrored one.
+	assert.Equal(t, "quay.io/example/pkg-extra:v1", imageprefix.Replace("quay.io/example/pkg-extra:v1", res.Overrides))
+	assert.Equal(t, "mirror.example.com/pko/quay.io/example/pkg:v1",
+		imageprefix.Replace("quay.io/example/pkg:v1", res.Overrides))
+
+	require.Len(t, res.Images, 4)
+	for _, img := range res.Images {
+		source, err := crane.Digest(img.Source, reg.CraneOpt)
+		require.NoError(t, err)
+		target, err := crane.Digest(img.Target, reg.CraneOpt)
+		require.NoError(t, err)
+		assert.Equal(t, source, target)
+	}
+}
+
+func TestMirror_MirrorPackage_Signatures(t *testing.T) {
+	t.Parallel()
+
+	reg := testutil.NewInMemoryRegistry()
+	pushMirrorTestPackages(t, reg)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ctx := context.Background()
+	signed, err := imageverify.Sign(ctx, "docker.io/library/nginx:1.25", key, reg.CraneOpt)
+	require.NoError(t, err)
+
+	m := NewMirror(WithCraneOptions{reg.CraneOpt})
+	res, err := m.MirrorPackage(ctx, "quay.io/example/pkg:v1", "mirror.example.com/pko")
+	require.NoError(t, err)
+	// 4 images and the signature of nginx.
+	assert.Len(t, res.Images, 5)
+
+	mirrored := "mirror.example.com/pko/index.docker.io/library/nginx@" + signed.DigestStr()
+	_, err = imageverify.Verify(ctx, mirrored, []crypto.PublicKey{key.Public()}, reg.CraneOpt)
+	require.NoError(t, err)
+}
+
+func TestMirror_MirrorPackage_Layout(t *testing.T) {
+	t.Parallel()
+
+	reg := testutil.NewInMemoryRegistry()
+	pushMirrorTestPackages(t, reg)
+
+	dir := t.TempDir()
+	m := NewMirror(WithCraneOptions{reg.CraneOpt})
+
+	// Mirroring twice must not duplicate images.
+	for range 2 {
+		res, err := m.MirrorPackage(context.Background(), "quay.io/example/pkg:v1", dir, WithLayout(true))
+		require.NoError(t, err)
+		assert.Empty(t, res.Overrides)
+		assert.Len(t, res.Images, 4)
+	}
+
+	p, err := layout.FromPath(dir)
+	require.NoError(t, err)
+	idx, err := p.ImageIndex()
+	require.NoError(t, err)
+	manifest, err := idx.IndexManifest()
+	require.NoError(t, err)
+	assert.Len(t, manifest.Manifests, 4)
+}
+
+func TestRepositoryOf(t *testing.T) {
+	t.Parallel()
+
+	for image, expected := range map[string]string{
+		"nginx":                         "nginx",
+		"nginx:1.25":                    "nginx",
+		"localhost:5000/nginx:1.25":     "localhost:5000/nginx",
+		"localhost:5000/nginx":          "localhost:5000/nginx",
+		"quay.io/example/pkg@sha256:00": "quay.io/example/pkg",
+	} {
+		assert.Equal(t, expected, repositoryOf(image), image)
+	}
+}
@@ -3,13 +3,18 @@ package cmd
 import (
 	"fmt"
 	"maps"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
 )
 
 const (
 	OutputFormatHuman  = "human"
 	OutputFormatDigest = "digest"
+	OutputFormatJSON   = "json"
+	OutputFormatSARIF  = "sarif"
+	OutputFormatYAML   = "yaml"
 )
 
 type WithClock struct{ Clock Clock }
@@ -42,6 +47,12 @@ func (w WithComponent) ConfigureRenderPackage(c *RenderPackageConfig) {
 	c.Component = string(w)
 }
 
+type WithCraneOptions []crane.Option
+
+func (w WithCraneOptions) ConfigureMirror(c *MirrorConfig) {
+	c.CraneOptions = append(c.CraneOptions, w...)
+}
+
 type WithDigestResolver struct{ Resolver DigestResolver }
 
 func (w WithDigestResolver) ConfigureBuild(c *BuildConfig) {
@@ -58,6 +69,22 @@ func (w WithLog) ConfigureBuild(c *BuildConfig) {
 	c.Log = w.Log
 }
 
+func (w WithLog) ConfigureInspect(c *InspectConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureLint(c *LintConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureMirror(c *MirrorConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureSign(c *SignConfig) {
+	c.Log = w.Log
+}
+
 func (w WithLog) ConfigureTree(c *TreeConfig) {
 	c.Log = w.Log
 }
@@ -86,14 +113,68 @@ func (w WithInsecure) ConfigureGenerateLockData(c *GenerateLockDataConfig) {
 	c.Insecure = bool(w)
 }
 
+func (w WithInsecure) ConfigureInspectPackage(c *InspectPackageConfig) {
+	c.Insecure = bool(w)
+}
+
+func (w WithInsecure) ConfigureMirrorPackage(c *MirrorPackageConfig) {
+	c.Insecure = bool(w)
+}
+
 func (w WithInsecure) ConfigureResolveDigest(c *ResolveDigestConfig) {
 	c.Insecure = bool(w)
 }
 
+func (w WithInsecure) ConfigureSignPackage(c *SignPackageConfig) {
+	c.Insecure = bool(w)
+}
+
 func (w WithInsecure) ConfigureValidatePackage(c *ValidatePackageConfig) {
 	c.Insecure = bool(w)
 }
 
+func (w WithInsecure) ConfigureVerifyPackage(c *VerifyPackageConfig) {
+	c.Insecure = bool(w)
+}
+
+type WithKeyPassword string
+
+func (w WithKeyPassword) ConfigureSignPackage(c *SignPackageConfig) {
+	c.KeyPassword = string(w)
+}
+
+type WithKeyPath string
+
+func (w WithKeyPath) ConfigureSignPackage(c *SignPackageConfig) {
+	c.KeyPath = string(w)
+}
+
+type WithKeyPaths []string
+
+func (w WithKeyPaths) ConfigureVerifyPackage(c *VerifyPackageConfig) {
+	c.KeyPaths = append(c.KeyPaths, w...)
+}
+
+type WithKubeVersion string
+
+func (w WithKubeVersion) ConfigureValidatePackage(c *ValidatePackageConfig) {
+	c.KubeVersion = string(w)
+}
+
+type WithLayout bool
+
+func (w WithLayout) ConfigureMirrorPackage(c *MirrorPackageConfig) {
+	c.Layout = bool(w)
+}
+
+func (w WithLayout) ConfigureSignPackage(c *SignPackageConfig) {
+	c.Layout = bool(w)
+}
+
+func (w WithLayout) ConfigureVerifyPackage(c *VerifyPackageConfig) {
+	c.Layout = bool(w)
+}
+
 type WithNamespace string
 
 func (w WithNamespace) ConfigureGetPackage(c *GetPackageConfig) {
@@ -145,12 +226,30 @@ func (w WithPush) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
 	c.Push = bool(w)
 }
 
+type WithRejectDeprecatedAPIs bool
+
+func (w WithRejectDeprecatedAPIs) ConfigureValidatePackage(c *ValidatePackageConfig) {
+	c.RejectDeprecatedAPIs = bool(w)
+}
+
 type WithRemoteReference string
 
 func (w WithRemoteReference) ConfigureValidatePackage(c *ValidatePackageConfig) {
 	c.RemoteReference = string(w)
 }
 
+type WithSBOMCreated time.Time
+
+func (w WithSBOMCreated) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.SBOMCreated = time.Time(w)
+}
+
+type WithSBOMFormat string
+
+func (w WithSBOMFormat) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.SBOMFormat = string(w)
+}
+
 type WithTags []string
 
 func (w WithTags) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"package-operator.run/internal/packages"
+)
+
+// Severity of all reported violations, as every violation fails validation.
+const violationSeverityError = "error"
+
+// ViolationReport lists the violations found in a package in a machine readable format.
+type ViolationReport struct {
+	Violations []ReportedViolation `json:"violations"`
+	// Errors that are not violations of a rule, e.g. failing to read files or to push images.
+	Errors []ReportedError `json:"errors,omitempty"`
+}
+
+// ReportedViolation is a single entry of a ViolationReport.
+type ReportedViolation struct {
+	// Stable identifier of the violated rule, e.g. "missing-phase-annotation".
+	RuleID   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	// Short description of the violated rule.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// File path relative to the package source folder.
+	Path string `json:"path,omitempty"`
+	// Component the file belongs to in multi-component packages.
+	Component string `json:"component,omitempty"`
+	// Index of the YAML document within the file.
+	Index *int `json:"index,omitempty"`
+	// Line and column the violation was found at, 0 if unknown.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// ReportedError is an error of a ViolationReport that is not a violation of a rule.
+type ReportedError struct {
+	Message string `json:"message"`
+	// Component the error occurred in for multi-component packages.
+	Component string `json:"component,omitempty"`
+}
+
+// NewViolationReport collects all violations and other errors from the given error.
+// srcPath is used to locate YAML documents in source files and may be empty.
+func NewViolationReport(ctx context.Context, srcPath string, err error) *ViolationReport {
+	r := &ViolationReport{Violations: []ReportedViolation{}}
+	if err == nil {
+		return r
+	}
+
+	var files packages.Files
+	if len(srcPath) > 0 {
+		// Best effort, violations are reported without line information otherwise.
+		if rawPkg, err := packages.FromFolder(ctx, srcPath); err == nil {
+			files = rawPkg.Files
+		}
+	}
+
+	r.collect(err, "", files)
+	return r
+}
+
+func (r *ViolationReport) collect(err error, component string, files packages.Files) {
+	var (
+		componentErr *packages.ComponentError
+		violation    packages.ViolationError
+	)
+	switch {
+	case errors.As(err, &componentErr) && err == error(componentErr):
+		r.collect(componentErr.Err, componentErr.Component, files)
+
+	case errors.As(err, &violation) && err == error(violation):
+		r.addViolation(violation, component, files)
+
+	default:
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				r.collect(err, component, files)
+			}
+			return
+		}
+		// Drop context from wrapping errors, if they contain structured errors.
+		if inner := errors.Unwrap(err); inner != nil &&
+			(errors.As(inner, &componentErr) || errors.As(inner, &violation)) {
+			r.collect(inner, component, files)
+			return
+		}
+		r.Errors = append(r.Errors, ReportedError{
+			Message:   err.Error(),
+			Component: component,
+		})
+	}
+}
+
+func (r *ViolationReport) addViolation(v packages.ViolationError, component string, files packages.Files) {
+	if len(v.Reason) == 0 {
+		v.Reason = packages.ViolationReasonUnknown
+	}
+	if len(v.Component) > 0 {
+		component = v.Component
+	}
+
+	rv := ReportedViolation{
+		RuleID:    v.Reason.ID(),
+		Severity:  violationSeverityError,
+		Reason:    string(v.Reason),
+		Message:   v.Error(),
+		Component: component,
+		Index:     v.Index,
+	}
+	if len(v.Path) > 0 {
+		rv.Path = v.Path
+		if len(component) > 0 {
+			rv.Path = filepath.Join(packages.ComponentsFolder, component, v.Path)
+		}
+
+		var line int
+		rv.Path, line = packages.SourceLocation(files, rv.Path, v.Index)
+		switch {
+		case v.Line > 0:
+			// Precise position, e.g. of template errors.
+			rv.Line, rv.Column = v.Line, max(v.Column, 1)
+		case line > 0:
+			rv.Line, rv.Column = line, 1
+		}
+	}
+	r.Violations = append(r.Violations, rv)
+}
+
+// Write the report in the given output format.
+func (r *ViolationReport) Write(w io.Writer, format, srcPath string) error {
+	var out any
+	switch format {
+	case OutputFormatJSON:
+		out = r
+	case OutputFormatSARIF:
+		out = r.sarif(srcPath)
+	default:
+		return fmt.Errorf("%w: unknown output format: %s", ErrInvalidArgs, format)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Minimal subset of the Static Analysis Results Interchange Format (SARIF) 2.1.0.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+// Reports errors that are not violations of a rule.
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func (r *ViolationReport) sarif(srcPath string) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "kubectl-package",
+			InformationURI: "https://package-operator.run",
+			Rules:          []sarifRule{},
+		}},
+		Invocations: []sarifInvocation{{ExecutionSuccessful: len(r.Errors) == 0}},
+		Results:     []sarifResult{},
+	}
+	for _, e := range r.Errors {
+		msg := e.Message
+		if len(e.Component) > 0 {
+			msg = fmt.Sprintf("component %s: %s", e.Component, msg)
+		}
+		run.Invocations[0].ToolExecutionNotifications = append(run.Invocations[0].ToolExecutionNotifications,
+			sarifNotification{Level: violationSeverityError, Message: sarifMessage{Text: msg}})
+	}
+
+	var ruleIDs []string
+	for _, v := range r.Violations {
+		if !slices.Contains(ruleIDs, v.RuleID) {
+			ruleIDs = append(ruleIDs, v.RuleID)
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID: v.RuleID, ShortDescription: sarifMessage{Text: v.Reason},
+			})
+		}
+
+		result := sarifResult{
+			RuleID:  v.RuleID,
+			Level:   v.Severity,
+			Message: sarifMessage{Text: v.Message},
+		}
+		if len(v.Path) > 0 {
+			loc := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI: filepath.ToSlash(filepath.Join(srcPath, v.Path)),
+				},
+			}
+			if v.Line > 0 {
+				loc.Region = &sarifRegion{StartLine: v.Line, StartColumn: v.Column}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/packages"
+)
+
+func TestNewViolationReport(t *testing.T) {
+	t.Parallel()
+
+	srcPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(srcPath, "components", "backend"), 0o755))
+	for path, content := range map[string]string{
+		"manifest.yaml": "apiVersion: manifests.package-operator.run/v1alpha1\nkind: PackageManifest\n",
+		"objects.yaml":  "a: 1\n---\nb: 2\n",
+		"components/backend/deployment.yaml.gotmpl": "c: {{ .config.c }}\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(srcPath, path), []byte(content), 0o600))
+	}
+
+	err := fmt.Errorf("validating: %w", errors.Join(
+		packages.ViolationError{
+			Reason: packages.ViolationReasonMissingPhaseAnnotation,
+			Path:   "objects.yaml",
+			Index:  new(1),
+		},
+		&packages.ComponentError{
+			Component: "backend",
+			Err: packages.ViolationError{
+				Reason: packages.ViolationReasonMissingPhaseAnnotation,
+				Path:   "deployment.yaml",
+				Index:  new(0),
+			},
+		},
+		packages.ViolationError{
+			Reason: packages.ViolationReasonTemplateExecution,
+			Path:   "objects.yaml",
+			Line:   2,
+			Column: 5,
+		},
+		errors.New("something else"),
+	))
+
+	r := NewViolationReport(context.Background(), srcPath, err)
+	require.Len(t, r.Violations, 3)
+
+	assert.Equal(t, ReportedViolation{
+		RuleID:   "missing-phase-annotation",
+		Severity: "error",
+		Reason:   string(packages.ViolationReasonMissingPhaseAnnotation),
+		Message:  "Missing package-operator.run/phase Annotation in objects.yaml idx 1",
+		Path:     "objects.yaml",
+		Index:    new(1),
+		Line:     3,
+		Column:   1,
+	}, r.Violations[0])
+
+	assert.Equal(t, "components/backend/deployment.yaml.gotmpl", r.Violations[1].Path)
+	assert.Equal(t, "backend", r.Violations[1].Component)
+	assert.Equal(t, 0, r.Violations[1].Line)
+
+	assert.Equal(t, "template-execution", r.Violations[2].RuleID)
+	assert.Equal(t, 2, r.Violations[2].Line)
+	assert.Equal(t, 5, r.Violations[2].Column)
+
+	assert.Equal(t, []ReportedError{{Message: "something else"}}, r.Errors)
+}
+
+func TestNewViolationReport_error(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("building from source: %w", &packages.ComponentError{
+		Component: "backend",
+		Err:       errors.New("pushing image: unauthorized"),
+	})
+
+	r := NewViolationReport(context.Background(), "", err)
+	assert.Empty(t, r.Violations)
+	assert.Equal(t, []ReportedError{{
+		Message:   "pushing image: unauthorized",
+		Component: "backend",
+	}}, r.Errors)
+}
+
+func TestViolationReport_Write(t *testing.T) {
+	t.Parallel()
+
+	r := &ViolationReport{
+		Violations: []ReportedViolation{
+			{RuleID: "a", Severity: "error", Reason: "A", Message: "a1", Path: "a.yaml", Line: 2, Column: 1},
+			{RuleID: "a", Severity: "error", Reason: "A", Message: "a2"},
+		},
+		Errors: []ReportedError{{Message: "e1", Component: "backend"}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+		require.NoError(t, r.Write(&out, OutputFormatJSON, "src"))
+
+		var decoded ViolationReport
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, *r, decoded)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+		require.NoError(t, r.Write(&out, OutputFormatSARIF, "src"))
+
+		var log sarifLog
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		require.Len(t, log.Runs, 1)
+		assert.Equal(t, []sarifRule{{ID: "a", ShortDescription: sarifMessage{Text: "A"}}}, log.Runs[0].Tool.Driver.Rules)
+		require.Len(t, log.Runs[0].Results, 2)
+		assert.Equal(t, sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: "src/a.yaml"},
+			Region:           &sarifRegion{StartLine: 2, StartColumn: 1},
+		}, log.Runs[0].Results[0].Locations[0].PhysicalLocation)
+		assert.Empty(t, log.Runs[0].Results[1].Locations)
+		assert.Equal(t, []sarifInvocation{{
+			ExecutionSuccessful: false,
+			ToolExecutionNotifications: []sarifNotification{
+				{Level: "error", Message: sarifMessage{Text: "component backend: e1"}},
+			},
+		}}, log.Runs[0].Invocations)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, r.Write(&bytes.Buffer{}, "xml", "src"), ErrInvalidArgs)
+	})
+}
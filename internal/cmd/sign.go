@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
+
+	"package-operator.run/internal/imageverify"
+)
+
+func NewSign(opts ...SignOption) *Sign {
+	var cfg SignConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Sign{
+		cfg: cfg,
+	}
+}
+
+type Sign struct {
+	cfg SignConfig
+}
+
+type SignConfig struct {
+	Log logr.Logger
+}
+
+func (c *SignConfig) Option(opts ...SignOption) {
+	for _, opt := range opts {
+		opt.ConfigureSign(c)
+	}
+}
+
+func (c *SignConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+}
+
+type SignOption interface {
+	ConfigureSign(*SignConfig)
+}
+
+// SignPackage signs the package image referenced by target
+// and returns the digest of the signed image.
+// Target is an image reference or, with WithLayout, the path to an OCI layout directory.
+func (s *Sign) SignPackage(ctx context.Context, target string, opts ...SignPackageOption) (string, error) {
+	var cfg SignPackageConfig
+
+	cfg.Option(opts...)
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("validating options: %w", err)
+	}
+
+	data, err := os.ReadFile(cfg.KeyPath)
+	if err != nil {
+		return "", fmt.Errorf("reading private key: %w", err)
+	}
+	signer, err := imageverify.ParsePrivateKey(data, []byte(cfg.KeyPassword))
+	if err != nil {
+		return "", fmt.Errorf("loading private key %s: %w", cfg.KeyPath, err)
+	}
+
+	if cfg.Layout {
+		s.cfg.Log.Info("signing package image in OCI layout", "path", target)
+
+		digest, err := imageverify.SignLayout(target, signer)
+		if err != nil {
+			return "", fmt.Errorf("signing package image: %w", err)
+		}
+		return digest.String(), nil
+	}
+
+	s.cfg.Log.Info("signing package image", "image", target)
+
+	digest, err := imageverify.Sign(ctx, target, signer, craneOptions(cfg.Insecure)...)
+	if err != nil {
+		return "", fmt.Errorf("signing package image: %w", err)
+	}
+	return digest.String(), nil
+}
+
+type SignPackageConfig struct {
+	Insecure    bool
+	KeyPath     string
+	KeyPassword string
+	Layout      bool
+}
+
+func (c *SignPackageConfig) Option(opts ...SignPackageOption) {
+	for _, opt := range opts {
+		opt.ConfigureSignPackage(c)
+	}
+}
+
+func (c *SignPackageConfig) Validate() error {
+	if c.KeyPath == "" {
+		return fmt.Errorf("%w: 'KeyPath' must be provided", ErrInvalidOptions)
+	}
+
+	return nil
+}
+
+type SignPackageOption interface {
+	ConfigureSignPackage(*SignPackageConfig)
+}
+
+// VerifyPackage verifies that the package image referenced by target
+// is signed by any of the given public keys and returns the digest of the verified image.
+// Target is an image reference or, with WithLayout, the path to an OCI layout directory.
+func (s *Sign) VerifyPackage(ctx context.Context, target string, opts ...VerifyPackageOption) (string, error) {
+	var cfg VerifyPackageConfig
+
+	cfg.Option(opts...)
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("validating options: %w", err)
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(cfg.KeyPaths))
+	for _, path := range cfg.KeyPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading public key: %w", err)
+		}
+		key, err := imageverify.ParsePublicKey(data)
+		if err != nil {
+			return "", fmt.Errorf("loading public key %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if cfg.Layout {
+		s.cfg.Log.Info("verifying package image in OCI layout", "path", target)
+
+		digest, err := imageverify.VerifyLayout(target, keys)
+		if err != nil {
+			return "", fmt.Errorf("verifying package image: %w", err)
+		}
+		return digest.String(), nil
+	}
+
+	s.cfg.Log.Info("verifying package image", "image", target)
+
+	digest, err := imageverify.Verify(ctx, target, keys, craneOptions(cfg.Insecure)...)
+	if err != nil {
+		return "", fmt.Errorf("verifying package image: %w", err)
+	}
+	return digest.String(), nil
+}
+
+type VerifyPackageConfig struct {
+	Insecure bool
+	KeyPaths []string
+	Layout   bool
+}
+
+func (c *VerifyPackageConfig) Option(opts ...VerifyPackageOption) {
+	for _, opt := range opts {
+		opt.ConfigureVerifyPackage(c)
+	}
+}
+
+func (c *VerifyPackageConfig) Validate() error {
+	if len(c.KeyPaths) == 0 {
+		return fmt.Errorf("%w: at least one of 'KeyPaths' must be provided", ErrInvalidOptions)
+	}
+
+	return nil
+}
+
+type VerifyPackageOption interface {
+	ConfigureVerifyPackage(*VerifyPackageConfig)
+}
+
+func craneOptions(insecure bool) []crane.Option {
+	var opts []crane.Option
+	if insecure {
+		opts = append(opts, crane.Insecure)
+	}
+	return opts
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/imageverify"
+)
+
+func TestSign_Registry(t *testing.T) {
+	t.Parallel()
+
+	privPath, pubPath := writeTestKeyPair(t)
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	image := strings.TrimPrefix(srv.URL, "http://") + "/pkg:v1"
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	ref, err := name.ParseReference(image, name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	imgDigest, err := img.Digest()
+	require.NoError(t, err)
+
+	s := NewSign()
+	ctx := context.Background()
+
+	_, err = s.VerifyPackage(ctx, image, WithKeyPaths{pubPath}, WithInsecure(true))
+	require.ErrorIs(t, err, imageverify.ErrSignatureInvalid)
+
+	signed, err := s.SignPackage(ctx, image, WithKeyPath(privPath), WithInsecure(true))
+	require.NoError(t, err)
+	assert.Equal(t, ref.Context().Digest(imgDigest.String()).String(), signed)
+
+	verified, err := s.VerifyPackage(ctx, image, WithKeyPaths{pubPath}, WithInsecure(true))
+	require.NoError(t, err)
+	assert.Equal(t, signed, verified)
+}
+
+func TestSign_Layout(t *testing.T) {
+	t.Parallel()
+
+	privPath, pubPath := writeTestKeyPair(t)
+
+	dir := t.TempDir()
+	p, err := layout.Write(dir, empty.Index)
+	require.NoError(t, err)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, p.AppendImage(img))
+	imgDigest, err := img.Digest()
+	require.NoError(t, err)
+
+	s := NewSign()
+	ctx := context.Background()
+
+	signed, err := s.SignPackage(ctx, dir, WithKeyPath(privPath), WithLayout(true))
+	require.NoError(t, err)
+	assert.Equal(t, imgDigest.String(), signed)
+
+	verified, err := s.VerifyPackage(ctx, dir, WithKeyPaths{pubPath}, WithLayout(true))
+	require.NoError(t, err)
+	assert.Equal(t, signed, verified)
+}
+
+func TestSign_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	s := NewSign()
+	ctx := context.Background()
+
+	_, err := s.SignPackage(ctx, "quay.io/package-operator/test:v1")
+	require.ErrorIs(t, err, ErrInvalidOptions)
+
+	_, err = s.VerifyPackage(ctx, "quay.io/package-operator/test:v1")
+	require.ErrorIs(t, err, ErrInvalidOptions)
+}
+
+// Writes a PEM encoded ECDSA key pair and returns the paths of the private and public key.
+func writeTestKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "cosign.key")
+	pubPath := filepath.Join(dir, "cosign.pub")
+	require.NoError(t, os.WriteFile(privPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600))
+	require.NoError(t, os.WriteFile(pubPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600))
+	return privPath, pubPath
+}
@@ -77,7 +77,10 @@ func (v *Validate) ValidatePackage(ctx context.Context, opts ...ValidatePackageO
 			return fmt.Errorf("getting package from path: %w", err)
 		}
 
-		validators = append(validators, packages.NewTemplateTestValidator(cfg.Path))
+		templateTestValidator := packages.NewTemplateTestValidator(cfg.Path)
+		templateTestValidator.KubernetesVersion = cfg.KubeVersion
+		templateTestValidator.RejectDeprecatedAPIs = cfg.RejectDeprecatedAPIs
+		validators = append(validators, templateTestValidator)
 	} else {
 		var err error
 
@@ -129,9 +132,11 @@ func (v *Validate) getPackageFromRemoteRef(
 }
 
 type ValidatePackageConfig struct {
-	Insecure        bool
-	Path            string
-	RemoteReference string
+	Insecure             bool
+	KubeVersion          string
+	Path                 string
+	RejectDeprecatedAPIs bool
+	RemoteReference      string
 }
 
 func (c *ValidatePackageConfig) Option(opts ...ValidatePackageOption) {
@@ -149,6 +154,13 @@ func (c *ValidatePackageConfig) Validate() error {
 	if c.Path != "" && c.RemoteReference != "" {
 		return fmt.Errorf("%w: 'Path' and 'RemoteReference' are mutually exclusive", ErrInvalidOptions)
 	}
+	if c.KubeVersion != "" && c.RemoteReference != "" {
+		// Template test cases are only part of package sources, not of package images.
+		return fmt.Errorf("%w: 'KubeVersion' is only supported with 'Path'", ErrInvalidOptions)
+	}
+	if c.RejectDeprecatedAPIs && c.RemoteReference != "" {
+		return fmt.Errorf("%w: 'RejectDeprecatedAPIs' is only supported with 'Path'", ErrInvalidOptions)
+	}
 
 	return nil
 }
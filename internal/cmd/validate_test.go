@@ -43,6 +43,27 @@ func TestValidatePackageConfig(t *testing.T) {
 			},
 			Assertion: require.Error,
 		},
+		"kube version with remote reference": {
+			Options: []ValidatePackageOption{
+				WithRemoteReference("test"),
+				WithKubeVersion("v1.27.0"),
+			},
+			Assertion: require.Error,
+		},
+		"reject deprecated APIs with remote reference": {
+			Options: []ValidatePackageOption{
+				WithRemoteReference("test"),
+				WithRejectDeprecatedAPIs(true),
+			},
+			Assertion: require.Error,
+		},
+		"kube version with path": {
+			Options: []ValidatePackageOption{
+				WithPath("test"),
+				WithKubeVersion("v1.27.0"),
+			},
+			Assertion: require.NoError,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/internal/constants"
+)
+
+// CacheOwnerResolver returns the owner of the dynamic cache
+// to reconcile objects of the given owner with.
+type CacheOwnerResolver interface {
+	CacheOwner(ctx context.Context, owner client.Object) (client.Object, error)
+}
+
+// StaticCacheOwnerResolver reconciles objects of all owners
+// with the shared cache running as Package Operator itself.
+type StaticCacheOwnerResolver struct{}
+
+func (StaticCacheOwnerResolver) CacheOwner(context.Context, client.Object) (client.Object, error) {
+	return constants.StaticCacheOwner(), nil
+}
@@ -0,0 +1,95 @@
+package hostedclusterpackages
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	internalkubeconfig "package-operator.run/internal/kubeconfig"
+)
+
+// clusterClientTimeout limits requests to target clusters,
+// so unreachable clusters don't block reconciliation of all others.
+const clusterClientTimeout = 30 * time.Second
+
+// clusterClientFactory creates a client for the target cluster described by the given kubeconfig.
+type clusterClientFactory func(kubeconfig []byte) (client.Client, error)
+
+func newClusterClientFactory(scheme *runtime.Scheme) clusterClientFactory {
+	return func(kubeconfig []byte) (client.Client, error) {
+		cfg, err := internalkubeconfig.RESTConfig(kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Timeout = clusterClientTimeout
+		return client.New(cfg, client.Options{Scheme: scheme})
+	}
+}
+
+// clusterClients caches clients for target clusters registered via kubeconfig Secrets.
+// Clients are recreated, when the Secret changes.
+type clusterClients struct {
+	newClient clusterClientFactory
+
+	lock    sync.Mutex
+	clients map[types.UID]cachedClusterClient
+}
+
+type cachedClusterClient struct {
+	resourceVersion string
+	client          client.Client
+}
+
+func newClusterClients(newClient clusterClientFactory) *clusterClients {
+	return &clusterClients{
+		newClient: newClient,
+		clients:   map[types.UID]cachedClusterClient{},
+	}
+}
+
+// Get returns a client for the cluster registered via the given kubeconfig Secret.
+func (cc *clusterClients) Get(secret *corev1.Secret) (client.Client, error) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	if cached, ok := cc.clients[secret.UID]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	kubeconfig, ok := secret.Data[corev1alpha1.ClusterKubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("missing key %q in Secret %s/%s",
+			corev1alpha1.ClusterKubeconfigSecretKey, secret.Namespace, secret.Name)
+	}
+	c, err := cc.newClient(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating client for Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	cc.clients[secret.UID] = cachedClusterClient{
+		resourceVersion: secret.ResourceVersion,
+		client:          c,
+	}
+	return c, nil
+}
+
+// Prune drops clients of clusters that are no longer registered.
+func (cc *clusterClients) Prune(secrets []corev1.Secret) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	registered := make(map[types.UID]struct{}, len(secrets))
+	for _, secret := range secrets {
+		registered[secret.UID] = struct{}{}
+	}
+	for uid := range cc.clients {
+		if _, ok := registered[uid]; !ok {
+			delete(cc.clients, uid)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package hostedclusterpackages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	internalkubeconfig "package-operator.run/internal/kubeconfig"
+)
+
+func TestClusterClients(t *testing.T) {
+	t.Parallel()
+
+	var created int
+	cc := newClusterClients(func([]byte) (client.Client, error) {
+		created++
+		return newFakeClusterClient(), nil
+	})
+
+	secret := makeClusterSecret("cluster-a", nil)
+	first, err := cc.Get(secret)
+	require.NoError(t, err)
+
+	// Cached.
+	second, err := cc.Get(secret)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, created)
+
+	// Secret changed.
+	secret.ResourceVersion = "2"
+	third, err := cc.Get(secret)
+	require.NoError(t, err)
+	assert.NotSame(t, first, third)
+	assert.Equal(t, 2, created)
+
+	// Secret no longer registered.
+	cc.Prune([]corev1.Secret{*makeClusterSecret("cluster-b", nil)})
+	assert.Empty(t, cc.clients)
+}
+
+func TestClusterClients_missingKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	cc := newClusterClients(func([]byte) (client.Client, error) {
+		return newFakeClusterClient(), nil
+	})
+
+	secret := makeClusterSecret("cluster-a", nil)
+	delete(secret.Data, corev1alpha1.ClusterKubeconfigSecretKey)
+	_, err := cc.Get(secret)
+	require.EqualError(t, err,
+		`missing key "kubeconfig" in Secret package-operator-system/cluster-a`)
+}
+
+func TestNewClusterClientFactory_unsupportedKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	newClient := newClusterClientFactory(clusterSetTestScheme)
+	_, err := newClient([]byte(`apiVersion: v1
+kind: Config
+current-context: target
+contexts:
+- name: target
+  context: {cluster: target, user: target}
+clusters:
+- name: target
+  cluster: {server: "https://target.example.com:6443"}
+users:
+- name: target
+  user:
+    exec: {apiVersion: client.authentication.k8s.io/v1, command: /bin/sh}
+`))
+	require.ErrorIs(t, err, internalkubeconfig.ErrUnsupportedKubeconfig)
+}
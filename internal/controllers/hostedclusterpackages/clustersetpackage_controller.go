@@ -0,0 +1,543 @@
+package hostedclusterpackages
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1acs "package-operator.run/apis/applyconfigurations/core/v1alpha1"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+	"package-operator.run/internal/controllers"
+)
+
+const (
+	// clusterSetPackageFinalizer ensures Packages are deleted from target clusters.
+	clusterSetPackageFinalizer = "package-operator.run/cluster-set-package"
+	// clusterFinalizer is set on kubeconfig Secrets of clusters Packages were rolled out to,
+	// so the kubeconfig is still available to delete the Packages when the cluster is unregistered.
+	clusterFinalizer = "package-operator.run/cluster-set-package-cluster"
+	// clusterPollInterval is the interval target clusters are checked for changes,
+	// because Packages on target clusters and kubeconfig Secrets are not watched.
+	clusterPollInterval = time.Minute
+)
+
+// ClusterSetPackageController rolls out Packages to clusters registered via kubeconfig Secrets.
+// Rollouts use the same strategies, partitions and status as HostedClusterPackages.
+type ClusterSetPackageController struct {
+	client         client.Client
+	uncachedClient client.Reader
+	log            logr.Logger
+	scheme         *runtime.Scheme
+	// namespace kubeconfig Secrets are registered in.
+	namespace      string
+	clusterClients *clusterClients
+	clock          clock
+}
+
+func NewClusterSetPackageController(
+	c client.Client, uncachedClient client.Reader, log logr.Logger,
+	scheme *runtime.Scheme, namespace string,
+) *ClusterSetPackageController {
+	return &ClusterSetPackageController{
+		client:         c,
+		uncachedClient: uncachedClient,
+		log:            log,
+		scheme:         scheme,
+		namespace:      namespace,
+		clusterClients: newClusterClients(newClusterClientFactory(scheme)),
+		clock:          defaultClock{},
+	}
+}
+
+func (c *ClusterSetPackageController) Reconcile(
+	ctx context.Context, req ctrl.Request,
+) (ctrl.Result, error) {
+	log := c.log.WithValues("ClusterSetPackage", req.String())
+	defer log.Info("reconciled")
+
+	ctx = logr.NewContext(ctx, log)
+	cspkg := &corev1alpha1.ClusterSetPackage{}
+	if err := c.client.Get(ctx, req.NamespacedName, cspkg); err != nil {
+		// Ignore not found errors on delete.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clusters, err := c.listClusters(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing clusters: %w", err)
+	}
+
+	if !cspkg.DeletionTimestamp.IsZero() {
+		log.Info("ClusterSetPackage is deleting")
+		return ctrl.Result{}, c.handleDeletion(ctx, cspkg, clusters)
+	}
+
+	if err := c.teardownLeftClusters(ctx, cspkg, clusters); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := controllers.EnsureFinalizer(ctx, c.client, cspkg, clusterSetPackageFinalizer); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	unstructuredClusterSetPackage, err := toUnstructured(cspkg)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	packageTemplateApplyConfiguration, err := ExtractPackageTemplateFields(unstructuredClusterSetPackage)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("extracting package template: %w", err)
+	}
+
+	// Same plan as for HostedClusterPackages,
+	// but clusters are registered via kubeconfig Secrets and Packages live on the target clusters.
+	hcpkg := asHostedClusterPackage(cspkg)
+	now := c.clock.Now()
+	updateRollout(hcpkg, now)
+
+	state, clients, err := c.indexPackageState(ctx, hcpkg, cspkg.Spec.Namespace, clusters, now)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("indexing package state: %w", err)
+	}
+	// Keep the kubeconfigs of all clusters Packages are rolled out to, until the Packages are deleted again.
+	for i := range clusters {
+		if _, ok := clients[clusters[i].UID]; !ok {
+			continue
+		}
+		if err := controllers.EnsureFinalizer(ctx, c.client, &clusters[i], clusterFinalizer); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	pruneProcessingQueue(hcpkg, state)
+	state.UpdatePartitionStatus()
+
+	exceeded, err := state.FailureThresholdExceeded()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if exceeded && !isRolloutStopped(hcpkg) {
+		log.Info("failure threshold exceeded, halting rollout")
+		hcpkg.Status.Rollout.State = corev1alpha1.HostedClusterPackageRolloutStateHalted
+	}
+
+	if err := c.createMissingPackages(
+		ctx, cspkg, hcpkg, packageTemplateApplyConfiguration, state, clients,
+	); err != nil {
+		return ctrl.Result{}, fmt.Errorf("creating missing Packages: %w", err)
+	}
+
+	if !isRolloutStopped(hcpkg) {
+		if err := c.updatePackages(
+			ctx, cspkg, hcpkg, packageTemplateApplyConfiguration, state, clients,
+		); err != nil {
+			return ctrl.Result{}, fmt.Errorf("updating Packages: %w", err)
+		}
+	}
+
+	state, _, err = c.indexPackageState(ctx, hcpkg, cspkg.Spec.Namespace, clusters, now)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("indexing package state: %w", err)
+	}
+
+	setStatus(hcpkg, state)
+	cspkg.Status = hcpkg.Status
+	if err := c.client.Status().Update(ctx, cspkg, client.FieldOwner(constants.FieldOwner)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating ClusterSetPackage status: %w", err)
+	}
+
+	requeueAfter := clusterPollInterval
+	if state.requeueAfter > 0 && state.requeueAfter < requeueAfter {
+		requeueAfter = state.requeueAfter
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// asHostedClusterPackage maps a ClusterSetPackage to a HostedClusterPackage
+// to share rollout logic and status reporting.
+func asHostedClusterPackage(cspkg *corev1alpha1.ClusterSetPackage) *corev1alpha1.HostedClusterPackage {
+	hcpkg := &corev1alpha1.HostedClusterPackage{
+		ObjectMeta: *cspkg.ObjectMeta.DeepCopy(),
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			Strategy:              *cspkg.Spec.Strategy.DeepCopy(),
+			HostedClusterSelector: *cspkg.Spec.ClusterSelector.DeepCopy(),
+			Template:              *cspkg.Spec.Template.DeepCopy(),
+			Partition:             cspkg.Spec.Partition.DeepCopy(),
+		},
+		Status: *cspkg.Status.DeepCopy(),
+	}
+	for _, overlay := range cspkg.Spec.ConfigOverlays {
+		hcpkg.Spec.ConfigOverlays = append(hcpkg.Spec.ConfigOverlays, corev1alpha1.HostedClusterPackageConfigOverlay{
+			HostedClusterSelector: *overlay.ClusterSelector.DeepCopy(),
+			Config:                *overlay.Config.DeepCopy(),
+		})
+	}
+	return hcpkg
+}
+
+// listClusters lists all kubeconfig Secrets registering target clusters.
+func (c *ClusterSetPackageController) listClusters(ctx context.Context) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.uncachedClient.List(
+		ctx, secrets, client.InNamespace(c.namespace),
+		client.HasLabels{corev1alpha1.ClusterKubeconfigSecretLabel},
+	); err != nil {
+		return nil, err
+	}
+	c.clusterClients.Prune(secrets.Items)
+	return secrets.Items, nil
+}
+
+// indexPackageState gets the Package from every target cluster selected by the ClusterSetPackage
+// and indexes everything for further processing.
+// Also returns clients for all indexed target clusters by kubeconfig Secret UID.
+func (c *ClusterSetPackageController) indexPackageState(
+	ctx context.Context, hcpkg *corev1alpha1.HostedClusterPackage,
+	namespace string, clusters []corev1.Secret, now time.Time,
+) (*packageStates, map[types.UID]client.Client, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	state := newPackageStates(hcpkg, now)
+
+	s, err := metav1.LabelSelectorAsSelector(&hcpkg.Spec.HostedClusterSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing label selector: %w", err)
+	}
+
+	clients := map[types.UID]client.Client{}
+	packages := map[types.UID]*corev1alpha1.Package{}
+	targets := make([]client.Object, 0, len(clusters))
+	for i := range clusters {
+		secret := &clusters[i]
+		if !secret.DeletionTimestamp.IsZero() || !s.Matches(labels.Set(secret.Labels)) {
+			continue
+		}
+		clusterLog := log.WithValues("Secret", client.ObjectKeyFromObject(secret))
+
+		clusterClient, err := c.clusterClients.Get(secret)
+		if err != nil {
+			clusterLog.Error(err, "skipping cluster")
+			continue
+		}
+
+		pkg := &corev1alpha1.Package{}
+		err = clusterClient.Get(ctx, client.ObjectKey{Name: hcpkg.Name, Namespace: namespace}, pkg)
+		switch {
+		case errors.IsNotFound(err):
+			// package does not exist.
+			pkg = nil
+		case err != nil:
+			// Completely skip unreachable clusters.
+			clusterLog.Error(err, "skipping unreachable cluster")
+			continue
+		case pkg.Labels[corev1alpha1.ClusterSetPackageLabel] != hcpkg.Name:
+			clusterLog.Info("skipping cluster, Package is not managed by this ClusterSetPackage")
+			continue
+		}
+
+		clients[secret.UID] = clusterClient
+		packages[secret.UID] = pkg
+		targets = append(targets, secret)
+	}
+	state.SelectCanaries(targets)
+
+	for _, target := range targets {
+		pkg := packages[target.GetUID()]
+		if pkg == nil {
+			state.Missing(target)
+			continue
+		}
+		if err := state.Add(target, pkg); err != nil {
+			return nil, nil, fmt.Errorf("indexing Package for cluster: %w", err)
+		}
+	}
+	return state, clients, nil
+}
+
+// pruneProcessingQueue removes Packages that are updated and report Available from the processing queue
+// to free up slots. Packages on target clusters are identified by UID, because they all share the same name.
+func pruneProcessingQueue(hcpkg *corev1alpha1.HostedClusterPackage, state *packageStates) {
+	packages := make(map[types.UID]*corev1alpha1.Package, len(state.targetToPackage))
+	for _, pkg := range state.targetToPackage {
+		if pkg != nil {
+			packages[pkg.UID] = pkg
+		}
+	}
+
+	updatedQueue := make([]corev1alpha1.HostedClusterPackageRefStatus, 0, len(hcpkg.Status.Processing))
+	for _, processingPkg := range hcpkg.Status.Processing {
+		pkg, ok := packages[processingPkg.UID]
+		if !ok {
+			// Package was deleted or its cluster is no longer selected or reachable.
+			continue
+		}
+		if isPackageAvailable(pkg) && state.IsPackageUpdated(pkg) {
+			// Package is available & up-to-date.
+			continue
+		}
+		updatedQueue = append(updatedQueue, processingPkg)
+	}
+	hcpkg.Status.Processing = updatedQueue
+}
+
+func (c *ClusterSetPackageController) createMissingPackages(
+	ctx context.Context,
+	cspkg *corev1alpha1.ClusterSetPackage,
+	hcpkg *corev1alpha1.HostedClusterPackage,
+	packageTemplateApplyConfiguration *corev1alpha1acs.PackageTemplateSpecApplyConfiguration,
+	state *packageStates,
+	clients map[types.UID]client.Client,
+) error {
+	for _, target := range state.ListTargetsMissingPackage() {
+		clusterClient := clients[target.GetUID()]
+		if err := ensureNamespace(ctx, clusterClient, cspkg.Spec.Namespace); err != nil {
+			return fmt.Errorf("cluster %s: %w", target.GetName(), err)
+		}
+
+		ac, err := constructClusterSetPackage(
+			hcpkg, packageTemplateApplyConfiguration, cspkg.Spec.Namespace, target, state.now)
+		if err != nil {
+			return err
+		}
+		if err := clusterClient.Apply(
+			ctx, ac, client.FieldOwner(constants.FieldOwner),
+		); err != nil {
+			return fmt.Errorf("creating Package on cluster %s: %w", target.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func (c *ClusterSetPackageController) updatePackages(
+	ctx context.Context,
+	cspkg *corev1alpha1.ClusterSetPackage,
+	hcpkg *corev1alpha1.HostedClusterPackage,
+	packageTemplateApplyConfiguration *corev1alpha1acs.PackageTemplateSpecApplyConfiguration,
+	state *packageStates,
+	clients map[types.UID]client.Client,
+) error {
+	disruptionBudget := state.DisruptionBudget()
+	packagesToUpdate := state.ListPackagesToUpdate()
+
+	if len(packagesToUpdate) == 0 {
+		return nil
+	}
+	state.MarkPartitionsStarted(packagesToUpdate)
+
+	hcpkg.Status.Processing = nil
+
+	if disruptionBudget > 0 {
+		// Make sure we have our updated processing queue persisted.
+		// But don't use the processing queue at all, if we have the disruption budget disabled.
+		for _, pkg := range packagesToUpdate {
+			hcpkg.Status.Processing = append(hcpkg.Status.Processing, corev1alpha1.HostedClusterPackageRefStatus{
+				UID:       pkg.UID,
+				Name:      pkg.Name,
+				Namespace: pkg.Namespace,
+			})
+		}
+	}
+
+	cspkg.Status = *hcpkg.Status.DeepCopy()
+	if err := c.client.Status().Update(ctx, cspkg, client.FieldOwner(constants.FieldOwner)); err != nil {
+		return fmt.Errorf("updating ClusterSetPackage status: %w", err)
+	}
+
+	for _, pkg := range packagesToUpdate {
+		updatedAt := state.now
+		if state.IsPackageUpdated(&pkg) {
+			// Package was already updated, keep the time the update started.
+			if t, ok := packageUpdatedAt(&pkg); ok {
+				updatedAt = t
+			}
+		}
+		target := state.PackageToTarget(&pkg)
+		ac, err := constructClusterSetPackage(
+			hcpkg, packageTemplateApplyConfiguration, cspkg.Spec.Namespace, target, updatedAt)
+		if err != nil {
+			return err
+		}
+		if err := clients[target.GetUID()].Apply(ctx, ac, client.FieldOwner(constants.FieldOwner)); err != nil {
+			return fmt.Errorf("updating Package on cluster %s: %w", target.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// constructClusterSetPackage constructs the Package for a target cluster.
+// Packages on target clusters can't have owner references,
+// so they are labeled with the name of their ClusterSetPackage instead.
+func constructClusterSetPackage(
+	hcpkg *corev1alpha1.HostedClusterPackage,
+	packageTemplateApplyConfiguration *corev1alpha1acs.PackageTemplateSpecApplyConfiguration,
+	namespace string,
+	target client.Object,
+	updatedAt time.Time,
+) (*corev1alpha1acs.PackageApplyConfiguration, error) {
+	config, err := packageConfig(hcpkg, target)
+	if err != nil {
+		return nil, fmt.Errorf("constructing Package config for cluster %s: %w", target.GetName(), err)
+	}
+	spec := *packageTemplateApplyConfiguration.Spec
+	spec.Config = config
+
+	ac := corev1alpha1acs.Package(hcpkg.Name, namespace).
+		WithLabels(packageTemplateApplyConfiguration.Labels).
+		WithLabels(map[string]string{
+			corev1alpha1.ClusterSetPackageLabel: hcpkg.Name,
+		}).
+		WithAnnotations(packageTemplateApplyConfiguration.Annotations).
+		WithSpec(&spec)
+	if rollingUpgrade(hcpkg) != nil {
+		ac.WithAnnotations(map[string]string{
+			updatedAtAnnotation: updatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return ac, nil
+}
+
+func ensureNamespace(ctx context.Context, c client.Client, name string) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := c.Create(ctx, ns); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating Namespace: %w", err)
+	}
+	return nil
+}
+
+// handleDeletion deletes the Packages of the ClusterSetPackage from all registered clusters.
+// Unreachable clusters block deletion.
+// Clusters that are being unregistered without a working kubeconfig are skipped.
+func (c *ClusterSetPackageController) handleDeletion(
+	ctx context.Context, cspkg *corev1alpha1.ClusterSetPackage, clusters []corev1.Secret,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+	for i := range clusters {
+		secret := &clusters[i]
+		clusterClient, err := c.clusterClients.Get(secret)
+		if err != nil && !secret.DeletionTimestamp.IsZero() {
+			log.Error(err, "orphaning Packages on unregistered cluster", "Secret", client.ObjectKeyFromObject(secret))
+			if err := controllers.RemoveFinalizer(ctx, c.client, secret, clusterFinalizer); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := deletePackage(ctx, clusterClient, cspkg); err != nil {
+			return fmt.Errorf("cluster %s: %w", secret.Name, err)
+		}
+		if err := c.releaseCluster(ctx, secret, clusterClient); err != nil {
+			return fmt.Errorf("cluster %s: %w", secret.Name, err)
+		}
+	}
+
+	return controllers.RemoveFinalizer(ctx, c.client, cspkg, clusterSetPackageFinalizer)
+}
+
+// teardownLeftClusters deletes the Packages of the ClusterSetPackage from clusters
+// that were rolled out to, but are no longer selected or are being unregistered by deleting their kubeconfig Secret.
+// Unreachable clusters are retried on the next reconcile.
+func (c *ClusterSetPackageController) teardownLeftClusters(
+	ctx context.Context, cspkg *corev1alpha1.ClusterSetPackage, clusters []corev1.Secret,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+	s, err := metav1.LabelSelectorAsSelector(&cspkg.Spec.ClusterSelector)
+	if err != nil {
+		return fmt.Errorf("parsing label selector: %w", err)
+	}
+
+	for i := range clusters {
+		secret := &clusters[i]
+		if !controllerutil.ContainsFinalizer(secret, clusterFinalizer) {
+			// Never rolled out to.
+			continue
+		}
+		unregistering := !secret.DeletionTimestamp.IsZero()
+		if !unregistering && s.Matches(labels.Set(secret.Labels)) {
+			continue
+		}
+		clusterLog := log.WithValues("Secret", client.ObjectKeyFromObject(secret))
+
+		clusterClient, err := c.clusterClients.Get(secret)
+		if err != nil && unregistering {
+			// Packages can never be deleted without a working kubeconfig, so don't block removing the Secret.
+			clusterLog.Error(err, "orphaning Packages on unregistered cluster")
+			if err := controllers.RemoveFinalizer(ctx, c.client, secret, clusterFinalizer); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			clusterLog.Error(err, "skipping cluster")
+			continue
+		}
+
+		if err := deletePackage(ctx, clusterClient, cspkg); err != nil {
+			clusterLog.Error(err, "skipping unreachable cluster")
+			continue
+		}
+		if err := c.releaseCluster(ctx, secret, clusterClient); err != nil {
+			clusterLog.Error(err, "skipping unreachable cluster")
+		}
+	}
+	return nil
+}
+
+// deletePackage deletes the Package of the ClusterSetPackage from a target cluster.
+// Packages not created by the ClusterSetPackage are kept.
+func deletePackage(ctx context.Context, clusterClient client.Client, cspkg *corev1alpha1.ClusterSetPackage) error {
+	pkg := &corev1alpha1.Package{}
+	err := clusterClient.Get(ctx, client.ObjectKey{Name: cspkg.Name, Namespace: cspkg.Spec.Namespace}, pkg)
+	switch {
+	case errors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("getting Package: %w", err)
+	case pkg.Labels[corev1alpha1.ClusterSetPackageLabel] != cspkg.Name:
+		// Not ours.
+		return nil
+	}
+
+	if err := clusterClient.Delete(ctx, pkg); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting Package: %w", err)
+	}
+	return nil
+}
+
+// releaseCluster removes the finalizer from the kubeconfig Secret of a target cluster,
+// once no Packages of any ClusterSetPackage are left on it.
+func (c *ClusterSetPackageController) releaseCluster(
+	ctx context.Context, secret *corev1.Secret, clusterClient client.Client,
+) error {
+	packages := &corev1alpha1.PackageList{}
+	if err := clusterClient.List(
+		ctx, packages, client.HasLabels{corev1alpha1.ClusterSetPackageLabel},
+	); err != nil {
+		return fmt.Errorf("listing Packages: %w", err)
+	}
+	if len(packages.Items) > 0 {
+		return nil
+	}
+	return controllers.RemoveFinalizer(ctx, c.client, secret, clusterFinalizer)
+}
+
+func (c *ClusterSetPackageController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha1.ClusterSetPackage{}).
+		Complete(c)
+}
@@ -0,0 +1,449 @@
+package hostedclusterpackages
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+const clusterSetTestNamespace = "package-operator-system"
+
+var (
+	clusterSetTestScheme = runtime.NewScheme()
+
+	errClusterUnreachable = errors.New("cluster unreachable")
+)
+
+func init() {
+	if err := clientgoscheme.AddToScheme(clusterSetTestScheme); err != nil {
+		panic(err)
+	}
+	if err := corev1alpha1.AddToScheme(clusterSetTestScheme); err != nil {
+		panic(err)
+	}
+}
+
+// newTestClusterSetPackageController returns a controller
+// looking up clients of target clusters by the kubeconfig in their Secret.
+func newTestClusterSetPackageController(
+	c client.Client, clusters map[string]client.Client,
+) *ClusterSetPackageController {
+	controller := NewClusterSetPackageController(
+		c, c, logr.Discard(), clusterSetTestScheme, clusterSetTestNamespace)
+	controller.clusterClients = newClusterClients(func(kubeconfig []byte) (client.Client, error) {
+		clusterClient, ok := clusters[string(kubeconfig)]
+		if !ok {
+			return nil, errClusterUnreachable
+		}
+		return clusterClient, nil
+	})
+	return controller
+}
+
+// makeClusterSecret returns a kubeconfig Secret registering the cluster of the given name.
+func makeClusterSecret(name string, labels map[string]string) *corev1.Secret {
+	l := map[string]string{corev1alpha1.ClusterKubeconfigSecretLabel: "True"}
+	for k, v := range labels {
+		l[k] = v
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       clusterSetTestNamespace,
+			UID:             types.UID(name + "-uid"),
+			ResourceVersion: "1",
+			Labels:          l,
+		},
+		Data: map[string][]byte{
+			corev1alpha1.ClusterKubeconfigSecretKey: []byte(name),
+		},
+	}
+}
+
+// newFakeClusterClient returns a client for a target cluster.
+// Fields of the given objects are managed by the controller as if it created them.
+func newFakeClusterClient(objs ...client.Object) client.Client {
+	for _, obj := range objs {
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+			{
+				Manager:    constants.FieldOwner,
+				Operation:  metav1.ManagedFieldsOperationApply,
+				APIVersion: corev1alpha1.GroupVersion.String(),
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:image":{}}}`)},
+			},
+		})
+	}
+	return fake.NewClientBuilder().WithScheme(clusterSetTestScheme).WithObjects(objs...).Build()
+}
+
+func TestClusterSetPackageController_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cspkg", Generation: 1},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				Instant: &corev1alpha1.HostedClusterPackageStrategyInstant{},
+			},
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+			Namespace: "test-ns",
+			Template: corev1alpha1.PackageTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"test": "label"},
+				},
+				Spec: corev1alpha1.PackageSpec{
+					Image:  "test-image:v1",
+					Config: &runtime.RawExtension{Raw: []byte(`{"region":"default"}`)},
+				},
+			},
+			ConfigOverlays: []corev1alpha1.ClusterSetPackageConfigOverlay{
+				{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"region": "eu"},
+					},
+					Config: runtime.RawExtension{Raw: []byte(`{"region":"eu"}`)},
+				},
+			},
+		},
+	}
+	managementClient := fake.NewClientBuilder().
+		WithScheme(clusterSetTestScheme).
+		WithObjects(
+			cspkg,
+			makeClusterSecret("cluster-a", map[string]string{"env": "prod", "region": "eu"}),
+			makeClusterSecret("cluster-b", map[string]string{"env": "prod"}),
+			makeClusterSecret("cluster-unreachable", map[string]string{"env": "prod"}),
+			makeClusterSecret("cluster-staging", map[string]string{"env": "staging"}),
+		).
+		WithStatusSubresource(&corev1alpha1.ClusterSetPackage{}).
+		Build()
+	clusterA := newFakeClusterClient()
+	clusterB := newFakeClusterClient()
+	clusterStaging := newFakeClusterClient()
+
+	controller := newTestClusterSetPackageController(managementClient, map[string]client.Client{
+		"cluster-a":       clusterA,
+		"cluster-b":       clusterB,
+		"cluster-staging": clusterStaging,
+	})
+
+	ctx := context.Background()
+	res, err := controller.Reconcile(ctx, ctrl.Request{
+		NamespacedName: client.ObjectKeyFromObject(cspkg),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: clusterPollInterval}, res)
+
+	for cluster, expectedConfig := range map[client.Client]string{
+		clusterA: `{"region":"eu"}`,
+		clusterB: `{"region":"default"}`,
+	} {
+		require.NoError(t, cluster.Get(ctx, client.ObjectKey{Name: "test-ns"}, &corev1.Namespace{}))
+
+		pkg := &corev1alpha1.Package{}
+		require.NoError(t, cluster.Get(ctx, client.ObjectKey{Name: "test-cspkg", Namespace: "test-ns"}, pkg))
+		assert.Equal(t, map[string]string{
+			"test":                              "label",
+			corev1alpha1.ClusterSetPackageLabel: "test-cspkg",
+		}, pkg.Labels)
+		assert.Equal(t, "test-image:v1", pkg.Spec.Image)
+		require.NotNil(t, pkg.Spec.Config)
+		assert.JSONEq(t, expectedConfig, string(pkg.Spec.Config.Raw))
+	}
+
+	// Clusters not matching the selector are left alone.
+	err = clusterStaging.Get(ctx, client.ObjectKey{Name: "test-cspkg", Namespace: "test-ns"}, &corev1alpha1.Package{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	updated := &corev1alpha1.ClusterSetPackage{}
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(cspkg), updated))
+	assert.Contains(t, updated.Finalizers, clusterSetPackageFinalizer)
+	assert.Equal(t, int32(1), updated.Status.ObservedGeneration)
+	// Unreachable clusters are skipped.
+	assert.Equal(t, int32(2), updated.Status.TotalPackages)
+	assert.Equal(t, int32(2), updated.Status.UpdatedPackages)
+}
+
+func TestClusterSetPackageController_Reconcile_rollingUpgrade(t *testing.T) {
+	t.Parallel()
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-cspkg",
+			Generation: 2,
+			Finalizers: []string{clusterSetPackageFinalizer},
+		},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				RollingUpgrade: &corev1alpha1.HostedClusterPackageStrategyRollingUpgrade{MaxUnavailable: 1},
+			},
+			Namespace: "test-ns",
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{Image: "test-image:v2"},
+			},
+		},
+	}
+	managementClient := fake.NewClientBuilder().
+		WithScheme(clusterSetTestScheme).
+		WithObjects(
+			cspkg,
+			makeClusterSecret("cluster-a", nil),
+			makeClusterSecret("cluster-b", nil),
+		).
+		WithStatusSubresource(&corev1alpha1.ClusterSetPackage{}).
+		Build()
+
+	clusters := map[string]client.Client{}
+	for i, name := range []string{"cluster-a", "cluster-b"} {
+		pkg := makePackage(i, true, true)
+		pkg.Name = "test-cspkg"
+		pkg.Namespace = "test-ns"
+		pkg.Labels = map[string]string{corev1alpha1.ClusterSetPackageLabel: "test-cspkg"}
+		clusters[name] = newFakeClusterClient(&pkg)
+	}
+
+	controller := newTestClusterSetPackageController(managementClient, clusters)
+
+	ctx := context.Background()
+	_, err := controller.Reconcile(ctx, ctrl.Request{
+		NamespacedName: client.ObjectKeyFromObject(cspkg),
+	})
+	require.NoError(t, err)
+
+	var updatedPackages int
+	for _, cluster := range clusters {
+		pkg := &corev1alpha1.Package{}
+		require.NoError(t, cluster.Get(ctx, client.ObjectKey{Name: "test-cspkg", Namespace: "test-ns"}, pkg))
+		if pkg.Spec.Image == "test-image:v2" {
+			updatedPackages++
+			assert.Contains(t, pkg.Annotations, updatedAtAnnotation)
+		}
+	}
+	// Only maxUnavailable Packages are updated at a time.
+	assert.Equal(t, 1, updatedPackages)
+
+	updated := &corev1alpha1.ClusterSetPackage{}
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(cspkg), updated))
+	assert.Len(t, updated.Status.Processing, 1)
+	assert.Equal(t, int32(2), updated.Status.TotalPackages)
+	require.NotNil(t, updated.Status.Rollout)
+	assert.Equal(t, corev1alpha1.HostedClusterPackageRolloutStateProgressing, updated.Status.Rollout.State)
+}
+
+func TestClusterSetPackageController_Reconcile_deletion(t *testing.T) {
+	t.Parallel()
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-cspkg",
+			Finalizers:        []string{clusterSetPackageFinalizer},
+			DeletionTimestamp: new(metav1.Now()),
+		},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			Namespace: "test-ns",
+		},
+	}
+	managementClient := fake.NewClientBuilder().
+		WithScheme(clusterSetTestScheme).
+		WithObjects(
+			cspkg,
+			makeClusterSecret("cluster-a", nil),
+			makeClusterSecret("cluster-b", nil),
+		).
+		WithStatusSubresource(&corev1alpha1.ClusterSetPackage{}).
+		Build()
+
+	managed := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cspkg",
+			Namespace: "test-ns",
+			Labels:    map[string]string{corev1alpha1.ClusterSetPackageLabel: "test-cspkg"},
+		},
+	}
+	unmanaged := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cspkg", Namespace: "test-ns"},
+	}
+	clusterA := newFakeClusterClient(managed)
+	clusterB := newFakeClusterClient(unmanaged)
+
+	controller := newTestClusterSetPackageController(managementClient, map[string]client.Client{
+		"cluster-a": clusterA,
+		"cluster-b": clusterB,
+	})
+
+	ctx := context.Background()
+	_, err := controller.Reconcile(ctx, ctrl.Request{
+		NamespacedName: client.ObjectKeyFromObject(cspkg),
+	})
+	require.NoError(t, err)
+
+	err = clusterA.Get(ctx, client.ObjectKeyFromObject(managed), &corev1alpha1.Package{})
+	assert.True(t, apierrors.IsNotFound(err))
+	// Packages not created by the ClusterSetPackage are kept.
+	require.NoError(t, clusterB.Get(ctx, client.ObjectKeyFromObject(unmanaged), &corev1alpha1.Package{}))
+
+	// Finalizer was removed, so the object is gone.
+	err = managementClient.Get(ctx, client.ObjectKeyFromObject(cspkg), &corev1alpha1.ClusterSetPackage{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestClusterSetPackageController_Reconcile_leftClusters(t *testing.T) {
+	t.Parallel()
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-cspkg",
+			Generation: 1,
+			Finalizers: []string{clusterSetPackageFinalizer},
+		},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				Instant: &corev1alpha1.HostedClusterPackageStrategyInstant{},
+			},
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+			Namespace: "test-ns",
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
+			},
+		},
+	}
+	unregistered := makeClusterSecret("cluster-unregistered", map[string]string{"env": "prod"})
+	unregistered.Finalizers = []string{clusterFinalizer}
+	unregistered.DeletionTimestamp = new(metav1.Now())
+	broken := makeClusterSecret("cluster-broken", map[string]string{"env": "prod"})
+	broken.Finalizers = []string{clusterFinalizer}
+	broken.DeletionTimestamp = new(metav1.Now())
+	deselected := makeClusterSecret("cluster-deselected", map[string]string{"env": "staging"})
+	deselected.Finalizers = []string{clusterFinalizer}
+	managementClient := fake.NewClientBuilder().
+		WithScheme(clusterSetTestScheme).
+		WithObjects(
+			cspkg,
+			makeClusterSecret("cluster-a", map[string]string{"env": "prod"}),
+			deselected,
+			makeClusterSecret("cluster-never-selected", map[string]string{"env": "staging"}),
+			unregistered,
+			broken,
+		).
+		WithStatusSubresource(&corev1alpha1.ClusterSetPackage{}).
+		Build()
+
+	managedPackage := func() *corev1alpha1.Package {
+		return &corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cspkg",
+				Namespace: "test-ns",
+				Labels:    map[string]string{corev1alpha1.ClusterSetPackageLabel: "test-cspkg"},
+			},
+		}
+	}
+	clusterA := newFakeClusterClient()
+	clusterDeselected := newFakeClusterClient(managedPackage())
+	clusterUnregistered := newFakeClusterClient(managedPackage())
+
+	controller := newTestClusterSetPackageController(managementClient, map[string]client.Client{
+		"cluster-a":            clusterA,
+		"cluster-deselected":   clusterDeselected,
+		"cluster-unregistered": clusterUnregistered,
+	})
+
+	ctx := context.Background()
+	_, err := controller.Reconcile(ctx, ctrl.Request{
+		NamespacedName: client.ObjectKeyFromObject(cspkg),
+	})
+	require.NoError(t, err)
+
+	pkgKey := client.ObjectKey{Name: "test-cspkg", Namespace: "test-ns"}
+	require.NoError(t, clusterA.Get(ctx, pkgKey, &corev1alpha1.Package{}))
+	secretA := &corev1.Secret{}
+	require.NoError(t, managementClient.Get(ctx,
+		client.ObjectKey{Name: "cluster-a", Namespace: clusterSetTestNamespace}, secretA))
+	assert.Contains(t, secretA.Finalizers, clusterFinalizer)
+
+	// Packages on clusters leaving the set are deleted.
+	err = clusterDeselected.Get(ctx, pkgKey, &corev1alpha1.Package{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = clusterUnregistered.Get(ctx, pkgKey, &corev1alpha1.Package{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// Kubeconfig Secrets are released once their cluster is torn down
+	// or can't be torn down because the kubeconfig does not work.
+	for _, secret := range []*corev1.Secret{unregistered, broken} {
+		err = managementClient.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err), secret.Name)
+	}
+	secretDeselected := &corev1.Secret{}
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(deselected), secretDeselected))
+	assert.NotContains(t, secretDeselected.Finalizers, clusterFinalizer)
+
+	updated := &corev1alpha1.ClusterSetPackage{}
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(cspkg), updated))
+	assert.Equal(t, int32(1), updated.Status.TotalPackages)
+}
+
+func TestAsHostedClusterPackage(t *testing.T) {
+	t.Parallel()
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cspkg", Generation: 3},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
+			},
+			ConfigOverlays: []corev1alpha1.ClusterSetPackageConfigOverlay{
+				{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"region": "eu"},
+					},
+					Config: runtime.RawExtension{Raw: []byte(`{"region":"eu"}`)},
+				},
+			},
+		},
+		Status: corev1alpha1.HostedClusterPackageStatus{
+			HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+				TotalPackages: 2,
+			},
+		},
+	}
+
+	hcpkg := asHostedClusterPackage(cspkg)
+	assert.Equal(t, "test-cspkg", hcpkg.Name)
+	assert.Equal(t, int64(3), hcpkg.Generation)
+	assert.Equal(t, cspkg.Spec.ClusterSelector, hcpkg.Spec.HostedClusterSelector)
+	assert.Equal(t, cspkg.Spec.Template, hcpkg.Spec.Template)
+	assert.Equal(t, []corev1alpha1.HostedClusterPackageConfigOverlay{
+		{
+			HostedClusterSelector: cspkg.Spec.ConfigOverlays[0].ClusterSelector,
+			Config:                cspkg.Spec.ConfigOverlays[0].Config,
+		},
+	}, hcpkg.Spec.ConfigOverlays)
+	assert.Equal(t, cspkg.Status, hcpkg.Status)
+
+	// Changes to the view must not leak into the ClusterSetPackage.
+	hcpkg.Status.TotalPackages = 5
+	assert.Equal(t, int32(2), cspkg.Status.TotalPackages)
+}
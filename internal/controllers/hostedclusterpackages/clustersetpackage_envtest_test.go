@@ -0,0 +1,156 @@
+package hostedclusterpackages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// startTargetCluster starts an API server with Package Operator CRDs
+// and returns a client and kubeconfig for it.
+func startTargetCluster(t *testing.T) (client.Client, []byte) {
+	t.Helper()
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crds")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, env.Stop())
+	})
+
+	user, err := env.AddUser(envtest.User{Name: "admin", Groups: []string{"system:masters"}}, nil)
+	require.NoError(t, err)
+	kubeconfig, err := user.KubeConfig()
+	require.NoError(t, err)
+
+	c, err := client.New(cfg, client.Options{Scheme: clusterSetTestScheme})
+	require.NoError(t, err)
+	return c, kubeconfig
+}
+
+// Rolls out Packages to multiple API servers started via envtest
+// and removes them again from clusters that are deselected or unregistered.
+// Requires KUBEBUILDER_ASSETS to point to kube-apiserver and etcd binaries, e.g. installed via setup-envtest.
+func TestClusterSetPackageController_envtest(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS not set")
+	}
+
+	cspkg := &corev1alpha1.ClusterSetPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cspkg", Generation: 1},
+		Spec: corev1alpha1.ClusterSetPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				Instant: &corev1alpha1.HostedClusterPackageStrategyInstant{},
+			},
+			Namespace: "test-ns",
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{
+					Image:  "test-image:v1",
+					Config: &runtime.RawExtension{Raw: []byte(`{"region":"default"}`)},
+				},
+			},
+			ConfigOverlays: []corev1alpha1.ClusterSetPackageConfigOverlay{
+				{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"region": "eu"},
+					},
+					Config: runtime.RawExtension{Raw: []byte(`{"region":"eu"}`)},
+				},
+			},
+		},
+	}
+
+	expectedConfigs := map[string]string{
+		"eu": `{"region":"eu"}`,
+		"us": `{"region":"default"}`,
+	}
+	objs := []client.Object{cspkg}
+	clusters := map[string]client.Client{}
+	secrets := map[string]*corev1.Secret{}
+	for region := range expectedConfigs {
+		c, kubeconfig := startTargetCluster(t)
+		secret := makeClusterSecret("cluster-"+region, map[string]string{"region": region})
+		secret.Data[corev1alpha1.ClusterKubeconfigSecretKey] = kubeconfig
+		objs = append(objs, secret)
+		clusters[region] = c
+		secrets[region] = secret
+	}
+
+	managementClient := fake.NewClientBuilder().
+		WithScheme(clusterSetTestScheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&corev1alpha1.ClusterSetPackage{}).
+		Build()
+	controller := NewClusterSetPackageController(
+		managementClient, managementClient, logr.Discard(), clusterSetTestScheme, clusterSetTestNamespace)
+
+	ctx := context.Background()
+	reconcile := func() {
+		t.Helper()
+		_, err := controller.Reconcile(ctx, ctrl.Request{
+			NamespacedName: client.ObjectKeyFromObject(cspkg),
+		})
+		require.NoError(t, err)
+	}
+	pkgKey := client.ObjectKey{Name: "test-cspkg", Namespace: "test-ns"}
+	reconcile()
+
+	for region, c := range clusters {
+		pkg := &corev1alpha1.Package{}
+		require.NoError(t, c.Get(ctx, pkgKey, pkg))
+		assert.Equal(t, "test-cspkg", pkg.Labels[corev1alpha1.ClusterSetPackageLabel])
+		require.NotNil(t, pkg.Spec.Config)
+		assert.JSONEq(t, expectedConfigs[region], string(pkg.Spec.Config.Raw))
+	}
+
+	updated := &corev1alpha1.ClusterSetPackage{}
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(cspkg), updated))
+	assert.Equal(t, int32(2), updated.Status.TotalPackages)
+
+	// Deselect the "us" cluster.
+	updated.Generation = 2
+	updated.Spec.ClusterSelector = metav1.LabelSelector{
+		MatchLabels: map[string]string{"region": "eu"},
+	}
+	require.NoError(t, managementClient.Update(ctx, updated))
+	reconcile()
+
+	err := clusters["us"].Get(ctx, pkgKey, &corev1alpha1.Package{})
+	assert.True(t, apierrors.IsNotFound(err), "Package on deselected cluster")
+	require.NoError(t, clusters["eu"].Get(ctx, pkgKey, &corev1alpha1.Package{}))
+	secret := &corev1.Secret{}
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(secrets["us"]), secret))
+	assert.NotContains(t, secret.Finalizers, clusterFinalizer)
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(secrets["eu"]), secret))
+	assert.Contains(t, secret.Finalizers, clusterFinalizer)
+
+	// Unregister the "eu" cluster.
+	require.NoError(t, managementClient.Delete(ctx, secrets["eu"]))
+	reconcile()
+
+	err = clusters["eu"].Get(ctx, pkgKey, &corev1alpha1.Package{})
+	assert.True(t, apierrors.IsNotFound(err), "Package on unregistered cluster")
+	err = managementClient.Get(ctx, client.ObjectKeyFromObject(secrets["eu"]), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "kubeconfig Secret of unregistered cluster")
+
+	require.NoError(t, managementClient.Get(ctx, client.ObjectKeyFromObject(cspkg), updated))
+	assert.Equal(t, int32(0), updated.Status.TotalPackages)
+}
@@ -0,0 +1,54 @@
+package hostedclusterpackages
+
+import (
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// desiredPackageSpec returns the Package spec desired for the given target.
+func desiredPackageSpec(
+	hcpkg *corev1alpha1.HostedClusterPackage, target client.Object,
+) (corev1alpha1.PackageSpec, error) {
+	spec := *hcpkg.Spec.Template.Spec.DeepCopy()
+	config, err := packageConfig(hcpkg, target)
+	if err != nil {
+		return spec, err
+	}
+	spec.Config = config
+	return spec, nil
+}
+
+// packageConfig merges all config overlays matching the given target into the template config.
+// Returns the template config unchanged, if no overlay matches.
+func packageConfig(
+	hcpkg *corev1alpha1.HostedClusterPackage, target client.Object,
+) (*runtime.RawExtension, error) {
+	config := hcpkg.Spec.Template.Spec.Config
+	for i, overlay := range hcpkg.Spec.ConfigOverlays {
+		selector, err := metav1.LabelSelectorAsSelector(&overlay.HostedClusterSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing label selector of config overlay %d: %w", i, err)
+		}
+		if !selector.Matches(labels.Set(target.GetLabels())) {
+			continue
+		}
+
+		original := []byte("{}")
+		if config != nil && len(config.Raw) > 0 {
+			original = config.Raw
+		}
+		merged, err := jsonpatch.MergePatch(original, overlay.Config.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("merging config overlay %d: %w", i, err)
+		}
+		config = &runtime.RawExtension{Raw: merged}
+	}
+	return config, nil
+}
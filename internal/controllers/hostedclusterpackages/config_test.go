@@ -0,0 +1,108 @@
+package hostedclusterpackages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	hypershiftv1beta1 "package-operator.run/internal/controllers/hostedclusters/hypershift/v1beta1"
+)
+
+func TestPackageConfig(t *testing.T) {
+	t.Parallel()
+
+	overlay := func(labels map[string]string, config string) corev1alpha1.HostedClusterPackageConfigOverlay {
+		return corev1alpha1.HostedClusterPackageConfigOverlay{
+			HostedClusterSelector: metav1.LabelSelector{MatchLabels: labels},
+			Config:                runtime.RawExtension{Raw: []byte(config)},
+		}
+	}
+	hcpkg := &corev1alpha1.HostedClusterPackage{
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{
+					Config: &runtime.RawExtension{
+						Raw: []byte(`{"region":"default","monitoring":{"enabled":true,"interval":"1m"}}`),
+					},
+				},
+			},
+			ConfigOverlays: []corev1alpha1.HostedClusterPackageConfigOverlay{
+				overlay(map[string]string{"region": "eu"}, `{"region":"eu"}`),
+				overlay(map[string]string{"tier": "premium"}, `{"monitoring":{"interval":"10s"}}`),
+				overlay(map[string]string{"tier": "dev"}, `{"monitoring":null}`),
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+	}{
+		{
+			name:     "no overlay",
+			labels:   map[string]string{"region": "us"},
+			expected: `{"region":"default","monitoring":{"enabled":true,"interval":"1m"}}`,
+		},
+		{
+			name:     "single overlay",
+			labels:   map[string]string{"region": "eu"},
+			expected: `{"region":"eu","monitoring":{"enabled":true,"interval":"1m"}}`,
+		},
+		{
+			name:     "overlays merged in order",
+			labels:   map[string]string{"region": "eu", "tier": "premium"},
+			expected: `{"region":"eu","monitoring":{"enabled":true,"interval":"10s"}}`,
+		},
+		{
+			name:     "null removes keys",
+			labels:   map[string]string{"tier": "dev"},
+			expected: `{"region":"default"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			hc := &hypershiftv1beta1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Labels: test.labels},
+			}
+			config, err := packageConfig(hcpkg, hc)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(config.Raw))
+		})
+	}
+}
+
+func TestPackageConfig_withoutTemplateConfig(t *testing.T) {
+	t.Parallel()
+
+	hcpkg := &corev1alpha1.HostedClusterPackage{
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			ConfigOverlays: []corev1alpha1.HostedClusterPackageConfigOverlay{
+				{Config: runtime.RawExtension{Raw: []byte(`{"region":"eu"}`)}},
+			},
+		},
+	}
+	config, err := packageConfig(hcpkg, &hypershiftv1beta1.HostedCluster{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"region":"eu"}`, string(config.Raw))
+}
+
+func TestPackageConfig_invalidOverlay(t *testing.T) {
+	t.Parallel()
+
+	hcpkg := &corev1alpha1.HostedClusterPackage{
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			ConfigOverlays: []corev1alpha1.HostedClusterPackageConfigOverlay{
+				{Config: runtime.RawExtension{Raw: []byte(`not json`)}},
+			},
+		},
+	}
+	_, err := packageConfig(hcpkg, &hypershiftv1beta1.HostedCluster{})
+	require.ErrorContains(t, err, "merging config overlay 0")
+}
@@ -1,11 +1,13 @@
 package hostedclusterpackages
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +29,7 @@ type HostedClusterPackageController struct {
 	client client.Client
 	log    logr.Logger
 	scheme *runtime.Scheme
+	clock  clock
 }
 
 func NewHostedClusterPackageController(
@@ -36,6 +39,7 @@ func NewHostedClusterPackageController(
 		client: c,
 		log:    log,
 		scheme: scheme,
+		clock:  defaultClock{},
 	}
 }
 
@@ -68,19 +72,36 @@ func (c *HostedClusterPackageController) Reconcile(
 	}
 
 	// Plan:
-	// 1. Check/Update processing queue to remove up-to-date and available packages.
-	// 2. (Re)Create any missing packages.
-	// 3. Add new updates - up to maxUnavailable to processing queue
-	// 4. Patch/Reconcile Packages in processing queue
-	// 5. Report status.
+	// 1. Handle resume/abort requests for the rollout.
+	// 2. Index HostedClusters and their Packages.
+	// 3. Check/Update processing queue to remove up-to-date and available packages.
+	// 4. Halt the rollout, if too many updated packages failed.
+	// 5. (Re)Create any missing packages.
+	// 6. Add new updates - up to maxUnavailable to processing queue,
+	//    once previous partitions are soaked.
+	// 7. Patch/Reconcile Packages in processing queue, unless the rollout is halted or aborted.
+	// 8. Report status.
+
+	now := c.clock.Now()
+	updateRollout(hostedClusterPackage, now)
+
+	state, err := c.indexPackageState(ctx, hostedClusterPackage, now)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("indexing package state: %w", err)
+	}
 
-	if err := c.rebuildProcessingQueue(ctx, hostedClusterPackage); err != nil {
+	if err := c.rebuildProcessingQueue(ctx, hostedClusterPackage, state); err != nil {
 		return ctrl.Result{}, fmt.Errorf("updating processing queue: %w", err)
 	}
+	state.UpdatePartitionStatus()
 
-	state, err := c.indexPackageState(ctx, hostedClusterPackage)
+	exceeded, err := state.FailureThresholdExceeded()
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("indexing package state: %w", err)
+		return ctrl.Result{}, err
+	}
+	if exceeded && !isRolloutStopped(hostedClusterPackage) {
+		log.Info("failure threshold exceeded, halting rollout")
+		hostedClusterPackage.Status.Rollout.State = corev1alpha1.HostedClusterPackageRolloutStateHalted
 	}
 
 	if err := c.createMissingPackages(
@@ -92,16 +113,18 @@ func (c *HostedClusterPackageController) Reconcile(
 		return ctrl.Result{}, fmt.Errorf("creating missing Packages: %w", err)
 	}
 
-	if err := c.updatePackages(
-		ctx,
-		hostedClusterPackage,
-		packageTemplateApplyConfiguration,
-		state,
-	); err != nil {
-		return ctrl.Result{}, fmt.Errorf("updating Packages: %w", err)
+	if !isRolloutStopped(hostedClusterPackage) {
+		if err := c.updatePackages(
+			ctx,
+			hostedClusterPackage,
+			packageTemplateApplyConfiguration,
+			state,
+		); err != nil {
+			return ctrl.Result{}, fmt.Errorf("updating Packages: %w", err)
+		}
 	}
 
-	state, err = c.indexPackageState(ctx, hostedClusterPackage)
+	state, err = c.indexPackageState(ctx, hostedClusterPackage, now)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("indexing package state: %w", err)
 	}
@@ -110,7 +133,7 @@ func (c *HostedClusterPackageController) Reconcile(
 		return ctrl.Result{}, fmt.Errorf("updating HostedClusterPackage status: %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: state.requeueAfter}, nil
 }
 
 func (c *HostedClusterPackageController) createMissingPackages(
@@ -119,8 +142,12 @@ func (c *HostedClusterPackageController) createMissingPackages(
 	packageTemplateApplyConfiguration *corev1alpha1acs.PackageTemplateSpecApplyConfiguration,
 	state *packageStates,
 ) error {
-	for _, hcMissingPackage := range state.ListHostedClustersMissingPackage() {
-		ac := c.constructPackage(hcpkg, packageTemplateApplyConfiguration, &hcMissingPackage)
+	for _, target := range state.ListTargetsMissingPackage() {
+		hc := target.(*v1beta1.HostedCluster)
+		ac, err := c.constructPackage(hcpkg, packageTemplateApplyConfiguration, hc, state.now)
+		if err != nil {
+			return err
+		}
 		if err := c.client.Apply(
 			ctx, ac, client.FieldOwner(constants.FieldOwner),
 		); err != nil {
@@ -142,6 +169,7 @@ func (c *HostedClusterPackageController) updatePackages(
 	if len(packagesToUpdate) == 0 {
 		return nil
 	}
+	state.MarkPartitionsStarted(packagesToUpdate)
 
 	hcpkg.Status.Processing = nil
 
@@ -162,12 +190,22 @@ func (c *HostedClusterPackageController) updatePackages(
 	}
 
 	for _, pkg := range packagesToUpdate {
-		pkg.Spec = hcpkg.Spec.Template.Spec
-		ac := c.constructPackage(
+		updatedAt := state.now
+		if state.IsPackageUpdated(&pkg) {
+			// Package was already updated, keep the time the update started.
+			if t, ok := packageUpdatedAt(&pkg); ok {
+				updatedAt = t
+			}
+		}
+		ac, err := c.constructPackage(
 			hcpkg,
 			packageTemplateApplyConfiguration,
-			state.PackageToHostedCluster(&pkg),
+			state.PackageToTarget(&pkg).(*v1beta1.HostedCluster),
+			updatedAt,
 		)
+		if err != nil {
+			return err
+		}
 		if err := c.client.Apply(ctx, ac, client.FieldOwner(constants.FieldOwner)); err != nil {
 			return fmt.Errorf("updating package: %w", err)
 		}
@@ -180,9 +218,9 @@ func (c *HostedClusterPackageController) updatePackages(
 // finds the Package instance that was created for them and indexes everything for
 // further processing.
 func (c *HostedClusterPackageController) indexPackageState(
-	ctx context.Context, hcpkg *corev1alpha1.HostedClusterPackage,
+	ctx context.Context, hcpkg *corev1alpha1.HostedClusterPackage, now time.Time,
 ) (*packageStates, error) {
-	state := newPackageStates(hcpkg)
+	state := newPackageStates(hcpkg, now)
 
 	s, err := metav1.LabelSelectorAsSelector(&hcpkg.Spec.HostedClusterSelector)
 	if err != nil {
@@ -194,25 +232,33 @@ func (c *HostedClusterPackageController) indexPackageState(
 		return state, fmt.Errorf("listing clusters: %w", err)
 	}
 
-	for _, hc := range hostedClusters.Items {
-		// Completely skip unavailable clusters.
-		if !meta.IsStatusConditionTrue(hc.Status.Conditions, v1beta1.HostedClusterAvailable) {
-			continue
+	// Completely skip unavailable clusters.
+	availableHostedClusters := make([]client.Object, 0, len(hostedClusters.Items))
+	for i := range hostedClusters.Items {
+		hc := &hostedClusters.Items[i]
+		if meta.IsStatusConditionTrue(hc.Status.Conditions, v1beta1.HostedClusterAvailable) {
+			availableHostedClusters = append(availableHostedClusters, hc)
 		}
+	}
+	state.SelectCanaries(availableHostedClusters)
 
+	for _, target := range availableHostedClusters {
+		hc := target.(*v1beta1.HostedCluster)
 		pkg := &corev1alpha1.Package{}
 		err := c.client.Get(ctx, client.ObjectKey{
 			Name:      hcpkg.Name,
-			Namespace: v1beta1.HostedClusterNamespace(hc),
+			Namespace: v1beta1.HostedClusterNamespace(*hc),
 		}, pkg)
 		if err == nil {
 			// package found.
-			state.Add(&hc, pkg)
+			if err := state.Add(hc, pkg); err != nil {
+				return nil, fmt.Errorf("indexing Package for HostedCluster: %w", err)
+			}
 			continue
 		}
 		if errors.IsNotFound(err) {
 			// package does not exist.
-			state.Missing(&hc)
+			state.Missing(hc)
 			continue
 		}
 		return nil, fmt.Errorf("getting Package for HostedCluster: %w", err)
@@ -223,7 +269,7 @@ func (c *HostedClusterPackageController) indexPackageState(
 // rebuildProcessingQueue looks at the current processing queue checking their Package status.
 // Packages that are updated and report Available are removed from the queue to free up slots.
 func (c *HostedClusterPackageController) rebuildProcessingQueue(
-	ctx context.Context, hcpkg *corev1alpha1.HostedClusterPackage,
+	ctx context.Context, hcpkg *corev1alpha1.HostedClusterPackage, state *packageStates,
 ) error {
 	updatedQueue := make([]corev1alpha1.HostedClusterPackageRefStatus, 0, len(hcpkg.Status.Processing))
 	for _, processingPkg := range hcpkg.Status.Processing {
@@ -247,7 +293,13 @@ func (c *HostedClusterPackageController) rebuildProcessingQueue(
 			continue
 		}
 
-		if isPackageAvailable(pkg) && equality.Semantic.DeepEqual(hcpkg.Spec.Template.Spec, pkg.Spec) {
+		if state.PackageToTarget(pkg) == nil {
+			// HostedCluster is no longer selected or unavailable,
+			// so the Package will not be updated.
+			continue
+		}
+
+		if isPackageAvailable(pkg) && state.IsPackageUpdated(pkg) {
 			// Package is available & up-to-date.
 			continue
 		}
@@ -263,9 +315,17 @@ func (c *HostedClusterPackageController) constructPackage(
 	hcpkg *corev1alpha1.HostedClusterPackage,
 	packageTemplateApplyConfiguration *corev1alpha1acs.PackageTemplateSpecApplyConfiguration,
 	hc *v1beta1.HostedCluster,
-) *corev1alpha1acs.PackageApplyConfiguration {
+	updatedAt time.Time,
+) (*corev1alpha1acs.PackageApplyConfiguration, error) {
 	ownerGVK := hcpkg.GroupVersionKind()
 
+	config, err := packageConfig(hcpkg, hc)
+	if err != nil {
+		return nil, fmt.Errorf("constructing Package config for HostedCluster %s: %w", hc.Name, err)
+	}
+	spec := *packageTemplateApplyConfiguration.Spec
+	spec.Config = config
+
 	ac := corev1alpha1acs.Package(hcpkg.Name, v1beta1.HostedClusterNamespace(*hc)).
 		WithOwnerReferences(v1.OwnerReference().
 			WithAPIVersion(ownerGVK.GroupVersion().String()).
@@ -276,9 +336,14 @@ func (c *HostedClusterPackageController) constructPackage(
 			WithController(true)).
 		WithLabels(packageTemplateApplyConfiguration.Labels).
 		WithAnnotations(packageTemplateApplyConfiguration.Annotations).
-		WithSpec(packageTemplateApplyConfiguration.Spec)
+		WithSpec(&spec)
+	if rollingUpgrade(hcpkg) != nil {
+		ac.WithAnnotations(map[string]string{
+			updatedAtAnnotation: updatedAt.UTC().Format(time.RFC3339),
+		})
+	}
 
-	return ac
+	return ac, nil
 }
 
 func (c *HostedClusterPackageController) SetupWithManager(mgr ctrl.Manager) error {
@@ -314,7 +379,13 @@ func (c *HostedClusterPackageController) updateStatus(
 	hcpkg *corev1alpha1.HostedClusterPackage,
 	state *packageStates,
 ) error {
-	totalPackages := int32(len(state.hcToPackage))
+	setStatus(hcpkg, state)
+	return c.client.Status().Update(ctx, hcpkg, client.FieldOwner(constants.FieldOwner))
+}
+
+// setStatus reports conditions, counts and the rollout state of the indexed Packages.
+func setStatus(hcpkg *corev1alpha1.HostedClusterPackage, state *packageStates) {
+	totalPackages := int32(len(state.targetToPackage))
 
 	if hcpkg.Status.Conditions == nil {
 		hcpkg.Status.Conditions = make([]metav1.Condition, 0, 2)
@@ -378,6 +449,36 @@ func (c *HostedClusterPackageController) updateStatus(
 		})
 	}
 
+	if rollout := hcpkg.Status.Rollout; rollout != nil {
+		rollout.Failed = state.failed
+		slices.SortFunc(rollout.Failed, func(a, b corev1alpha1.HostedClusterPackageFailureStatus) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+	}
+	switch {
+	case isRolloutStopped(hcpkg) &&
+		hcpkg.Status.Rollout.State == corev1alpha1.HostedClusterPackageRolloutStateAborted:
+		meta.SetStatusCondition(&hcpkg.Status.Conditions, metav1.Condition{
+			ObservedGeneration: hcpkg.Generation,
+			Type:               corev1alpha1.HostedClusterPackageRolloutHalted,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Aborted",
+			Message:            "Rollout was aborted.",
+		})
+	case isRolloutStopped(hcpkg):
+		meta.SetStatusCondition(&hcpkg.Status.Conditions, metav1.Condition{
+			ObservedGeneration: hcpkg.Generation,
+			Type:               corev1alpha1.HostedClusterPackageRolloutHalted,
+			Status:             metav1.ConditionTrue,
+			Reason:             "FailureThresholdExceeded",
+			Message:            fmt.Sprintf("%d/%d packages failed.", len(state.failed), totalPackages),
+		})
+	default:
+		meta.RemoveStatusCondition(&hcpkg.Status.Conditions, corev1alpha1.HostedClusterPackageRolloutHalted)
+	}
+
+	state.UpdatePartitionStatus()
+
 	hcpkg.Status.HostedClusterPackageCountsStatus = corev1alpha1.HostedClusterPackageCountsStatus{
 		ObservedGeneration: int32(hcpkg.Generation),
 		TotalPackages:      totalPackages,
@@ -385,6 +486,14 @@ func (c *HostedClusterPackageController) updateStatus(
 		ProgressedPackages: int32(state.progressedPkgs),
 		UpdatedPackages:    int32(state.updatedPkgs),
 	}
+}
 
-	return c.client.Status().Update(ctx, hcpkg, client.FieldOwner(constants.FieldOwner))
+type clock interface {
+	Now() time.Time
+}
+
+type defaultClock struct{}
+
+func (c defaultClock) Now() time.Time {
+	return time.Now()
 }
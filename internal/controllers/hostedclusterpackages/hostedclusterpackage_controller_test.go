@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
@@ -809,6 +810,76 @@ func TestHostedClusterPackageController_Reconcile(t *testing.T) {
 			},
 			expectedResult: ctrl.Result{},
 		},
+		{
+			name: "Rolling upgrade halted, failure threshold exceeded",
+			setupClient: func(c *testutil.CtrlClient) {
+				hcpkg := &corev1alpha1.HostedClusterPackage{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-hcpkg", Generation: 1},
+					Spec: corev1alpha1.HostedClusterPackageSpec{
+						Strategy: corev1alpha1.HostedClusterPackageStrategy{
+							RollingUpgrade: &corev1alpha1.HostedClusterPackageStrategyRollingUpgrade{
+								MaxUnavailable:          2,
+								FailureThreshold:        new(intstr.FromInt32(1)),
+								ProgressDeadlineSeconds: 60,
+							},
+						},
+						Template: corev1alpha1.PackageTemplateSpec{
+							Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
+						},
+					},
+				}
+				mockHostedClusterPackageGet(c, hcpkg)
+
+				hostedClusters := makeHostedClusters()
+				c.On("List",
+					mock.Anything,
+					mock.AnythingOfType("*v1beta1.HostedClusterList"),
+					mock.Anything,
+				).Run(func(args mock.Arguments) {
+					arg := args.Get(1).(*hypershiftv1beta1.HostedClusterList)
+					*arg = hypershiftv1beta1.HostedClusterList{Items: hostedClusters}
+				}).Return(nil)
+
+				for i := range 5 {
+					available := i > 1
+					pkg := makePackage(i, available, available)
+					pkg.Annotations = map[string]string{
+						updatedAtAnnotation: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+					}
+					c.On("Get",
+						mock.Anything,
+						types.NamespacedName{Name: "test-hcpkg", Namespace: fmt.Sprintf("default-hc-%d", i)},
+						mock.AnythingOfType("*v1alpha1.Package"),
+						mock.Anything,
+					).Run(func(args mock.Arguments) {
+						arg := args.Get(2).(*corev1alpha1.Package)
+						*arg = pkg
+					}).Return(nil)
+				}
+
+				c.StatusMock.On("Update",
+					mock.Anything,
+					mock.IsType(&corev1alpha1.HostedClusterPackage{}),
+					mock.Anything,
+				).Run(func(args mock.Arguments) {
+					hcpkg := args.Get(1).(*corev1alpha1.HostedClusterPackage)
+
+					require.NotNil(t, hcpkg.Status.Rollout)
+					assert.Equal(t, corev1alpha1.HostedClusterPackageRolloutStateHalted, hcpkg.Status.Rollout.State)
+					require.Len(t, hcpkg.Status.Rollout.Failed, 2)
+					assert.Equal(t, "hc-0", hcpkg.Status.Rollout.Failed[0].Name)
+					assert.Equal(t, "hc-1", hcpkg.Status.Rollout.Failed[1].Name)
+
+					require.Len(t, hcpkg.Status.Conditions, 4)
+					haltedCond := meta.FindStatusCondition(hcpkg.Status.Conditions, corev1alpha1.HostedClusterPackageRolloutHalted)
+					require.NotNil(t, haltedCond)
+					assert.Equal(t, metav1.ConditionTrue, haltedCond.Status)
+					assert.Equal(t, "FailureThresholdExceeded", haltedCond.Reason)
+					assert.Equal(t, "2/5 packages failed.", haltedCond.Message)
+				}).Return(nil)
+			},
+			expectedResult: ctrl.Result{},
+		},
 		{
 			name: "Instant strategy with mixed states",
 			setupClient: func(c *testutil.CtrlClient) {
@@ -1028,11 +1099,13 @@ func TestHostedClusterPackageController_constructPackage(t *testing.T) {
 
 	controller := NewHostedClusterPackageController(nil, logr.Discard(), testScheme)
 
-	ac := controller.constructPackage(
+	ac, err := controller.constructPackage(
 		hostedClusterPackage,
 		pkgTplAC,
 		hostedCluster,
+		time.Now(),
 	)
+	require.NoError(t, err)
 
 	require.NotNil(t, ac)
 
@@ -1040,12 +1113,73 @@ func TestHostedClusterPackageController_constructPackage(t *testing.T) {
 	assert.Equal(t, hypershiftv1beta1.HostedClusterNamespace(*hostedCluster), *ac.Namespace)
 	assert.Equal(t, hostedClusterPackage.Spec.Template.Spec.Image, *ac.Spec.Image)
 	assert.Equal(t, hostedClusterPackage.Spec.Template.Labels, ac.Labels)
+	assert.NotContains(t, ac.Annotations, updatedAtAnnotation)
 
 	assert.Len(t, ac.OwnerReferences, 1)
 	assert.Equal(t, hostedClusterPackage.Name, *ac.OwnerReferences[0].Name)
 	assert.Equal(t, hostedClusterPackage.UID, *ac.OwnerReferences[0].UID)
 }
 
+func TestHostedClusterPackageController_constructPackage_configOverlays(t *testing.T) {
+	t.Parallel()
+
+	hostedClusterPackage := &corev1alpha1.HostedClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-hcpkg",
+			UID:  "test-uid",
+		},
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{
+					Image:  "test-image",
+					Config: &runtime.RawExtension{Raw: []byte(`{"region":"default","replicas":1}`)},
+				},
+			},
+			ConfigOverlays: []corev1alpha1.HostedClusterPackageConfigOverlay{
+				{
+					HostedClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"region": "eu"},
+					},
+					Config: runtime.RawExtension{Raw: []byte(`{"region":"eu"}`)},
+				},
+			},
+		},
+	}
+
+	uns, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hostedClusterPackage)
+	require.NoError(t, err)
+
+	pkgTplAC, err := ExtractPackageTemplateFields(&unstructured.Unstructured{Object: uns})
+	require.NoError(t, err)
+
+	controller := NewHostedClusterPackageController(nil, logr.Discard(), testScheme)
+
+	euCluster := &hypershiftv1beta1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eu-hc",
+			Namespace: "default",
+			Labels:    map[string]string{"region": "eu"},
+		},
+	}
+	ac, err := controller.constructPackage(hostedClusterPackage, pkgTplAC, euCluster, time.Now())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"region":"eu","replicas":1}`, string(ac.Spec.Config.Raw))
+
+	usCluster := &hypershiftv1beta1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "us-hc",
+			Namespace: "default",
+			Labels:    map[string]string{"region": "us"},
+		},
+	}
+	ac, err = controller.constructPackage(hostedClusterPackage, pkgTplAC, usCluster, time.Now())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"region":"default","replicas":1}`, string(ac.Spec.Config.Raw))
+
+	// The shared template applyconfiguration must not be changed.
+	assert.JSONEq(t, `{"region":"default","replicas":1}`, string(pkgTplAC.Spec.Config.Raw))
+}
+
 // makeHostedClusters creates 5 HostedCluster test objects.
 func makeHostedClusters() []hypershiftv1beta1.HostedCluster {
 	n := 5
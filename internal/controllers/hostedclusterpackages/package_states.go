@@ -3,28 +3,40 @@ package hostedclusterpackages
 import (
 	"math"
 	"slices"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
-	"package-operator.run/internal/controllers/hostedclusters/hypershift/v1beta1"
 )
 
 const (
 	defaultPartitionGroup = "_*_"
+	canaryPartitionGroup  = "_canary_"
 )
 
+// packageStates indexes the Packages rolled out to a set of targets,
+// e.g. HostedClusters or clusters registered via kubeconfig Secrets.
 type packageStates struct {
 	// HostedClusterPackage object controlling this state.
 	hcpkg *corev1alpha1.HostedClusterPackage
-	// UID of HostedCluster objects mapped to Package objects in their namespace.
-	hcToPackage map[types.UID]*corev1alpha1.Package
-	// UID of Package objects mapped to HostedCluster objects in their namespace.
-	packageToHc map[types.UID]*v1beta1.HostedCluster
-	// HostedCluster objects selected by the HostedClusterPackage
+	// UID of target objects mapped to their Package objects.
+	targetToPackage map[types.UID]*corev1alpha1.Package
+	// UID of Package objects mapped to their target objects.
+	packageToTarget map[types.UID]client.Object
+	// Target objects selected by the HostedClusterPackage
 	// indexed by their own UID.
-	hostedClusters map[types.UID]*v1beta1.HostedCluster
+	targets map[types.UID]client.Object
+	// UIDs of Packages with a Spec matching the Spec desired for their target.
+	updated map[types.UID]struct{}
+	// UIDs of targets in the canary partition.
+	canaries map[types.UID]struct{}
+	// Partition the canary partition was split off.
+	canaryPartition string
+	// partitionCounts maps partitions to the counts of Packages belonging to that partition.
+	partitionCounts map[string]*corev1alpha1.HostedClusterPackageCountsStatus
 	// needsUpdate maps partitions to lists of Packages belonging to that partition.
 	needsUpdate map[string][]*corev1alpha1.Package
 	// needsUpdateAndUnavailable maps partitions to lists of Packages belonging to that partition.
@@ -39,32 +51,60 @@ type packageStates struct {
 	updatedPkgs int
 	// pausedPkgs tracks total number of Packages that have Spec.Paused set to true.
 	pausedPkgs int
+	// now is the time progress deadlines are checked against.
+	now time.Time
+	// failed lists targets with updated Packages that exceeded the progress deadline.
+	failed []corev1alpha1.HostedClusterPackageFailureStatus
+	// countedFailures tracks failures counting towards the failure threshold.
+	countedFailures int
+	// requeueAfter is the shortest time until a progress deadline or soak time is exceeded.
+	requeueAfter time.Duration
 }
 
-func newPackageStates(hcpkg *corev1alpha1.HostedClusterPackage) *packageStates {
+func newPackageStates(hcpkg *corev1alpha1.HostedClusterPackage, now time.Time) *packageStates {
 	return &packageStates{
-		hcToPackage:               map[types.UID]*corev1alpha1.Package{},
-		packageToHc:               map[types.UID]*v1beta1.HostedCluster{},
-		hostedClusters:            map[types.UID]*v1beta1.HostedCluster{},
+		now:                       now,
+		targetToPackage:           map[types.UID]*corev1alpha1.Package{},
+		packageToTarget:           map[types.UID]client.Object{},
+		targets:                   map[types.UID]client.Object{},
+		updated:                   map[types.UID]struct{}{},
+		canaries:                  map[types.UID]struct{}{},
+		partitionCounts:           map[string]*corev1alpha1.HostedClusterPackageCountsStatus{},
 		needsUpdate:               map[string][]*corev1alpha1.Package{},
 		needsUpdateAndUnavailable: map[string][]*corev1alpha1.Package{},
 		hcpkg:                     hcpkg,
 	}
 }
 
-func (ps *packageStates) Add(hc *v1beta1.HostedCluster, pkg *corev1alpha1.Package) {
-	ps.hcToPackage[hc.UID] = pkg
-	ps.packageToHc[pkg.UID] = hc
-	ps.hostedClusters[hc.UID] = hc
+func (ps *packageStates) Add(target client.Object, pkg *corev1alpha1.Package) error {
+	desiredSpec, err := desiredPackageSpec(ps.hcpkg, target)
+	if err != nil {
+		return err
+	}
+
+	ps.targetToPackage[target.GetUID()] = pkg
+	ps.packageToTarget[pkg.UID] = target
+	ps.targets[target.GetUID()] = target
+
+	partitionCounts, ok := ps.partitionCounts[ps.partitionKey(target)]
+	if !ok {
+		partitionCounts = &corev1alpha1.HostedClusterPackageCountsStatus{
+			ObservedGeneration: int32(ps.hcpkg.Generation),
+		}
+		ps.partitionCounts[ps.partitionKey(target)] = partitionCounts
+	}
+	partitionCounts.TotalPackages++
 
 	if isPackageAvailable(pkg) {
 		ps.availablePkgs++
+		partitionCounts.AvailablePackages++
 	} else {
 		ps.unavailablePkgs++
 	}
 
 	if isPackageProgressed(pkg) {
 		ps.progressedPkgs++
+		partitionCounts.ProgressedPackages++
 	}
 
 	if isPackagePaused(pkg) {
@@ -72,32 +112,36 @@ func (ps *packageStates) Add(hc *v1beta1.HostedCluster, pkg *corev1alpha1.Packag
 	}
 
 	// Check if the Package needs to be updated.
-	if equality.Semantic.DeepEqual(pkg.Spec, ps.hcpkg.Spec.Template.Spec) {
+	if equality.Semantic.DeepEqual(pkg.Spec, desiredSpec) {
+		ps.updated[pkg.UID] = struct{}{}
 		ps.updatedPkgs++
-		return
+		partitionCounts.UpdatedPackages++
+		ps.trackProgress(target, pkg)
+		return nil
 	}
 
 	if isPackageAvailable(pkg) {
-		ps.needsUpdate[ps.partitionKey(hc)] = append(ps.needsUpdate[ps.partitionKey(hc)], pkg)
+		ps.needsUpdate[ps.partitionKey(target)] = append(ps.needsUpdate[ps.partitionKey(target)], pkg)
 	} else {
-		ps.needsUpdateAndUnavailable[ps.partitionKey(hc)] = append(
-			ps.needsUpdateAndUnavailable[ps.partitionKey(hc)], pkg)
+		ps.needsUpdateAndUnavailable[ps.partitionKey(target)] = append(
+			ps.needsUpdateAndUnavailable[ps.partitionKey(target)], pkg)
 	}
+	return nil
 }
 
-func (ps *packageStates) Missing(hc *v1beta1.HostedCluster) {
-	ps.hostedClusters[hc.UID] = hc
+func (ps *packageStates) Missing(target client.Object) {
+	ps.targets[target.GetUID()] = target
 	ps.unavailablePkgs++
 }
 
-func (ps *packageStates) ListHostedClustersMissingPackage() []v1beta1.HostedCluster {
-	var hcMissingPackages []v1beta1.HostedCluster
-	for hcUID, hc := range ps.hostedClusters {
-		if pkg, ok := ps.hcToPackage[hcUID]; !ok || pkg == nil {
-			hcMissingPackages = append(hcMissingPackages, *hc)
+func (ps *packageStates) ListTargetsMissingPackage() []client.Object {
+	var targetsMissingPackages []client.Object
+	for targetUID, target := range ps.targets {
+		if pkg, ok := ps.targetToPackage[targetUID]; !ok || pkg == nil {
+			targetsMissingPackages = append(targetsMissingPackages, target)
 		}
 	}
-	return hcMissingPackages
+	return targetsMissingPackages
 }
 
 // ListPackagesToUpdate returns Packages that need to be updated.
@@ -114,13 +158,14 @@ func (ps *packageStates) ListPackagesToUpdate() []corev1alpha1.Package {
 	for _, processing := range ps.hcpkg.Status.Processing {
 		processingUIDs[processing.UID] = struct{}{}
 	}
-	for _, pkg := range ps.hcToPackage {
+	for _, pkg := range ps.targetToPackage {
 		if _, ok := processingUIDs[pkg.GetUID()]; ok {
 			packages = append(packages, *pkg)
 		}
 	}
 
 	// Add additional packages.
+	gated := ps.isPartitionGated()
 	for partitionIdx, partition := range ps.partitionList() {
 		for _, pkg := range ps.needsUpdateAndUnavailable[partition] {
 			if len(packages) >= limit && partitionIdx > 0 {
@@ -143,6 +188,11 @@ func (ps *packageStates) ListPackagesToUpdate() []corev1alpha1.Package {
 			}
 			packages = append(packages, *pkg)
 		}
+
+		if gated && !ps.isPartitionSoaked(partition) {
+			// Next partitions have to wait.
+			return packages
+		}
 	}
 
 	return packages
@@ -162,11 +212,44 @@ func (ps *packageStates) DisruptionBudget() int {
 	return numToUpdate
 }
 
-func (ps *packageStates) PackageToHostedCluster(pkg *corev1alpha1.Package) *v1beta1.HostedCluster {
-	return ps.packageToHc[pkg.UID]
+// IsPackageUpdated returns true if the Package has the Spec desired for its target.
+func (ps *packageStates) IsPackageUpdated(pkg *corev1alpha1.Package) bool {
+	_, ok := ps.updated[pkg.UID]
+	return ok
+}
+
+// requeueIn makes sure the HostedClusterPackage is reconciled again after the given duration.
+func (ps *packageStates) requeueIn(d time.Duration) {
+	if ps.requeueAfter == 0 || d < ps.requeueAfter {
+		ps.requeueAfter = d
+	}
+}
+
+func (ps *packageStates) PackageToTarget(pkg *corev1alpha1.Package) client.Object {
+	return ps.packageToTarget[pkg.UID]
 }
 
 func (ps *packageStates) partitionList() []string {
+	partitionKeys := map[string]struct{}{}
+	for partitionGroupKey := range ps.needsUpdate {
+		partitionKeys[partitionGroupKey] = struct{}{}
+	}
+	for partitionGroupKey := range ps.needsUpdateAndUnavailable {
+		partitionKeys[partitionGroupKey] = struct{}{}
+	}
+	for partitionGroupKey := range ps.partitionCounts {
+		partitionKeys[partitionGroupKey] = struct{}{}
+	}
+
+	partitions := ps.orderPartitions(partitionKeys)
+	if len(ps.canaries) > 0 {
+		// canary group always comes first
+		return append([]string{canaryPartitionGroup}, partitions...)
+	}
+	return partitions
+}
+
+func (ps *packageStates) orderPartitions(partitionKeys map[string]struct{}) []string {
 	if ps.hcpkg.Spec.Partition == nil {
 		return []string{defaultPartitionGroup}
 	}
@@ -174,16 +257,9 @@ func (ps *packageStates) partitionList() []string {
 	if ps.hcpkg.Spec.Partition.Order == nil ||
 		ps.hcpkg.Spec.Partition.Order.AlphanumericAsc != nil {
 		var partitions []string
-		partitionKeys := map[string]struct{}{}
-		for partitionGroupKey := range ps.needsUpdate {
-			partitionKeys[partitionGroupKey] = struct{}{}
-		}
-		for partitionGroupKey := range ps.needsUpdateAndUnavailable {
-			partitionKeys[partitionGroupKey] = struct{}{}
-		}
-
 		for partitionGroupKey := range partitionKeys {
-			if partitionGroupKey == defaultPartitionGroup {
+			if partitionGroupKey == defaultPartitionGroup ||
+				partitionGroupKey == canaryPartitionGroup {
 				continue // will be added back at the end
 			}
 			partitions = append(partitions, partitionGroupKey)
@@ -205,12 +281,15 @@ func (ps *packageStates) partitionList() []string {
 	return partitions
 }
 
-func (ps *packageStates) partitionKey(hc *v1beta1.HostedCluster) string {
+func (ps *packageStates) partitionKey(target client.Object) string {
+	if _, ok := ps.canaries[target.GetUID()]; ok {
+		return canaryPartitionGroup
+	}
 	if ps.hcpkg.Spec.Partition == nil ||
-		hc.Labels == nil ||
-		len(hc.Labels[ps.hcpkg.Spec.Partition.LabelKey]) == 0 {
+		target.GetLabels() == nil ||
+		len(target.GetLabels()[ps.hcpkg.Spec.Partition.LabelKey]) == 0 {
 		return defaultPartitionGroup
 	}
 
-	return hc.Labels[ps.hcpkg.Spec.Partition.LabelKey]
+	return target.GetLabels()[ps.hcpkg.Spec.Partition.LabelKey]
 }
@@ -2,10 +2,12 @@ package hostedclusterpackages
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
@@ -19,12 +21,12 @@ func TestNewPackageStates(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "test-hcpkg"},
 	}
 
-	ps := newPackageStates(hcpkg)
+	ps := newPackageStates(hcpkg, time.Now())
 
 	require.NotNil(t, ps)
 	assert.Equal(t, hcpkg, ps.hcpkg)
-	assert.NotNil(t, ps.hcToPackage)
-	assert.NotNil(t, ps.hostedClusters)
+	assert.NotNil(t, ps.targetToPackage)
+	assert.NotNil(t, ps.targets)
 	assert.NotNil(t, ps.needsUpdate)
 	assert.Equal(t, 0, ps.unavailablePkgs)
 }
@@ -158,6 +160,54 @@ func TestPackageStates_Add(t *testing.T) {
 			expectedNeedsUpdate:  true,
 			expectedPartitionKey: defaultPartitionGroup,
 		},
+		{
+			name: "add package missing config overlay",
+			hcpkg: &corev1alpha1.HostedClusterPackage{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hcpkg"},
+				Spec: corev1alpha1.HostedClusterPackageSpec{
+					Template: corev1alpha1.PackageTemplateSpec{
+						Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
+					},
+					ConfigOverlays: []corev1alpha1.HostedClusterPackageConfigOverlay{
+						{
+							HostedClusterSelector: metav1.LabelSelector{
+								MatchLabels: map[string]string{"tier": "premium"},
+							},
+							Config: runtime.RawExtension{Raw: []byte(`{"replicas":3}`)},
+						},
+					},
+				},
+			},
+			hc: &hypershiftv1beta1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-hc",
+					Namespace: "default",
+					UID:       "hc-uid-1",
+					Labels:    map[string]string{"tier": "premium"},
+				},
+			},
+			pkg: &corev1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-pkg",
+					Namespace:  "default",
+					UID:        "pkg-uid-1",
+					Generation: 1,
+				},
+				Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
+				Status: corev1alpha1.PackageStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:               corev1alpha1.PackageAvailable,
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 1,
+						},
+					},
+				},
+			},
+			expectedUnavailable:  0,
+			expectedNeedsUpdate:  true,
+			expectedPartitionKey: defaultPartitionGroup,
+		},
 		{
 			name: "add package with partition label",
 			hcpkg: &corev1alpha1.HostedClusterPackage{
@@ -209,18 +259,19 @@ func TestPackageStates_Add(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ps := newPackageStates(tt.hcpkg)
-			ps.Add(tt.hc, tt.pkg)
+			ps := newPackageStates(tt.hcpkg, time.Now())
+			require.NoError(t, ps.Add(tt.hc, tt.pkg))
 
 			assert.Equal(t, tt.expectedUnavailable, ps.unavailablePkgs)
-			assert.Equal(t, tt.pkg, ps.hcToPackage[tt.hc.UID])
-			assert.Equal(t, tt.hc, ps.hostedClusters[tt.hc.UID])
+			assert.Equal(t, tt.pkg, ps.targetToPackage[tt.hc.UID])
+			assert.Equal(t, tt.hc, ps.targets[tt.hc.UID])
 
 			partitionKey := ps.partitionKey(tt.hc)
 			assert.Equal(t, tt.expectedPartitionKey, partitionKey)
 
 			if tt.expectedNeedsUpdate {
 				assert.Contains(t, ps.needsUpdate[partitionKey], tt.pkg)
+				assert.False(t, ps.IsPackageUpdated(tt.pkg))
 			} else {
 				assert.NotContains(t, ps.needsUpdate[partitionKey], tt.pkg)
 			}
@@ -242,15 +293,15 @@ func TestPackageStates_Missing(t *testing.T) {
 		},
 	}
 
-	ps := newPackageStates(hcpkg)
+	ps := newPackageStates(hcpkg, time.Now())
 	ps.Missing(hc)
 
 	assert.Equal(t, 1, ps.unavailablePkgs)
-	assert.Equal(t, hc, ps.hostedClusters[hc.UID])
-	assert.Nil(t, ps.hcToPackage[hc.UID])
+	assert.Equal(t, hc, ps.targets[hc.UID])
+	assert.Nil(t, ps.targetToPackage[hc.UID])
 }
 
-func TestPackageStates_ListHostedClustersMissingPackage(t *testing.T) {
+func TestPackageStates_ListTargetsMissingPackage(t *testing.T) {
 	t.Parallel()
 
 	hcpkg := &corev1alpha1.HostedClusterPackage{
@@ -287,14 +338,14 @@ func TestPackageStates_ListHostedClustersMissingPackage(t *testing.T) {
 		Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
 	}
 
-	ps := newPackageStates(hcpkg)
+	ps := newPackageStates(hcpkg, time.Now())
 	ps.Missing(hcMissing)
-	ps.Add(hcWithPackage, pkg)
+	require.NoError(t, ps.Add(hcWithPackage, pkg))
 
-	missing := ps.ListHostedClustersMissingPackage()
+	missing := ps.ListTargetsMissingPackage()
 
 	assert.Len(t, missing, 1)
-	assert.Equal(t, "hc-missing", missing[0].Name)
+	assert.Equal(t, "hc-missing", missing[0].GetName())
 }
 
 func TestPackageStates_DisruptionBudget(t *testing.T) {
@@ -393,7 +444,7 @@ func TestPackageStates_DisruptionBudget(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ps := newPackageStates(tt.hcpkg)
+			ps := newPackageStates(tt.hcpkg, time.Now())
 			ps.unavailablePkgs = tt.unavailablePkgs
 
 			budget := ps.DisruptionBudget()
@@ -497,7 +548,7 @@ func TestPackageStates_PartitionKey(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ps := newPackageStates(tt.hcpkg)
+			ps := newPackageStates(tt.hcpkg, time.Now())
 			key := ps.partitionKey(tt.hc)
 
 			assert.Equal(t, tt.expectedKey, key)
@@ -629,7 +680,7 @@ func TestPackageStates_PartitionList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ps := newPackageStates(tt.hcpkg)
+			ps := newPackageStates(tt.hcpkg, time.Now())
 			ps.needsUpdate = tt.needsUpdate
 
 			order := ps.partitionList()
@@ -738,13 +789,13 @@ func TestPackageStates_ListPackagesToUpdate(t *testing.T) {
 				},
 			},
 			setupState: func(ps *packageStates) {
-				ps.hcToPackage["hc-1"] = &corev1alpha1.Package{
+				ps.targetToPackage["hc-1"] = &corev1alpha1.Package{
 					ObjectMeta: metav1.ObjectMeta{UID: "pkg-1"},
 				}
-				ps.hcToPackage["hc-2"] = &corev1alpha1.Package{
+				ps.targetToPackage["hc-2"] = &corev1alpha1.Package{
 					ObjectMeta: metav1.ObjectMeta{UID: "pkg-2"},
 				}
-				ps.hcToPackage["hc-3"] = &corev1alpha1.Package{
+				ps.targetToPackage["hc-3"] = &corev1alpha1.Package{
 					ObjectMeta: metav1.ObjectMeta{UID: "pkg-3"},
 				}
 				ps.needsUpdate[defaultPartitionGroup] = []*corev1alpha1.Package{
@@ -792,7 +843,7 @@ func TestPackageStates_ListPackagesToUpdate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ps := newPackageStates(tt.hcpkg)
+			ps := newPackageStates(tt.hcpkg, time.Now())
 			tt.setupState(ps)
 
 			packages := ps.ListPackagesToUpdate()
@@ -0,0 +1,202 @@
+package hostedclusterpackages
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// SelectCanaries splits the canary partition off the first partition.
+// Canaries recorded in status for the current generation are kept,
+// so targets coming and going during a rollout don't change them.
+// Must be called before Packages are added.
+func (ps *packageStates) SelectCanaries(targets []client.Object) {
+	if ps.hcpkg.Spec.Partition == nil || ps.hcpkg.Spec.Partition.Canary == nil {
+		return
+	}
+	if ps.keepCanaries(targets) {
+		return
+	}
+
+	partitions := map[string][]client.Object{}
+	partitionKeys := map[string]struct{}{}
+	for _, target := range targets {
+		key := ps.partitionKey(target)
+		partitions[key] = append(partitions[key], target)
+		partitionKeys[key] = struct{}{}
+	}
+
+	for _, key := range ps.orderPartitions(partitionKeys) {
+		candidates := partitions[key]
+		if len(candidates) == 0 {
+			continue
+		}
+
+		slices.SortFunc(candidates, func(a, b client.Object) int {
+			return cmp.Or(cmp.Compare(a.GetNamespace(), b.GetNamespace()), cmp.Compare(a.GetName(), b.GetName()))
+		})
+		size := min(len(candidates), int(ps.hcpkg.Spec.Partition.Canary.HostedClusters))
+		for _, target := range candidates[:size] {
+			ps.canaries[target.GetUID()] = struct{}{}
+		}
+		ps.canaryPartition = key
+		return
+	}
+}
+
+// keepCanaries selects the canaries recorded in status for the current generation, that are still targeted.
+// Returns false, if there are none.
+func (ps *packageStates) keepCanaries(targets []client.Object) bool {
+	var members []corev1alpha1.HostedClusterPackageRefStatus
+	for _, status := range ps.hcpkg.Status.Partitions {
+		if status.Canary && status.ObservedGeneration == int32(ps.hcpkg.Generation) {
+			members = status.Members
+			break
+		}
+	}
+
+	for _, target := range targets {
+		if !slices.ContainsFunc(members, func(m corev1alpha1.HostedClusterPackageRefStatus) bool {
+			return m.UID == target.GetUID()
+		}) {
+			continue
+		}
+		if len(ps.canaries) == 0 {
+			ps.canaryPartition = ps.partitionKey(target)
+		}
+		ps.canaries[target.GetUID()] = struct{}{}
+	}
+	return len(ps.canaries) > 0
+}
+
+// canaryMembers returns references to the targets of the canary partition, ordered by namespace and name.
+func (ps *packageStates) canaryMembers() []corev1alpha1.HostedClusterPackageRefStatus {
+	members := make([]corev1alpha1.HostedClusterPackageRefStatus, 0, len(ps.canaries))
+	for uid := range ps.canaries {
+		target, ok := ps.targets[uid]
+		if !ok {
+			continue
+		}
+		members = append(members, corev1alpha1.HostedClusterPackageRefStatus{
+			UID:       uid,
+			Name:      target.GetName(),
+			Namespace: target.GetNamespace(),
+		})
+	}
+	slices.SortFunc(members, func(a, b corev1alpha1.HostedClusterPackageRefStatus) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return members
+}
+
+// partitionName returns the name of a partition as reported in status.
+func (ps *packageStates) partitionName(key string) (name string, canary bool) {
+	switch key {
+	case canaryPartitionGroup:
+		name, _ = ps.partitionName(ps.canaryPartition)
+		return name, true
+	case defaultPartitionGroup:
+		return "*", false
+	}
+	return key, false
+}
+
+// isPartitionGated returns true if upgrades of a partition
+// have to wait for all previous partitions to finish soaking.
+func (ps *packageStates) isPartitionGated() bool {
+	return ps.hcpkg.Spec.Partition != nil &&
+		(ps.hcpkg.Spec.Partition.SoakSeconds > 0 || ps.hcpkg.Spec.Partition.Canary != nil)
+}
+
+// isPartitionSoaked returns true if upgrades in the next partition may start.
+func (ps *packageStates) isPartitionSoaked(key string) bool {
+	if _, ok := ps.partitionCounts[key]; !ok {
+		// Nothing to wait for.
+		return true
+	}
+	status := ps.partitionStatus(key)
+	return status != nil && status.SoakedAt != nil
+}
+
+func (ps *packageStates) partitionStatus(key string) *corev1alpha1.HostedClusterPackagePartitionStatus {
+	name, canary := ps.partitionName(key)
+	for i := range ps.hcpkg.Status.Partitions {
+		status := &ps.hcpkg.Status.Partitions[i]
+		if status.Name == name && status.Canary == canary {
+			return status
+		}
+	}
+	return nil
+}
+
+// UpdatePartitionStatus reports counts and timestamps of all partitions in the HostedClusterPackage status.
+func (ps *packageStates) UpdatePartitionStatus() {
+	if ps.hcpkg.Spec.Partition == nil {
+		ps.hcpkg.Status.Partitions = nil
+		return
+	}
+	soak := time.Duration(ps.hcpkg.Spec.Partition.SoakSeconds) * time.Second
+
+	var partitions []corev1alpha1.HostedClusterPackagePartitionStatus
+	for _, key := range ps.partitionList() {
+		counts, ok := ps.partitionCounts[key]
+		if !ok {
+			continue
+		}
+
+		name, canary := ps.partitionName(key)
+		status := corev1alpha1.HostedClusterPackagePartitionStatus{
+			HostedClusterPackageCountsStatus: *counts,
+			Name:                             name,
+			Canary:                           canary,
+		}
+		if canary {
+			status.Members = ps.canaryMembers()
+		}
+		if previous := ps.partitionStatus(key); previous != nil &&
+			previous.ObservedGeneration == counts.ObservedGeneration {
+			// Timestamps are kept until the next generation.
+			status.UpdateStartedAt = previous.UpdateStartedAt
+			status.AvailableSince = previous.AvailableSince
+			status.SoakedAt = previous.SoakedAt
+		}
+
+		switch {
+		case counts.UpdatedPackages != counts.TotalPackages ||
+			counts.AvailablePackages != counts.TotalPackages:
+			status.AvailableSince = nil
+		case status.AvailableSince == nil:
+			status.AvailableSince = &metav1.Time{Time: ps.now}
+		}
+
+		if status.SoakedAt == nil && status.AvailableSince != nil {
+			if remaining := status.AvailableSince.Add(soak).Sub(ps.now); remaining > 0 {
+				// Check again, when soaking is done.
+				ps.requeueIn(remaining)
+			} else {
+				status.SoakedAt = &metav1.Time{Time: ps.now}
+			}
+		}
+		partitions = append(partitions, status)
+	}
+	ps.hcpkg.Status.Partitions = partitions
+}
+
+// MarkPartitionsStarted records the start of upgrades in the partitions of the given Packages.
+func (ps *packageStates) MarkPartitionsStarted(packages []corev1alpha1.Package) {
+	for i := range packages {
+		pkg := &packages[i]
+		target := ps.PackageToTarget(pkg)
+		if target == nil || ps.IsPackageUpdated(pkg) {
+			continue
+		}
+		if status := ps.partitionStatus(ps.partitionKey(target)); status != nil && status.UpdateStartedAt == nil {
+			status.UpdateStartedAt = &metav1.Time{Time: ps.now}
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package hostedclusterpackages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	hypershiftv1beta1 "package-operator.run/internal/controllers/hostedclusters/hypershift/v1beta1"
+)
+
+// makePartitionedHostedClusterPackage returns a HostedClusterPackage
+// partitioning HostedClusters by the "risk-group" label.
+func makePartitionedHostedClusterPackage(
+	partition corev1alpha1.HostedClusterPackagePartitionSpec,
+) *corev1alpha1.HostedClusterPackage {
+	partition.LabelKey = "risk-group"
+	return &corev1alpha1.HostedClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-hcpkg", Generation: 1},
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				RollingUpgrade: &corev1alpha1.HostedClusterPackageStrategyRollingUpgrade{MaxUnavailable: 5},
+			},
+			Template: corev1alpha1.PackageTemplateSpec{
+				Spec: corev1alpha1.PackageSpec{Image: "test-image:v2"},
+			},
+			Partition: &partition,
+		},
+	}
+}
+
+// makePartitionedHostedClusters labels the 5 HostedClusters from makeHostedClusters
+// with risk-group "a" (hc-0, hc-1, hc-2) and "b" (hc-3, hc-4).
+func makePartitionedHostedClusters() []hypershiftv1beta1.HostedCluster {
+	hostedClusters := makeHostedClusters()
+	for i := range hostedClusters {
+		group := "a"
+		if i > 2 {
+			group = "b"
+		}
+		hostedClusters[i].Labels = map[string]string{"risk-group": group}
+	}
+	return hostedClusters
+}
+
+// addPackages adds a Package for every HostedCluster,
+// Packages of the given HostedClusters are up-to-date.
+func addPackages(
+	t *testing.T, ps *packageStates, hostedClusters []hypershiftv1beta1.HostedCluster, updated ...int,
+) {
+	t.Helper()
+
+	for i := range hostedClusters {
+		pkg := makePackage(i, true, true)
+		for _, u := range updated {
+			if u == i {
+				pkg.Spec.Image = "test-image:v2"
+			}
+		}
+		require.NoError(t, ps.Add(&hostedClusters[i], &pkg))
+	}
+}
+
+func packageUIDs(packages []corev1alpha1.Package) []types.UID {
+	uids := make([]types.UID, len(packages))
+	for i, pkg := range packages {
+		uids[i] = pkg.UID
+	}
+	return uids
+}
+
+func TestPackageStates_SelectCanaries(t *testing.T) {
+	t.Parallel()
+
+	hcpkg := makePartitionedHostedClusterPackage(corev1alpha1.HostedClusterPackagePartitionSpec{
+		Canary: &corev1alpha1.HostedClusterPackagePartitionCanarySpec{HostedClusters: 2},
+	})
+	hostedClusters := makePartitionedHostedClusters()
+
+	ps := newPackageStates(hcpkg, time.Now())
+	// Order must not matter.
+	ps.SelectCanaries([]client.Object{
+		&hostedClusters[4], &hostedClusters[2], &hostedClusters[1], &hostedClusters[0], &hostedClusters[3],
+	})
+	addPackages(t, ps, hostedClusters)
+
+	assert.Equal(t, map[types.UID]struct{}{"hc-uid-0": {}, "hc-uid-1": {}}, ps.canaries)
+	assert.Equal(t, "a", ps.canaryPartition)
+	assert.Equal(t, []string{canaryPartitionGroup, "a", "b", defaultPartitionGroup}, ps.partitionList())
+
+	name, canary := ps.partitionName(canaryPartitionGroup)
+	assert.Equal(t, "a", name)
+	assert.True(t, canary)
+
+	// Canaries are updated first.
+	assert.Equal(t, []types.UID{"pkg-uid-0", "pkg-uid-1"}, packageUIDs(ps.ListPackagesToUpdate()))
+}
+
+func TestPackageStates_SelectCanaries_keepRecorded(t *testing.T) {
+	t.Parallel()
+
+	hcpkg := makePartitionedHostedClusterPackage(corev1alpha1.HostedClusterPackagePartitionSpec{
+		Canary: &corev1alpha1.HostedClusterPackagePartitionCanarySpec{HostedClusters: 2},
+	})
+	hcpkg.Status.Partitions = []corev1alpha1.HostedClusterPackagePartitionStatus{
+		{
+			HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+				ObservedGeneration: 1,
+			},
+			Name:   "a",
+			Canary: true,
+			// hc-1 and hc-3 were picked when the rollout started, hc-3 is gone.
+			Members: []corev1alpha1.HostedClusterPackageRefStatus{
+				{UID: "hc-uid-1", Name: "hc-1", Namespace: "default"},
+				{UID: "hc-uid-3", Name: "hc-3", Namespace: "default"},
+			},
+		},
+	}
+	hostedClusters := makePartitionedHostedClusters()
+	remaining := []hypershiftv1beta1.HostedCluster{
+		hostedClusters[0], hostedClusters[1], hostedClusters[2], hostedClusters[4],
+	}
+
+	ps := newPackageStates(hcpkg, time.Now())
+	ps.SelectCanaries([]client.Object{&remaining[0], &remaining[1], &remaining[2], &remaining[3]})
+	addPackages(t, ps, remaining)
+	ps.UpdatePartitionStatus()
+
+	// hc-0 would be picked, if canaries were selected again.
+	assert.Equal(t, map[types.UID]struct{}{"hc-uid-1": {}}, ps.canaries)
+	assert.Equal(t, "a", ps.canaryPartition)
+	assert.Equal(t, []corev1alpha1.HostedClusterPackageRefStatus{
+		{UID: "hc-uid-1", Name: "hc-1", Namespace: "default"},
+	}, hcpkg.Status.Partitions[0].Members)
+
+	// A new generation picks canaries again.
+	hcpkg.Generation = 2
+	ps = newPackageStates(hcpkg, time.Now())
+	ps.SelectCanaries([]client.Object{&remaining[0], &remaining[1], &remaining[2], &remaining[3]})
+	assert.Equal(t, map[types.UID]struct{}{"hc-uid-0": {}, "hc-uid-1": {}}, ps.canaries)
+}
+
+func TestPackageStates_ListPackagesToUpdate_soak(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		availableSince  *metav1.Time
+		expectedUIDs    []types.UID
+		expectedRequeue time.Duration
+	}{
+		{
+			name:            "partition became available",
+			expectedUIDs:    []types.UID{},
+			expectedRequeue: 10 * time.Minute,
+		},
+		{
+			name:            "partition soaking",
+			availableSince:  &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			expectedUIDs:    []types.UID{},
+			expectedRequeue: 5 * time.Minute,
+		},
+		{
+			name:           "partition soaked",
+			availableSince: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			expectedUIDs:   []types.UID{"pkg-uid-3", "pkg-uid-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hcpkg := makePartitionedHostedClusterPackage(corev1alpha1.HostedClusterPackagePartitionSpec{
+				SoakSeconds: 600,
+			})
+			hcpkg.Status.Partitions = []corev1alpha1.HostedClusterPackagePartitionStatus{
+				{
+					HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+						ObservedGeneration: 1,
+					},
+					Name:           "a",
+					AvailableSince: tt.availableSince,
+				},
+			}
+
+			ps := newPackageStates(hcpkg, now)
+			addPackages(t, ps, makePartitionedHostedClusters(), 0, 1, 2)
+			ps.UpdatePartitionStatus()
+
+			assert.Equal(t, tt.expectedUIDs, packageUIDs(ps.ListPackagesToUpdate()))
+			assert.Equal(t, tt.expectedRequeue, ps.requeueAfter)
+		})
+	}
+}
+
+func TestPackageStates_UpdatePartitionStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+
+	hcpkg := makePartitionedHostedClusterPackage(corev1alpha1.HostedClusterPackagePartitionSpec{
+		SoakSeconds: 60,
+		Canary:      &corev1alpha1.HostedClusterPackagePartitionCanarySpec{HostedClusters: 1},
+	})
+	hcpkg.Status.Partitions = []corev1alpha1.HostedClusterPackagePartitionStatus{
+		{
+			HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+				ObservedGeneration: 1,
+			},
+			Name:            "a",
+			Canary:          true,
+			UpdateStartedAt: &earlier,
+			AvailableSince:  &earlier,
+			SoakedAt:        &earlier,
+		},
+		{
+			// Previous generation.
+			Name:            "a",
+			UpdateStartedAt: &earlier,
+			AvailableSince:  &earlier,
+		},
+	}
+	hostedClusters := makePartitionedHostedClusters()
+
+	ps := newPackageStates(hcpkg, now)
+	ps.SelectCanaries([]client.Object{
+		&hostedClusters[0], &hostedClusters[1], &hostedClusters[2], &hostedClusters[3], &hostedClusters[4],
+	})
+	addPackages(t, ps, hostedClusters, 0)
+	ps.UpdatePartitionStatus()
+
+	// The next partition starts.
+	packages := ps.ListPackagesToUpdate()
+	assert.Equal(t, []types.UID{"pkg-uid-1", "pkg-uid-2"}, packageUIDs(packages))
+	ps.MarkPartitionsStarted(packages)
+
+	assert.Equal(t, []corev1alpha1.HostedClusterPackagePartitionStatus{
+		{
+			HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+				ObservedGeneration: 1,
+				TotalPackages:      1,
+				AvailablePackages:  1,
+				ProgressedPackages: 1,
+				UpdatedPackages:    1,
+			},
+			Name:   "a",
+			Canary: true,
+			Members: []corev1alpha1.HostedClusterPackageRefStatus{
+				{UID: "hc-uid-0", Name: "hc-0", Namespace: "default"},
+			},
+			UpdateStartedAt: &earlier,
+			AvailableSince:  &earlier,
+			SoakedAt:        &earlier,
+		},
+		{
+			HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+				ObservedGeneration: 1,
+				TotalPackages:      2,
+				AvailablePackages:  2,
+				ProgressedPackages: 2,
+			},
+			Name:            "a",
+			UpdateStartedAt: &metav1.Time{Time: now},
+		},
+		{
+			HostedClusterPackageCountsStatus: corev1alpha1.HostedClusterPackageCountsStatus{
+				ObservedGeneration: 1,
+				TotalPackages:      2,
+				AvailablePackages:  2,
+				ProgressedPackages: 2,
+			},
+			Name: "b",
+		},
+	}, hcpkg.Status.Partitions)
+}
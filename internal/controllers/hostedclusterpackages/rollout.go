@@ -0,0 +1,141 @@
+package hostedclusterpackages
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+const (
+	// updatedAtAnnotation records when the Package was last updated to a new Spec.
+	updatedAtAnnotation = "package-operator.run/updated-at"
+
+	defaultProgressDeadline = 10 * time.Minute
+)
+
+// rollingUpgrade returns the RollingUpgrade strategy or nil,
+// if the HostedClusterPackage is not using it.
+func rollingUpgrade(
+	hcpkg *corev1alpha1.HostedClusterPackage,
+) *corev1alpha1.HostedClusterPackageStrategyRollingUpgrade {
+	if hcpkg.Spec.Strategy.Instant != nil {
+		return nil
+	}
+	return hcpkg.Spec.Strategy.RollingUpgrade
+}
+
+func progressDeadline(ru *corev1alpha1.HostedClusterPackageStrategyRollingUpgrade) time.Duration {
+	if ru.ProgressDeadlineSeconds <= 0 {
+		return defaultProgressDeadline
+	}
+	return time.Duration(ru.ProgressDeadlineSeconds) * time.Second
+}
+
+// updateRollout starts a new rollout for every new generation
+// and handles the resume and abort annotations.
+func updateRollout(hcpkg *corev1alpha1.HostedClusterPackage, now time.Time) {
+	if rollingUpgrade(hcpkg) == nil {
+		hcpkg.Status.Rollout = nil
+		return
+	}
+
+	rollout := hcpkg.Status.Rollout
+	if rollout == nil {
+		rollout = &corev1alpha1.HostedClusterPackageRolloutStatus{}
+		hcpkg.Status.Rollout = rollout
+	}
+	if rollout.Generation != hcpkg.Generation || rollout.State == "" {
+		rollout.Generation = hcpkg.Generation
+		rollout.State = corev1alpha1.HostedClusterPackageRolloutStateProgressing
+		rollout.ResumedAt = nil
+	}
+
+	annotations := hcpkg.GetAnnotations()
+	if abort := annotations[corev1alpha1.HostedClusterPackageAbortRolloutAnnotation]; abort != "" &&
+		abort != rollout.LastHandledAbort {
+		rollout.LastHandledAbort = abort
+		rollout.State = corev1alpha1.HostedClusterPackageRolloutStateAborted
+	}
+	if resume := annotations[corev1alpha1.HostedClusterPackageResumeRolloutAnnotation]; resume != "" &&
+		resume != rollout.LastHandledResume {
+		rollout.LastHandledResume = resume
+		if rollout.State != corev1alpha1.HostedClusterPackageRolloutStateProgressing {
+			rollout.State = corev1alpha1.HostedClusterPackageRolloutStateProgressing
+			rollout.ResumedAt = &metav1.Time{Time: now}
+		}
+	}
+}
+
+// isRolloutStopped returns true if Packages must not be updated.
+func isRolloutStopped(hcpkg *corev1alpha1.HostedClusterPackage) bool {
+	return hcpkg.Status.Rollout != nil &&
+		hcpkg.Status.Rollout.State != corev1alpha1.HostedClusterPackageRolloutStateProgressing
+}
+
+// packageUpdatedAt returns the time the Package was last updated to a new Spec.
+func packageUpdatedAt(pkg *corev1alpha1.Package) (time.Time, bool) {
+	v, ok := pkg.GetAnnotations()[updatedAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// trackProgress records updated Packages that failed to become Available within the progress deadline.
+func (ps *packageStates) trackProgress(target client.Object, pkg *corev1alpha1.Package) {
+	ru := rollingUpgrade(ps.hcpkg)
+	if ru == nil || isPackageAvailable(pkg) {
+		return
+	}
+	updatedAt, ok := packageUpdatedAt(pkg)
+	if !ok {
+		return
+	}
+
+	remaining := updatedAt.Add(progressDeadline(ru)).Sub(ps.now)
+	if remaining > 0 {
+		// Check again, when the deadline is exceeded.
+		ps.requeueIn(remaining)
+		return
+	}
+
+	failure := corev1alpha1.HostedClusterPackageFailureStatus{
+		Name:      target.GetName(),
+		Namespace: target.GetNamespace(),
+		UpdatedAt: metav1.Time{Time: updatedAt},
+	}
+	if cond := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageAvailable); cond != nil {
+		failure.Message = cond.Message
+	}
+	ps.failed = append(ps.failed, failure)
+
+	// Failures from before the rollout was resumed don't count towards the threshold.
+	if rollout := ps.hcpkg.Status.Rollout; rollout == nil || rollout.ResumedAt == nil ||
+		!updatedAt.Before(rollout.ResumedAt.Time) {
+		ps.countedFailures++
+	}
+}
+
+// FailureThresholdExceeded returns true if more updated Packages failed than the RollingUpgrade strategy allows.
+func (ps *packageStates) FailureThresholdExceeded() (bool, error) {
+	ru := rollingUpgrade(ps.hcpkg)
+	if ru == nil || ru.FailureThreshold == nil {
+		return false, nil
+	}
+
+	allowed, err := intstr.GetScaledValueFromIntOrPercent(ru.FailureThreshold, len(ps.targets), true)
+	if err != nil {
+		return false, fmt.Errorf("calculating failure threshold: %w", err)
+	}
+	return ps.countedFailures > allowed, nil
+}
@@ -0,0 +1,313 @@
+package hostedclusterpackages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	hypershiftv1beta1 "package-operator.run/internal/controllers/hostedclusters/hypershift/v1beta1"
+)
+
+func TestUpdateRollout(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name        string
+		strategy    corev1alpha1.HostedClusterPackageStrategy
+		annotations map[string]string
+		rollout     *corev1alpha1.HostedClusterPackageRolloutStatus
+		expected    *corev1alpha1.HostedClusterPackageRolloutStatus
+	}{
+		{
+			name: "instant strategy",
+			strategy: corev1alpha1.HostedClusterPackageStrategy{
+				Instant: &corev1alpha1.HostedClusterPackageStrategyInstant{},
+			},
+			rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{Generation: 1},
+		},
+		{
+			name: "new rollout",
+			expected: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation: 2,
+				State:      corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+			},
+		},
+		{
+			name: "new generation restarts halted rollout",
+			rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:        1,
+				State:             corev1alpha1.HostedClusterPackageRolloutStateHalted,
+				ResumedAt:         &earlier,
+				LastHandledResume: "a",
+			},
+			annotations: map[string]string{
+				corev1alpha1.HostedClusterPackageResumeRolloutAnnotation: "a",
+			},
+			expected: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:        2,
+				State:             corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+				LastHandledResume: "a",
+			},
+		},
+		{
+			name: "abort",
+			rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation: 2,
+				State:      corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+			},
+			annotations: map[string]string{
+				corev1alpha1.HostedClusterPackageAbortRolloutAnnotation: "a",
+			},
+			expected: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:       2,
+				State:            corev1alpha1.HostedClusterPackageRolloutStateAborted,
+				LastHandledAbort: "a",
+			},
+		},
+		{
+			name: "abort already handled",
+			rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:        2,
+				State:             corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+				ResumedAt:         &earlier,
+				LastHandledAbort:  "a",
+				LastHandledResume: "b",
+			},
+			annotations: map[string]string{
+				corev1alpha1.HostedClusterPackageAbortRolloutAnnotation:  "a",
+				corev1alpha1.HostedClusterPackageResumeRolloutAnnotation: "b",
+			},
+			expected: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:        2,
+				State:             corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+				ResumedAt:         &earlier,
+				LastHandledAbort:  "a",
+				LastHandledResume: "b",
+			},
+		},
+		{
+			name: "resume",
+			rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation: 2,
+				State:      corev1alpha1.HostedClusterPackageRolloutStateHalted,
+			},
+			annotations: map[string]string{
+				corev1alpha1.HostedClusterPackageResumeRolloutAnnotation: "a",
+			},
+			expected: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:        2,
+				State:             corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+				ResumedAt:         &metav1.Time{Time: now},
+				LastHandledResume: "a",
+			},
+		},
+		{
+			name: "resume progressing rollout",
+			rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation: 2,
+				State:      corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+			},
+			annotations: map[string]string{
+				corev1alpha1.HostedClusterPackageResumeRolloutAnnotation: "a",
+			},
+			expected: &corev1alpha1.HostedClusterPackageRolloutStatus{
+				Generation:        2,
+				State:             corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+				LastHandledResume: "a",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy := tt.strategy
+			if strategy.Instant == nil {
+				strategy.RollingUpgrade = &corev1alpha1.HostedClusterPackageStrategyRollingUpgrade{MaxUnavailable: 1}
+			}
+			hcpkg := &corev1alpha1.HostedClusterPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-hcpkg",
+					Generation:  2,
+					Annotations: tt.annotations,
+				},
+				Spec: corev1alpha1.HostedClusterPackageSpec{Strategy: strategy},
+				Status: corev1alpha1.HostedClusterPackageStatus{
+					Rollout: tt.rollout,
+				},
+			}
+
+			updateRollout(hcpkg, now)
+			assert.Equal(t, tt.expected, hcpkg.Status.Rollout)
+		})
+	}
+}
+
+func TestPackageStates_FailureThresholdExceeded(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		threshold        *intstr.IntOrString
+		resumedAt        *metav1.Time
+		updatedAt        []time.Duration
+		expected         bool
+		expectedFailed   int
+		expectedRequeue  time.Duration
+		expectedCounting int
+	}{
+		{
+			name:             "no threshold",
+			updatedAt:        []time.Duration{-time.Hour, -time.Hour, -time.Hour},
+			expectedFailed:   3,
+			expectedCounting: 3,
+		},
+		{
+			name:             "within threshold",
+			threshold:        new(intstr.FromInt32(2)),
+			updatedAt:        []time.Duration{-time.Hour, -time.Hour},
+			expectedFailed:   2,
+			expectedCounting: 2,
+		},
+		{
+			name:             "threshold exceeded",
+			threshold:        new(intstr.FromInt32(1)),
+			updatedAt:        []time.Duration{-time.Hour, -time.Hour},
+			expected:         true,
+			expectedFailed:   2,
+			expectedCounting: 2,
+		},
+		{
+			name:      "percentage threshold exceeded",
+			threshold: new(intstr.FromString("20%")),
+			updatedAt: []time.Duration{-time.Hour, -time.Hour, -time.Minute, -2 * time.Minute},
+			expected:  true,
+			// 20% of 4 HostedClusters rounds up to 1 allowed failure.
+			expectedFailed:   2,
+			expectedCounting: 2,
+			expectedRequeue:  8 * time.Minute,
+		},
+		{
+			name:             "failures before resume",
+			threshold:        new(intstr.FromInt32(0)),
+			resumedAt:        &metav1.Time{Time: now.Add(-30 * time.Minute)},
+			updatedAt:        []time.Duration{-time.Hour, -time.Hour},
+			expectedFailed:   2,
+			expectedCounting: 0,
+		},
+		{
+			name:            "within progress deadline",
+			threshold:       new(intstr.FromInt32(0)),
+			updatedAt:       []time.Duration{-5 * time.Minute},
+			expectedRequeue: 5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hcpkg := &corev1alpha1.HostedClusterPackage{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hcpkg", Generation: 1},
+				Spec: corev1alpha1.HostedClusterPackageSpec{
+					Strategy: corev1alpha1.HostedClusterPackageStrategy{
+						RollingUpgrade: &corev1alpha1.HostedClusterPackageStrategyRollingUpgrade{
+							MaxUnavailable:   1,
+							FailureThreshold: tt.threshold,
+						},
+					},
+					Template: corev1alpha1.PackageTemplateSpec{
+						Spec: corev1alpha1.PackageSpec{Image: "test-image:v1"},
+					},
+				},
+				Status: corev1alpha1.HostedClusterPackageStatus{
+					Rollout: &corev1alpha1.HostedClusterPackageRolloutStatus{
+						Generation: 1,
+						State:      corev1alpha1.HostedClusterPackageRolloutStateProgressing,
+						ResumedAt:  tt.resumedAt,
+					},
+				},
+			}
+
+			ps := newPackageStates(hcpkg, now)
+			hostedClusters := makeHostedClusters()
+			for i, d := range tt.updatedAt {
+				pkg := makePackage(i, false, false)
+				pkg.Annotations = map[string]string{
+					updatedAtAnnotation: now.Add(d).Format(time.RFC3339),
+				}
+				require.NoError(t, ps.Add(&hostedClusters[i], &pkg))
+			}
+
+			exceeded, err := ps.FailureThresholdExceeded()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, exceeded)
+			assert.Len(t, ps.failed, tt.expectedFailed)
+			assert.Equal(t, tt.expectedCounting, ps.countedFailures)
+			assert.Equal(t, tt.expectedRequeue, ps.requeueAfter)
+		})
+	}
+}
+
+func TestHostedClusterPackageController_constructPackage_updatedAt(t *testing.T) {
+	t.Parallel()
+
+	hostedClusterPackage := &corev1alpha1.HostedClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-hcpkg",
+			UID:  "test-uid",
+		},
+		Spec: corev1alpha1.HostedClusterPackageSpec{
+			Strategy: corev1alpha1.HostedClusterPackageStrategy{
+				RollingUpgrade: &corev1alpha1.HostedClusterPackageStrategyRollingUpgrade{MaxUnavailable: 1},
+			},
+			Template: corev1alpha1.PackageTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"test": "annotation"},
+				},
+				Spec: corev1alpha1.PackageSpec{Image: "test-image"},
+			},
+		},
+	}
+	hostedCluster := &hypershiftv1beta1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-hc", Namespace: "default"},
+	}
+
+	uns, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hostedClusterPackage)
+	require.NoError(t, err)
+	pkgTplAC, err := ExtractPackageTemplateFields(&unstructured.Unstructured{Object: uns})
+	require.NoError(t, err)
+
+	controller := NewHostedClusterPackageController(nil, logr.Discard(), testScheme)
+	updatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ac, err := controller.constructPackage(hostedClusterPackage, pkgTplAC, hostedCluster, updatedAt)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"test":              "annotation",
+		updatedAtAnnotation: "2026-01-01T12:00:00Z",
+	}, ac.Annotations)
+	// The shared template applyconfiguration must not be changed.
+	assert.NotContains(t, pkgTplAC.Annotations, updatedAtAnnotation)
+
+	pkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Annotations: ac.Annotations},
+	}
+	parsed, ok := packageUpdatedAt(pkg)
+	require.True(t, ok)
+	assert.Equal(t, updatedAt, parsed)
+}
@@ -2,25 +2,25 @@ package hostedclusterpackages
 
 import (
 	"fmt"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-
-	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// toUnstructured converts a typed HostedClusterPackage object to an unstructured.Unstructured.
+// toUnstructured converts a typed HostedClusterPackage or ClusterSetPackage object to an unstructured.Unstructured.
 // Unspecified/defaulted fields will be dropped during the conversion and are not present in the returned data.
-func toUnstructured(hostedClusterPackage *corev1alpha1.HostedClusterPackage) (*unstructured.Unstructured, error) {
-	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hostedClusterPackage)
+func toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
-		return nil, fmt.Errorf("converting HostedClusterPackage to unstructured: %w", err)
+		return nil, fmt.Errorf("converting %s to unstructured: %w", reflect.TypeOf(obj).Elem().Name(), err)
 	}
 
 	uns := &unstructured.Unstructured{
 		Object: m,
 	}
-	uns.SetGroupVersionKind(hostedClusterPackage.GroupVersionKind())
+	uns.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
 
 	return uns, nil
 }
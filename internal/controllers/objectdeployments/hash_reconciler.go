@@ -6,6 +6,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	adapters "package-operator.run/internal/adapters"
 	"package-operator.run/internal/utils"
 )
@@ -15,8 +16,19 @@ type hashReconciler struct{ client client.Client }
 func (h *hashReconciler) Reconcile(
 	_ context.Context, objectSetDeployment adapters.ObjectDeploymentAccessor,
 ) (ctrl.Result, error) {
-	objectSetTemplate := objectSetDeployment.GetSpecObjectSetTemplate()
-	templateHash := utils.ComputeFNV32Hash(objectSetTemplate, objectSetDeployment.GetStatusCollisionCount())
+	var hashInput any = objectSetDeployment.GetSpecObjectSetTemplate()
+	// The ServiceAccount is only hashed when set,
+	// so hashes of ObjectDeployments without ServiceAccount stay stable.
+	if serviceAccount := objectSetDeployment.GetSpecServiceAccount(); serviceAccount != nil {
+		hashInput = struct {
+			Template       corev1alpha1.ObjectSetTemplate
+			ServiceAccount corev1alpha1.ServiceAccountReference
+		}{
+			Template:       objectSetDeployment.GetSpecObjectSetTemplate(),
+			ServiceAccount: *serviceAccount,
+		}
+	}
+	templateHash := utils.ComputeFNV32Hash(hashInput, objectSetDeployment.GetStatusCollisionCount())
 	objectSetDeployment.SetStatusTemplateHash(templateHash)
 	return ctrl.Result{}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
@@ -29,6 +31,7 @@ func TestHashReconciler(t *testing.T) {
 		objectSetDeployment := &adaptermocks.ObjectSetDeploymentMock{}
 		objectSetDeployment.On("GetSpecObjectSetTemplate").Return(corev1alpha1.ObjectSetTemplate{})
 		objectSetDeployment.On("GetStatusCollisionCount").Return(1)
+		objectSetDeployment.On("GetSpecServiceAccount").Return(nil)
 
 		hash := utils.ComputeFNV32Hash(
 			objectSetDeployment.GetSpecObjectSetTemplate(),
@@ -40,4 +43,30 @@ func TestHashReconciler(t *testing.T) {
 		require.NoError(t, err)
 		objectSetDeployment.AssertExpectations(t)
 	})
+	t.Run("service account changes hash", func(t *testing.T) {
+		t.Parallel()
+
+		hr := hashReconciler{
+			client: testutil.NewClient(),
+		}
+
+		ctx := context.Background()
+
+		objectSetDeployment := &adaptermocks.ObjectSetDeploymentMock{}
+		objectSetDeployment.On("GetSpecObjectSetTemplate").Return(corev1alpha1.ObjectSetTemplate{})
+		objectSetDeployment.On("GetStatusCollisionCount").Return(nil)
+		objectSetDeployment.On("GetSpecServiceAccount").
+			Return(&corev1alpha1.ServiceAccountReference{Name: "tenant"})
+
+		var hash string
+		objectSetDeployment.On("SetStatusTemplateHash", mock.Anything).
+			Run(func(args mock.Arguments) {
+				hash = args.String(0)
+			})
+
+		_, err := hr.Reconcile(ctx, objectSetDeployment)
+		require.NoError(t, err)
+		assert.NotEmpty(t, hash)
+		assert.NotEqual(t, utils.ComputeFNV32Hash(corev1alpha1.ObjectSetTemplate{}, nil), hash)
+	})
 }
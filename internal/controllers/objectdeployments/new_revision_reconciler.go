@@ -69,7 +69,8 @@ func (r *newRevisionReconciler) Reconcile(ctx context.Context,
 		conflictingObjectSet.GetSpecRevision() >= latestRevisionNumber &&
 		controllerRef != nil &&
 		controllerRef.UID == objectDeployment.ClientObject().GetUID() &&
-		equality.Semantic.DeepEqual(newObjectSet.GetSpecTemplateSpec(), conflictingObjectSet.GetSpecTemplateSpec()) {
+		equality.Semantic.DeepEqual(newObjectSet.GetSpecTemplateSpec(), conflictingObjectSet.GetSpecTemplateSpec()) &&
+		equality.Semantic.DeepEqual(newObjectSet.GetSpecServiceAccount(), conflictingObjectSet.GetSpecServiceAccount()) {
 		// This ObjectDeployment is controller of the conflicting ObjectSet and the ObjectSet is deep equal to the
 		// desired new ObjectSet. So no conflict :) This case can happen if the local cache is a little bit slow to
 		// record the ObjectSet Create event.
@@ -109,6 +110,7 @@ func (r *newRevisionReconciler) newObjectSetFromDeployment(
 	)
 	newObjectSet.SetSpecPreviousRevisions(prevObjectSets)
 	newObjectSet.SetSpecRevision(latestRevisionNumber(prevObjectSets) + 1)
+	newObjectSet.SetSpecServiceAccount(objectDeployment.GetSpecServiceAccount())
 
 	if newObjectSetClientObj.GetLabels() == nil {
 		newObjectSetClientObj.SetLabels(map[string]string{})
@@ -59,6 +59,7 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 		prevRevisions              []corev1alpha1.ObjectSet
 		deploymentGeneration       int64
 		deploymentHash             string
+		serviceAccount             *corev1alpha1.ServiceAccountReference
 		conflict                   bool
 		conflictObject             corev1alpha1.ObjectSet
 		expectedHashCollisionCount int
@@ -74,6 +75,7 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 			},
 			deploymentGeneration:       5,
 			deploymentHash:             "test1",
+			serviceAccount:             &corev1alpha1.ServiceAccountReference{Name: "tenant"},
 			conflict:                   false,
 			expectedHashCollisionCount: 0,
 		},
@@ -147,6 +149,7 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 				Phases: []corev1alpha1.ObjectSetTemplatePhase{{}},
 			})
 			objectDeployment.SetStatusTemplateHash(testCase.deploymentHash)
+			objectDeployment.SetSpecServiceAccount(testCase.serviceAccount)
 
 			// If conflict object is present
 			// make the client return an AlreadyExists error
@@ -221,6 +224,7 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 				mock.MatchedBy(func(item any) bool {
 					obj := item.(*corev1alpha1.ObjectSet)
 					requireObject(t, obj, testCase.deploymentHash, testCase.prevRevisions)
+					require.Equal(t, testCase.serviceAccount, obj.Spec.ServiceAccount)
 
 					return true
 				}),
@@ -13,9 +13,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/autoimpersonation"
 	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/controllers/boxcutterutil"
@@ -42,6 +44,10 @@ type ownerStrategy interface {
 	) handler.EventHandler
 }
 
+type eventSource interface {
+	Source(handler handler.EventHandler, predicates ...predicate.Predicate) source.Source
+}
+
 type teardownHandler interface {
 	Teardown(
 		ctx context.Context, objectSetPhase adapters.ObjectSetPhaseAccessor,
@@ -52,11 +58,12 @@ type teardownHandler interface {
 type GenericObjectSetPhaseController struct {
 	newObjectSetPhase adapters.ObjectSetPhaseFactory
 
+	name            string // Controller name, defaults to the lowercased kind.
 	class           string // Phase class this controller is operating for.
 	log             logr.Logger
 	scheme          *runtime.Scheme
 	client          client.Client // client to get and update ObjectSetPhases.
-	accessManager   managedcache.ObjectBoundAccessManager[client.Object]
+	eventSource     eventSource   // source of events for objects reconciled by phases.
 	ownerStrategy   ownerStrategy
 	teardownHandler teardownHandler
 
@@ -82,6 +89,8 @@ func NewMultiClusterObjectSetPhaseController(
 		discoveryClient,
 		targetRESTMapper,
 		validation.NewClusterPhaseValidator(targetRESTMapper, targetWriter),
+		// ServiceAccounts of the management cluster don't exist in the hosted cluster.
+		controllers.StaticCacheOwnerResolver{},
 	)
 }
 
@@ -104,6 +113,8 @@ func NewMultiClusterClusterObjectSetPhaseController(
 		discoveryClient,
 		targetRESTMapper,
 		validation.NewClusterPhaseValidator(targetRESTMapper, targetWriter),
+		// ServiceAccounts of the management cluster don't exist in the hosted cluster.
+		controllers.StaticCacheOwnerResolver{},
 	)
 }
 
@@ -125,6 +136,7 @@ func NewSameClusterObjectSetPhaseController(
 		discoveryClient,
 		restMapper,
 		validation.NewNamespacedPhaseValidator(restMapper, client),
+		autoimpersonation.NewResolver(client, scheme),
 	)
 }
 
@@ -146,7 +158,81 @@ func NewSameClusterClusterObjectSetPhaseController(
 		discoveryClient,
 		restMapper,
 		validation.NewNamespacedPhaseValidator(restMapper, client),
+		autoimpersonation.NewResolver(client, scheme),
+	)
+}
+
+// NewKubeconfigSecretObjectSetPhaseController reconciles ObjectSetPhases against the cluster
+// described by the kubeconfig Secret referenced via annotation on the phase or its namespace.
+func NewKubeconfigSecretObjectSetPhaseController(
+	log logr.Logger, scheme *runtime.Scheme,
+	class string,
+	client client.Client, // client to get and update ObjectSetPhases.
+	uncachedClient client.Reader, // client to get kubeconfig Secrets and Namespaces.
+) *GenericObjectSetPhaseController {
+	return newKubeconfigSecretObjectSetPhaseController(
+		"objectsetphase-kubeconfig-secret",
+		adapters.NewObjectSetPhaseAccessor,
+		adapters.NewObjectSet,
+		adapters.NewObjectSetList,
+		log, scheme, class,
+		client, uncachedClient, "",
+	)
+}
+
+// NewKubeconfigSecretClusterObjectSetPhaseController reconciles ClusterObjectSetPhases against the cluster
+// described by the kubeconfig Secret in the given namespace referenced via annotation on the phase.
+func NewKubeconfigSecretClusterObjectSetPhaseController(
+	log logr.Logger, scheme *runtime.Scheme,
+	class string,
+	client client.Client, // client to get and update ClusterObjectSetPhases.
+	uncachedClient client.Reader, // client to get kubeconfig Secrets.
+	namespace string, // namespace of kubeconfig Secrets.
+) *GenericObjectSetPhaseController {
+	return newKubeconfigSecretObjectSetPhaseController(
+		"clusterobjectsetphase-kubeconfig-secret",
+		adapters.NewClusterObjectSetPhaseAccessor,
+		adapters.NewClusterObjectSet,
+		adapters.NewClusterObjectSetList,
+		log, scheme, class,
+		client, uncachedClient, namespace,
+	)
+}
+
+func newKubeconfigSecretObjectSetPhaseController(
+	name string,
+	newObjectSetPhase adapters.ObjectSetPhaseFactory,
+	newObjectSet adapters.ObjectSetAccessorFactory,
+	newObjectSetList adapters.ObjectSetListAccessorFactory,
+	log logr.Logger, scheme *runtime.Scheme,
+	class string,
+	client client.Client,
+	uncachedClient client.Reader,
+	namespace string,
+) *GenericObjectSetPhaseController {
+	ownerStrategy := ownerhandling.NewAnnotation(scheme, constants.OwnerStrategyAnnotationKey)
+	siblingLookup := controllers.NewSiblingOwnerLookup(scheme, client, newObjectSet, newObjectSetList)
+	targetClusters := newTargetClusterPool(
+		log, uncachedClient, namespace,
+		newTargetClusterFactory(log, scheme, siblingLookup.ClassifierForObjectSetPhase, ownerStrategy),
 	)
+
+	return &GenericObjectSetPhaseController{
+		newObjectSetPhase: newObjectSetPhase,
+
+		name:   name,
+		class:  class,
+		log:    log,
+		scheme: scheme,
+
+		client:          client,
+		ownerStrategy:   ownerStrategy,
+		eventSource:     targetClusters,
+		teardownHandler: targetClusters,
+		reconciler: []reconciler{
+			targetClusters,
+		},
+	}
 }
 
 func NewGenericObjectSetPhaseController(
@@ -161,6 +247,7 @@ func NewGenericObjectSetPhaseController(
 	discoveryClient boxcutterutil.DiscoveryClient,
 	targetRESTMapper meta.RESTMapper,
 	phaseValidator *validation.PhaseValidator,
+	cacheOwner controllers.CacheOwnerResolver, // resolves the cache to reconcile objects with.
 ) *GenericObjectSetPhaseController {
 	controller := &GenericObjectSetPhaseController{
 		newObjectSetPhase: newObjectSetPhase,
@@ -171,7 +258,7 @@ func NewGenericObjectSetPhaseController(
 
 		client:        client,
 		ownerStrategy: ownerStrategy,
-		accessManager: accessManager,
+		eventSource:   accessManager,
 	}
 	siblingLookup := controllers.NewSiblingOwnerLookup(scheme, client, newObjectSet, newObjectSetList)
 	phaseReconciler := newObjectSetPhaseReconciler(
@@ -181,6 +268,7 @@ func NewGenericObjectSetPhaseController(
 			scheme, discoveryClient, targetRESTMapper, phaseValidator),
 		siblingLookup.ClassifierForObjectSetPhase,
 		ownerStrategy,
+		withCacheOwnerResolver{CacheOwner: cacheOwner},
 	)
 	controller.teardownHandler = phaseReconciler
 	controller.reconciler = []reconciler{
@@ -293,10 +381,15 @@ func (c *GenericObjectSetPhaseController) SetupWithManager(
 ) error {
 	objectSetPhase := c.newObjectSetPhase(c.scheme).ClientObject()
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr)
+	if len(c.name) > 0 {
+		// Multiple controllers for the same kind need unique names.
+		b = b.Named(c.name)
+	}
+	return b.
 		For(objectSetPhase).
 		WatchesRawSource(
-			c.accessManager.Source(
+			c.eventSource.Source(
 				c.ownerStrategy.EnqueueRequestForOwner(objectSetPhase, mgr.GetRESTMapper(), false),
 				predicate.NewPredicateFuncs(func(object client.Object) bool {
 					c.log.V(constants.LogLevelDebug).Info(
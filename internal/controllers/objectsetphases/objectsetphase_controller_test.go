@@ -63,7 +63,7 @@ func newControllerAndMocks() (
 		scheme: scheme,
 
 		client:        c,
-		accessManager: accessManager,
+		eventSource:   accessManager,
 		ownerStrategy: ownerhandling.NewNative(scheme),
 	}
 
@@ -317,4 +317,24 @@ func TestInitializers(t *testing.T) {
 
 		require.NotNil(t, ctrl)
 	})
+
+	t.Run("NewKubeconfigSecretObjectSetPhaseController", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := NewKubeconfigSecretObjectSetPhaseController(
+			log, scheme, class, client, client,
+		)
+
+		require.NotNil(t, ctrl)
+	})
+
+	t.Run("NewKubeconfigSecretClusterObjectSetPhaseController", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := NewKubeconfigSecretClusterObjectSetPhaseController(
+			log, scheme, class, client, client, "pko-system",
+		)
+
+		require.NotNil(t, ctrl)
+	})
 }
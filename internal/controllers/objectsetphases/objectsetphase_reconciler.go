@@ -36,6 +36,7 @@ type objectSetPhaseReconciler struct {
 	phaseEngineFactory           boxcutterutil.PhaseEngineFactory
 	lookupSiblingOwnerClassifier lookupSiblingOwnerClassifierFunc
 	ownerStrategy                boxcutterutil.OwnerStrategy
+	cacheOwner                   controllers.CacheOwnerResolver
 	backoff                      *flowcontrol.Backoff
 }
 
@@ -45,9 +46,11 @@ func newObjectSetPhaseReconciler(
 	phaseEngineFactory boxcutterutil.PhaseEngineFactory,
 	lookupSiblingOwnerClassifier lookupSiblingOwnerClassifierFunc,
 	ownerStrategy boxcutterutil.OwnerStrategy,
+	opts ...objectSetPhaseReconcilerOption,
 ) *objectSetPhaseReconciler {
 	var cfg objectSetPhaseReconcilerConfig
 
+	cfg.Option(opts...)
 	cfg.Default()
 
 	return &objectSetPhaseReconciler{
@@ -56,6 +59,7 @@ func newObjectSetPhaseReconciler(
 		phaseEngineFactory:           phaseEngineFactory,
 		lookupSiblingOwnerClassifier: lookupSiblingOwnerClassifier,
 		ownerStrategy:                ownerStrategy,
+		cacheOwner:                   cfg.CacheOwner,
 		backoff:                      cfg.GetBackoff(),
 	}
 }
@@ -84,9 +88,13 @@ func (r *objectSetPhaseReconciler) Reconcile(
 	for _, object := range objectSetPhase.GetPhase().Objects {
 		objectsInPhase = append(objectsInPhase, &object.Object)
 	}
+	cacheOwner, err := r.cacheOwner.CacheOwner(ctx, objectSetPhase.ClientObject())
+	if err != nil {
+		return res, err
+	}
 	cache, err := r.accessManager.GetWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner,
 		objectSetPhase.ClientObject(),
 		objectsInPhase,
 	)
@@ -201,9 +209,13 @@ func (r *objectSetPhaseReconciler) Teardown(
 	for _, object := range objectSetPhase.GetPhase().Objects {
 		objectsInPhase = append(objectsInPhase, &object.Object)
 	}
+	cacheOwner, err := r.cacheOwner.CacheOwner(ctx, objectSetPhase.ClientObject())
+	if err != nil {
+		return false, err
+	}
 	cache, err := r.accessManager.GetWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner,
 		objectSetPhase.ClientObject(),
 		objectsInPhase,
 	)
@@ -244,7 +256,7 @@ func (r *objectSetPhaseReconciler) Teardown(
 
 	if err := r.accessManager.FreeWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner,
 		objectSetPhase.ClientObject(),
 	); err != nil {
 		return false, fmt.Errorf("freewithuser: %w", err)
@@ -255,6 +267,9 @@ func (r *objectSetPhaseReconciler) Teardown(
 
 type objectSetPhaseReconcilerConfig struct {
 	controllers.BackoffConfig
+
+	// Resolves the owner of the cache objects are reconciled with.
+	CacheOwner controllers.CacheOwnerResolver
 }
 
 func (c *objectSetPhaseReconcilerConfig) Option(opts ...objectSetPhaseReconcilerOption) {
@@ -264,6 +279,10 @@ func (c *objectSetPhaseReconcilerConfig) Option(opts ...objectSetPhaseReconciler
 }
 
 func (c *objectSetPhaseReconcilerConfig) Default() {
+	if c.CacheOwner == nil {
+		c.CacheOwner = controllers.StaticCacheOwnerResolver{}
+	}
+
 	c.BackoffConfig.Default()
 }
 
@@ -271,6 +290,14 @@ type objectSetPhaseReconcilerOption interface {
 	ConfigureObjectSetPhaseReconciler(*objectSetPhaseReconcilerConfig)
 }
 
+type withCacheOwnerResolver struct {
+	CacheOwner controllers.CacheOwnerResolver
+}
+
+func (w withCacheOwnerResolver) ConfigureObjectSetPhaseReconciler(c *objectSetPhaseReconcilerConfig) {
+	c.CacheOwner = w.CacheOwner
+}
+
 // Convert a  kubernetes object to an unstructured object.
 func convertToUnstructured(obj machinery.Object) *unstructured.Unstructured {
 	return obj.(*unstructured.Unstructured)
@@ -13,11 +13,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"pkg.package-operator.run/boxcutter/machinery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/autoimpersonation"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/testutil"
 	"package-operator.run/internal/testutil/boxcuttermocks"
@@ -159,6 +161,68 @@ func TestPhaseReconciler_ReconcileBackoff(t *testing.T) {
 	}, res)
 }
 
+func TestPhaseReconciler_ReconcileAsServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	lookup := func(
+		_ context.Context, _ adapters.ObjectSetPhaseAccessor,
+	) (
+		controllers.SiblingOwnerClassifier, error,
+	) {
+		return func(metav1.OwnerReference) bool { return false }, nil
+	}
+
+	objectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "test", UID: "tenant-uid"},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "tenant"},
+		},
+		Status: corev1alpha1.ObjectSetStatus{
+			RemotePhases: []corev1alpha1.RemotePhaseReference{{Name: "tenant-phase", UID: "tenant-phase-uid"}},
+		},
+	}
+	objectSetPhase := adapters.NewObjectSetPhaseAccessor(scheme)
+	objectSetPhase.ClientObject().SetName("tenant-phase")
+	objectSetPhase.ClientObject().SetNamespace("test")
+	objectSetPhase.ClientObject().SetUID("tenant-phase-uid")
+	require.NoError(t, controllerutil.SetControllerReference(objectSet, objectSetPhase.ClientObject(), scheme))
+
+	accessManager := &managedcachemocks.ObjectBoundAccessManagerMock[client.Object]{}
+	accessor := &managedcachemocks.AccessorMock{}
+	phaseEngineFactory := &boxcuttermocks.PhaseEngineFactoryMock{}
+	phaseEngine := &boxcuttermocks.PhaseEngineMock{}
+	phaseResult := &boxcuttermocks.PhaseResultMock{}
+	ownerStrategy := &ownerhandlingmocks.OwnerStrategyMock{}
+	r := newObjectSetPhaseReconciler(testScheme, accessManager,
+		phaseEngineFactory, lookup, ownerStrategy,
+		withCacheOwnerResolver{
+			CacheOwner: autoimpersonation.NewResolver(
+				fake.NewClientBuilder().WithScheme(scheme).WithObjects(objectSet).Build(), scheme),
+		})
+
+	accessManager.On("GetWithUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(accessor, nil)
+	phaseEngineFactory.On("New", accessor).Return(phaseEngine, nil)
+	phaseEngine.
+		On("Reconcile", mock.Anything, objectSetPhase.GetStatusRevision(),
+			mock.Anything, mock.Anything).
+		Return(phaseResult, nil).
+		Once()
+	phaseResult.On("GetObjects").Return([]machinery.ObjectResult{})
+	phaseResult.On("IsComplete").Return(true)
+	phaseResult.On("String").Return("")
+
+	_, err := r.Reconcile(context.Background(), objectSetPhase)
+	require.NoError(t, err)
+
+	accessManager.AssertCalled(t, "GetWithUser", mock.Anything,
+		mock.MatchedBy(func(owner client.Object) bool {
+			sa, ok := owner.(*corev1.ServiceAccount)
+			return ok && sa.Name == "tenant" && sa.Namespace == "test"
+		}), objectSetPhase.ClientObject(), mock.Anything)
+}
+
 func TestPhaseReconciler_Teardown(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,374 @@
+package objectsetphases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"pkg.package-operator.run/boxcutter/managedcache"
+	"pkg.package-operator.run/boxcutter/validation"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/constants"
+	"package-operator.run/internal/controllers/boxcutterutil"
+	internalkubeconfig "package-operator.run/internal/kubeconfig"
+)
+
+var (
+	errTargetClusterPoolNotStarted = errors.New("target cluster pool not started")
+	errNoKubeconfigSecretReference = fmt.Errorf("no target cluster kubeconfig Secret referenced via %q annotation",
+		corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation)
+)
+
+// Reason of the Available condition of phases torn down without access to their target cluster.
+const targetClusterGoneReason = "TargetClusterGone"
+
+type phaseReconciler interface {
+	reconciler
+	teardownHandler
+}
+
+// targetCluster bundles caches and reconciler of a cluster phases are reconciled against.
+type targetCluster struct {
+	accessManager   managedcache.ObjectBoundAccessManager[client.Object]
+	phaseReconciler phaseReconciler
+}
+
+// targetClusterFactory creates a targetCluster for the cluster described by the given kubeconfig.
+type targetClusterFactory func(kubeconfig []byte) (*targetCluster, error)
+
+func newTargetClusterFactory(
+	log logr.Logger, scheme *runtime.Scheme,
+	lookupSiblingOwnerClassifier lookupSiblingOwnerClassifierFunc,
+	ownerStrategy boxcutterutil.OwnerStrategy,
+) targetClusterFactory {
+	return func(kubeconfig []byte) (*targetCluster, error) {
+		cfg, err := internalkubeconfig.RESTConfig(kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		httpClient, err := rest.HTTPClientFor(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("building http client for kubeconfig: %w", err)
+		}
+		mapper, err := apiutil.NewDynamicRESTMapper(cfg, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("creating target cluster rest mapper: %w", err)
+		}
+		targetClient, err := client.New(cfg, client.Options{
+			HTTPClient: httpClient,
+			Scheme:     scheme,
+			Mapper:     mapper,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating target cluster client: %w", err)
+		}
+		discoveryClient, err := discovery.NewDiscoveryClientForConfigAndClient(cfg, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("creating target cluster discovery client: %w", err)
+		}
+
+		cacheMapper := func(
+			_ context.Context, _ client.Object,
+			c *rest.Config, o cache.Options,
+		) (*rest.Config, cache.Options, error) {
+			return c, o, nil
+		}
+		accessManager := managedcache.NewObjectBoundAccessManager(
+			log, cacheMapper, cfg,
+			cache.Options{
+				Scheme: scheme,
+				Mapper: mapper,
+				DefaultLabelSelector: labels.SelectorFromSet(labels.Set{
+					constants.DynamicCacheLabel: "True",
+				}),
+			},
+		)
+
+		return &targetCluster{
+			accessManager: accessManager,
+			phaseReconciler: newObjectSetPhaseReconciler(
+				scheme,
+				accessManager,
+				boxcutterutil.NewPhaseEngineFactory(
+					scheme, discoveryClient, mapper,
+					validation.NewClusterPhaseValidator(mapper, targetClient)),
+				lookupSiblingOwnerClassifier,
+				ownerStrategy,
+			),
+		}, nil
+	}
+}
+
+// targetClusterPool reconciles phases against the cluster referenced by their kubeconfig Secret.
+// Caches and clients of target clusters are shared by all phases referencing the same Secret,
+// recreated when the Secret changes and stopped when the last phase using them is torn down.
+type targetClusterPool struct {
+	log              logr.Logger
+	uncachedClient   client.Reader // client to get kubeconfig Secrets and Namespaces.
+	namespace        string        // namespace of kubeconfig Secrets referenced by cluster-scoped phases.
+	newTargetCluster targetClusterFactory
+
+	lock     sync.Mutex
+	ctx      context.Context // lifetime of event sources, parent of target cluster contexts.
+	sources  []targetClusterSource
+	clusters map[types.UID]*pooledTargetCluster
+}
+
+type pooledTargetCluster struct {
+	*targetCluster
+
+	secretUID       types.UID
+	resourceVersion string
+	ctx             context.Context
+	cancel          context.CancelFunc
+	users           map[types.UID]struct{}
+}
+
+// targetClusterSource is an event source to start on every target cluster.
+type targetClusterSource struct {
+	handler    handler.EventHandler
+	predicates []predicate.Predicate
+	queue      workqueue.TypedRateLimitingInterface[reconcile.Request]
+}
+
+func newTargetClusterPool(
+	log logr.Logger, uncachedClient client.Reader, namespace string,
+	newTargetCluster targetClusterFactory,
+) *targetClusterPool {
+	return &targetClusterPool{
+		log:              log,
+		uncachedClient:   uncachedClient,
+		namespace:        namespace,
+		newTargetCluster: newTargetCluster,
+		clusters:         map[types.UID]*pooledTargetCluster{},
+	}
+}
+
+func (p *targetClusterPool) Reconcile(
+	ctx context.Context, objectSetPhase adapters.ObjectSetPhaseAccessor,
+) (ctrl.Result, error) {
+	cluster, err := p.get(ctx, objectSetPhase)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return cluster.phaseReconciler.Reconcile(ctx, objectSetPhase)
+}
+
+func (p *targetClusterPool) Teardown(
+	ctx context.Context, objectSetPhase adapters.ObjectSetPhaseAccessor,
+) (cleanupDone bool, err error) {
+	cluster, err := p.get(ctx, objectSetPhase)
+	if errors.Is(err, errNoKubeconfigSecretReference) || apierrors.IsNotFound(err) {
+		// The target cluster can't be reached anymore, don't block deletion forever.
+		// Objects on the target cluster are orphaned.
+		p.releaseAll(objectSetPhase.ClientObject().GetUID())
+		logr.FromContextOrDiscard(ctx).Info("orphaning objects on target cluster, kubeconfig Secret is gone",
+			"reason", err.Error())
+		meta.SetStatusCondition(objectSetPhase.GetStatusConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPhaseAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: objectSetPhase.GetGeneration(),
+			Reason:             targetClusterGoneReason,
+			Message:            "Objects on the target cluster were orphaned: " + err.Error(),
+		})
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	done, err := cluster.phaseReconciler.Teardown(ctx, objectSetPhase)
+	if err != nil || !done {
+		return done, err
+	}
+
+	p.release(cluster.secretUID, objectSetPhase.ClientObject().GetUID())
+	return true, nil
+}
+
+// Source returns an event source for objects on all target clusters.
+// Caches of target clusters are running as long as the returned source.
+func (p *targetClusterPool) Source(
+	handler handler.EventHandler, predicates ...predicate.Predicate,
+) source.Source {
+	return source.Func(func(
+		ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request],
+	) error {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		if p.ctx == nil {
+			p.ctx = ctx
+		}
+		src := targetClusterSource{
+			handler:    handler,
+			predicates: predicates,
+			queue:      queue,
+		}
+		p.sources = append(p.sources, src)
+		for _, cluster := range p.clusters {
+			if err := cluster.startSource(src); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// get returns the target cluster of the given phase and registers the phase as its user.
+func (p *targetClusterPool) get(
+	ctx context.Context, objectSetPhase adapters.ObjectSetPhaseAccessor,
+) (*pooledTargetCluster, error) {
+	secret, err := p.getKubeconfigSecret(ctx, objectSetPhase)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.ctx == nil {
+		return nil, errTargetClusterPoolNotStarted
+	}
+
+	users := map[types.UID]struct{}{}
+	if cached, ok := p.clusters[secret.UID]; ok {
+		if cached.resourceVersion == secret.ResourceVersion {
+			cached.users[objectSetPhase.ClientObject().GetUID()] = struct{}{}
+			return cached, nil
+		}
+		// Kubeconfig changed, recreate clients and caches.
+		cached.cancel()
+		delete(p.clusters, secret.UID)
+		users = cached.users
+	}
+
+	kubeconfig, ok := secret.Data[corev1alpha1.ClusterKubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("missing key %q in Secret %s/%s",
+			corev1alpha1.ClusterKubeconfigSecretKey, secret.Namespace, secret.Name)
+	}
+	tc, err := p.newTargetCluster(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating target cluster for Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	clusterCtx, cancel := context.WithCancel(p.ctx)
+	cluster := &pooledTargetCluster{
+		targetCluster:   tc,
+		secretUID:       secret.UID,
+		resourceVersion: secret.ResourceVersion,
+		ctx:             clusterCtx,
+		cancel:          cancel,
+		users:           users,
+	}
+	go func() {
+		if err := tc.accessManager.Start(clusterCtx); err != nil {
+			p.log.Error(err, "running target cluster caches",
+				"secret", client.ObjectKeyFromObject(secret))
+		}
+	}()
+	for _, src := range p.sources {
+		if err := cluster.startSource(src); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	cluster.users[objectSetPhase.ClientObject().GetUID()] = struct{}{}
+	p.clusters[secret.UID] = cluster
+	return cluster, nil
+}
+
+// release unregisters the given phase from the target cluster,
+// stopping its caches when no other phase is using them.
+func (p *targetClusterPool) release(secretUID, phaseUID types.UID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	cluster, ok := p.clusters[secretUID]
+	if !ok {
+		return
+	}
+	delete(cluster.users, phaseUID)
+	if len(cluster.users) > 0 {
+		return
+	}
+	cluster.cancel()
+	delete(p.clusters, secretUID)
+}
+
+// releaseAll unregisters the given phase from all target clusters,
+// stopping caches no other phase is using.
+func (p *targetClusterPool) releaseAll(phaseUID types.UID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for secretUID, cluster := range p.clusters {
+		delete(cluster.users, phaseUID)
+		if len(cluster.users) > 0 {
+			continue
+		}
+		cluster.cancel()
+		delete(p.clusters, secretUID)
+	}
+}
+
+// getKubeconfigSecret looks up the kubeconfig Secret referenced by the namespace of the phase.
+// Cluster-scoped phases reference Secrets in the namespace of Package Operator.
+// Annotations of namespaced phases are ignored, as they are controlled by tenants
+// via their ObjectSets, while Namespace annotations are set by administrators.
+func (p *targetClusterPool) getKubeconfigSecret(
+	ctx context.Context, objectSetPhase adapters.ObjectSetPhaseAccessor,
+) (*corev1.Secret, error) {
+	obj := objectSetPhase.ClientObject()
+	key := client.ObjectKey{Namespace: obj.GetNamespace()}
+	if len(key.Namespace) == 0 {
+		key.Namespace = p.namespace
+		key.Name = obj.GetAnnotations()[corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation]
+	} else {
+		ns := &corev1.Namespace{}
+		if err := p.uncachedClient.Get(ctx, client.ObjectKey{Name: key.Namespace}, ns); err != nil {
+			return nil, fmt.Errorf("getting Namespace: %w", err)
+		}
+		key.Name = ns.Annotations[corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation]
+	}
+	if len(key.Name) == 0 {
+		return nil, errNoKubeconfigSecretReference
+	}
+
+	secret := &corev1.Secret{}
+	if err := p.uncachedClient.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("getting kubeconfig Secret: %w", err)
+	}
+	return secret, nil
+}
+
+func (c *pooledTargetCluster) startSource(src targetClusterSource) error {
+	if err := c.accessManager.Source(src.handler, src.predicates...).Start(c.ctx, src.queue); err != nil {
+		return fmt.Errorf("starting target cluster event source: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package objectsetphases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/testutil/managedcachemocks"
+)
+
+func newTargetClusterTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, corev1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newKubeconfigSecret(namespace, name, resourceVersion string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			UID:             types.UID("uid-" + namespace + "-" + name),
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string][]byte{
+			corev1alpha1.ClusterKubeconfigSecretKey: []byte("kubeconfig-" + name),
+		},
+	}
+}
+
+func TestTargetClusterPool_getKubeconfigSecret(t *testing.T) {
+	t.Parallel()
+
+	scheme := newTargetClusterTestScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "annotated",
+					Annotations: map[string]string{
+						corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "ns-cluster",
+					},
+				},
+			},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+			newKubeconfigSecret("annotated", "ns-cluster", ""),
+			newKubeconfigSecret("annotated", "phase-cluster", ""),
+			newKubeconfigSecret("pko-system", "cluster-phase-cluster", ""),
+		).
+		Build()
+	pool := newTargetClusterPool(testr.New(t), c, "pko-system", nil)
+
+	tests := []struct {
+		name           string
+		objectSetPhase adapters.ObjectSetPhaseAccessor
+		expectedSecret client.ObjectKey
+		expectedErr    string
+	}{
+		{
+			name: "phase annotation ignored",
+			objectSetPhase: &adapters.ObjectSetPhaseAdapter{
+				ObjectSetPhase: corev1alpha1.ObjectSetPhase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "phase", Namespace: "annotated",
+						Annotations: map[string]string{
+							corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "phase-cluster",
+						},
+					},
+				},
+			},
+			expectedSecret: client.ObjectKey{Namespace: "annotated", Name: "ns-cluster"},
+		},
+		{
+			name: "namespace annotation",
+			objectSetPhase: &adapters.ObjectSetPhaseAdapter{
+				ObjectSetPhase: corev1alpha1.ObjectSetPhase{
+					ObjectMeta: metav1.ObjectMeta{Name: "phase", Namespace: "annotated"},
+				},
+			},
+			expectedSecret: client.ObjectKey{Namespace: "annotated", Name: "ns-cluster"},
+		},
+		{
+			name: "cluster-scoped phase",
+			objectSetPhase: &adapters.ClusterObjectSetPhaseAdapter{
+				ClusterObjectSetPhase: corev1alpha1.ClusterObjectSetPhase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "phase",
+						Annotations: map[string]string{
+							corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "cluster-phase-cluster",
+						},
+					},
+				},
+			},
+			expectedSecret: client.ObjectKey{Namespace: "pko-system", Name: "cluster-phase-cluster"},
+		},
+		{
+			name: "no annotation",
+			objectSetPhase: &adapters.ObjectSetPhaseAdapter{
+				ObjectSetPhase: corev1alpha1.ObjectSetPhase{
+					ObjectMeta: metav1.ObjectMeta{Name: "phase", Namespace: "plain"},
+				},
+			},
+			expectedErr: `no target cluster kubeconfig Secret referenced via ` +
+				`"package-operator.run/target-cluster-kubeconfig-secret" annotation`,
+		},
+		{
+			name: "phase annotation without namespace annotation",
+			objectSetPhase: &adapters.ObjectSetPhaseAdapter{
+				ObjectSetPhase: corev1alpha1.ObjectSetPhase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "phase", Namespace: "plain",
+						Annotations: map[string]string{
+							corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "phase-cluster",
+						},
+					},
+				},
+			},
+			expectedErr: `no target cluster kubeconfig Secret referenced via ` +
+				`"package-operator.run/target-cluster-kubeconfig-secret" annotation`,
+		},
+		{
+			name: "missing Secret",
+			objectSetPhase: &adapters.ClusterObjectSetPhaseAdapter{
+				ClusterObjectSetPhase: corev1alpha1.ClusterObjectSetPhase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "phase",
+						Annotations: map[string]string{
+							corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "missing",
+						},
+					},
+				},
+			},
+			expectedErr: `getting kubeconfig Secret: secrets "missing" not found`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			secret, err := pool.getKubeconfigSecret(context.Background(), test.objectSetPhase)
+			if len(test.expectedErr) > 0 {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSecret, client.ObjectKeyFromObject(secret))
+		})
+	}
+}
+
+func TestTargetClusterPool(t *testing.T) {
+	t.Parallel()
+
+	secret := newKubeconfigSecret("test", "cluster", "1")
+	c := fake.NewClientBuilder().
+		WithScheme(newTargetClusterTestScheme(t)).
+		WithObjects(secret, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				Annotations: map[string]string{
+					corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "cluster",
+				},
+			},
+		}).
+		Build()
+
+	objectSetPhase := &adapters.ObjectSetPhaseAdapter{
+		ObjectSetPhase: corev1alpha1.ObjectSetPhase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "phase", Namespace: "test", UID: "phase-uid",
+			},
+		},
+	}
+
+	var (
+		kubeconfigs    []string
+		startedSources int
+		reconcilers    []*objectSetPhaseReconcilerMock
+	)
+	pool := newTargetClusterPool(testr.New(t), c, "", func(kubeconfig []byte) (*targetCluster, error) {
+		kubeconfigs = append(kubeconfigs, string(kubeconfig))
+
+		accessManager := &managedcachemocks.ObjectBoundAccessManagerMock[client.Object]{}
+		accessManager.On("Start", mock.Anything).Return(nil).Maybe()
+		accessManager.On("Source", mock.Anything, mock.Anything).
+			Return(source.Func(func(
+				context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request],
+			) error {
+				startedSources++
+				return nil
+			}))
+
+		pr := &objectSetPhaseReconcilerMock{}
+		pr.On("Reconcile", mock.Anything, mock.Anything).Return(ctrl.Result{}, nil)
+		pr.On("Teardown", mock.Anything, mock.Anything).Return(true, nil)
+		reconcilers = append(reconcilers, pr)
+
+		return &targetCluster{accessManager: accessManager, phaseReconciler: pr}, nil
+	})
+	ctx := context.Background()
+
+	// Not started.
+	_, err := pool.Reconcile(ctx, objectSetPhase)
+	require.ErrorIs(t, err, errTargetClusterPoolNotStarted)
+
+	require.NoError(t, pool.Source(&handler.EnqueueRequestForObject{}).Start(ctx, nil))
+
+	// Cluster created and reused.
+	for range 2 {
+		_, err = pool.Reconcile(ctx, objectSetPhase)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"kubeconfig-cluster"}, kubeconfigs)
+	assert.Equal(t, 1, startedSources)
+	reconcilers[0].AssertNumberOfCalls(t, "Reconcile", 2)
+
+	// Secret changed.
+	secret.Data[corev1alpha1.ClusterKubeconfigSecretKey] = []byte("kubeconfig-rotated")
+	require.NoError(t, c.Update(ctx, secret))
+	_, err = pool.Reconcile(ctx, objectSetPhase)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kubeconfig-cluster", "kubeconfig-rotated"}, kubeconfigs)
+	assert.Equal(t, 2, startedSources)
+	reconcilers[1].AssertNumberOfCalls(t, "Reconcile", 1)
+
+	// Last user torn down.
+	done, err := pool.Teardown(ctx, objectSetPhase)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Empty(t, pool.clusters)
+}
+
+func TestTargetClusterPool_Teardown_secretGone(t *testing.T) {
+	t.Parallel()
+
+	secret := newKubeconfigSecret("test", "cluster", "1")
+	c := fake.NewClientBuilder().
+		WithScheme(newTargetClusterTestScheme(t)).
+		WithObjects(secret, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				Annotations: map[string]string{
+					corev1alpha1.ObjectSetPhaseTargetClusterSecretAnnotation: "cluster",
+				},
+			},
+		}).
+		Build()
+
+	objectSetPhase := &adapters.ObjectSetPhaseAdapter{
+		ObjectSetPhase: corev1alpha1.ObjectSetPhase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "phase", Namespace: "test", UID: "phase-uid", Generation: 3,
+			},
+		},
+	}
+
+	pr := &objectSetPhaseReconcilerMock{}
+	pr.On("Reconcile", mock.Anything, mock.Anything).Return(ctrl.Result{}, nil)
+	pool := newTargetClusterPool(testr.New(t), c, "", func([]byte) (*targetCluster, error) {
+		accessManager := &managedcachemocks.ObjectBoundAccessManagerMock[client.Object]{}
+		accessManager.On("Start", mock.Anything).Return(nil).Maybe()
+		accessManager.On("Source", mock.Anything, mock.Anything).
+			Return(source.Func(func(
+				context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request],
+			) error {
+				return nil
+			}))
+		return &targetCluster{accessManager: accessManager, phaseReconciler: pr}, nil
+	})
+	ctx := context.Background()
+	require.NoError(t, pool.Source(&handler.EnqueueRequestForObject{}).Start(ctx, nil))
+
+	_, err := pool.Reconcile(ctx, objectSetPhase)
+	require.NoError(t, err)
+	require.Len(t, pool.clusters, 1)
+
+	require.NoError(t, c.Delete(ctx, secret))
+	done, err := pool.Teardown(ctx, objectSetPhase)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Empty(t, pool.clusters)
+	pr.AssertNotCalled(t, "Teardown", mock.Anything, mock.Anything)
+
+	cond := meta.FindStatusCondition(*objectSetPhase.GetStatusConditions(), corev1alpha1.ObjectSetPhaseAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, targetClusterGoneReason, cond.Reason)
+	assert.Equal(t, int64(3), cond.ObservedGeneration)
+}
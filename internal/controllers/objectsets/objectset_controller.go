@@ -18,8 +18,11 @@ import (
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/autoimpersonation"
 	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
+	"package-operator.run/internal/environment"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/preflight"
 
@@ -39,9 +42,13 @@ type GenericObjectSetController struct {
 
 	recorder        metricsRecorder
 	accessManager   managedcache.ObjectBoundAccessManager[client.Object]
+	cacheOwner      controllers.CacheOwnerResolver
 	teardownHandler teardownHandler
+	apiDeprecation  *preflight.APIDeprecation
 }
 
+var _ environment.Sinker = (*GenericObjectSetController)(nil)
+
 type reconciler interface {
 	Reconcile(ctx context.Context, objectSet adapters.ObjectSetAccessor) (ctrl.Result, error)
 }
@@ -95,15 +102,19 @@ func newGenericObjectSetController(
 	accessManager managedcache.ObjectBoundAccessManager[client.Object], uncachedClient client.Reader,
 	recorder metricsRecorder, restMapper meta.RESTMapper,
 ) *GenericObjectSetController {
+	// Objects are reconciled as the ServiceAccount of their owning tree, if set.
+	impersonation := autoimpersonation.NewResolver(client, scheme)
 	controller := &GenericObjectSetController{
 		newObjectSet:      newObjectSet,
 		newObjectSetPhase: newObjectSetPhase,
 
-		client:        client,
-		log:           log,
-		scheme:        scheme,
-		accessManager: accessManager,
-		recorder:      recorder,
+		client:         client,
+		log:            log,
+		scheme:         scheme,
+		accessManager:  accessManager,
+		cacheOwner:     impersonation,
+		recorder:       recorder,
+		apiDeprecation: preflight.NewAPIDeprecation(),
 	}
 
 	phasesReconciler := newObjectSetPhasesReconciler(
@@ -129,7 +140,10 @@ func newGenericObjectSetController(
 			}, client).Lookup,
 		preflight.PhasesCheckerList{
 			preflight.NewObjectDuplicate(),
+			controller.apiDeprecation,
 		},
+		preflight.NewRBAC(client, restMapper, impersonation),
+		withCacheOwnerResolver{CacheOwner: impersonation},
 	)
 
 	controller.teardownHandler = phasesReconciler
@@ -147,6 +161,10 @@ func newGenericObjectSetController(
 	return controller
 }
 
+func (c *GenericObjectSetController) SetEnvironment(env *manifests.PackageEnvironment) {
+	c.apiDeprecation.SetEnvironment(env)
+}
+
 func (c *GenericObjectSetController) SetupWithManager(mgr ctrl.Manager) error {
 	objectSet := c.newObjectSet(c.scheme).ClientObject()
 	objectSetPhase := c.newObjectSetPhase(c.scheme).ClientObject()
@@ -366,7 +384,11 @@ func (c *GenericObjectSetController) handleDeletionAndArchival(
 		return nil
 	}
 
-	if err := c.accessManager.FreeWithUser(ctx, constants.StaticCacheOwner(), objectSet.ClientObject()); err != nil {
+	cacheOwner, err := c.cacheOwner.CacheOwner(ctx, objectSet.ClientObject())
+	if err != nil {
+		return err
+	}
+	if err := c.accessManager.FreeWithUser(ctx, cacheOwner, objectSet.ClientObject()); err != nil {
 		return fmt.Errorf("freeing cache: %w", err)
 	}
 
@@ -451,6 +451,7 @@ func newControllerAndMocks() (
 		log:               ctrl.Log.WithName("controllers"),
 		scheme:            scheme,
 		accessManager:     accessManager,
+		cacheOwner:        controllers.StaticCacheOwnerResolver{},
 	}
 	pr := &controllersmocks.ObjectSetPhasesReconcilerMock{}
 
@@ -18,7 +18,6 @@ import (
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
-	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/preflight"
 	internalprobing "package-operator.run/internal/probing"
@@ -37,6 +36,7 @@ type objectSetPhasesReconciler struct {
 	lookupPreviousRevisions lookupPreviousRevisions
 	ownerStrategy           ownerStrategy
 	preflightChecker        phasesChecker
+	accessReviewChecker     phasesChecker
 	backoff                 *flowcontrol.Backoff
 }
 
@@ -58,6 +58,7 @@ func newObjectSetPhasesReconciler(
 	remotePhase remotePhaseReconciler,
 	lookupPreviousRevisions lookupPreviousRevisions,
 	checker phasesChecker,
+	accessReviewChecker phasesChecker,
 	opts ...objectSetPhasesReconcilerOption,
 ) *objectSetPhasesReconciler {
 	var cfg objectSetPhasesReconcilerConfig
@@ -74,6 +75,7 @@ func newObjectSetPhasesReconciler(
 		lookupPreviousRevisions: lookupPreviousRevisions,
 		ownerStrategy:           ownerhandling.NewNative(scheme),
 		preflightChecker:        checker,
+		accessReviewChecker:     accessReviewChecker,
 		backoff:                 cfg.GetBackoff(),
 	}
 }
@@ -93,6 +95,54 @@ type lookupPreviousRevisions func(
 	ctx context.Context, owner controllers.PreviousOwner,
 ) ([]controllers.PreviousObjectSet, error)
 
+// Access reviews only need to pass once per generation.
+// Checking again on every reconcile would send access reviews for every object to the API server.
+func permissionsVerified(objectSet adapters.ObjectSetAccessor) bool {
+	verified := meta.FindStatusCondition(*objectSet.GetStatusConditions(), corev1alpha1.ObjectSetPermissionsVerified)
+	return verified != nil &&
+		verified.Status == metav1.ConditionTrue &&
+		verified.ObservedGeneration == objectSet.ClientObject().GetGeneration()
+}
+
+func (r *objectSetPhasesReconciler) preflight(
+	ctx context.Context, objectSet adapters.ObjectSetAccessor,
+) error {
+	ctx = preflight.NewContextWithOwner(ctx, objectSet.ClientObject())
+	violations, err := r.preflightChecker.Check(ctx, objectSet.GetSpecPhases())
+	if err != nil {
+		return err
+	}
+
+	if !permissionsVerified(objectSet) {
+		accessViolations, err := r.accessReviewChecker.Check(ctx, objectSet.GetSpecPhases())
+		if err != nil {
+			return err
+		}
+		violations = append(violations, accessViolations...)
+
+		cond := metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPermissionsVerified,
+			Status:             metav1.ConditionTrue,
+			Reason:             "AccessReviewsPassed",
+			Message:            "All objects can be managed.",
+			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+		}
+		if len(accessViolations) > 0 {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = "MissingPermissions"
+			cond.Message = "Access reviews failed, see Available condition."
+		}
+		meta.SetStatusCondition(objectSet.GetStatusConditions(), cond)
+	}
+
+	if len(violations) > 0 {
+		return &preflight.Error{
+			Violations: violations,
+		}
+	}
+	return nil
+}
+
 func (r *objectSetPhasesReconciler) Reconcile(
 	ctx context.Context, objectSet adapters.ObjectSetAccessor,
 ) (res ctrl.Result, err error) {
@@ -101,16 +151,9 @@ func (r *objectSetPhasesReconciler) Reconcile(
 	log.Info("reconcile")
 	defer log.Info("reconciled")
 
-	violations, err := r.preflightChecker.Check(ctx, objectSet.GetSpecPhases())
-	if err != nil {
+	if err := r.preflight(ctx, objectSet); err != nil {
 		return res, err
 	}
-	if len(violations) > 0 {
-		preflightErr := &preflight.Error{
-			Violations: violations,
-		}
-		return res, preflightErr
-	}
 
 	controllers.DeleteMappedConditions(ctx, objectSet.GetStatusConditions())
 
@@ -197,9 +240,13 @@ func (r *objectSetPhasesReconciler) reconcile(
 	}
 
 	log.Info("getting cache accessor")
+	cacheOwner, err := r.cfg.CacheOwner.CacheOwner(ctx, objectSet.ClientObject())
+	if err != nil {
+		return nil, controllers.ProbingResult{}, err
+	}
 	cache, err := r.accessManager.GetWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner,
 		objectSet.ClientObject(),
 		aggregateLocalObjects(objectSet),
 	)
@@ -284,9 +331,13 @@ func (r *objectSetPhasesReconciler) Teardown(
 	phases := objectSet.GetSpecPhases()
 	reverse(phases) // teardown in reverse order
 
+	cacheOwner, err := r.cfg.CacheOwner.CacheOwner(ctx, objectSet.ClientObject())
+	if err != nil {
+		return false, err
+	}
 	cache, err := r.accessManager.GetWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner,
 		objectSet.ClientObject(),
 		aggregateLocalObjects(objectSet),
 	)
@@ -307,7 +358,7 @@ func (r *objectSetPhasesReconciler) Teardown(
 
 	if err := r.accessManager.FreeWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner,
 		objectSet.ClientObject(),
 	); err != nil {
 		return false, fmt.Errorf("freewithuser: %w", err)
@@ -415,6 +466,8 @@ type objectSetPhasesReconcilerConfig struct {
 	controllers.BackoffConfig
 
 	Clock clock
+	// Resolves the owner of the cache objects are reconciled with.
+	CacheOwner controllers.CacheOwnerResolver
 }
 
 func (c *objectSetPhasesReconcilerConfig) Option(opts ...objectSetPhasesReconcilerOption) {
@@ -427,6 +480,9 @@ func (c *objectSetPhasesReconcilerConfig) Default() {
 	if c.Clock == nil {
 		c.Clock = defaultClock{}
 	}
+	if c.CacheOwner == nil {
+		c.CacheOwner = controllers.StaticCacheOwnerResolver{}
+	}
 
 	c.BackoffConfig.Default()
 }
@@ -443,6 +499,14 @@ func (w withClock) ConfigureObjectSetPhasesReconciler(c *objectSetPhasesReconcil
 	c.Clock = w.Clock
 }
 
+type withCacheOwnerResolver struct {
+	CacheOwner controllers.CacheOwnerResolver
+}
+
+func (w withCacheOwnerResolver) ConfigureObjectSetPhasesReconciler(c *objectSetPhasesReconcilerConfig) {
+	c.CacheOwner = w.CacheOwner
+}
+
 type clock interface {
 	Now() time.Time
 }
@@ -8,15 +8,19 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/autoimpersonation"
+	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/preflight"
 	"package-operator.run/internal/testutil/controllersmocks"
@@ -31,12 +35,13 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 		accessor                  *managedcachemocks.AccessorMock
 		factory                   *controllersmocks.PhaseReconcilerFactoryMock
 		checker                   *phasesCheckerMock
+		accessReviewer            *phasesCheckerMock
 		phaseReconciler           *phaseReconcilerMock
 		remotePhaseReconciler     *remotePhaseReconcilerMock
 		objectSetPhasesReconciler *objectSetPhasesReconciler
 	}
 
-	prepare := func() *prepared {
+	prepare := func(opts ...objectSetPhasesReconcilerOption) *prepared {
 		accessManager := &managedcachemocks.ObjectBoundAccessManagerMock[client.Object]{}
 		accessor := &managedcachemocks.AccessorMock{}
 		factory := &controllersmocks.PhaseReconcilerFactoryMock{}
@@ -54,6 +59,7 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 			return []controllers.PreviousObjectSet{}, nil
 		}
 		checker := &phasesCheckerMock{}
+		accessReviewer := &phasesCheckerMock{}
 		objectSetPhasesReconciler := newObjectSetPhasesReconciler(
 			testScheme,
 			accessManager,
@@ -61,6 +67,8 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 			remotePhaseReconciler,
 			lookup,
 			checker,
+			accessReviewer,
+			opts...,
 		)
 
 		return &prepared{
@@ -70,6 +78,7 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 			phaseReconciler:           phaseReconciler,
 			remotePhaseReconciler:     remotePhaseReconciler,
 			checker:                   checker,
+			accessReviewer:            accessReviewer,
 			objectSetPhasesReconciler: objectSetPhasesReconciler,
 		}
 	}
@@ -98,6 +107,7 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 		p.remotePhaseReconciler.On("Reconcile", mock.Anything, mock.Anything, mock.Anything).
 			Return([]corev1alpha1.ControlledObjectReference{}, controllers.ProbingResult{}, nil)
 		p.checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+		p.accessReviewer.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
 
 		res, err := p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
 		assert.Empty(t, res)
@@ -106,20 +116,24 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 		p.phaseReconciler.AssertCalled(t, "ReconcilePhase", mock.Anything, os, phase1, mock.Anything, mock.Anything)
 		p.remotePhaseReconciler.AssertCalled(t, "Reconcile", mock.Anything, os, phase2)
 		p.checker.AssertCalled(t, "Check", mock.Anything, mock.Anything)
+		p.accessReviewer.AssertCalled(t, "Check", mock.Anything, mock.Anything)
 
 		conds := *os.GetStatusConditions()
-		require.Len(t, conds, 2)
-		var succeededCond, availableCond metav1.Condition
+		require.Len(t, conds, 3)
+		var succeededCond, availableCond, permissionsCond metav1.Condition
 		for _, cond := range conds {
 			switch cond.Type {
 			case corev1alpha1.ObjectSetSucceeded:
 				succeededCond = cond
 			case corev1alpha1.ObjectSetAvailable:
 				availableCond = cond
+			case corev1alpha1.ObjectSetPermissionsVerified:
+				permissionsCond = cond
 			}
 		}
 		assert.Equal(t, metav1.ConditionTrue, succeededCond.Status)
 		assert.Equal(t, metav1.ConditionTrue, availableCond.Status)
+		assert.Equal(t, metav1.ConditionTrue, permissionsCond.Status)
 	})
 
 	t.Run("ReconcileBackoff", func(t *testing.T) {
@@ -137,6 +151,7 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 		p.phaseReconciler.On("ReconcilePhase", mock.Anything, os, os.Spec.Phases[0], mock.Anything, mock.Anything).
 			Return([]client.Object{}, controllers.ProbingResult{}, controllers.NewExternalResourceNotFoundError(nil))
 		p.checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+		p.accessReviewer.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
 
 		res, err := p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
 		require.NoError(t, err)
@@ -147,6 +162,109 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 		}, res)
 	})
 
+	t.Run("ReconcileSkipsAccessReviewsWhenVerified", func(t *testing.T) {
+		t.Parallel()
+
+		p := prepare()
+
+		os := &adapters.ObjectSetAdapter{}
+		os.Generation = 2
+		os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "phase1"}}
+		os.Status.Conditions = []metav1.Condition{{
+			Type:               corev1alpha1.ObjectSetPermissionsVerified,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 2,
+		}}
+
+		p.phaseReconciler.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]client.Object{}, controllers.ProbingResult{}, nil)
+		p.checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+		p.accessReviewer.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+
+		_, err := p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
+		require.NoError(t, err)
+		// Other preflight checks always run.
+		p.checker.AssertNumberOfCalls(t, "Check", 1)
+		p.accessReviewer.AssertNotCalled(t, "Check", mock.Anything, mock.Anything)
+
+		// Changed generation is checked again.
+		os.Generation = 3
+		_, err = p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
+		require.NoError(t, err)
+		p.checker.AssertNumberOfCalls(t, "Check", 2)
+		p.accessReviewer.AssertNumberOfCalls(t, "Check", 1)
+		cond := meta.FindStatusCondition(os.Status.Conditions, corev1alpha1.ObjectSetPermissionsVerified)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, int64(3), cond.ObservedGeneration)
+	})
+
+	t.Run("ReconcileMissingPermissions", func(t *testing.T) {
+		t.Parallel()
+
+		p := prepare()
+
+		os := &adapters.ObjectSetAdapter{}
+		os.Generation = 1
+		os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "phase1"}}
+
+		p.checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+		p.accessReviewer.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{
+			{Error: "Missing permissions to create."},
+		}, nil)
+
+		_, err := p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
+		var preflightErr *preflight.Error
+		require.ErrorAs(t, err, &preflightErr)
+		p.phaseReconciler.AssertNotCalled(t,
+			"ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.True(t, meta.IsStatusConditionFalse(os.Status.Conditions, corev1alpha1.ObjectSetPermissionsVerified))
+
+		// Access reviews are repeated until they pass.
+		_, err = p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
+		require.ErrorAs(t, err, &preflightErr)
+		p.accessReviewer.AssertNumberOfCalls(t, "Check", 2)
+	})
+
+	t.Run("ReconcileAsServiceAccount", func(t *testing.T) {
+		t.Parallel()
+
+		p := prepare(withCacheOwnerResolver{
+			CacheOwner: autoimpersonation.NewResolver(
+				fake.NewClientBuilder().WithScheme(testScheme).Build(), testScheme),
+		})
+
+		phase1 := corev1alpha1.ObjectSetTemplatePhase{
+			Name: "phase1",
+		}
+		os := &adapters.ObjectSetAdapter{}
+		os.Namespace = "test"
+		os.Spec.ServiceAccount = &corev1alpha1.ServiceAccountReference{Name: "tenant"}
+		os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{phase1}
+
+		p.phaseReconciler.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]client.Object{}, controllers.ProbingResult{}, nil)
+		p.phaseReconciler.On("TeardownPhase", mock.Anything, os, mock.Anything).
+			Return(true, nil)
+		p.checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+		p.accessReviewer.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+		p.accessManager.On("FreeWithUser", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := p.objectSetPhasesReconciler.Reconcile(context.Background(), os)
+		require.NoError(t, err)
+		done, err := p.objectSetPhasesReconciler.Teardown(context.Background(), os)
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		isServiceAccountCache := mock.MatchedBy(func(owner client.Object) bool {
+			sa, ok := owner.(*corev1.ServiceAccount)
+			return ok && sa.Name == "tenant" && sa.Namespace == "test"
+		})
+		p.accessManager.AssertCalled(t, "GetWithUser", mock.Anything, isServiceAccountCache, os, mock.Anything)
+		p.accessManager.AssertNotCalled(t, "GetWithUser", mock.Anything, constants.StaticCacheOwner(), os, mock.Anything)
+		p.accessManager.AssertCalled(t, "FreeWithUser", mock.Anything, isServiceAccountCache, os)
+	})
+
 	t.Run("Teardown", func(t *testing.T) {
 		tests := []struct {
 			name                string
@@ -226,8 +344,9 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 				},
 			},
 			ExpectedConditionStatuses: map[string]metav1.ConditionStatus{
-				corev1alpha1.ObjectSetAvailable: metav1.ConditionTrue,
-				corev1alpha1.ObjectSetSucceeded: metav1.ConditionTrue,
+				corev1alpha1.ObjectSetAvailable:           metav1.ConditionTrue,
+				corev1alpha1.ObjectSetSucceeded:           metav1.ConditionTrue,
+				corev1alpha1.ObjectSetPermissionsVerified: metav1.ConditionTrue,
 			},
 		},
 		"success delay 2s/time since available 1s": {
@@ -247,7 +366,8 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 			},
 			TimeSinceAvailable: 1 * time.Second,
 			ExpectedConditionStatuses: map[string]metav1.ConditionStatus{
-				corev1alpha1.ObjectSetAvailable: metav1.ConditionTrue,
+				corev1alpha1.ObjectSetAvailable:           metav1.ConditionTrue,
+				corev1alpha1.ObjectSetPermissionsVerified: metav1.ConditionTrue,
 			},
 		},
 		"success delay 1s/time since available 2s": {
@@ -267,8 +387,9 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 			},
 			TimeSinceAvailable: 2 * time.Second,
 			ExpectedConditionStatuses: map[string]metav1.ConditionStatus{
-				corev1alpha1.ObjectSetAvailable: metav1.ConditionTrue,
-				corev1alpha1.ObjectSetSucceeded: metav1.ConditionTrue,
+				corev1alpha1.ObjectSetAvailable:           metav1.ConditionTrue,
+				corev1alpha1.ObjectSetSucceeded:           metav1.ConditionTrue,
+				corev1alpha1.ObjectSetPermissionsVerified: metav1.ConditionTrue,
 			},
 		},
 	}
@@ -294,6 +415,7 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 				return []controllers.PreviousObjectSet{}, nil
 			}
 			checker := &phasesCheckerMock{}
+			accessReviewer := &phasesCheckerMock{}
 
 			clock := &clockMock{}
 
@@ -303,6 +425,7 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 			remotePhaseReconciler.On("Reconcile", mock.Anything, tc.ObjectSet, mock.Anything, mock.Anything).
 				Return([]corev1alpha1.ControlledObjectReference{}, controllers.ProbingResult{}, nil)
 			checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+			accessReviewer.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
 
 			rec := newObjectSetPhasesReconciler(
 				testScheme,
@@ -311,6 +434,7 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 				remotePhaseReconciler,
 				lookup,
 				checker,
+				accessReviewer,
 				withClock{
 					Clock: clock,
 				},
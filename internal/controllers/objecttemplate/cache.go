@@ -0,0 +1,85 @@
+package objecttemplate
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+const namespaceCacheOwnerUIDPrefix = "objecttemplate-namespace:"
+
+// MapCacheOptions adjusts the options of the dynamic cache bound to owner.
+// Caches returned by namespaceCacheOwner watch all objects in their namespace
+// instead of only labeled objects, so sources created or changed later on trigger re-rendering.
+func MapCacheOptions(owner client.Object, opts cache.Options) cache.Options {
+	if _, ok := owner.(*corev1.Namespace); !ok ||
+		!strings.HasPrefix(string(owner.GetUID()), namespaceCacheOwnerUIDPrefix) {
+		return opts
+	}
+
+	opts.DefaultLabelSelector = nil
+	opts.DefaultNamespaces = nil
+	if len(owner.GetName()) > 0 {
+		opts.DefaultNamespaces = map[string]cache.Config{
+			owner.GetName(): {},
+		}
+	}
+	return opts
+}
+
+// Returns the owner of the dynamic cache used by objectTemplate.
+// All namespaced ObjectTemplates of a namespace share the cache of that namespace.
+// ClusterObjectTemplates share the static cache.
+func cacheOwner(objectTemplate client.Object) client.Object {
+	if _, ok := objectTemplate.(*corev1alpha1.ObjectTemplate); ok {
+		return namespaceCacheOwner(objectTemplate.GetNamespace())
+	}
+	return constants.StaticCacheOwner()
+}
+
+// Returns the owner of the cache watching all objects in the given namespace.
+// An empty namespace selects the cache watching objects in all namespaces.
+func namespaceCacheOwner(namespace string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+			// Not a real UID, just a unique key for the cache of this namespace.
+			UID: types.UID(namespaceCacheOwnerUIDPrefix + namespace),
+		},
+	}
+}
+
+// Returns the owners of all caches used by objectTemplate.
+// Objects matching a source selector are listed from the cache of the namespace of the source,
+// so objects created later on are seen without labeling them first.
+func cacheOwners(objectTemplate client.Object, sources []corev1alpha1.ObjectTemplateSource) []client.Object {
+	owners := []client.Object{cacheOwner(objectTemplate)}
+	seen := map[types.UID]struct{}{owners[0].GetUID(): {}}
+	for _, src := range sources {
+		if src.Selector == nil {
+			continue
+		}
+		owner := namespaceCacheOwner(sourceNamespace(objectTemplate, src))
+		if _, ok := seen[owner.GetUID()]; ok {
+			continue
+		}
+		seen[owner.GetUID()] = struct{}{}
+		owners = append(owners, owner)
+	}
+	return owners
+}
+
+// Returns the namespace src is looked up in.
+func sourceNamespace(objectTemplate client.Object, src corev1alpha1.ObjectTemplateSource) string {
+	if len(src.Namespace) > 0 {
+		return src.Namespace
+	}
+	return objectTemplate.GetNamespace()
+}
@@ -0,0 +1,91 @@
+package objecttemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+func TestMapCacheOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := cache.Options{
+		DefaultLabelSelector: labels.SelectorFromSet(labels.Set{
+			constants.DynamicCacheLabel: "True",
+		}),
+	}
+
+	t.Run("namespace cache", func(t *testing.T) {
+		t.Parallel()
+
+		mapped := MapCacheOptions(namespaceCacheOwner("test-ns"), opts)
+		assert.Nil(t, mapped.DefaultLabelSelector)
+		assert.Equal(t, map[string]cache.Config{"test-ns": {}}, mapped.DefaultNamespaces)
+		// Options of other owners must not be changed.
+		assert.NotNil(t, opts.DefaultLabelSelector)
+	})
+
+	t.Run("cluster-wide cache", func(t *testing.T) {
+		t.Parallel()
+
+		mapped := MapCacheOptions(namespaceCacheOwner(""), opts)
+		assert.Nil(t, mapped.DefaultLabelSelector)
+		assert.Nil(t, mapped.DefaultNamespaces)
+	})
+
+	t.Run("other owners", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, opts, MapCacheOptions(constants.StaticCacheOwner(), opts))
+		assert.Equal(t, opts, MapCacheOptions(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ns", UID: "1234"},
+		}, opts))
+	})
+}
+
+func Test_cacheOwner(t *testing.T) {
+	t.Parallel()
+
+	a := &corev1alpha1.ObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test-ns"},
+	}
+	b := &corev1alpha1.ObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-ns"},
+	}
+	// ObjectTemplates of the same namespace share a cache.
+	assert.Equal(t, namespaceCacheOwner("test-ns"), cacheOwner(a))
+	assert.Equal(t, cacheOwner(a), cacheOwner(b))
+
+	clusterObjectTemplate := &corev1alpha1.ClusterObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+	}
+	assert.Equal(t, constants.StaticCacheOwner(), cacheOwner(clusterObjectTemplate))
+}
+
+func Test_cacheOwners(t *testing.T) {
+	t.Parallel()
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+	clusterObjectTemplate := &corev1alpha1.ClusterObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+	}
+	owners := cacheOwners(clusterObjectTemplate, []corev1alpha1.ObjectTemplateSource{
+		{Kind: "Secret", Namespace: "a", Selector: selector},
+		{Kind: "ConfigMap", Namespace: "a", Selector: selector},
+		{Kind: "ConfigMap", Namespace: "b", Name: "named"},
+		{Kind: "Namespace", Selector: selector},
+	})
+	assert.Equal(t, []client.Object{
+		constants.StaticCacheOwner(),
+		namespaceCacheOwner("a"),
+		namespaceCacheOwner(""),
+	}, owners)
+}
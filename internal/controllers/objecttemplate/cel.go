@@ -0,0 +1,154 @@
+package objecttemplate
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Limits the runtime cost of a single expression,
+// so a bad expression cannot block the controller.
+const celCostLimit = 1_000_000
+
+// Maximum number of compiled expressions kept in celPrograms.
+const celProgramCacheSize = 1024
+
+// CEL environment shared by all source expressions.
+// Exposes the source object as `object`, with string, encoder, list and optional extensions.
+var celEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.OptionalTypes(),
+		ext.Strings(),
+		ext.Encoders(),
+		ext.Lists(),
+	)
+})
+
+// Evaluates a CEL expression with sourceObj as input
+// and returns the result as JSON compatible value.
+func evaluateExpression(expression string, sourceObj *unstructured.Unstructured) (any, error) {
+	value, err := evaluate(expression, sourceObj)
+	if err != nil {
+		return nil, &ExpressionError{Expression: expression, Err: err}
+	}
+	return value, nil
+}
+
+func evaluate(expression string, sourceObj *unstructured.Unstructured) (any, error) {
+	program, err := celPrograms.get(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := program.Eval(map[string]any{"object": sourceObj.Object})
+	if err != nil {
+		return nil, err
+	}
+	return celValueToJSON(out)
+}
+
+// Compiled programs by expression, so expressions are not compiled again on every reconcile.
+var celPrograms = &celProgramCache{programs: map[string]cel.Program{}}
+
+type celProgramCache struct {
+	lock     sync.RWMutex
+	programs map[string]cel.Program
+}
+
+// Returns the compiled program of expression, compiling it on first use.
+func (c *celProgramCache) get(expression string) (cel.Program, error) {
+	c.lock.RLock()
+	program, ok := c.programs[expression]
+	c.lock.RUnlock()
+	if ok {
+		return program, nil
+	}
+
+	program, err := compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.programs) >= celProgramCacheSize {
+		// Expressions of deleted or changed templates are never used again, start over.
+		clear(c.programs)
+	}
+	c.programs[expression] = program
+	return program, nil
+}
+
+func compile(expression string) (cel.Program, error) {
+	env, err := celEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	return env.Program(ast, cel.CostLimit(celCostLimit))
+}
+
+// Converts CEL values into values that can be stored in unstructured objects.
+func celValueToJSON(val ref.Val) (any, error) {
+	switch v := val.(type) {
+	case traits.Mapper:
+		out := map[string]any{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			key := it.Next()
+			k, ok := key.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported map key type %s", key.Type().TypeName())
+			}
+			value, err := celValueToJSON(v.Get(key))
+			if err != nil {
+				return nil, err
+			}
+			out[k] = value
+		}
+		return out, nil
+
+	case traits.Lister:
+		out := []any{}
+		for it := v.Iterator(); it.HasNext() == types.True; {
+			value, err := celValueToJSON(it.Next())
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+		return out, nil
+
+	case types.Null:
+		return nil, nil //nolint:nilnil
+	case types.Bool:
+		return bool(v), nil
+	case types.Int:
+		return int64(v), nil
+	case types.Uint:
+		return int64(v), nil //nolint:gosec
+	case types.Double:
+		return float64(v), nil
+	case types.String:
+		return string(v), nil
+	case types.Bytes:
+		// e.g. results of base64.decode, which are text in almost all cases.
+		return string(v), nil
+	case types.Timestamp:
+		return v.Format(time.RFC3339), nil
+	case types.Duration:
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("unsupported result type %s", val.Type().TypeName())
+}
@@ -0,0 +1,118 @@
+package objecttemplate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_evaluateExpression(t *testing.T) {
+	t.Parallel()
+
+	sourceObj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]any{
+			"name": "test",
+		},
+		"data": map[string]any{
+			"password": "aHVudGVyMg==",
+			"hosts":    "a.example.com,b.example.com",
+		},
+		"spec": map[string]any{
+			"ports": []any{
+				map[string]any{"name": "http", "port": int64(80)},
+				map[string]any{"name": "metrics", "port": int64(8080)},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   any
+	}{
+		{
+			name:       "base64 decode",
+			expression: "base64.decode(object.data.password)",
+			expected:   "hunter2",
+		},
+		{
+			name:       "split",
+			expression: "object.data.hosts.split(',')",
+			expected:   []any{"a.example.com", "b.example.com"},
+		},
+		{
+			name:       "default",
+			expression: "object.?data.user.orValue('admin')",
+			expected:   "admin",
+		},
+		{
+			name:       "filter",
+			expression: "object.spec.ports.filter(p, p.name != 'metrics').map(p, p.port)",
+			expected:   []any{int64(80)},
+		},
+		{
+			name:       "map",
+			expression: "{'name': object.metadata.name, 'ports': size(object.spec.ports)}",
+			expected:   map[string]any{"name": "test", "ports": int64(2)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := evaluateExpression(test.expression, sourceObj)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func Test_evaluateExpression_error(t *testing.T) {
+	t.Parallel()
+
+	sourceObj := &unstructured.Unstructured{Object: map[string]any{}}
+
+	for _, expression := range []string{
+		// does not compile
+		"object.",
+		// missing field
+		"object.data.password",
+		// not representable as JSON
+		"type(object)",
+	} {
+		_, err := evaluateExpression(expression, sourceObj)
+		var exprErr *ExpressionError
+		require.ErrorAs(t, err, &exprErr, expression)
+		assert.Equal(t, expression, exprErr.Expression)
+	}
+}
+
+func Test_celProgramCache(t *testing.T) {
+	t.Parallel()
+
+	c := &celProgramCache{programs: map[string]cel.Program{}}
+
+	first, err := c.get("object.metadata.name")
+	require.NoError(t, err)
+	second, err := c.get("object.metadata.name")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Len(t, c.programs, 1)
+
+	// Expressions failing to compile are not cached.
+	_, err = c.get("object.")
+	require.Error(t, err)
+	assert.Len(t, c.programs, 1)
+
+	for i := range celProgramCacheSize {
+		_, err := c.get(fmt.Sprintf("object.metadata.name + '%d'", i))
+		require.NoError(t, err)
+	}
+	assert.Len(t, c.programs, 1)
+}
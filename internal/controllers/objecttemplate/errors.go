@@ -15,6 +15,19 @@ func (e *JSONPathFormatError) Error() string {
 	return fmt.Sprintf("path %s must be a JSONPath with a leading dot", e.Path)
 }
 
+type ExpressionError struct {
+	Expression string
+	Err        error
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("expression %q: %s", e.Expression, e.Err)
+}
+
+func (e *ExpressionError) Unwrap() error {
+	return e.Err
+}
+
 type SourceError struct {
 	Source client.Object
 	Err    error
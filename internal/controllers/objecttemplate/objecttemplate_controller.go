@@ -6,13 +6,18 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"pkg.package-operator.run/boxcutter/managedcache"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
 	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/constants"
@@ -127,8 +132,10 @@ func (c *GenericObjectTemplateController) Reconcile(
 	}
 
 	if !objectTemplate.ClientObject().GetDeletionTimestamp().IsZero() {
-		if err := c.accessManager.FreeWithUser(ctx, constants.StaticCacheOwner(), objectTemplate.ClientObject()); err != nil {
-			return ctrl.Result{}, fmt.Errorf("free cache: %w", err)
+		for _, owner := range cacheOwners(objectTemplate.ClientObject(), objectTemplate.GetSources()) {
+			if err := c.accessManager.FreeWithUser(ctx, owner, objectTemplate.ClientObject()); err != nil {
+				return ctrl.Result{}, fmt.Errorf("free cache: %w", err)
+			}
 		}
 
 		if err := controllers.RemoveCacheFinalizer(
@@ -176,8 +183,18 @@ func (c *GenericObjectTemplateController) SetupWithManager(
 ) error {
 	objectTemplate := c.newObjectTemplate(c.scheme).ClientObject()
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(objectTemplate).
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(objectTemplate)
+	if _, ok := objectTemplate.(*corev1alpha1.ClusterObjectTemplate); ok {
+		// Namespaces coming and going or changing labels change the objects of templates with a namespace selector.
+		b = b.Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(c.enqueueNamespaceSelectingTemplates),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		)
+	}
+
+	return b.
 		WatchesRawSource(
 			c.accessManager.Source(
 				managedcache.NewEnqueueWatchingObjects(c.accessManager, objectTemplate, mgr.GetScheme()),
@@ -194,3 +211,22 @@ func (c *GenericObjectTemplateController) SetupWithManager(
 		).
 		Complete(c)
 }
+
+// Namespace labels might have changed, so all templates with a namespace selector have to be re-evaluated.
+func (c *GenericObjectTemplateController) enqueueNamespaceSelectingTemplates(
+	ctx context.Context, _ client.Object,
+) []reconcile.Request {
+	templateList := &corev1alpha1.ClusterObjectTemplateList{}
+	if err := c.client.List(ctx, templateList); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, template := range templateList.Items {
+		if template.Spec.NamespaceSelector == nil {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&template)})
+	}
+	return requests
+}
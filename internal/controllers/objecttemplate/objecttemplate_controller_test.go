@@ -110,3 +110,33 @@ func TestObjectTemplateController_Reconcile_deletion(t *testing.T) {
 	c.StatusMock.AssertExpectations(t)
 	accessManager.AssertExpectations(t)
 }
+
+func TestObjectTemplateController_enqueueNamespaceSelectingTemplates(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	controller := NewClusterObjectTemplateController(
+		c, testutil.NewClient(), testr.New(t),
+		&managedcachemocks.ObjectBoundAccessManagerMock[client.Object]{},
+		testScheme, &restmappermock.RestMapperMock{}, ControllerConfig{},
+	)
+
+	c.
+		On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplateList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*corev1alpha1.ClusterObjectTemplateList)
+			list.Items = []corev1alpha1.ClusterObjectTemplate{
+				{ObjectMeta: metav1.ObjectMeta{Name: "single"}},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fan-out"},
+					Spec: corev1alpha1.ObjectTemplateSpec{
+						NamespaceSelector: &metav1.LabelSelector{},
+					},
+				},
+			}
+		}).
+		Return(nil)
+
+	requests := controller.enqueueNamespaceSelectingTemplates(context.Background(), nil)
+	assert.Equal(t, []reconcile.Request{{NamespacedName: client.ObjectKey{Name: "fan-out"}}}, requests)
+}
@@ -1,16 +1,21 @@
 package objecttemplate
 
 import (
+	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/util/jsonpath"
 	"pkg.package-operator.run/boxcutter/managedcache"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -31,16 +37,14 @@ import (
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/environment"
 	"package-operator.run/internal/preflight"
+	"package-operator.run/internal/utils"
 )
 
-// Requeue every 30s to check if input sources exist now.
-var defaultMissingResourceRetryInterval = 30 * time.Second
-
 type templateReconciler struct {
 	*environment.Sink
 
 	scheme                        *runtime.Scheme
-	client                        client.Writer
+	client                        client.Client
 	uncachedClient                client.Reader
 	accessManager                 managedcache.ObjectBoundAccessManager[client.Object]
 	preflightChecker              preflightChecker
@@ -84,7 +88,7 @@ func (r *templateReconciler) Reconcile(
 	}
 	cache, err := r.accessManager.GetWithUser(
 		ctx,
-		constants.StaticCacheOwner(),
+		cacheOwner(objectTemplate.ClientObject()),
 		objectTemplate.ClientObject(),
 		localObjects,
 	)
@@ -92,37 +96,213 @@ func (r *templateReconciler) Reconcile(
 		return res, err
 	}
 	sourcesConfig := map[string]any{}
-	retryLater, err := r.getValuesFromSources(ctx, objectTemplate, sourcesConfig, cache)
+	observed, err := r.getValuesFromSources(ctx, objectTemplate, sourcesConfig, cache)
+	setSourcesAvailableCondition(objectTemplate, observed, err)
 	if err != nil {
 		if isMissingResourceError(err) {
 			res.RequeueAfter = r.resourceRetryInterval
 		}
 		return res, fmt.Errorf("retrieving values from sources: %w", err)
 	}
-	// For optional resources.
-	if retryLater {
+	// Optional sources might be created later on.
+	if len(observed.missingOptional) > 0 {
 		res.RequeueAfter = r.optionalResourceRetryInterval
 	}
 
-	obj := &unstructured.Unstructured{
-		Object: map[string]any{},
+	var (
+		controllerOf []corev1alpha1.ControlledObjectReference
+		rendered     []*unstructured.Unstructured
+	)
+	if objectTemplate.GetNamespaceSelector() != nil {
+		var namespaces *corev1alpha1.ObjectTemplateNamespacesStatus
+		controllerOf, rendered, namespaces, err = r.reconcileNamespaces(
+			ctx, objectTemplate, cache, sourcesConfig, originalControllerOf)
+		if err != nil {
+			return res, err
+		}
+		objectTemplate.SetStatusNamespaces(namespaces)
+		if len(namespaces.Failed) > 0 {
+			res.RequeueAfter = r.resourceRetryInterval
+		}
+	} else {
+		objs, err := r.templateObjects(ctx, sourcesConfig, objectTemplate, nil)
+		if err != nil {
+			return res, err
+		}
+		// Objects are mutated when reconciled, keep the rendered state for hashing.
+		rendered = deepCopyObjects(objs)
+		controllerOf, err = r.reconcileObjects(ctx, objectTemplate, cache, objs)
+		if err != nil {
+			return res, err
+		}
+		objectTemplate.SetStatusNamespaces(nil)
 	}
-	if err := r.templateObject(ctx, sourcesConfig, objectTemplate, obj); err != nil {
+	objectTemplate.SetStatusSources(observed.found)
+	objectTemplate.SetStatusRenderedHash(utils.ComputeSHA256Hash(rendered, nil))
+	if err := r.deleteStaleObjects(ctx, objectTemplate, cache, originalControllerOf, controllerOf); err != nil {
 		return res, err
 	}
 
+	objectTemplate.SetStatusControllerOf(controllerOf)
+
+	if !slices.Equal(controllerOf, originalControllerOf) {
+		// start watches for output gvks that were not controlled before
+
+		localObjects, err := r.aggregateLocalObjects(ctx, objectTemplate, objectTemplate.GetStatusControllerOf())
+		if err != nil {
+			return res, err
+		}
+
+		if _, err := r.accessManager.GetWithUser(
+			ctx,
+			cacheOwner(objectTemplate.ClientObject()),
+			objectTemplate.ClientObject(),
+			localObjects,
+		); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// Templates objects into every namespace selected by the namespace selector of the ObjectTemplate.
+// Failures are reported per namespace and objects in failed namespaces are left untouched.
+// Returns references to all controlled objects and the objects rendered into ready namespaces.
+func (r *templateReconciler) reconcileNamespaces(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	cache managedcache.Accessor, sourcesConfig map[string]any,
+	previous []corev1alpha1.ControlledObjectReference,
+) (
+	[]corev1alpha1.ControlledObjectReference, []*unstructured.Unstructured,
+	*corev1alpha1.ObjectTemplateNamespacesStatus, error,
+) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	namespaces, err := r.selectNamespaces(ctx, objectTemplate)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		controllerOf []corev1alpha1.ControlledObjectReference
+		rendered     []*unstructured.Unstructured
+		failed       = map[string]struct{}{}
+		status       = &corev1alpha1.ObjectTemplateNamespacesStatus{
+			Selected: int32(len(namespaces)), //nolint:gosec
+		}
+	)
+	for i := range namespaces {
+		namespace := &namespaces[i]
+
+		refs, objs, err := r.reconcileNamespace(ctx, objectTemplate, cache, sourcesConfig, namespace)
+		if err != nil {
+			log.Error(err, "templating objects into namespace", "namespace", namespace.Name)
+			status.Failed = append(status.Failed, corev1alpha1.ObjectTemplateNamespaceFailure{
+				Name:    namespace.Name,
+				Message: err.Error(),
+			})
+			failed[namespace.Name] = struct{}{}
+			continue
+		}
+		status.Ready++
+		controllerOf = append(controllerOf, refs...)
+		rendered = append(rendered, objs...)
+	}
+
+	// Keep objects of failed namespaces, instead of deleting them as stale.
+	for _, ref := range previous {
+		if _, ok := failed[ref.Namespace]; ok {
+			controllerOf = append(controllerOf, ref)
+		}
+	}
+	return controllerOf, rendered, status, nil
+}
+
+func (r *templateReconciler) reconcileNamespace(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	cache managedcache.Accessor, sourcesConfig map[string]any, namespace *corev1.Namespace,
+) ([]corev1alpha1.ControlledObjectReference, []*unstructured.Unstructured, error) {
+	objs, err := r.templateObjects(ctx, sourcesConfig, objectTemplate, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	rendered := deepCopyObjects(objs)
+	refs, err := r.reconcileObjects(ctx, objectTemplate, cache, objs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return refs, rendered, nil
+}
+
+func deepCopyObjects(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	out := make([]*unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		out[i] = obj.DeepCopy()
+	}
+	return out
+}
+
+// Lists all active namespaces matching the namespace selector of the ObjectTemplate, ordered by name.
+func (r *templateReconciler) selectNamespaces(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+) ([]corev1.Namespace, error) {
+	selector, err := metav1.LabelSelectorAsSelector(objectTemplate.GetNamespaceSelector())
+	if err != nil {
+		return nil, &TemplateError{Err: fmt.Errorf("invalid namespace selector: %w", err)}
+	}
+
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+
+	namespaces := make([]corev1.Namespace, 0, len(namespaceList.Items))
+	for _, namespace := range namespaceList.Items {
+		// Objects can no longer be created in terminating namespaces.
+		if !namespace.DeletionTimestamp.IsZero() {
+			continue
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	slices.SortFunc(namespaces, func(a, b corev1.Namespace) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return namespaces, nil
+}
+
+// Creates or updates all templated objects and returns references to them.
+func (r *templateReconciler) reconcileObjects(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	cache managedcache.Accessor, objs []*unstructured.Unstructured,
+) ([]corev1alpha1.ControlledObjectReference, error) {
+	controllerOf := make([]corev1alpha1.ControlledObjectReference, 0, len(objs))
+	for _, obj := range objs {
+		if err := r.reconcileObject(ctx, objectTemplate, cache, obj); err != nil {
+			return nil, err
+		}
+		controllerOf = append(controllerOf, newControlledObjectReference(obj))
+	}
+	return controllerOf, nil
+}
+
+// Creates or updates a single templated object.
+func (r *templateReconciler) reconcileObject(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	cache managedcache.Accessor, obj *unstructured.Unstructured,
+) error {
 	existingObj := &unstructured.Unstructured{}
 	existingObj.SetGroupVersionKind(obj.GroupVersionKind())
 	if err := cache.Get(ctx, client.ObjectKeyFromObject(obj), existingObj); apimachineryerrors.IsNotFound(err) {
 		if err := r.handleCreation(ctx, objectTemplate.ClientObject(), obj); err != nil {
-			return res, fmt.Errorf("handling creation: %w", err)
+			return fmt.Errorf("handling creation: %w", err)
 		}
-		return res, nil
+		return nil
 	} else if err != nil {
-		return res, fmt.Errorf("getting existing object: %w", err)
+		return fmt.Errorf("getting existing object: %w", err)
 	}
 	if err := updateStatusConditionsFromOwnedObject(ctx, objectTemplate, existingObj); err != nil {
-		return res, fmt.Errorf("updating status conditions from owned object: %w", err)
+		return fmt.Errorf("updating status conditions from owned object: %w", err)
 	}
 
 	obj.SetOwnerReferences(existingObj.GetOwnerReferences())
@@ -132,39 +312,54 @@ func (r *templateReconciler) Reconcile(
 
 	obj.SetResourceVersion(existingObj.GetResourceVersion())
 	if err := r.client.Update(ctx, obj); err != nil {
-		return res, fmt.Errorf("updating templated object: %w", err)
+		return fmt.Errorf("updating templated object: %w", err)
 	}
+	return nil
+}
 
+// Deletes objects that were previously templated, but are no longer part of the rendered template.
+func (r *templateReconciler) deleteStaleObjects(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	cache managedcache.Accessor, previous, current []corev1alpha1.ControlledObjectReference,
+) error {
+	for _, ref := range previous {
+		if slices.Contains(current, ref) {
+			continue
+		}
+
+		staleObj := &unstructured.Unstructured{}
+		staleObj.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   ref.Group,
+			Version: ref.Version,
+			Kind:    ref.Kind,
+		})
+		key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+		if err := cache.Get(ctx, key, staleObj); apimachineryerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("getting stale object: %w", err)
+		}
+		// Never delete objects taken over by someone else in the meantime.
+		if !metav1.IsControlledBy(staleObj, objectTemplate.ClientObject()) {
+			continue
+		}
+
+		if err := r.client.Delete(ctx, staleObj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting stale object: %w", err)
+		}
+	}
+	return nil
+}
+
+func newControlledObjectReference(obj client.Object) corev1alpha1.ControlledObjectReference {
 	gvk := obj.GetObjectKind().GroupVersionKind()
-	controllerOf := corev1alpha1.ControlledObjectReference{
+	return corev1alpha1.ControlledObjectReference{
 		Kind:      gvk.Kind,
 		Group:     gvk.Group,
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
 		Version:   gvk.Version,
 	}
-
-	objectTemplate.SetStatusControllerOf(controllerOf)
-
-	if controllerOf != originalControllerOf {
-		// start watches for output gvk if controllerof wasn't set before
-
-		localObjects, err := r.aggregateLocalObjects(ctx, objectTemplate, objectTemplate.GetStatusControllerOf())
-		if err != nil {
-			return res, err
-		}
-
-		if _, err := r.accessManager.GetWithUser(
-			ctx,
-			constants.StaticCacheOwner(),
-			objectTemplate.ClientObject(),
-			localObjects,
-		); err != nil {
-			return res, err
-		}
-	}
-
-	return res, nil
 }
 
 func (r *templateReconciler) handleCreation(ctx context.Context, owner, object client.Object) error {
@@ -179,28 +374,69 @@ func (r *templateReconciler) handleCreation(ctx context.Context, owner, object c
 	return nil
 }
 
+// Sources observed while copying values into the template context.
+type sourceObservation struct {
+	// Source objects values have been copied from.
+	found []corev1alpha1.ObjectTemplateObservedSource
+	// Optional sources that do not exist.
+	missingOptional []string
+}
+
 func (r *templateReconciler) getValuesFromSources(
 	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
 	sourcesConfig map[string]any,
 	cache managedcache.Accessor,
-) (retryLater bool, err error) {
+) (observed sourceObservation, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	for _, src := range objectTemplate.GetSources() {
+		if src.Selector != nil {
+			found, err := r.copySourceList(ctx, objectTemplate, src, sourcesConfig, cache)
+			if err != nil {
+				return observed, err
+			}
+			observed.found = append(observed.found, found...)
+			continue
+		}
+
 		sourceObj, found, err := r.getSourceObject(ctx, objectTemplate, src, cache)
 		if err != nil {
-			return false, err
+			return observed, err
 		}
 		if !found {
-			log.Info(fmt.Sprintf("optional source not found, retry in %s", defaultMissingResourceRetryInterval),
-				"source", fmt.Sprintf("%s %s/%s", src.Kind, src.Namespace, src.Name))
-			retryLater = true
+			namespace := cmp.Or(src.Namespace, objectTemplate.ClientObject().GetNamespace())
+			log.Info("optional source not found",
+				"source", fmt.Sprintf("%s %s/%s", src.Kind, namespace, src.Name))
+			observed.missingOptional = append(observed.missingOptional,
+				fmt.Sprintf("%s %s", src.Kind, client.ObjectKey{Namespace: namespace, Name: src.Name}))
 			continue
 		}
+		observed.found = append(observed.found, newObservedSource(sourceObj))
 		if err := copySourceItems(src.Items, sourceObj, sourcesConfig); err != nil {
-			return false, &SourceError{Source: sourceObj, Err: err}
+			return observed, &SourceError{Source: sourceObj, Err: err}
+		}
+		if len(src.Expression) > 0 {
+			value, err := evaluateExpression(src.Expression, sourceObj)
+			if err != nil {
+				return observed, &SourceError{Source: sourceObj, Err: err}
+			}
+			if err := setDestination(sourcesConfig, src.Destination, value); err != nil {
+				return observed, &SourceError{Source: sourceObj, Err: err}
+			}
 		}
 	}
-	return retryLater, nil
+	return observed, nil
+}
+
+func newObservedSource(obj client.Object) corev1alpha1.ObjectTemplateObservedSource {
+	apiVersion, kind := obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	return corev1alpha1.ObjectTemplateObservedSource{
+		APIVersion:      apiVersion,
+		Kind:            kind,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		UID:             obj.GetUID(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
 }
 
 func (r *templateReconciler) getSourceObject(
@@ -238,6 +474,130 @@ func (r *templateReconciler) getSourceObject(
 	return sourceObj, true, nil
 }
 
+// Copies all objects matched by the selector of src into sourcesConfig
+// and returns the matched objects.
+func (r *templateReconciler) copySourceList(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	src corev1alpha1.ObjectTemplateSource, sourcesConfig map[string]any,
+	cache managedcache.Accessor,
+) ([]corev1alpha1.ObjectTemplateObservedSource, error) {
+	sourceObj, err := r.constructSourceObject(ctx, objectTemplate, src)
+	if err != nil {
+		return nil, err
+	}
+	listCache, err := r.selectorSourceCache(ctx, objectTemplate, sourceObj.GetNamespace(), cache)
+	if err != nil {
+		return nil, err
+	}
+	matchedObjs, err := listSourceObjects(ctx, src, sourceObj, listCache)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		list     = make([]any, 0, len(matchedObjs))
+		byName   = make(map[string]any, len(matchedObjs))
+		observed = make([]corev1alpha1.ObjectTemplateObservedSource, 0, len(matchedObjs))
+	)
+	for i := range matchedObjs {
+		matchedObj := &matchedObjs[i]
+
+		entry, err := sourceListEntry(src, matchedObj)
+		if err != nil {
+			return nil, &SourceError{Source: matchedObj, Err: err}
+		}
+		list = append(list, entry)
+		byName[matchedObj.GetName()] = entry
+		observed = append(observed, newObservedSource(matchedObj))
+	}
+
+	var value any = list
+	if src.Format == corev1alpha1.ObjectTemplateSourceFormatMap {
+		value = byName
+	}
+	if err := setDestination(sourcesConfig, src.Destination, value); err != nil {
+		return nil, &SourceError{Source: sourceObj, Err: err}
+	}
+	return observed, nil
+}
+
+// Returns the value stored for a single object matched by the selector of src.
+func sourceListEntry(src corev1alpha1.ObjectTemplateSource, matchedObj *unstructured.Unstructured) (any, error) {
+	switch {
+	case len(src.Expression) > 0:
+		return evaluateExpression(src.Expression, matchedObj)
+	case len(src.Items) == 0:
+		entry := matchedObj.DeepCopy().Object
+		unstructured.RemoveNestedField(entry, "metadata", "managedFields")
+		return entry, nil
+	}
+
+	entry := map[string]any{}
+	if err := copySourceItems(src.Items, matchedObj, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Returns the cache to list objects matching a source selector in namespace from.
+// The cache watches all objects in namespace, so matching objects created later on trigger a reconcile.
+func (r *templateReconciler) selectorSourceCache(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor,
+	namespace string, cache managedcache.Accessor,
+) (managedcache.Accessor, error) {
+	owner := namespaceCacheOwner(namespace)
+	if owner.GetUID() == cacheOwner(objectTemplate.ClientObject()).GetUID() {
+		return cache, nil
+	}
+
+	// Register the kinds of all sources selecting objects in this namespace at once.
+	var usedFor []client.Object
+	for _, src := range objectTemplate.GetSources() {
+		if src.Selector == nil || sourceNamespace(objectTemplate.ClientObject(), src) != namespace {
+			continue
+		}
+		sourceObj, err := r.constructSourceObject(ctx, objectTemplate, src)
+		if err != nil {
+			return nil, err
+		}
+		usedFor = append(usedFor, sourceObj)
+	}
+	return r.accessManager.GetWithUser(ctx, owner, objectTemplate.ClientObject(), usedFor)
+}
+
+// Lists all objects matching the selector of src from cache, ordered by namespace and name.
+// sourceObj carries the GroupVersionKind and namespace to list objects in.
+func listSourceObjects(
+	ctx context.Context, src corev1alpha1.ObjectTemplateSource,
+	sourceObj *unstructured.Unstructured, cache managedcache.Accessor,
+) ([]unstructured.Unstructured, error) {
+	selector, err := metav1.LabelSelectorAsSelector(src.Selector)
+	if err != nil {
+		return nil, &SourceError{Source: sourceObj, Err: err}
+	}
+
+	list := &unstructured.UnstructuredList{}
+	gvk := sourceObj.GroupVersionKind()
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := cache.List(ctx, list,
+		client.InNamespace(sourceObj.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return nil, fmt.Errorf("listing source objects of kind %s in namespace %s: %w",
+			gvk.Kind, sourceObj.GetNamespace(), err)
+	}
+
+	// Keep rendered output stable.
+	objs := list.Items
+	slices.SortFunc(objs, func(a, b unstructured.Unstructured) int {
+		return cmp.Or(
+			strings.Compare(a.GetNamespace(), b.GetNamespace()),
+			strings.Compare(a.GetName(), b.GetName()),
+		)
+	})
+	return objs, nil
+}
+
 func (r *templateReconciler) lookupUncached(
 	ctx context.Context, src corev1alpha1.ObjectTemplateSource, key client.ObjectKey, obj client.Object,
 ) (found bool, err error) {
@@ -271,6 +631,14 @@ func copySourceItem(
 	sourceObj *unstructured.Unstructured,
 	sourcesConfig map[string]any,
 ) error {
+	if len(item.Expression) > 0 {
+		value, err := evaluateExpression(item.Expression, sourceObj)
+		if err != nil {
+			return err
+		}
+		return setDestination(sourcesConfig, item.Destination, value)
+	}
+
 	jpString, err := RelaxedJSONPathExpression(item.Key)
 	if err != nil {
 		return err
@@ -278,6 +646,7 @@ func copySourceItem(
 
 	jp := jsonpath.New("key")
 	jp.EnableJSONOutput(true)
+	jp.AllowMissingKeys(true)
 	if err := jp.Parse(jpString); err != nil {
 		return err
 	}
@@ -290,45 +659,110 @@ func copySourceItem(
 	if err := json.Unmarshal(buf.Bytes(), &value); err != nil {
 		return err
 	}
-	if vslice, ok := value.([]any); ok && len(vslice) == 1 {
+	vslice, ok := value.([]any)
+	if ok && len(vslice) == 0 {
+		return &SourceKeyNotFoundError{Key: item.Key}
+	}
+	if ok && len(vslice) == 1 {
 		value = vslice[0]
 	}
 
-	if string(item.Destination[0]) != "." {
-		return &JSONPathFormatError{Path: item.Destination}
+	return setDestination(sourcesConfig, item.Destination, value)
+}
+
+// Stores value at the JSONPath destination in sourcesConfig.
+func setDestination(sourcesConfig map[string]any, destination string, value any) error {
+	if len(destination) == 0 || string(destination[0]) != "." {
+		return &JSONPathFormatError{Path: destination}
 	}
-	trimmedDestination := strings.TrimPrefix(item.Destination, ".")
+	trimmedDestination := strings.TrimPrefix(destination, ".")
 	if err := unstructured.SetNestedField(sourcesConfig, value, strings.Split(trimmedDestination, ".")...); err != nil {
-		return fmt.Errorf("setting nested field at %s: %w", item.Destination, err)
+		return fmt.Errorf("setting nested field at %s: %w", destination, err)
 	}
 
 	return nil
 }
 
-func (r *templateReconciler) templateObject(
+// Renders the template into all objects of its YAML document stream.
+// namespace is the namespace selected by the namespace selector, if any.
+func (r *templateReconciler) templateObjects(
 	ctx context.Context, sourcesConfig map[string]any,
-	objectTemplate adapters.ObjectTemplateAccessor, object client.Object,
-) error {
+	objectTemplate adapters.ObjectTemplateAccessor, namespace *corev1.Namespace,
+) ([]*unstructured.Unstructured, error) {
 	env, err := r.getEnvironment(ctx, objectTemplate.ClientObject().GetNamespace())
 	if err != nil {
-		return fmt.Errorf("getting environment: %w", err)
+		return nil, fmt.Errorf("getting environment: %w", err)
 	}
 	templateContext := TemplateContext{
 		Config:      sourcesConfig,
 		Environment: env,
 	}
+	var namespaceName string
+	if namespace != nil {
+		namespaceName = namespace.Name
+		templateContext.Namespace, err = runtime.DefaultUnstructuredConverter.ToUnstructured(namespace)
+		if err != nil {
+			return nil, fmt.Errorf("converting namespace: %w", err)
+		}
+		unstructured.RemoveNestedField(templateContext.Namespace, "metadata", "managedFields")
+	}
 	transformer, err := NewTemplateTransformer(templateContext)
 	if err != nil {
-		return fmt.Errorf("creating transformer: %w", err)
+		return nil, fmt.Errorf("creating transformer: %w", err)
 	}
 	renderedTemplate, err := transformer.transform(ctx, []byte(objectTemplate.GetTemplate()))
 	if err != nil {
-		return fmt.Errorf("rendering template: %w", err)
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+
+	var (
+		objs   []*unstructured.Unstructured
+		seen   = map[corev1alpha1.ControlledObjectReference]struct{}{}
+		reader = utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(renderedTemplate)))
+	)
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading yaml of rendered template: %w", err)
+		}
+
+		obj := &unstructured.Unstructured{
+			Object: map[string]any{},
+		}
+		if err := yaml.Unmarshal(document, &obj.Object); err != nil {
+			return nil, fmt.Errorf("unmarshalling yaml of rendered template: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			// Empty document, e.g. produced by a conditional block.
+			continue
+		}
+		if err := r.prepareObject(ctx, objectTemplate, obj, namespaceName); err != nil {
+			return nil, err
+		}
+
+		ref := newControlledObjectReference(obj)
+		if _, ok := seen[ref]; ok {
+			return nil, &TemplateError{Err: fmt.Errorf(
+				"rendered template contains %s %s more than once", ref.Kind, client.ObjectKeyFromObject(obj))}
+		}
+		seen[ref] = struct{}{}
+		objs = append(objs, obj)
 	}
+	return objs, nil
+}
 
-	if err := yaml.Unmarshal(renderedTemplate, object); err != nil {
-		return fmt.Errorf("unmarshalling yaml of rendered template: %w", err)
+// Runs preflight checks on a templated object and scopes it to the ObjectTemplate.
+// Objects without a namespace are defaulted into namespace, if given.
+func (r *templateReconciler) prepareObject(
+	ctx context.Context, objectTemplate adapters.ObjectTemplateAccessor, object client.Object, namespace string,
+) error {
+	if len(namespace) > 0 && len(object.GetNamespace()) == 0 {
+		object.SetNamespace(namespace)
 	}
+
 	violations, err := r.preflightChecker.Check(ctx, objectTemplate.ClientObject(), object)
 	if err != nil {
 		return err
@@ -415,6 +849,37 @@ func updateStatusConditionsFromOwnedObject(
 	return nil
 }
 
+// Reports whether all sources and keys referenced by the ObjectTemplate could be found.
+// err is the error returned while copying values from sources.
+func setSourcesAvailableCondition(
+	objectTemplate adapters.ObjectTemplateAccessor, observed sourceObservation, err error,
+) {
+	cond := metav1.Condition{
+		Type:               corev1alpha1.ObjectTemplateSourcesAvailable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: objectTemplate.GetGeneration(),
+		Reason:             "Available",
+		Message:            "All sources are available.",
+	}
+
+	switch {
+	case isMissingResourceError(err):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "SourceNotFound"
+		cond.Message = err.Error()
+	case isMissingKeyError(err):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "KeyNotFound"
+		cond.Message = err.Error()
+	case err != nil:
+		// Source availability is unknown.
+		return
+	case len(observed.missingOptional) > 0:
+		cond.Message = "Optional sources not found: " + strings.Join(observed.missingOptional, ", ") + "."
+	}
+	meta.SetStatusCondition(objectTemplate.GetStatusConditions(), cond)
+}
+
 func setObjectTemplateConditionBasedOnError(objectTemplate adapters.ObjectTemplateAccessor, err error) error {
 	var sourceError *SourceError
 	if errors.As(err, &sourceError) {
@@ -485,10 +950,21 @@ func isMissingResourceError(err error) bool {
 	return false
 }
 
+func isMissingKeyError(err error) bool {
+	var (
+		sourceError      *SourceError
+		keyNotFoundError *SourceKeyNotFoundError
+	)
+	if errors.As(err, &sourceError) {
+		return errors.As(sourceError.Err, &keyNotFoundError)
+	}
+	return false
+}
+
 func (r *templateReconciler) aggregateLocalObjects(
 	ctx context.Context,
 	objectTemplate adapters.ObjectTemplateAccessor,
-	outputObjectRef corev1alpha1.ControlledObjectReference,
+	outputObjectRefs []corev1alpha1.ControlledObjectReference,
 ) ([]client.Object, error) {
 	objects := []client.Object{}
 	for _, src := range objectTemplate.GetSources() {
@@ -499,23 +975,25 @@ func (r *templateReconciler) aggregateLocalObjects(
 		objects = append(objects, srcObject)
 	}
 
-	// first reconcile, unknown output gvk
-	// Allow group to be empty as core apis have no group
-	if outputObjectRef.Kind == "" || outputObjectRef.Name == "" || outputObjectRef.Version == "" {
-		return objects, nil
-	}
+	for _, outputObjectRef := range outputObjectRefs {
+		// first reconcile, unknown output gvk
+		// Allow group to be empty as core apis have no group
+		if outputObjectRef.Kind == "" || outputObjectRef.Name == "" || outputObjectRef.Version == "" {
+			continue
+		}
 
-	outputObject := &unstructured.Unstructured{}
-	outputObject.SetName(outputObjectRef.Name)
-	outputObject.SetNamespace(outputObjectRef.Namespace)
-	gvk := schema.GroupVersionKind{
-		Group:   outputObjectRef.Group,
-		Version: outputObjectRef.Version,
-		Kind:    outputObjectRef.Kind,
-	}
-	outputObject.SetGroupVersionKind(gvk)
+		outputObject := &unstructured.Unstructured{}
+		outputObject.SetName(outputObjectRef.Name)
+		outputObject.SetNamespace(outputObjectRef.Namespace)
+		gvk := schema.GroupVersionKind{
+			Group:   outputObjectRef.Group,
+			Version: outputObjectRef.Version,
+			Kind:    outputObjectRef.Kind,
+		}
+		outputObject.SetGroupVersionKind(gvk)
 
-	objects = append(objects, outputObject)
+		objects = append(objects, outputObject)
+	}
 	return objects, nil
 }
 
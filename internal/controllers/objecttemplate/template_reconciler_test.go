@@ -11,10 +11,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -188,6 +192,31 @@ func Test_copySourceItems(t *testing.T) {
 	}
 }
 
+func Test_copySourceItems_expression(t *testing.T) {
+	t.Parallel()
+	sourceObj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"data": map[string]any{
+				"password": "aHVudGVyMg==",
+			},
+		},
+	}
+	sourcesConfig := map[string]any{}
+	items := []corev1alpha1.ObjectTemplateSourceItem{
+		{Key: ".data.password", Destination: ".encoded"},
+		{Expression: "base64.decode(object.data.password)", Destination: ".password"},
+		{Expression: "object.?data.user.orValue('admin')", Destination: ".user"},
+	}
+	err := copySourceItems(
+		items, sourceObj, sourcesConfig)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"encoded":  "aHVudGVyMg==",
+		"password": "hunter2",
+		"user":     "admin",
+	}, sourcesConfig)
+}
+
 func Test_copySourceItems_notfound(t *testing.T) {
 	t.Parallel()
 	sourceObj := &unstructured.Unstructured{
@@ -199,7 +228,9 @@ func Test_copySourceItems_notfound(t *testing.T) {
 	}
 	err := copySourceItems(
 		items, sourceObj, sourcesConfig)
-	require.EqualError(t, err, "data is not found")
+	var keyNotFoundErr *SourceKeyNotFoundError
+	require.ErrorAs(t, err, &keyNotFoundErr)
+	assert.Equal(t, ".data.something", keyNotFoundErr.Key)
 }
 
 func Test_copySourceItems_nonJSONPath_destination(t *testing.T) {
@@ -220,6 +251,173 @@ func Test_copySourceItems_nonJSONPath_destination(t *testing.T) {
 	require.EqualError(t, err, "path banana must be a JSONPath with a leading dot")
 }
 
+func Test_templateReconciler_copySourceList(t *testing.T) {
+	t.Parallel()
+
+	newSecret := func(name string, labels map[string]string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"data":       map[string]any{"token": name + "-token"},
+		}}
+		obj.SetName(name)
+		obj.SetNamespace("default")
+		obj.SetLabels(labels)
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "test"}})
+		return obj
+	}
+
+	tests := []struct {
+		name     string
+		source   corev1alpha1.ObjectTemplateSource
+		expected map[string]any
+	}{
+		{
+			name: "list with items",
+			source: corev1alpha1.ObjectTemplateSource{
+				Items: []corev1alpha1.ObjectTemplateSourceItem{
+					{Key: ".metadata.name", Destination: ".name"},
+					{Key: ".data.token", Destination: ".token"},
+				},
+			},
+			expected: map[string]any{"secrets": []any{
+				map[string]any{"name": "a", "token": "a-token"},
+				map[string]any{"name": "b", "token": "b-token"},
+			}},
+		},
+		{
+			name: "list of expression results",
+			source: corev1alpha1.ObjectTemplateSource{
+				Expression: "object.metadata.name + '=' + object.data.token",
+			},
+			expected: map[string]any{"secrets": []any{"a=a-token", "b=b-token"}},
+		},
+		{
+			name: "map of whole objects",
+			source: corev1alpha1.ObjectTemplateSource{
+				Format: corev1alpha1.ObjectTemplateSourceFormatMap,
+			},
+			expected: map[string]any{"secrets": map[string]any{
+				"a": map[string]any{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"data":       map[string]any{"token": "a-token"},
+					"metadata": map[string]any{
+						"name":      "a",
+						"namespace": "default",
+						"labels":    map[string]any{"app": "test"},
+					},
+				},
+				"b": map[string]any{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"data":       map[string]any{"token": "b-token"},
+					"metadata": map[string]any{
+						"name":      "b",
+						"namespace": "default",
+						"labels":    map[string]any{"app": "test"},
+					},
+				},
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			accessor := &managedcachemocks.AccessorMock{}
+			r := &templateReconciler{
+				preflightChecker: preflight.List{},
+			}
+
+			accessor.
+				On("List", mock.Anything, mock.AnythingOfType("*unstructured.UnstructuredList"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*unstructured.UnstructuredList)
+					assert.Equal(t, "SecretList", list.GetKind())
+					// Unordered.
+					list.Items = []unstructured.Unstructured{
+						newSecret("b", map[string]string{"app": "test"}),
+						newSecret("a", map[string]string{"app": "test"}),
+					}
+				}).
+				Return(nil)
+
+			src := test.source
+			src.APIVersion = "v1"
+			src.Kind = "Secret"
+			src.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+			src.Destination = ".secrets"
+
+			objectTemplate := &adapters.GenericObjectTemplate{}
+			objectTemplate.Namespace = "default"
+
+			sourcesConfig := map[string]any{}
+			observed, err := r.copySourceList(context.Background(), objectTemplate, src, sourcesConfig, accessor)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, sourcesConfig)
+			assert.Equal(t, []corev1alpha1.ObjectTemplateObservedSource{
+				{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "a"},
+				{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "b"},
+			}, observed)
+			accessor.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_templateReconciler_selectorSourceCache(t *testing.T) {
+	t.Parallel()
+
+	accessManager := &managedcachemocks.ObjectBoundAccessManagerMock[client.Object]{}
+	r := &templateReconciler{
+		accessManager:    accessManager,
+		preflightChecker: preflight.List{},
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+
+	t.Run("namespaced ObjectTemplate", func(t *testing.T) {
+		t.Parallel()
+
+		objectTemplate := &adapters.GenericObjectTemplate{}
+		objectTemplate.Namespace = "default"
+		objectTemplate.Spec.Sources = []corev1alpha1.ObjectTemplateSource{
+			{APIVersion: "v1", Kind: "Secret", Selector: selector},
+		}
+
+		cache := &managedcachemocks.AccessorMock{}
+		listCache, err := r.selectorSourceCache(context.Background(), objectTemplate, "default", cache)
+		require.NoError(t, err)
+		assert.Same(t, cache, listCache)
+	})
+
+	t.Run("ClusterObjectTemplate", func(t *testing.T) {
+		t.Parallel()
+
+		objectTemplate := &adapters.GenericClusterObjectTemplate{}
+		objectTemplate.Name = "test"
+		objectTemplate.Spec.Sources = []corev1alpha1.ObjectTemplateSource{
+			{APIVersion: "v1", Kind: "Secret", Namespace: "default", Selector: selector},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "named"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other", Selector: selector},
+		}
+
+		namespaceCache := &managedcachemocks.AccessorMock{}
+		accessManager.
+			On("GetWithUser", mock.Anything, namespaceCacheOwner("default"), objectTemplate.ClientObject(),
+				mock.MatchedBy(func(usedFor []client.Object) bool {
+					return len(usedFor) == 1 &&
+						usedFor[0].GetObjectKind().GroupVersionKind().Kind == "Secret"
+				})).
+			Return(namespaceCache, nil).Once()
+
+		listCache, err := r.selectorSourceCache(
+			context.Background(), objectTemplate, "default", &managedcachemocks.AccessorMock{})
+		require.NoError(t, err)
+		assert.Same(t, namespaceCache, listCache)
+		accessManager.AssertExpectations(t)
+	})
+}
+
 func Test_templateReconciler_templateObject(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -259,7 +457,6 @@ func Test_templateReconciler_templateObject(t *testing.T) {
 				},
 			}
 
-			pkg := &corev1alpha1.Package{}
 			sourcesConfig := map[string]any{
 				"Database":      "asdf",
 				"username1":     "user",
@@ -267,9 +464,12 @@ func Test_templateReconciler_templateObject(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			err = r.templateObject(ctx, sourcesConfig, &objectTemplate, pkg)
-
+			objs, err := r.templateObjects(ctx, sourcesConfig, &objectTemplate, nil)
 			require.NoError(t, err)
+			require.Len(t, objs, 1)
+
+			pkg := &corev1alpha1.Package{}
+			require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(objs[0].Object, pkg))
 
 			for key, value := range sourcesConfig {
 				config := map[string]any{}
@@ -280,6 +480,193 @@ func Test_templateReconciler_templateObject(t *testing.T) {
 	}
 }
 
+func Test_templateReconciler_templateObjects_multiple(t *testing.T) {
+	t.Parallel()
+
+	r := &templateReconciler{
+		Sink:             environment.NewSink(nil),
+		preflightChecker: preflight.List{},
+	}
+	r.SetEnvironment(&manifests.PackageEnvironment{})
+
+	objectTemplate := &adapters.GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				Template: `{{- range .config.names }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ . }}
+{{- end }}
+---
+`,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	objs, err := r.templateObjects(ctx, map[string]any{"names": []any{"a", "b"}}, objectTemplate, nil)
+	require.NoError(t, err)
+	if assert.Len(t, objs, 2) {
+		for i, name := range []string{"a", "b"} {
+			assert.Equal(t, name, objs[i].GetName())
+			assert.Equal(t, "default", objs[i].GetNamespace())
+			assert.Equal(t, "True", objs[i].GetLabels()[constants.DynamicCacheLabel])
+		}
+	}
+
+	_, err = r.templateObjects(ctx, map[string]any{"names": []any{"a", "a"}}, objectTemplate, nil)
+	var templateErr *TemplateError
+	require.ErrorAs(t, err, &templateErr)
+
+	objs, err = r.templateObjects(ctx, map[string]any{"names": []any{}}, objectTemplate, nil)
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+}
+
+func Test_templateReconciler_reconcileNamespaces(t *testing.T) {
+	t.Parallel()
+
+	r, c, _, _ := newControllerAndMocks(t)
+	r.SetEnvironment(&manifests.PackageEnvironment{})
+	accessor := &managedcachemocks.AccessorMock{}
+
+	objectTemplate := &adapters.GenericClusterObjectTemplate{
+		ClusterObjectTemplate: corev1alpha1.ClusterObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				UID:  "test-uid",
+			},
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				Template: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  team: {{ .namespace.metadata.labels.team }}
+`,
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"pull-secret": "true"},
+				},
+			},
+		},
+	}
+	newNamespace := func(name string) corev1.Namespace {
+		return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"pull-secret": "true", "team": name + "-team"},
+		}}
+	}
+	ref := func(namespace string) corev1alpha1.ControlledObjectReference {
+		return corev1alpha1.ControlledObjectReference{
+			Kind: "ConfigMap", Version: "v1", Name: "test", Namespace: namespace,
+		}
+	}
+
+	c.On("List", mock.Anything, mock.AnythingOfType("*v1.NamespaceList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			terminating := newNamespace("terminating")
+			terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			args.Get(1).(*corev1.NamespaceList).Items = []corev1.Namespace{
+				newNamespace("b"), newNamespace("a"), terminating,
+			}
+		}).
+		Return(nil)
+	accessor.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apimachineryerrors.NewNotFound(schema.GroupResource{}, "test"))
+	var created []*unstructured.Unstructured
+	c.On("Create", mock.Anything, mock.MatchedBy(func(obj client.Object) bool {
+		return obj.GetNamespace() == "a"
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = append(created, args.Get(1).(*unstructured.Unstructured))
+		}).
+		Return(nil)
+	c.On("Create", mock.Anything, mock.Anything, mock.Anything).
+		Return(errTest)
+
+	controllerOf, rendered, status, err := r.reconcileNamespaces(
+		context.Background(), objectTemplate, accessor, map[string]any{},
+		[]corev1alpha1.ControlledObjectReference{ref("b"), ref("removed")})
+	require.NoError(t, err)
+
+	// b failed, so its object is kept, while the object in the removed namespace is not.
+	assert.Equal(t, []corev1alpha1.ControlledObjectReference{ref("a"), ref("b")}, controllerOf)
+	assert.Equal(t, &corev1alpha1.ObjectTemplateNamespacesStatus{
+		Selected: 2,
+		Ready:    1,
+		Failed: []corev1alpha1.ObjectTemplateNamespaceFailure{{
+			Name:    "b",
+			Message: "handling creation: creating object: something",
+		}},
+	}, status)
+	if assert.Len(t, created, 1) {
+		team, _, _ := unstructured.NestedString(created[0].Object, "data", "team")
+		assert.Equal(t, "a-team", team)
+	}
+	// Rendered objects are not changed by reconciling them.
+	if assert.Len(t, rendered, 1) {
+		assert.Equal(t, "a", rendered[0].GetNamespace())
+		assert.Empty(t, rendered[0].GetOwnerReferences())
+	}
+}
+
+func Test_templateReconciler_deleteStaleObjects(t *testing.T) {
+	t.Parallel()
+
+	r, c, _, _ := newControllerAndMocks(t)
+	accessor := &managedcachemocks.AccessorMock{}
+
+	objectTemplate := &adapters.GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+				UID:       "test-uid",
+			},
+		},
+	}
+	ref := func(name string) corev1alpha1.ControlledObjectReference {
+		return corev1alpha1.ControlledObjectReference{
+			Kind: "ConfigMap", Version: "v1", Name: name, Namespace: "default",
+		}
+	}
+
+	accessor.
+		On("Get", mock.Anything, client.ObjectKey{Name: "stale", Namespace: "default"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.SetName("stale")
+			obj.SetNamespace("default")
+			obj.SetOwnerReferences([]metav1.OwnerReference{{
+				Name: "test", UID: "test-uid", Controller: new(true),
+			}})
+		}).
+		Return(nil)
+	accessor.
+		On("Get", mock.Anything, client.ObjectKey{Name: "adopted", Namespace: "default"}, mock.Anything, mock.Anything).
+		Return(nil)
+	accessor.
+		On("Get", mock.Anything, client.ObjectKey{Name: "gone", Namespace: "default"}, mock.Anything, mock.Anything).
+		Return(apimachineryerrors.NewNotFound(schema.GroupResource{}, "gone"))
+	c.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := r.deleteStaleObjects(context.Background(), objectTemplate, accessor,
+		[]corev1alpha1.ControlledObjectReference{ref("current"), ref("stale"), ref("adopted"), ref("gone")},
+		[]corev1alpha1.ControlledObjectReference{ref("current")})
+	require.NoError(t, err)
+
+	c.AssertExpectations(t)
+	c.AssertCalled(t, "Delete", mock.Anything, mock.MatchedBy(func(obj client.Object) bool {
+		return obj.GetName() == "stale"
+	}), mock.Anything)
+	accessor.AssertExpectations(t)
+}
+
 func Test_updateStatusConditionsFromOwnedObject(t *testing.T) {
 	t.Parallel()
 
@@ -437,6 +824,9 @@ func Test_templateReconcilerReconcile(t *testing.T) {
 			res, err := r.Reconcile(context.Background(), objectTemplate)
 			assert.Empty(t, res)
 			require.NoError(t, err)
+			assert.NotEmpty(t, objectTemplate.Status.RenderedHash)
+			assert.True(t, meta.IsStatusConditionTrue(
+				objectTemplate.Status.Conditions, corev1alpha1.ObjectTemplateSourcesAvailable))
 			client.AssertExpectations(t)
 			accessManager.AssertExpectations(t)
 			accessor.AssertExpectations(t)
@@ -570,6 +960,83 @@ func Test_setObjectTemplateConditionBasedOnError(t *testing.T) {
 	}
 }
 
+func Test_setSourcesAvailableCondition(t *testing.T) {
+	t.Parallel()
+
+	source := &unstructured.Unstructured{}
+	source.SetKind("Secret")
+	source.SetName("test")
+	source.SetNamespace("test-ns")
+
+	tests := []struct {
+		name            string
+		observed        sourceObservation
+		err             error
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "all available",
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "Available",
+			expectedMessage: "All sources are available.",
+		},
+		{
+			name: "optional source missing",
+			observed: sourceObservation{
+				missingOptional: []string{"Secret test-ns/optional"},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "Available",
+			expectedMessage: "Optional sources not found: Secret test-ns/optional.",
+		},
+		{
+			name: "source missing",
+			err: &SourceError{
+				Source: source,
+				Err:    apimachineryerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "test"),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "SourceNotFound",
+			expectedMessage: `for source Secret test-ns/test: secrets "test" not found`,
+		},
+		{
+			name: "key missing",
+			err: &SourceError{
+				Source: source,
+				Err:    &SourceKeyNotFoundError{Key: ".data.password"},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "KeyNotFound",
+			expectedMessage: "for source Secret test-ns/test: key .data.password not found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			objectTemplate := &adapters.GenericObjectTemplate{}
+			setSourcesAvailableCondition(objectTemplate, test.observed, test.err)
+
+			cond := meta.FindStatusCondition(
+				objectTemplate.Status.Conditions, corev1alpha1.ObjectTemplateSourcesAvailable)
+			require.NotNil(t, cond)
+			assert.Equal(t, test.expectedStatus, cond.Status)
+			assert.Equal(t, test.expectedReason, cond.Reason)
+			assert.Equal(t, test.expectedMessage, cond.Message)
+		})
+	}
+
+	t.Run("other errors", func(t *testing.T) {
+		t.Parallel()
+
+		objectTemplate := &adapters.GenericObjectTemplate{}
+		setSourcesAvailableCondition(objectTemplate, sourceObservation{}, errTest)
+		assert.Empty(t, objectTemplate.Status.Conditions)
+	})
+}
+
 func TestRequeueDurationOnMissingSource(t *testing.T) {
 	t.Parallel()
 	t.Run("missing optional source returns configured optionalResourceRetryInterval", func(t *testing.T) {
@@ -679,6 +1146,97 @@ func TestRequeueDurationOnMissingSource(t *testing.T) {
 		accessor.AssertExpectations(t)
 	})
 
+	t.Run("missing source of namespaced ObjectTemplate is rendered once created", func(t *testing.T) {
+		t.Parallel()
+		r, client, uncachedClient, accessManager := newControllerAndMocks(t)
+		r.optionalResourceRetryInterval = optionalResourceRetryInterval
+		r.resourceRetryInterval = resourceRetryInterval
+
+		template, err := os.ReadFile("testdata/package_template_to_json.yaml")
+		require.NoError(t, err)
+		objectTemplate := &adapters.GenericObjectTemplate{
+			ObjectTemplate: corev1alpha1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-ns",
+				},
+				Spec: corev1alpha1.ObjectTemplateSpec{
+					Template: string(template),
+					Sources: []corev1alpha1.ObjectTemplateSource{
+						{
+							APIVersion: "v1",
+							Kind:       "ConfigMap",
+							Name:       "test",
+							Items: []corev1alpha1.ObjectTemplateSourceItem{
+								{Key: ".data.database", Destination: ".database"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		// The ObjectTemplate uses the cache of its namespace, to see sources without the dynamic cache label.
+		accessor := &managedcachemocks.AccessorMock{}
+		accessManager.
+			On("GetWithUser", mock.Anything,
+				namespaceCacheOwner("test-ns"), objectTemplate.ClientObject(), mock.Anything).
+			Return(accessor, nil)
+
+		sourceKey := types.NamespacedName{Name: "test", Namespace: "test-ns"}
+		accessor.
+			On("Get", mock.Anything, sourceKey, mock.Anything, mock.Anything).
+			Return(apimachineryerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "test")).
+			Once()
+		uncachedClient.
+			On("Get", mock.Anything, sourceKey, mock.Anything, mock.Anything).
+			Return(apimachineryerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "test")).
+			Once()
+
+		res, err := r.Reconcile(context.Background(), objectTemplate)
+		require.NoError(t, err)
+		assert.Equal(t, resourceRetryInterval, res.RequeueAfter)
+		assert.Empty(t, objectTemplate.Status.RenderedHash)
+		assert.False(t, meta.IsStatusConditionTrue(
+			objectTemplate.Status.Conditions, corev1alpha1.ObjectTemplateSourcesAvailable))
+
+		// Source is created.
+		accessor.
+			On("Get", mock.Anything, sourceKey, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(2).(*unstructured.Unstructured)
+				obj.Object["data"] = map[string]any{"database": "big"}
+			}).
+			Return(nil)
+		accessor.
+			On("Get", mock.Anything, types.NamespacedName{Name: "test-stub", Namespace: "test-ns"},
+				mock.Anything, mock.Anything).
+			Return(nil)
+		var updated *unstructured.Unstructured
+		client.
+			On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				updated = args.Get(1).(*unstructured.Unstructured)
+			}).
+			Return(nil)
+
+		res, err = r.Reconcile(context.Background(), objectTemplate)
+		require.NoError(t, err)
+		assert.True(t, res.IsZero())
+		assert.NotEmpty(t, objectTemplate.Status.RenderedHash)
+		assert.True(t, meta.IsStatusConditionTrue(
+			objectTemplate.Status.Conditions, corev1alpha1.ObjectTemplateSourcesAvailable))
+		require.NotNil(t, updated)
+		database, _, err := unstructured.NestedString(updated.Object, "spec", "config", "database")
+		require.NoError(t, err)
+		assert.Equal(t, "big", database)
+
+		uncachedClient.AssertExpectations(t)
+		client.AssertExpectations(t)
+		accessManager.AssertExpectations(t)
+		accessor.AssertExpectations(t)
+	})
+
 	t.Run("reconciler returns error on non missing source errors", func(t *testing.T) {
 		t.Parallel()
 		r, _, uncachedClient, accessManager := newControllerAndMocks(t)
@@ -11,6 +11,8 @@ import (
 type TemplateContext struct {
 	Config      map[string]any `json:"config"`
 	Environment map[string]any `json:"environment"`
+	// Namespace selected by the namespace selector of a ClusterObjectTemplate.
+	Namespace map[string]any `json:"namespace,omitempty"`
 }
 
 type TemplateTransformer struct {
@@ -6,17 +6,26 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
 	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/environment"
-	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
 )
@@ -52,13 +61,14 @@ func NewPackageController(
 	imagePuller imagePuller,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
+	imagePolicies *imageverify.PolicyResolver,
 ) *GenericPackageController {
 	return newGenericPackageController(
 		adapters.NewGenericPackage, adapters.NewObjectDeployment,
 		c, uncachedClient, log, scheme, imagePuller,
-		packages.NewPackageDeployer(c, uncachedClient, scheme, imagePrefixOverrides),
-		metricsRecorder, packageHashModifier, imagePrefixOverrides,
+		packages.NewPackageDeployer(c, uncachedClient, scheme, imageMirrors),
+		metricsRecorder, packageHashModifier, imageMirrors, imagePolicies,
 	)
 }
 
@@ -68,12 +78,13 @@ func NewClusterPackageController(
 	imagePuller imagePuller,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
+	imagePolicies *imageverify.PolicyResolver,
 ) *GenericPackageController {
 	return newGenericPackageController(
 		adapters.NewGenericClusterPackage, adapters.NewClusterObjectDeployment,
-		c, uncachedClient, log, scheme, imagePuller, packages.NewClusterPackageDeployer(c, scheme, imagePrefixOverrides),
-		metricsRecorder, packageHashModifier, imagePrefixOverrides,
+		c, uncachedClient, log, scheme, imagePuller, packages.NewClusterPackageDeployer(c, scheme, imageMirrors),
+		metricsRecorder, packageHashModifier, imageMirrors, imagePolicies,
 	)
 }
 
@@ -86,7 +97,8 @@ func newGenericPackageController(
 	packageDeployer packageDeployer,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
+	imagePolicies *imageverify.PolicyResolver,
 ) *GenericPackageController {
 	controller := &GenericPackageController{
 		newPackage:          newPackage,
@@ -96,9 +108,9 @@ func newGenericPackageController(
 		log:                 log,
 		scheme:              scheme,
 		unpackReconciler: newUnpackReconciler(
-			uncachedClient, imagePuller, packageDeployer,
+			client, uncachedClient, scheme, newObjectDeployment, imagePuller, packageDeployer,
 			metricsRecorder, environment.NewSink(client), packageHashModifier,
-			imagePrefixOverrides,
+			imageMirrors, imagePolicies,
 		),
 		objDepStatusReconciler: &objectDeploymentStatusReconciler{
 			client:              client,
@@ -123,11 +135,79 @@ func (c *GenericPackageController) SetupWithManager(mgr ctrl.Manager) error {
 	pkg := c.newPackage(c.scheme).ClientObject()
 	objDep := c.newObjectDeployment(c.scheme).ClientObject()
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 5}).
 		For(pkg).
 		Owns(objDep).
-		Complete(c)
+		Watches(
+			&corev1alpha1.ClusterImageMirrorSet{},
+			handler.EnqueueRequestsFromMapFunc(c.enqueueAllPackages),
+		).
+		Watches(
+			&corev1alpha1.ClusterImageVerificationPolicy{},
+			handler.EnqueueRequestsFromMapFunc(c.enqueueAllPackages),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(c.enqueuePackagesForPublicKeySecret),
+			builder.OnlyMetadata,
+		)
+	if _, ok := pkg.(*corev1alpha1.Package); ok {
+		// ClusterImageMirrorSets and ClusterImageVerificationPolicies select namespaces by label,
+		// so label changes may change the mirrors and policies of a Package.
+		b = b.Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(c.enqueueNamespacePackages),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		)
+	}
+	return b.Complete(c)
+}
+
+// Image mirrors and verification policies may apply to any package,
+// so all of them have to be re-evaluated on change.
+func (c *GenericPackageController) enqueueAllPackages(ctx context.Context, _ client.Object) []reconcile.Request {
+	return c.enqueuePackages(ctx)
+}
+
+// Public keys referenced by verification policies may have been rotated or revoked,
+// so all packages have to be verified again.
+func (c *GenericPackageController) enqueuePackagesForPublicKeySecret(
+	ctx context.Context, secret client.Object,
+) []reconcile.Request {
+	referenced, err := c.unpackReconciler.imagePolicies.ReferencesSecret(ctx, client.ObjectKeyFromObject(secret))
+	if err != nil || !referenced {
+		return nil
+	}
+	return c.enqueuePackages(ctx)
+}
+
+// Image mirrors and verification policies of all packages in the namespace
+// have to be re-evaluated when its labels change.
+func (c *GenericPackageController) enqueueNamespacePackages(
+	ctx context.Context, ns client.Object,
+) []reconcile.Request {
+	return c.enqueuePackages(ctx, client.InNamespace(ns.GetName()))
+}
+
+func (c *GenericPackageController) enqueuePackages(
+	ctx context.Context, opts ...client.ListOption,
+) []reconcile.Request {
+	gvk, err := apiutil.GVKForObject(c.newPackage(c.scheme).ClientObject(), c.scheme)
+	if err != nil {
+		return nil
+	}
+	pkgList := &metav1.PartialObjectMetadataList{}
+	pkgList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.client.List(ctx, pkgList, opts...); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(pkgList.Items))
+	for i, pkg := range pkgList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pkg)}
+	}
+	return requests
 }
 
 func (c *GenericPackageController) Reconcile(
@@ -7,15 +7,19 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
 	"package-operator.run/internal/testutil"
@@ -46,6 +50,7 @@ func TestPackageController_Err(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 
 	clientMock.
@@ -79,6 +84,7 @@ func TestPackageController_NotFound(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	c.reconciler = nil
 
@@ -132,6 +138,7 @@ func TestPackageController_Paused(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	c.reconciler = nil
 
@@ -180,6 +187,7 @@ func TestPackageController_Reconcile(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	c.reconciler = nil
 
@@ -227,6 +235,7 @@ func TestClusterPackageController_Err(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	clientMock.
 		On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.ClusterPackage"), mock.Anything).
@@ -259,6 +268,7 @@ func TestClusterOPackageController_NotFound(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	c.reconciler = nil
 
@@ -312,6 +322,7 @@ func TestClusterPackageController_Paused(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	c.reconciler = nil
 
@@ -360,6 +371,7 @@ func TestClusterPackageController_Reconcile(t *testing.T) {
 		mr,
 		&hash,
 		nil,
+		nil,
 	)
 	c.reconciler = nil
 
@@ -388,3 +400,105 @@ func TestClusterPackageController_Reconcile(t *testing.T) {
 	clientMock.AssertExpectations(t)
 	clientMock.StatusMock.AssertExpectations(t)
 }
+
+func TestPackageController_enqueueAllPackages(t *testing.T) {
+	t.Parallel()
+
+	clientMock := testutil.NewClient()
+	c := NewPackageController(
+		clientMock, clientMock, ctrl.Log.WithName("package test"), packageScheme,
+		&imagePullerMock{}, metrics.NewRecorder(), nil, nil, nil,
+	)
+
+	clientMock.
+		On("List", mock.Anything, mock.AnythingOfType("*v1.PartialObjectMetadataList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*metav1.PartialObjectMetadataList)
+			assert.Equal(t, "PackageList", list.Kind)
+			list.Items = []metav1.PartialObjectMetadata{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns-2"}},
+			}
+		}).
+		Return(nil)
+
+	requests := c.enqueueAllPackages(context.Background(), &corev1alpha1.ClusterImageMirrorSet{})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Name: "a", Namespace: "ns-1"}},
+		{NamespacedName: client.ObjectKey{Name: "b", Namespace: "ns-2"}},
+	}, requests)
+}
+
+func TestPackageController_enqueueNamespacePackages(t *testing.T) {
+	t.Parallel()
+
+	clientMock := testutil.NewClient()
+	c := NewPackageController(
+		clientMock, clientMock, ctrl.Log.WithName("package test"), packageScheme,
+		&imagePullerMock{}, metrics.NewRecorder(), nil, nil, nil,
+	)
+
+	clientMock.
+		On("List", mock.Anything, mock.AnythingOfType("*v1.PartialObjectMetadataList"),
+			[]client.ListOption{client.InNamespace("ns-1")}).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*metav1.PartialObjectMetadataList)
+			list.Items = []metav1.PartialObjectMetadata{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns-1"}},
+			}
+		}).
+		Return(nil)
+
+	requests := c.enqueueNamespacePackages(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns-1"},
+	})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Name: "a", Namespace: "ns-1"}},
+	}, requests)
+	clientMock.AssertExpectations(t)
+}
+
+func TestPackageController_enqueuePackagesForPublicKeySecret(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	policyClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1alpha1.ClusterImageVerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+				PublicKeys: []corev1alpha1.PublicKeySecretReference{
+					{Name: "keys", Namespace: "package-operator-system"},
+				},
+			},
+		},
+	).Build()
+
+	clientMock := testutil.NewClient()
+	c := NewPackageController(
+		clientMock, clientMock, ctrl.Log.WithName("package test"), packageScheme,
+		&imagePullerMock{}, metrics.NewRecorder(), nil, nil, imageverify.NewPolicyResolver(policyClient),
+	)
+
+	clientMock.
+		On("List", mock.Anything, mock.AnythingOfType("*v1.PartialObjectMetadataList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*metav1.PartialObjectMetadataList)
+			list.Items = []metav1.PartialObjectMetadata{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns-1"}},
+			}
+		}).
+		Return(nil)
+
+	requests := c.enqueuePackagesForPublicKeySecret(context.Background(), &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "package-operator-system"},
+	})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Name: "a", Namespace: "ns-1"}},
+	}, requests)
+
+	// Secrets not referenced by any policy don't matter.
+	requests = c.enqueuePackagesForPublicKeySecret(context.Background(), &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "package-operator-system"},
+	})
+	assert.Empty(t, requests)
+}
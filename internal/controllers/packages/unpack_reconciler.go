@@ -2,21 +2,27 @@ package packages
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/flowcontrol"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/internal/adapters"
 	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/controllers"
-	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
 	"package-operator.run/internal/utils"
@@ -26,15 +32,19 @@ import (
 type unpackReconciler struct {
 	environmentSink
 
-	uncachedClient client.Client
+	client              client.Reader
+	uncachedClient      client.Client
+	scheme              *runtime.Scheme
+	newObjectDeployment adapters.ObjectDeploymentFactory
 
 	imagePuller         imagePuller
 	packageDeployer     packageDeployer
 	packageLoadRecorder packageLoadRecorder
 
-	backoff              *flowcontrol.Backoff
-	packageHashModifier  *int32
-	imagePrefixOverrides []imageprefix.Override
+	backoff             *flowcontrol.Backoff
+	packageHashModifier *int32
+	imageMirrors        *imagemirror.Resolver
+	imagePolicies       *imageverify.PolicyResolver
 }
 
 type environmentSink interface {
@@ -48,13 +58,17 @@ type packageLoadRecorder interface {
 }
 
 func newUnpackReconciler(
+	c client.Reader,
 	uncachedClient client.Client,
+	scheme *runtime.Scheme,
+	newObjectDeployment adapters.ObjectDeploymentFactory,
 	imagePuller imagePuller,
 	packageDeployer packageDeployer,
 	packageLoadRecorder packageLoadRecorder,
 	environmentSink environmentSink,
 	packageHashModifier *int32,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
+	imagePolicies *imageverify.PolicyResolver,
 ) *unpackReconciler {
 	var cfg unpackReconcilerConfig
 
@@ -63,18 +77,22 @@ func newUnpackReconciler(
 	return &unpackReconciler{
 		environmentSink,
 
+		c,
 		uncachedClient,
+		scheme,
+		newObjectDeployment,
 		imagePuller,
 		packageDeployer,
 		packageLoadRecorder,
 		cfg.GetBackoff(),
 		packageHashModifier,
-		imagePrefixOverrides,
+		imageMirrors,
+		imagePolicies,
 	}
 }
 
 type imagePuller interface {
-	Pull(ctx context.Context, image string) (*packages.RawPackage, error)
+	Pull(ctx context.Context, namespace, image string) (*packages.RawPackage, error)
 }
 
 type packageDeployer interface {
@@ -92,10 +110,19 @@ func (r *unpackReconciler) Reconcile(
 	// run back off garbage collection to prevent stale data building up.
 	defer r.backoff.GC()
 
-	specHash := pkg.GetSpecHash(r.packageHashModifier)
-	if len(r.imagePrefixOverrides) > 0 {
-		// upgrading from PKO without overrides won't cause repulls
-		specHash += utils.ComputeSHA256Hash(r.imagePrefixOverrides, nil)
+	mirrors, err := r.imageMirrors.Resolve(ctx, pkg.ClientObject().GetNamespace())
+	if err != nil {
+		return res, fmt.Errorf("resolving image mirrors: %w", err)
+	}
+
+	policyRevisions, err := r.imagePolicies.Revisions(ctx, pkg.ClientObject().GetNamespace())
+	if err != nil {
+		return res, fmt.Errorf("resolving image verification policies: %w", err)
+	}
+
+	specHash, err := r.unpackHash(ctx, r.client, pkg, mirrors, policyRevisions)
+	if err != nil {
+		return res, err
 	}
 
 	if pkg.GetStatusUnpackedHash() == specHash {
@@ -117,13 +144,18 @@ func (r *unpackReconciler) Reconcile(
 
 	pullStart := time.Now()
 	log := logr.FromContextOrDiscard(ctx)
-	rawPkg, err := r.imagePuller.Pull(ctx, pkg.GetSpecImage())
+	rawPkg, err := r.imagePuller.Pull(ctx, pkg.ClientObject().GetNamespace(), pkg.GetSpecImage())
 	if err != nil {
+		reason := "ImagePullBackOff"
+		if errors.Is(err, imageverify.ErrSignatureInvalid) {
+			// Signatures may still be pushed after the image, so keep retrying.
+			reason = "SignatureInvalid"
+		}
 		meta.SetStatusCondition(
 			pkg.GetStatusConditions(), metav1.Condition{
 				Type:               corev1alpha1.PackageUnpacked,
 				Status:             metav1.ConditionFalse,
-				Reason:             "ImagePullBackOff",
+				Reason:             reason,
 				Message:            err.Error(),
 				ObservedGeneration: pkg.ClientObject().GetGeneration(),
 			})
@@ -150,6 +182,11 @@ func (r *unpackReconciler) Reconcile(
 		r.packageLoadRecorder.RecordPackageLoadMetric(
 			pkg, time.Since(pullStart))
 	}
+	// Images used by the package may have changed with this deployment.
+	specHash, err = r.unpackHash(ctx, r.uncachedClient, pkg, mirrors, policyRevisions)
+	if err != nil {
+		return res, err
+	}
 	pkg.SetStatusUnpackedHash(specHash)
 	meta.SetStatusCondition(
 		pkg.GetStatusConditions(), metav1.Condition{
@@ -163,6 +200,73 @@ func (r *unpackReconciler) Reconcile(
 	return
 }
 
+// unpackHash changes whenever the package has to be pulled and deployed again.
+func (r *unpackReconciler) unpackHash(
+	ctx context.Context, c client.Reader, pkg adapters.PackageAccessor,
+	mirrors imagemirror.Overrides, policyRevisions map[string]string,
+) (string, error) {
+	specHash := pkg.GetSpecHash(r.packageHashModifier)
+	mirroredImages, err := r.mirroredImages(ctx, c, pkg, mirrors)
+	if err != nil {
+		return "", err
+	}
+	if len(mirroredImages) > 0 {
+		// Only overrides rewriting images of this package cause repulls,
+		// upgrading from PKO without overrides won't cause repulls.
+		specHash += utils.ComputeSHA256Hash(mirroredImages, nil)
+	}
+	if len(policyRevisions) > 0 {
+		// Verify signatures again when policies or their keys change,
+		// without repulling packages not subject to verification.
+		specHash += utils.ComputeSHA256Hash(policyRevisions, nil)
+	}
+	return specHash, nil
+}
+
+// mirroredImages returns the images of the package rewritten by image overrides
+// of Package Operator and ClusterImageMirrorSets, keyed by their original reference.
+// Images used by the package are taken from its ObjectDeployment, because the package is not pulled yet.
+func (r *unpackReconciler) mirroredImages(
+	ctx context.Context, c client.Reader, pkg adapters.PackageAccessor, mirrors imagemirror.Overrides,
+) (map[string]string, error) {
+	mirrored := map[string]string{}
+	pullImage, err := mirrors.PullImage(pkg.GetSpecImage())
+	if err != nil {
+		return nil, fmt.Errorf("resolving package image: %w", err)
+	}
+	if pullImage != pkg.GetSpecImage() {
+		mirrored[pkg.GetSpecImage()] = pullImage
+	}
+	if len(mirrors.ImagePrefixOverrides) == 0 {
+		return mirrored, nil
+	}
+
+	objDep := r.newObjectDeployment(r.scheme)
+	err = c.Get(ctx, client.ObjectKeyFromObject(pkg.ClientObject()), objDep.ClientObject())
+	if apierrors.IsNotFound(err) {
+		// Not deployed yet.
+		return mirrored, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting ObjectDeployment: %w", err)
+	}
+
+	imagesJSON, ok := objDep.ClientObject().GetAnnotations()[manifestsv1alpha1.PackageImagesAnnotation]
+	if !ok {
+		return mirrored, nil
+	}
+	var images []string
+	if err := json.Unmarshal([]byte(imagesJSON), &images); err != nil {
+		return nil, fmt.Errorf("parsing %s annotation: %w", manifestsv1alpha1.PackageImagesAnnotation, err)
+	}
+	for _, image := range images {
+		if replaced := mirrors.ReplaceImagePrefix(image); replaced != image {
+			mirrored[image] = replaced
+		}
+	}
+	return mirrored, nil
+}
+
 type unpackReconcilerConfig struct {
 	controllers.BackoffConfig
 }
@@ -3,18 +3,26 @@ package packages
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/internal/adapters"
 	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/environment"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/packages"
 	"package-operator.run/internal/testutil"
 )
@@ -26,7 +34,9 @@ func TestUnpackReconciler(t *testing.T) {
 
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(uc, ipm, pd, nil, environment.NewSink(c), nil, nil)
+	ur := newUnpackReconciler(
+		c, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, environment.NewSink(c), nil, nil, nil)
 
 	const image = "test123:latest"
 
@@ -67,7 +77,9 @@ func TestUnpackReconciler_noop(t *testing.T) {
 
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(uc, ipm, pd, nil, environment.NewSink(c), nil, nil)
+	ur := newUnpackReconciler(
+		c, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, environment.NewSink(c), nil, nil, nil)
 
 	const image = "test123:latest"
 
@@ -85,6 +97,191 @@ func TestUnpackReconciler_noop(t *testing.T) {
 	assert.True(t, res.IsZero())
 }
 
+func TestUnpackReconciler_registryHostOverrides(t *testing.T) {
+	t.Parallel()
+	c := testutil.NewClient()
+	uc := testutil.NewClient()
+
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	mirrors := imagemirror.NewResolver(nil, imagemirror.Overrides{
+		RegistryHostOverrides: map[string]string{"quay.io": "mirror.example.com"},
+	})
+	ur := newUnpackReconciler(
+		c, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, environment.NewSink(c), nil, mirrors, nil)
+
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(&packages.RawPackage{}, nil)
+	pd.
+		On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{
+				Image: "quay.io/test123:latest",
+			},
+		},
+	}
+	// Unpacked before the registry host override was configured.
+	pkg.Status.UnpackedHash = pkg.GetSpecHash(nil)
+	ur.SetEnvironment(&manifests.PackageEnvironment{})
+	res, err := ur.Reconcile(context.Background(), pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	ipm.AssertCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything)
+	assert.NotEqual(t, pkg.GetSpecHash(nil), pkg.Status.UnpackedHash)
+}
+
+func TestUnpackReconciler_imagePrefixOverrides(t *testing.T) {
+	t.Parallel()
+
+	objDep := &corev1alpha1.ObjectDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test", Namespace: "test",
+			Annotations: map[string]string{
+				manifestsv1alpha1.PackageImagesAnnotation: `["quay.io/original/app:v1"]`,
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(packageScheme).WithObjects(objDep).Build()
+
+	newPackage := func() *adapters.GenericPackage {
+		pkg := &adapters.GenericPackage{
+			Package: corev1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: corev1alpha1.PackageSpec{
+					Image: "quay.io/package:v1",
+				},
+			},
+		}
+		// Unpacked before the image prefix override was configured.
+		pkg.Status.UnpackedHash = pkg.GetSpecHash(nil)
+		return pkg
+	}
+
+	tests := map[string]struct {
+		override     imageprefix.Override
+		expectedPull bool
+	}{
+		"unrelated override": {
+			override: imageprefix.Override{From: "quay.io/other/", To: "mirror.example.com/other/"},
+		},
+		"override rewriting package images": {
+			override:     imageprefix.Override{From: "quay.io/original/", To: "mirror.example.com/original/"},
+			expectedPull: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ipm := &imagePullerMock{}
+			pd := &packageDeployerMock{}
+			mirrors := imagemirror.NewResolver(nil, imagemirror.Overrides{
+				ImagePrefixOverrides: []imageprefix.Override{test.override},
+			})
+			ur := newUnpackReconciler(
+				c, c, packageScheme, adapters.NewObjectDeployment,
+				ipm, pd, nil, environment.NewSink(c), nil, mirrors, nil)
+			ur.SetEnvironment(&manifests.PackageEnvironment{})
+
+			ipm.
+				On("Pull", mock.Anything, mock.Anything, mock.Anything).
+				Return(&packages.RawPackage{}, nil)
+			pd.
+				On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			pkg := newPackage()
+			res, err := ur.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+
+			if !test.expectedPull {
+				ipm.AssertNotCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			ipm.AssertCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything)
+			assert.NotEqual(t, pkg.GetSpecHash(nil), pkg.Status.UnpackedHash)
+
+			// Not pulled again, until overrides change.
+			_, err = ur.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			ipm.AssertNumberOfCalls(t, "Pull", 1)
+		})
+	}
+}
+
+func TestUnpackReconciler_verificationPolicyChange(t *testing.T) {
+	t.Parallel()
+	c := testutil.NewClient()
+	uc := testutil.NewClient()
+
+	keySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "package-operator-system"},
+	}
+	policy := &corev1alpha1.ClusterImageVerificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "all", Generation: 1},
+		Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+			PublicKeys: []corev1alpha1.PublicKeySecretReference{
+				{Name: keySecret.Name, Namespace: keySecret.Namespace},
+			},
+		},
+	}
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	policyClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(policy, keySecret).
+		Build()
+
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	ur := newUnpackReconciler(
+		c, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, environment.NewSink(c), nil, nil, imageverify.NewPolicyResolver(policyClient))
+	ur.SetEnvironment(&manifests.PackageEnvironment{})
+
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(&packages.RawPackage{}, nil)
+	pd.
+		On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	pkg := &adapters.GenericClusterPackage{
+		ClusterPackage: corev1alpha1.ClusterPackage{
+			Spec: corev1alpha1.PackageSpec{
+				Image: "test123:latest",
+			},
+		},
+	}
+	ctx := context.Background()
+	_, err := ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	_, err = ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	ipm.AssertNumberOfCalls(t, "Pull", 1)
+
+	// Policy changed, so the image has to be verified again.
+	policy.Generation = 2
+	require.NoError(t, policyClient.Update(ctx, policy))
+	_, err = ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	ipm.AssertNumberOfCalls(t, "Pull", 2)
+
+	// Key rotated, so the image has to be verified again.
+	keySecret.Data = map[string][]byte{"cosign.pub": []byte("rotated")}
+	require.NoError(t, policyClient.Update(ctx, keySecret))
+	_, err = ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	ipm.AssertNumberOfCalls(t, "Pull", 3)
+}
+
 var errTest = errors.New("test error")
 
 func TestUnpackReconciler_pullBackoff(t *testing.T) {
@@ -94,7 +291,9 @@ func TestUnpackReconciler_pullBackoff(t *testing.T) {
 
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(uc, ipm, pd, nil, environment.NewSink(c), nil, nil)
+	ur := newUnpackReconciler(
+		c, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, environment.NewSink(c), nil, nil, nil)
 
 	const image = "test123:latest"
 
@@ -120,6 +319,41 @@ func TestUnpackReconciler_pullBackoff(t *testing.T) {
 			corev1alpha1.PackageUnpacked))
 }
 
+func TestUnpackReconciler_signatureInvalid(t *testing.T) {
+	t.Parallel()
+	c := testutil.NewClient()
+	uc := testutil.NewClient()
+
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	ur := newUnpackReconciler(
+		c, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, environment.NewSink(c), nil, nil, nil)
+
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(&packages.RawPackage{}, fmt.Errorf("verifying image signature: %w", imageverify.ErrSignatureInvalid))
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{
+				Image: "test123:latest",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	res, err := ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.Equal(t, controllers.DefaultInitialBackoff, res.RequeueAfter)
+
+	cond := meta.FindStatusCondition(*pkg.GetStatusConditions(), corev1alpha1.PackageUnpacked)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "SignatureInvalid", cond.Reason)
+	pd.AssertNotCalled(t, "Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUnpackReconciler_getEnvironment_error(t *testing.T) {
 	t.Parallel()
 	uc := testutil.NewClient()
@@ -127,9 +361,11 @@ func TestUnpackReconciler_getEnvironment_error(t *testing.T) {
 	ipm := &imagePullerMock{}
 	sink := &environmentSinkMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(uc, ipm, pd, nil, sink, nil, nil)
+	ur := newUnpackReconciler(
+		uc, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, sink, nil, nil, nil)
 
-	ipm.On("Pull", mock.Anything, mock.Anything).Return(&packages.RawPackage{}, nil)
+	ipm.On("Pull", mock.Anything, mock.Anything, mock.Anything).Return(&packages.RawPackage{}, nil)
 	sink.On("GetEnvironment", mock.Anything, mock.Anything).Return(&manifests.PackageEnvironment{}, errTest)
 
 	const image = "test123:latest"
@@ -155,7 +391,9 @@ func TestUnpackReconciler_packageDeploy_error(t *testing.T) {
 	ipm := &imagePullerMock{}
 	sink := &environmentSinkMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(uc, ipm, pd, nil, sink, nil, nil)
+	ur := newUnpackReconciler(
+		uc, uc, packageScheme, adapters.NewObjectDeployment,
+		ipm, pd, nil, sink, nil, nil, nil)
 
 	ipm.
 		On("Pull", mock.Anything, mock.Anything, mock.Anything).
@@ -185,9 +423,9 @@ type imagePullerMock struct {
 }
 
 func (m *imagePullerMock) Pull(
-	ctx context.Context, image string,
+	ctx context.Context, namespace, image string,
 ) (*packages.RawPackage, error) {
-	args := m.Called(ctx, image)
+	args := m.Called(ctx, namespace, image)
 	return args.Get(0).(*packages.RawPackage), args.Error(1)
 }
 
@@ -0,0 +1,136 @@
+package imagemirror
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/utils"
+)
+
+// Overrides rewrite image references, e.g. to use mirror registries.
+type Overrides struct {
+	// Image prefix overrides, applied to package images and images used by packages.
+	ImagePrefixOverrides []imageprefix.Override
+	// Registry host overrides, only applied when pulling package images.
+	RegistryHostOverrides map[string]string
+}
+
+// ReplaceImagePrefix replaces the image prefix with the most specific image prefix override.
+func (o Overrides) ReplaceImagePrefix(image string) string {
+	return imageprefix.Replace(image, o.ImagePrefixOverrides)
+}
+
+// PullImage returns the reference to pull a package image from,
+// applying image prefix and registry host overrides.
+func (o Overrides) PullImage(image string) (string, error) {
+	image = o.ReplaceImagePrefix(image)
+	for original, override := range o.RegistryHostOverrides {
+		if strings.HasPrefix(image, original) {
+			return utils.ImageURLWithOverride(image, override)
+		}
+	}
+	return image, nil
+}
+
+// Resolver looks up the image overrides to use for Packages in a namespace.
+// Overrides configured for Package Operator are combined with all ClusterImageMirrorSets selecting the namespace.
+type Resolver struct {
+	client   client.Reader
+	defaults Overrides
+}
+
+// Creates a new Resolver.
+// Only defaults are resolved if the client is nil.
+func NewResolver(c client.Reader, defaults Overrides) *Resolver {
+	return &Resolver{client: c, defaults: defaults}
+}
+
+// Resolve returns the overrides for Packages in the given namespace
+// or for ClusterPackages if namespace is empty.
+// A nil Resolver resolves no overrides.
+func (r *Resolver) Resolve(ctx context.Context, namespace string) (Overrides, error) {
+	if r == nil {
+		return Overrides{}, nil
+	}
+	out := Overrides{
+		ImagePrefixOverrides:  slices.Clone(r.defaults.ImagePrefixOverrides),
+		RegistryHostOverrides: maps.Clone(r.defaults.RegistryHostOverrides),
+	}
+	if r.client == nil {
+		return out, nil
+	}
+
+	mirrorSets := &corev1alpha1.ClusterImageMirrorSetList{}
+	if err := r.client.List(ctx, mirrorSets); meta.IsNoMatchError(err) {
+		// CRD not installed yet, e.g. while bootstrapping.
+		return out, nil
+	} else if err != nil {
+		return Overrides{}, fmt.Errorf("listing ClusterImageMirrorSets: %w", err)
+	}
+	if len(mirrorSets.Items) == 0 {
+		return out, nil
+	}
+
+	var namespaceLabels labels.Set
+	if len(namespace) > 0 {
+		ns := &corev1.Namespace{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+			return Overrides{}, fmt.Errorf("getting namespace: %w", err)
+		}
+		namespaceLabels = ns.Labels
+	}
+
+	// Order by name for stable results when mirror sets overlap.
+	slices.SortFunc(mirrorSets.Items, func(a, b corev1alpha1.ClusterImageMirrorSet) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, mirrorSet := range mirrorSets.Items {
+		selected, err := selectsNamespace(mirrorSet, namespace, namespaceLabels)
+		if err != nil {
+			return Overrides{}, fmt.Errorf("ClusterImageMirrorSet %s: %w", mirrorSet.Name, err)
+		}
+		if !selected {
+			continue
+		}
+
+		for _, o := range mirrorSet.Spec.ImagePrefixOverrides {
+			out.ImagePrefixOverrides = append(out.ImagePrefixOverrides, imageprefix.Override{From: o.From, To: o.To})
+		}
+		for _, o := range mirrorSet.Spec.RegistryHostOverrides {
+			if out.RegistryHostOverrides == nil {
+				out.RegistryHostOverrides = map[string]string{}
+			}
+			out.RegistryHostOverrides[o.From] = o.To
+		}
+	}
+	return out, nil
+}
+
+func selectsNamespace(
+	mirrorSet corev1alpha1.ClusterImageMirrorSet, namespace string, namespaceLabels labels.Set,
+) (bool, error) {
+	if mirrorSet.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	if len(namespace) == 0 {
+		// ClusterPackages are not part of any namespace.
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(mirrorSet.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	return selector.Matches(namespaceLabels), nil
+}
@@ -0,0 +1,126 @@
+package imagemirror
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/testutil"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a", Labels: map[string]string{"team": "a"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "team-b", Labels: map[string]string{"team": "b"},
+		}},
+		&corev1alpha1.ClusterImageMirrorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: corev1alpha1.ClusterImageMirrorSetSpec{
+				RegistryHostOverrides: []corev1alpha1.RegistryHostOverride{
+					{From: "quay.io", To: "mirror.example.com"},
+				},
+			},
+		},
+		&corev1alpha1.ClusterImageMirrorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec: corev1alpha1.ClusterImageMirrorSetSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				ImagePrefixOverrides: []corev1alpha1.PackageImagePrefixOverride{
+					{From: "quay.io/original/", To: "quay.io/team-a/"},
+				},
+			},
+		},
+	).Build()
+
+	defaults := Overrides{
+		ImagePrefixOverrides: []imageprefix.Override{{From: "quay.io/", To: "quay.io/default/"}},
+	}
+	r := NewResolver(c, defaults)
+	ctx := context.Background()
+
+	tests := []struct {
+		namespace string
+		expected  Overrides
+	}{
+		{
+			namespace: "team-a",
+			expected: Overrides{
+				ImagePrefixOverrides: []imageprefix.Override{
+					{From: "quay.io/", To: "quay.io/default/"},
+					{From: "quay.io/original/", To: "quay.io/team-a/"},
+				},
+				RegistryHostOverrides: map[string]string{"quay.io": "mirror.example.com"},
+			},
+		},
+		{
+			namespace: "team-b",
+			expected: Overrides{
+				ImagePrefixOverrides:  []imageprefix.Override{{From: "quay.io/", To: "quay.io/default/"}},
+				RegistryHostOverrides: map[string]string{"quay.io": "mirror.example.com"},
+			},
+		},
+		{
+			// ClusterPackages
+			namespace: "",
+			expected: Overrides{
+				ImagePrefixOverrides:  []imageprefix.Override{{From: "quay.io/", To: "quay.io/default/"}},
+				RegistryHostOverrides: map[string]string{"quay.io": "mirror.example.com"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.namespace, func(t *testing.T) {
+			t.Parallel()
+			overrides, err := r.Resolve(ctx, test.namespace)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, overrides)
+		})
+	}
+
+	// Defaults must not be modified.
+	assert.Len(t, defaults.ImagePrefixOverrides, 1)
+}
+
+func TestResolver_Resolve_Defaults(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var nilResolver *Resolver
+	overrides, err := nilResolver.Resolve(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, Overrides{}, overrides)
+
+	defaults := Overrides{RegistryHostOverrides: map[string]string{"quay.io": "localhost:5001"}}
+	overrides, err = NewResolver(nil, defaults).Resolve(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, defaults, overrides)
+}
+
+func TestOverrides_PullImage(t *testing.T) {
+	t.Parallel()
+
+	o := Overrides{
+		ImagePrefixOverrides:  []imageprefix.Override{{From: "quay.io/original/", To: "quay.io/mirror/"}},
+		RegistryHostOverrides: map[string]string{"quay.io": "localhost:5001"},
+	}
+
+	image, err := o.PullImage("quay.io/original/pkg:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:5001/mirror/pkg:v1", image)
+	assert.Equal(t, "quay.io/mirror/pkg:v1", o.ReplaceImagePrefix("quay.io/original/pkg:v1"))
+}
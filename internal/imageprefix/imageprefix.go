@@ -45,3 +45,13 @@ func mostSpecificIndex(image string, overrides []Override) int {
 
 	return bestMatch
 }
+
+// ReplaceInOrder replaces the image prefix with the most specific override of each override list in turn,
+// each list applying to the result of the previous one.
+// Allows layering overrides, e.g. Package overrides redirecting images that are then pulled through mirrors.
+func ReplaceInOrder(image string, overrideLists ...[]Override) string {
+	for _, overrides := range overrideLists {
+		image = Replace(image, overrides)
+	}
+	return image
+}
@@ -33,3 +33,23 @@ func TestNoAppliedOverride(t *testing.T) {
 	overridden := Replace(originalImage, overrides)
 	assert.Equal(t, "quay.io/original/foo:tag", overridden)
 }
+
+func TestReplaceInOrder(t *testing.T) {
+	t.Parallel()
+
+	pkgOverrides := []Override{
+		{From: "quay.io/original/foo", To: "quay.io/team/foo"},
+	}
+	globalOverrides := []Override{
+		{From: "quay.io/original/", To: "quay.io/mirror/"},
+		{From: "quay.io/team/", To: "mirror.example.com/team/"},
+	}
+
+	// Overrides of later lists apply to the result of earlier lists.
+	assert.Equal(t, "mirror.example.com/team/foo:tag",
+		ReplaceInOrder("quay.io/original/foo:tag", pkgOverrides, globalOverrides))
+	assert.Equal(t, "quay.io/mirror/baz:tag",
+		ReplaceInOrder("quay.io/original/baz:tag", pkgOverrides, globalOverrides))
+	assert.Equal(t, "quay.io/other/baz:tag",
+		ReplaceInOrder("quay.io/other/baz:tag", pkgOverrides, globalOverrides))
+}
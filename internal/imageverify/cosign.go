@@ -0,0 +1,210 @@
+package imageverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+)
+
+const (
+	// Media type of cosign simple signing payloads.
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// Layer annotation holding the base64 encoded signature of the payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// Type of cosign container image signatures.
+	SimpleSigningType = "cosign container image signature"
+)
+
+// ErrSignatureInvalid is returned when no valid signature by a trusted key was found for an image.
+var ErrSignatureInvalid = errors.New("signature invalid")
+
+// SimpleSigningPayload is the signed payload of a cosign image signature.
+type SimpleSigningPayload struct {
+	Critical SimpleSigningCritical `json:"critical"`
+	Optional map[string]any        `json:"optional"`
+}
+
+// SimpleSigningCritical holds the claims of a signature that must be verified.
+type SimpleSigningCritical struct {
+	Identity SimpleSigningIdentity `json:"identity"`
+	Image    SimpleSigningImage    `json:"image"`
+	Type     string                `json:"type"`
+}
+
+// SimpleSigningIdentity identifies the signed repository.
+type SimpleSigningIdentity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+// SimpleSigningImage identifies the signed image manifest.
+type SimpleSigningImage struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// ParsePublicKey parses a PEM encoded PKIX public key.
+// ECDSA, RSA and Ed25519 keys are supported.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// SignatureTag returns the tag cosign stores signatures of the image digest under.
+func SignatureTag(digest name.Digest) name.Tag {
+	return digest.Context().Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + ".sig")
+}
+
+// Verify resolves the digest of the given image and verifies,
+// that a cosign signature of this digest was created by one of the given keys.
+// Returns the digest reference of the verified image,
+// which must be used for pulling to not be affected by tags moving after verification.
+func Verify(ctx context.Context, image string, keys []crypto.PublicKey, opts ...crane.Option) (name.Digest, error) {
+	o := crane.GetOptions(append(opts, crane.WithContext(ctx))...)
+	digest, err := resolveDigest(image, o)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	sigImg, err := remote.Image(SignatureTag(digest), o.Remote...)
+	if isNotFound(err) {
+		return name.Digest{}, fmt.Errorf("%w: no signature found for %s", ErrSignatureInvalid, digest)
+	}
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("getting signature: %w", err)
+	}
+
+	if err := verifySignatureImage(sigImg, digest.DigestStr(), keys); err != nil {
+		return name.Digest{}, fmt.Errorf("%s: %w", digest, err)
+	}
+	return digest, nil
+}
+
+func resolveDigest(image string, o crane.Options) (name.Digest, error) {
+	ref, err := name.ParseReference(image, o.Name...)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("parsing image reference: %w", err)
+	}
+	desc, err := remote.Head(ref, o.Remote...)
+	if err != nil {
+		// Some registries don't support HEAD requests for manifests.
+		full, getErr := remote.Get(ref, o.Remote...)
+		if getErr != nil {
+			return name.Digest{}, fmt.Errorf("resolving image digest: %w", getErr)
+		}
+		desc = &full.Descriptor
+	}
+	return ref.Context().Digest(desc.Digest.String()), nil
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
+
+// Verifies that any layer of the signature image holds a valid signature of digest.
+func verifySignatureImage(sigImg v1.Image, digest string, keys []crypto.PublicKey) error {
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return fmt.Errorf("getting signature manifest: %w", err)
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		ok, err := verifyLayer(sigImg, layer, digest, keys)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: not signed by a trusted key", ErrSignatureInvalid)
+}
+
+// Checks whether a single signature layer is valid for the image digest.
+func verifyLayer(sigImg v1.Image, desc v1.Descriptor, digest string, keys []crypto.PublicKey) (bool, error) {
+	layer, err := sigImg.LayerByDigest(desc.Digest)
+	if err != nil {
+		return false, fmt.Errorf("getting signature payload: %w", err)
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return false, fmt.Errorf("reading signature payload: %w", err)
+	}
+	defer rc.Close()
+	payload, err := io.ReadAll(rc)
+	if err != nil {
+		return false, fmt.Errorf("reading signature payload: %w", err)
+	}
+	return validSignature(desc.Annotations[SignatureAnnotation], payload, digest, keys), nil
+}
+
+// Malformed signatures are not an error, other layers may still hold a valid signature.
+func validSignature(encodedSignature string, payload []byte, digest string, keys []crypto.PublicKey) bool {
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+	if !verifySignature(payload, signature, keys) {
+		return false
+	}
+
+	// Only trust the payload after the signature has been verified.
+	var p SimpleSigningPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return false
+	}
+	return p.Critical.Type == SimpleSigningType &&
+		p.Critical.Image.DockerManifestDigest == digest
+}
+
+func verifySignature(payload, signature []byte, keys []crypto.PublicKey) bool {
+	hash := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package imageverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	untrustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		signingKeys []crypto.Signer
+		// signs another digest than the one of the image.
+		signOtherDigest bool
+		trustedKeys     []crypto.PublicKey
+		err             string
+	}{
+		{
+			name:        "ecdsa",
+			signingKeys: []crypto.Signer{ecdsaKey},
+			trustedKeys: []crypto.PublicKey{ecdsaKey.Public()},
+		},
+		{
+			name:        "rsa",
+			signingKeys: []crypto.Signer{rsaKey},
+			trustedKeys: []crypto.PublicKey{rsaKey.Public()},
+		},
+		{
+			name:        "ed25519",
+			signingKeys: []crypto.Signer{ed25519Key},
+			trustedKeys: []crypto.PublicKey{ed25519Key.Public()},
+		},
+		{
+			name:        "any trusted signature",
+			signingKeys: []crypto.Signer{untrustedKey, ecdsaKey},
+			trustedKeys: []crypto.PublicKey{rsaKey.Public(), ecdsaKey.Public()},
+		},
+		{
+			name:        "untrusted key",
+			signingKeys: []crypto.Signer{untrustedKey},
+			trustedKeys: []crypto.PublicKey{ecdsaKey.Public()},
+			err:         "not signed by a trusted key",
+		},
+		{
+			name:            "other digest",
+			signingKeys:     []crypto.Signer{ecdsaKey},
+			signOtherDigest: true,
+			trustedKeys:     []crypto.PublicKey{ecdsaKey.Public()},
+			err:             "not signed by a trusted key",
+		},
+		{
+			name:        "unsigned",
+			trustedKeys: []crypto.PublicKey{ecdsaKey.Public()},
+			err:         "no signature found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := newTestRegistry(t)
+			image := host + "/pkg:v1"
+			digest := pushRandomImage(t, image)
+			if test.signOtherDigest {
+				otherDigest := pushRandomImage(t, host+"/pkg:v2")
+				pushSignature(t, otherDigest, SignatureTag(digest), test.signingKeys[0])
+			} else {
+				for _, key := range test.signingKeys {
+					signed, err := Sign(context.Background(), image, key, crane.Insecure)
+					require.NoError(t, err)
+					assert.Equal(t, digest, signed)
+				}
+			}
+
+			verified, err := Verify(context.Background(), image, test.trustedKeys, crane.Insecure)
+			if len(test.err) > 0 {
+				require.ErrorIs(t, err, ErrSignatureInvalid)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, digest.String(), verified.String())
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	parsed, err := ParsePublicKey(encodePublicKey(t, key.Public()))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(parsed))
+
+	_, err = ParsePublicKey([]byte("banana"))
+	require.EqualError(t, err, "no PEM encoded public key found")
+}
+
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func pushRandomImage(t *testing.T, image string) name.Digest {
+	t.Helper()
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	ref, err := name.ParseReference(image, name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	d, err := img.Digest()
+	require.NoError(t, err)
+	return ref.Context().Digest(d.String())
+}
+
+// Pushes a signature of digest to the given tag, which may belong to another image.
+func pushSignature(t *testing.T, digest name.Digest, tag name.Tag, key crypto.Signer) {
+	t.Helper()
+
+	sigImg, err := appendSignature(empty.Image, digest.Context().String(), digest.DigestStr(), key)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, sigImg))
+}
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
@@ -0,0 +1,186 @@
+package imageverify
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Default key of public keys in Secrets, matching the file name cosign uses.
+const DefaultPublicKeySecretKey = "cosign.pub"
+
+// PolicyResolver looks up the public keys that package images in a namespace must be signed with.
+type PolicyResolver struct {
+	client client.Reader
+}
+
+// Creates a new PolicyResolver.
+// A nil PolicyResolver never requires verification.
+func NewPolicyResolver(c client.Reader) *PolicyResolver {
+	return &PolicyResolver{client: c}
+}
+
+// Resolve returns the trusted public keys for Packages in the given namespace
+// or for ClusterPackages if namespace is empty.
+// No keys are returned, if package images don't need to be verified.
+func (r *PolicyResolver) Resolve(ctx context.Context, namespace string) ([]crypto.PublicKey, error) {
+	policies, err := r.selectPolicies(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []crypto.PublicKey
+	for _, policy := range policies {
+		for _, ref := range policy.Spec.PublicKeys {
+			key, err := r.publicKey(ctx, ref)
+			if err != nil {
+				return nil, fmt.Errorf("ClusterImageVerificationPolicy %s: %w", policy.Name, err)
+			}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// Revisions returns the generation of every ClusterImageVerificationPolicy
+// applying to Packages in the given namespace or to ClusterPackages if namespace is empty,
+// and the resourceVersion of every public key Secret these policies reference.
+// Images have to be verified again when these change, e.g. when keys are rotated or revoked.
+func (r *PolicyResolver) Revisions(ctx context.Context, namespace string) (map[string]string, error) {
+	policies, err := r.selectPolicies(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := map[string]string{}
+	for _, policy := range policies {
+		revisions["ClusterImageVerificationPolicy/"+policy.Name] = strconv.FormatInt(policy.Generation, 10)
+
+		for _, ref := range policy.Spec.PublicKeys {
+			secret := &metav1.PartialObjectMetadata{}
+			secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+			err := r.client.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, secret)
+			if client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("ClusterImageVerificationPolicy %s: getting public key Secret: %w", policy.Name, err)
+			}
+			// Missing Secrets are reported when resolving keys for verification.
+			revisions["Secret/"+ref.Namespace+"/"+ref.Name] = secret.ResourceVersion
+		}
+	}
+	return revisions, nil
+}
+
+// ReferencesSecret returns true if any ClusterImageVerificationPolicy references the given public key Secret.
+func (r *PolicyResolver) ReferencesSecret(ctx context.Context, key client.ObjectKey) (bool, error) {
+	if r == nil {
+		return false, nil
+	}
+
+	policies := &corev1alpha1.ClusterImageVerificationPolicyList{}
+	if err := r.client.List(ctx, policies); meta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("listing ClusterImageVerificationPolicies: %w", err)
+	}
+	for _, policy := range policies.Items {
+		for _, ref := range policy.Spec.PublicKeys {
+			if ref.Name == key.Name && ref.Namespace == key.Namespace {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Returns all ClusterImageVerificationPolicies selecting the given namespace.
+func (r *PolicyResolver) selectPolicies(
+	ctx context.Context, namespace string,
+) ([]corev1alpha1.ClusterImageVerificationPolicy, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	policies := &corev1alpha1.ClusterImageVerificationPolicyList{}
+	if err := r.client.List(ctx, policies); meta.IsNoMatchError(err) {
+		// CRD not installed yet, e.g. while bootstrapping.
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("listing ClusterImageVerificationPolicies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	var namespaceLabels labels.Set
+	if len(namespace) > 0 {
+		ns := &corev1.Namespace{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+			return nil, fmt.Errorf("getting namespace: %w", err)
+		}
+		namespaceLabels = ns.Labels
+	}
+
+	var selected []corev1alpha1.ClusterImageVerificationPolicy
+	for _, policy := range policies.Items {
+		ok, err := selectsNamespace(policy, namespace, namespaceLabels)
+		if err != nil {
+			return nil, fmt.Errorf("ClusterImageVerificationPolicy %s: %w", policy.Name, err)
+		}
+		if ok {
+			selected = append(selected, policy)
+		}
+	}
+	return selected, nil
+}
+
+func (r *PolicyResolver) publicKey(
+	ctx context.Context, ref corev1alpha1.PublicKeySecretReference,
+) (crypto.PublicKey, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Name: ref.Name, Namespace: ref.Namespace,
+	}, secret); err != nil {
+		return nil, fmt.Errorf("getting public key Secret: %w", err)
+	}
+
+	dataKey := ref.Key
+	if len(dataKey) == 0 {
+		dataKey = DefaultPublicKeySecretKey
+	}
+	data, ok := secret.Data[dataKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %q", ref.Namespace, ref.Name, dataKey)
+	}
+	key, err := ParsePublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("secret %s/%s key %q: %w", ref.Namespace, ref.Name, dataKey, err)
+	}
+	return key, nil
+}
+
+func selectsNamespace(
+	policy corev1alpha1.ClusterImageVerificationPolicy, namespace string, namespaceLabels labels.Set,
+) (bool, error) {
+	if policy.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	if len(namespace) == 0 {
+		// ClusterPackages are not part of any namespace.
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	return selector.Matches(namespaceLabels), nil
+}
@@ -0,0 +1,164 @@
+package imageverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/testutil"
+)
+
+func TestPolicyResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	clusterKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	teamKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "verified", Labels: map[string]string{"verify": "true"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "package-operator-system"},
+			Data: map[string][]byte{
+				DefaultPublicKeySecretKey: encodePublicKey(t, clusterKey.Public()),
+				"team.pub":                encodePublicKey(t, teamKey.Public()),
+			},
+		},
+		&corev1alpha1.ClusterImageVerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "opt-in"},
+			Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"verify": "true"}},
+				PublicKeys: []corev1alpha1.PublicKeySecretReference{
+					{Name: "keys", Namespace: "package-operator-system"},
+					{Name: "keys", Namespace: "package-operator-system", Key: "team.pub"},
+				},
+			},
+		},
+	).Build()
+
+	r := NewPolicyResolver(c)
+	ctx := context.Background()
+
+	keys, err := r.Resolve(ctx, "verified")
+	require.NoError(t, err)
+	if assert.Len(t, keys, 2) {
+		assert.True(t, clusterKey.PublicKey.Equal(keys[0]))
+		assert.True(t, teamKey.PublicKey.Equal(keys[1]))
+	}
+
+	keys, err = r.Resolve(ctx, "other")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// ClusterPackages
+	keys, err = r.Resolve(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestPolicyResolver_Resolve_MissingKey(t *testing.T) {
+	t.Parallel()
+
+	c := fake.NewClientBuilder().WithScheme(testutil.NewTestSchemeWithCoreV1Alpha1()).WithObjects(
+		&corev1alpha1.ClusterImageVerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+				PublicKeys: []corev1alpha1.PublicKeySecretReference{
+					{Name: "keys", Namespace: "package-operator-system"},
+				},
+			},
+		},
+	).Build()
+
+	// Verification must not be skipped silently, when keys can't be loaded.
+	_, err := NewPolicyResolver(c).Resolve(context.Background(), "")
+	require.ErrorContains(t, err, "ClusterImageVerificationPolicy all: getting public key Secret")
+
+	var nilResolver *PolicyResolver
+	keys, err := nilResolver.Resolve(context.Background(), "test")
+	require.NoError(t, err)
+	assert.Equal(t, []crypto.PublicKey(nil), keys)
+}
+
+func TestPolicyResolver_Revisions(t *testing.T) {
+	t.Parallel()
+
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "verified", Labels: map[string]string{"verify": "true"},
+		}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "package-operator-system", ResourceVersion: "42"},
+		},
+		&corev1alpha1.ClusterImageVerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all", Generation: 3},
+			Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+				PublicKeys: []corev1alpha1.PublicKeySecretReference{
+					{Name: "keys", Namespace: "package-operator-system"},
+				},
+			},
+		},
+		&corev1alpha1.ClusterImageVerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "opt-in", Generation: 2},
+			Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"verify": "true"}},
+				PublicKeys: []corev1alpha1.PublicKeySecretReference{
+					{Name: "missing", Namespace: "package-operator-system"},
+				},
+			},
+		},
+	).Build()
+
+	r := NewPolicyResolver(c)
+	ctx := context.Background()
+
+	revisions, err := r.Revisions(ctx, "verified")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"ClusterImageVerificationPolicy/all":     "3",
+		"ClusterImageVerificationPolicy/opt-in":  "2",
+		"Secret/package-operator-system/keys":    "42",
+		"Secret/package-operator-system/missing": "",
+	}, revisions)
+
+	// ClusterPackages
+	revisions, err = r.Revisions(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"ClusterImageVerificationPolicy/all":  "3",
+		"Secret/package-operator-system/keys": "42",
+	}, revisions)
+
+	referenced, err := r.ReferencesSecret(ctx, client.ObjectKey{Name: "keys", Namespace: "package-operator-system"})
+	require.NoError(t, err)
+	assert.True(t, referenced)
+	referenced, err = r.ReferencesSecret(ctx, client.ObjectKey{Name: "keys", Namespace: "default"})
+	require.NoError(t, err)
+	assert.False(t, referenced)
+
+	var nilResolver *PolicyResolver
+	revisions, err = nilResolver.Revisions(ctx, "verified")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
@@ -0,0 +1,134 @@
+package imageverify
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+)
+
+// OCI annotation naming images in an OCI layout, like tags do in registries.
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+var errLayoutImage = errors.New("OCI layout must contain exactly one image besides signatures")
+
+// SignLayout signs the package image stored in the OCI layout at path.
+// The signature is added to the layout as image named like the signature tag in a registry,
+// so it is kept when copying the layout into a registry.
+// Returns the digest of the signed image.
+func SignLayout(path string, signer crypto.Signer) (v1.Hash, error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("reading OCI layout: %w", err)
+	}
+	desc, err := layoutImage(p)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	tag := signatureTagName(desc.Digest)
+	sigImg, found, err := layoutSignatureImage(p, tag)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	if !found {
+		sigImg = empty.Image
+	}
+
+	sigImg, err = appendSignature(sigImg, desc.Annotations[refNameAnnotation], desc.Digest.String(), signer)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	if err := p.ReplaceImage(sigImg, match.Name(tag), layout.WithAnnotations(map[string]string{
+		refNameAnnotation: tag,
+	})); err != nil {
+		return v1.Hash{}, fmt.Errorf("writing signature: %w", err)
+	}
+	return desc.Digest, nil
+}
+
+// VerifyLayout verifies that the package image stored in the OCI layout at path
+// is signed by one of the given keys.
+// Returns the digest of the verified image.
+func VerifyLayout(path string, keys []crypto.PublicKey) (v1.Hash, error) {
+	p, err := layout.FromPath(path)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("reading OCI layout: %w", err)
+	}
+	desc, err := layoutImage(p)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	sigImg, found, err := layoutSignatureImage(p, signatureTagName(desc.Digest))
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	if !found {
+		return v1.Hash{}, fmt.Errorf("%w: no signature found for %s", ErrSignatureInvalid, desc.Digest)
+	}
+	if err := verifySignatureImage(sigImg, desc.Digest.String(), keys); err != nil {
+		return v1.Hash{}, fmt.Errorf("%s: %w", desc.Digest, err)
+	}
+	return desc.Digest, nil
+}
+
+// Returns the descriptor of the only image in the layout, that is not a signature.
+func layoutImage(p layout.Path) (v1.Descriptor, error) {
+	index, err := p.ImageIndex()
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("reading OCI layout index: %w", err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("reading OCI layout index: %w", err)
+	}
+
+	var images []v1.Descriptor
+	for _, desc := range manifest.Manifests {
+		if !desc.MediaType.IsImage() || isSignatureTagName(desc.Annotations[refNameAnnotation]) {
+			continue
+		}
+		images = append(images, desc)
+	}
+	if len(images) != 1 {
+		return v1.Descriptor{}, fmt.Errorf("%w, found %d", errLayoutImage, len(images))
+	}
+	return images[0], nil
+}
+
+// Returns the signature image with the given name, if it exists.
+func layoutSignatureImage(p layout.Path, tag string) (img v1.Image, found bool, err error) {
+	index, err := p.ImageIndex()
+	if err != nil {
+		return nil, false, fmt.Errorf("reading OCI layout index: %w", err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, false, fmt.Errorf("reading OCI layout index: %w", err)
+	}
+	for _, desc := range manifest.Manifests {
+		if !match.Name(tag)(desc) {
+			continue
+		}
+		sigImg, err := p.Image(desc.Digest)
+		if err != nil {
+			return nil, false, fmt.Errorf("reading signature: %w", err)
+		}
+		return sigImg, true, nil
+	}
+	return nil, false, nil
+}
+
+func signatureTagName(digest v1.Hash) string {
+	return digest.Algorithm + "-" + digest.Hex + ".sig"
+}
+
+func isSignatureTagName(name string) bool {
+	return strings.HasPrefix(name, "sha256-") && strings.HasSuffix(name, ".sig")
+}
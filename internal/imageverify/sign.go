@@ -0,0 +1,189 @@
+package imageverify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+var errPasswordRequired = errors.New("private key is encrypted, but no password was given")
+
+// PEM block types of encrypted private keys created by cosign.
+var encryptedPrivateKeyTypes = map[string]bool{
+	"ENCRYPTED SIGSTORE PRIVATE KEY": true,
+	"ENCRYPTED COSIGN PRIVATE KEY":   true,
+}
+
+// ParsePrivateKey parses a PEM encoded private key for signing.
+// Unencrypted PKCS#8, SEC 1 and PKCS#1 keys are supported,
+// as well as encrypted keys generated by `cosign generate-key-pair`, which require a password.
+func ParsePrivateKey(data, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch {
+	case encryptedPrivateKeyTypes[block.Type]:
+		if len(password) == 0 {
+			return nil, errPasswordRequired
+		}
+		der, decryptErr := decryptCosignKey(block.Bytes, password)
+		if decryptErr != nil {
+			return nil, decryptErr
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case block.Type == "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case block.Type == "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	if signer, ok := key.(crypto.Signer); ok {
+		switch signer.Public().(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+			return signer, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
+
+// Format of encrypted cosign private keys.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(data, password []byte) ([]byte, error) {
+	var ek encryptedKey
+	if err := json.Unmarshal(data, &ek); err != nil {
+		return nil, fmt.Errorf("decoding encrypted private key: %w", err)
+	}
+	if ek.KDF.Name != "scrypt" || ek.Cipher.Name != "nacl/secretbox" || len(ek.Cipher.Nonce) != 24 {
+		return nil, fmt.Errorf("unsupported private key encryption %s/%s", ek.KDF.Name, ek.Cipher.Name)
+	}
+
+	secret, err := scrypt.Key(password, ek.KDF.Salt, ek.KDF.Params.N, ek.KDF.Params.R, ek.KDF.Params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving private key encryption key: %w", err)
+	}
+	var (
+		nonce [24]byte
+		key   [32]byte
+	)
+	copy(nonce[:], ek.Cipher.Nonce)
+	copy(key[:], secret)
+	der, ok := secretbox.Open(nil, ek.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.New("decrypting private key: invalid password")
+	}
+	return der, nil
+}
+
+// Sign resolves the digest of the given image and pushes a cosign compatible signature of this digest
+// next to the image in the registry. Existing signatures of the digest are kept.
+// Returns the digest reference of the signed image.
+func Sign(ctx context.Context, image string, signer crypto.Signer, opts ...crane.Option) (name.Digest, error) {
+	o := crane.GetOptions(append(opts, crane.WithContext(ctx))...)
+	digest, err := resolveDigest(image, o)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	tag := SignatureTag(digest)
+	sigImg, err := remote.Image(tag, o.Remote...)
+	if isNotFound(err) {
+		sigImg = empty.Image
+	} else if err != nil {
+		return name.Digest{}, fmt.Errorf("getting existing signatures: %w", err)
+	}
+
+	sigImg, err = appendSignature(sigImg, digest.Context().String(), digest.DigestStr(), signer)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	if err := remote.Write(tag, sigImg, o.Remote...); err != nil {
+		return name.Digest{}, fmt.Errorf("pushing signature: %w", err)
+	}
+	return digest, nil
+}
+
+// Appends a signature layer for the digest to the signature image.
+func appendSignature(sigImg v1.Image, repository, digest string, signer crypto.Signer) (v1.Image, error) {
+	payload, err := json.Marshal(SimpleSigningPayload{
+		Critical: SimpleSigningCritical{
+			Identity: SimpleSigningIdentity{DockerReference: repository},
+			Image:    SimpleSigningImage{DockerManifestDigest: digest},
+			Type:     SimpleSigningType,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding signature payload: %w", err)
+	}
+
+	signature, err := signPayload(signer, payload)
+	if err != nil {
+		return nil, fmt.Errorf("signing: %w", err)
+	}
+
+	sigImg, err = mutate.Append(sigImg, mutate.Addendum{
+		Layer: static.NewLayer(payload, SimpleSigningMediaType),
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("appending signature: %w", err)
+	}
+	return sigImg, nil
+}
+
+// Signs the payload like verifySignature expects it.
+func signPayload(signer crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	hash := sha256.Sum256(payload)
+	return signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+}
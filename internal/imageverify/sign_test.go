@@ -0,0 +1,208 @@
+package imageverify
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		password string
+		expected crypto.Signer
+		err      string
+	}{
+		{
+			name:     "pkcs8",
+			data:     encodePKCS8(t, ed25519Key),
+			expected: ed25519Key,
+		},
+		{
+			name:     "sec1",
+			data:     pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			expected: ecdsaKey,
+		},
+		{
+			name: "pkcs1",
+			data: pem.EncodeToMemory(&pem.Block{
+				Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+			}),
+			expected: rsaKey,
+		},
+		{
+			name:     "cosign encrypted",
+			data:     encryptCosignKey(t, ecdsaKey, "secret"),
+			password: "secret",
+			expected: ecdsaKey,
+		},
+		{
+			name:     "cosign encrypted, wrong password",
+			data:     encryptCosignKey(t, ecdsaKey, "secret"),
+			password: "banana",
+			err:      "decrypting private key: invalid password",
+		},
+		{
+			name: "cosign encrypted, no password",
+			data: encryptCosignKey(t, ecdsaKey, "secret"),
+			err:  errPasswordRequired.Error(),
+		},
+		{
+			name: "no key",
+			data: []byte("banana"),
+			err:  "no PEM encoded private key found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := ParsePrivateKey(test.data, []byte(test.password))
+			if len(test.err) > 0 {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected.Public(), signer.Public())
+		})
+	}
+}
+
+func TestSignLayout(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := t.TempDir()
+	p, err := layout.Write(path, empty.Index)
+	require.NoError(t, err)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, p.AppendImage(img, layout.WithAnnotations(map[string]string{
+		refNameAnnotation: "quay.io/package-operator/test:v1",
+	})))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	_, err = VerifyLayout(path, []crypto.PublicKey{key.Public()})
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	// Signing again must keep the existing signature.
+	for _, k := range []crypto.Signer{key, otherKey} {
+		signed, err := SignLayout(path, k)
+		require.NoError(t, err)
+		assert.Equal(t, digest, signed)
+	}
+
+	for _, k := range []crypto.Signer{key, otherKey} {
+		verified, err := VerifyLayout(path, []crypto.PublicKey{k.Public()})
+		require.NoError(t, err)
+		assert.Equal(t, digest, verified)
+	}
+
+	untrustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = VerifyLayout(path, []crypto.PublicKey{untrustedKey.Public()})
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	// The layout contains the image and a single signature image.
+	index, err := p.ImageIndex()
+	require.NoError(t, err)
+	manifest, err := index.IndexManifest()
+	require.NoError(t, err)
+	if assert.Len(t, manifest.Manifests, 2) {
+		assert.Equal(t, signatureTagName(digest), manifest.Manifests[1].Annotations[refNameAnnotation])
+	}
+}
+
+func TestSignLayout_MultipleImages(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := t.TempDir()
+	p, err := layout.Write(path, empty.Index)
+	require.NoError(t, err)
+	for range 2 {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+		require.NoError(t, p.AppendImage(img))
+	}
+
+	_, err = SignLayout(path, key)
+	require.ErrorIs(t, err, errLayoutImage)
+}
+
+func encodePKCS8(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// Encrypts the key like `cosign generate-key-pair`, with cheaper scrypt parameters.
+func encryptCosignKey(t *testing.T, key crypto.Signer, password string) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	var ek encryptedKey
+	ek.KDF.Name = "scrypt"
+	ek.KDF.Params.N, ek.KDF.Params.R, ek.KDF.Params.P = 1024, 8, 1
+	ek.KDF.Salt = make([]byte, 32)
+	_, err = rand.Read(ek.KDF.Salt)
+	require.NoError(t, err)
+	ek.Cipher.Name = "nacl/secretbox"
+	ek.Cipher.Nonce = make([]byte, 24)
+	_, err = rand.Read(ek.Cipher.Nonce)
+	require.NoError(t, err)
+
+	secret, err := scrypt.Key([]byte(password), ek.KDF.Salt, ek.KDF.Params.N, ek.KDF.Params.R, ek.KDF.Params.P, 32)
+	require.NoError(t, err)
+	var (
+		nonce  [24]byte
+		secKey [32]byte
+	)
+	copy(nonce[:], ek.Cipher.Nonce)
+	copy(secKey[:], secret)
+	ek.Ciphertext = secretbox.Seal(nil, der, &nonce, &secKey)
+
+	data, err := json.Marshal(ek)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: data})
+}
@@ -0,0 +1,64 @@
+// Package kubeconfig loads kubeconfigs of target clusters stored in Secrets.
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var ErrUnsupportedKubeconfig = errors.New("unsupported kubeconfig")
+
+// RESTConfig returns the client config for the current context of the given kubeconfig.
+//
+// Kubeconfigs are supplied by users via Secrets, so only inline credentials are supported.
+// Exec plugins, auth-providers and references to files would run commands
+// or read files of Package Operator and are rejected.
+func RESTConfig(kubeconfig []byte) (*rest.Config, error) {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig: %w", err)
+	}
+	if err := validate(cfg); err != nil {
+		return nil, err
+	}
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(
+		*cfg, cfg.CurrentContext, &clientcmd.ConfigOverrides{}, nil,
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	return restConfig, nil
+}
+
+func validate(cfg *clientcmdapi.Config) error {
+	for name, cluster := range cfg.Clusters {
+		if len(cluster.CertificateAuthority) > 0 {
+			return fmt.Errorf(
+				"%w: cluster %q references certificate-authority file, use certificate-authority-data",
+				ErrUnsupportedKubeconfig, name)
+		}
+	}
+	for name, authInfo := range cfg.AuthInfos {
+		switch {
+		case authInfo.Exec != nil:
+			return fmt.Errorf("%w: user %q uses exec plugin", ErrUnsupportedKubeconfig, name)
+		case authInfo.AuthProvider != nil:
+			return fmt.Errorf("%w: user %q uses auth-provider", ErrUnsupportedKubeconfig, name)
+		case len(authInfo.TokenFile) > 0:
+			return fmt.Errorf(
+				"%w: user %q references tokenFile, use token", ErrUnsupportedKubeconfig, name)
+		case len(authInfo.ClientCertificate) > 0:
+			return fmt.Errorf(
+				"%w: user %q references client-certificate file, use client-certificate-data",
+				ErrUnsupportedKubeconfig, name)
+		case len(authInfo.ClientKey) > 0:
+			return fmt.Errorf(
+				"%w: user %q references client-key file, use client-key-data", ErrUnsupportedKubeconfig, name)
+		}
+	}
+	return nil
+}
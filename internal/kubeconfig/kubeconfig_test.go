@@ -0,0 +1,88 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+current-context: target
+contexts:
+- name: target
+  context:
+    cluster: target
+    user: target
+clusters:
+- name: target
+  cluster:
+    server: https://target.example.com:6443
+%s
+users:
+- name: target
+  user:
+%s
+`
+
+func TestRESTConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := RESTConfig(fmt.Appendf(nil, kubeconfigTemplate,
+		"    certificate-authority-data: Y2E=",
+		"    token: secret-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://target.example.com:6443", cfg.Host)
+	assert.Equal(t, "secret-token", cfg.BearerToken)
+	assert.Equal(t, []byte("ca"), cfg.CAData)
+}
+
+func TestRESTConfig_unsupported(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster string
+		user    string
+	}{
+		{
+			name:    "certificate-authority file",
+			cluster: "    certificate-authority: /etc/kubernetes/pki/ca.crt",
+			user:    "    token: secret-token",
+		},
+		{
+			name: "exec plugin",
+			user: `    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: /bin/sh
+      args: ["-c", "cat /var/run/secrets/kubernetes.io/serviceaccount/token"]`,
+		},
+		{
+			name: "auth-provider",
+			user: `    auth-provider:
+      name: oidc`,
+		},
+		{
+			name: "tokenFile",
+			user: "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token",
+		},
+		{
+			name: "client-certificate file",
+			user: "    client-certificate: /etc/kubernetes/pki/admin.crt\n    client-key-data: a2V5",
+		},
+		{
+			name: "client-key file",
+			user: "    client-certificate-data: Y2VydA==\n    client-key: /etc/kubernetes/pki/admin.key",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := RESTConfig(fmt.Appendf(nil, kubeconfigTemplate, test.cluster, test.user))
+			require.ErrorIs(t, err, ErrUnsupportedKubeconfig)
+		})
+	}
+}
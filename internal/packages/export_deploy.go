@@ -12,4 +12,6 @@ var (
 	NewPackageDeployer = packagedeploy.NewPackageDeployer
 	// Returns a new cluster-scoped loader for the ClusterPackage API.
 	NewClusterPackageDeployer = packagedeploy.NewClusterPackageDeployer
+	// Replaces the tag or digest of the image reference with the given digest.
+	ImageWithDigest = packagedeploy.ImageWithDigest
 )
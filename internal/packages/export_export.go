@@ -9,4 +9,8 @@ var (
 	ToOCIFile = packageexport.ToOCIFile
 	// Exports the given package by pushing it to an OCI registry.
 	ToPushedOCI = packageexport.ToPushedOCI
+	// Exports the given package image to an OCI tar under the given name and tags.
+	ImageToOCIFile = packageexport.ImageToOCIFile
+	// Exports the given package image by pushing it to an OCI registry.
+	ImageToPushedOCI = packageexport.ImageToPushedOCI
 )
@@ -0,0 +1,30 @@
+package packages
+
+import "package-operator.run/internal/packages/internal/packagelint"
+
+type (
+	// Runs lint rules against the rendered output of all template test cases of a package.
+	Linter = packagelint.Linter
+	// LintRule checks the rendered output of a template test case for best practice violations.
+	LintRule = packagelint.Rule
+	// Package rendered for a single template test case, as passed to lint rules.
+	LintTestCase = packagelint.TestCase
+
+	// Reports objects that are not selected by any availability probe of the PackageManifest.
+	AvailabilityProbesLintRule = packagelint.AvailabilityProbesRule
+	// Reports containers using images that are not declared in .spec.images of the PackageManifest.
+	DeclaredImagesLintRule = packagelint.DeclaredImagesRule
+	// Reports containers without cpu and memory requests.
+	ResourceRequestsLintRule = packagelint.ResourceRequestsRule
+	// Reports objects without a phase annotation.
+	PhaseAnnotationLintRule = packagelint.PhaseAnnotationRule
+	// Reports templates rendering different output on repeated runs.
+	DeterministicTemplatesLintRule = packagelint.DeterministicTemplatesRule
+)
+
+var (
+	// Creates a new Linter running the given rules or DefaultLintRules.
+	NewLinter = packagelint.NewLinter
+	// The built-in lint rule set.
+	DefaultLintRules = packagelint.DefaultRules
+)
@@ -3,8 +3,10 @@ package packages
 import "package-operator.run/internal/packages/internal/packagerender"
 
 var (
-	// Runs a go-template transformer on all .gotmpl files.
+	// Runs a go-template transformer on all .gotmpl files and the Jsonnet engine on all .jsonnet files.
 	RenderTemplates = packagerender.RenderTemplates
+	// Evaluates a Jsonnet file from disk into YAML documents.
+	EvaluateJsonnetFile = packagerender.EvaluateJsonnetFile
 	// Renders all .yml and .yaml files into Kubernetes Objects.
 	RenderObjects = packagerender.RenderObjects
 	// Renders all .yml and .yaml files into Kubernetes Objects and applies CEL conditionals to filter objects.
@@ -0,0 +1,35 @@
+package packages
+
+import "package-operator.run/internal/packages/internal/packagesbom"
+
+const (
+	// SPDX 2.3 JSON documents.
+	SBOMFormatSPDX = packagesbom.FormatSPDX
+	// CycloneDX 1.5 JSON documents.
+	SBOMFormatCycloneDX = packagesbom.FormatCycloneDX
+)
+
+type (
+	// BillOfMaterials lists everything that went into a package image.
+	BillOfMaterials = packagesbom.BillOfMaterials
+	// SBOM is a bill of materials document attached to a package image.
+	SBOM = packagesbom.SBOM
+)
+
+var (
+	// SBOMFormats lists all supported SBOM formats.
+	SBOMFormats = packagesbom.Formats
+	// ErrUnknownSBOMFormat is returned for SBOM formats other than the ones listed in SBOMFormats.
+	ErrUnknownSBOMFormat = packagesbom.ErrUnknownFormat
+	// ErrNoSBOM is returned when a package image has no SBOM attached.
+	ErrNoSBOM = packagesbom.ErrNoSBOM
+
+	// Collects the images, dependencies and files of the given package.
+	NewBillOfMaterials = packagesbom.NewBillOfMaterials
+	// Encodes the bill of materials as document of the given format.
+	EncodeSBOM = packagesbom.Encode
+	// Adds the SBOM to the package image as additional layer.
+	AttachSBOM = packagesbom.Attach
+	// Reads the SBOM attached to the given package image.
+	SBOMFromOCI = packagesbom.FromOCI
+)
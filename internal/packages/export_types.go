@@ -7,6 +7,8 @@ const (
 	PackageManifestFilename = packagetypes.PackageManifestFilename
 	// Package manifest lock filename without file-extension.
 	PackageManifestLockFilename = packagetypes.PackageManifestLockFilename
+	// Folder containing the components of multi-component packages.
+	ComponentsFolder = packagetypes.ComponentsFolder
 )
 
 type (
@@ -25,6 +27,8 @@ type (
 	// Files is an in-memory representation of the package FileSystem.
 	// It maps file paths to their contents.
 	Files = packagetypes.Files
+	// ComponentError wraps errors returned while validating a component of a multi-component package.
+	ComponentError = packagetypes.ComponentError
 )
 
 var (
@@ -32,4 +36,9 @@ var (
 	PackageManifestGroupKind = packagetypes.PackageManifestGroupKind
 	// PackageManifestLockGroupKind is the kubernetes schema group kind of a PackageManifestLock.
 	PackageManifestLockGroupKind = packagetypes.PackageManifestLockGroupKind
+
+	// Locates the source file and line of the YAML document at index within the file at path.
+	SourceLocation = packagetypes.SourceLocation
+	// Is path suffixed by .jsonnet.
+	IsJsonnetFile = packagetypes.IsJsonnetFile
 )
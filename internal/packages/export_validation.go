@@ -36,6 +36,8 @@ type (
 	ObjectGVKValidator = packagevalidation.ObjectGVKValidator
 	// Validates that all labels are valid.
 	ObjectLabelsValidator = packagevalidation.ObjectLabelsValidator
+	// Validates that objects are not using APIs that are deprecated or removed in the given Kubernetes version.
+	ObjectAPIDeprecationValidator = packagevalidation.ObjectAPIDeprecationValidator
 
 	// Function given to ValidateEachObject to validate individual objects in a package.
 	ValidateEachObjectFn = packagevalidation.ValidateEachObjectFn
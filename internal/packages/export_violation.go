@@ -35,4 +35,15 @@ var (
 	ViolationReasonNestedMultiComponentPkg       = packagetypes.ViolationReasonNestedMultiComponentPkg
 	ViolationReasonInvalidFileInComponentsDir    = packagetypes.ViolationReasonInvalidFileInComponentsDir
 	ViolationReasonKubeconform                   = packagetypes.ViolationReasonKubeconform
+	ViolationReasonAPIRemoved                    = packagetypes.ViolationReasonAPIRemoved
+	ViolationReasonAPIDeprecated                 = packagetypes.ViolationReasonAPIDeprecated
+	ViolationReasonUnknownLintRule               = packagetypes.ViolationReasonUnknownLintRule
+	ViolationReasonMissingAvailabilityProbe      = packagetypes.ViolationReasonMissingAvailabilityProbe
+	ViolationReasonUndeclaredImage               = packagetypes.ViolationReasonUndeclaredImage
+	ViolationReasonMissingResourceRequests       = packagetypes.ViolationReasonMissingResourceRequests
+	ViolationReasonNonDeterministicTemplate      = packagetypes.ViolationReasonNonDeterministicTemplate
+	ViolationReasonTemplateParse                 = packagetypes.ViolationReasonTemplateParse
+	ViolationReasonTemplateExecution             = packagetypes.ViolationReasonTemplateExecution
+	ViolationReasonJsonnetEvaluation             = packagetypes.ViolationReasonJsonnetEvaluation
+	ViolationReasonJsonnetOutputConflict         = packagetypes.ViolationReasonJsonnetOutputConflict
 )
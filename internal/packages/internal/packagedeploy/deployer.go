@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
 	"pkg.package-operator.run/semver"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -24,7 +26,7 @@ import (
 	"package-operator.run/internal/adapters"
 	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/constants"
-	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imagemirror"
 	"package-operator.run/internal/packages/internal/packagemanifestvalidation"
 	"package-operator.run/internal/packages/internal/packagerender"
 	"package-operator.run/internal/packages/internal/packagestructure"
@@ -47,7 +49,7 @@ type PackageDeployer struct {
 	deploymentReconciler deploymentReconciler
 	packageValidators    packagevalidation.PackageValidatorList
 
-	imagePrefixOverrides []imageprefix.Override
+	imageMirrors *imagemirror.Resolver
 }
 
 type (
@@ -65,7 +67,7 @@ type (
 func NewPackageDeployer(
 	c client.Client, uncachedClient client.Client,
 	scheme *runtime.Scheme,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
 ) *PackageDeployer {
 	return &PackageDeployer{
 		client:         c,
@@ -85,7 +87,7 @@ func NewPackageDeployer(
 			packagevalidation.DefaultPackageValidators,
 			packagevalidation.PackageScopeValidator(manifests.PackageManifestScopeNamespaced),
 		),
-		imagePrefixOverrides: imagePrefixOverrides,
+		imageMirrors: imageMirrors,
 	}
 }
 
@@ -93,7 +95,7 @@ func NewPackageDeployer(
 func NewClusterPackageDeployer(
 	c client.Client,
 	scheme *runtime.Scheme,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
 ) *PackageDeployer {
 	return &PackageDeployer{
 		client: c,
@@ -114,7 +116,7 @@ func NewClusterPackageDeployer(
 			packagevalidation.DefaultPackageValidators,
 			packagevalidation.PackageScopeValidator(manifests.PackageManifestScopeCluster),
 		),
-		imagePrefixOverrides: imagePrefixOverrides,
+		imageMirrors: imageMirrors,
 	}
 }
 
@@ -171,29 +173,18 @@ func (l *PackageDeployer) Deploy(
 		setInvalidConditionBasedOnLoadError(apiPkg, aggregateErr)
 		return aggregateErr
 	}
-	images := map[string]string{}
-	if pkg.ManifestLock != nil {
-		for _, packageImage := range pkg.ManifestLock.Spec.Images {
-			replacedImage := imageprefix.Replace(packageImage.Image, l.imagePrefixOverrides)
-
-			resolvedImage, err := ImageWithDigest(replacedImage, packageImage.Digest)
-			if err != nil {
-				return err
-			}
-			images[packageImage.Name] = resolvedImage
-		}
+	mirrors, err := l.imageMirrors.Resolve(ctx, apiPkg.ClientObject().GetNamespace())
+	if err != nil {
+		return fmt.Errorf("resolving image mirrors: %w", err)
 	}
-	dependencies := map[string]string{}
-	if pkg.ManifestLock != nil {
-		for _, packageImage := range pkg.ManifestLock.Spec.Dependencies {
-			replacedImage := imageprefix.Replace(packageImage.Image, l.imagePrefixOverrides)
-
-			resolvedImage, err := ImageWithDigest(replacedImage, packageImage.Digest)
-			if err != nil {
-				return err
-			}
-			dependencies[packageImage.Name] = resolvedImage
-		}
+	images, dependencies, err := resolveImages(apiPkg, pkg.ManifestLock, mirrors)
+	if errors.Is(err, errInvalidImageOverride) {
+		setInvalidCondition(apiPkg, "InvalidImageOverride", err)
+		// Image overrides only change together with the Package spec.
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
 	// render package instance
@@ -205,13 +196,32 @@ func (l *PackageDeployer) Deploy(
 			Images:       images,
 			Dependencies: dependencies,
 			Environment:  env,
-		}, l.packageValidators, packagevalidation.DefaultObjectValidators)
+		}, l.packageValidators, packagevalidation.ObjectValidatorList{
+			packagevalidation.DefaultObjectValidators,
+			// Objects using APIs that are no longer served would never become available.
+			&packagevalidation.ObjectAPIDeprecationValidator{
+				KubernetesVersion: apiDeprecationKubernetesVersion(ctx, env.Kubernetes.Version),
+			},
+		})
 	if err != nil {
 		setInvalidConditionBasedOnLoadError(apiPkg, err)
 		return err
 	}
 
-	desiredDeploy, err := l.desiredObjectDeployment(ctx, apiPkg, pkgInstance)
+	// apply Package patches on top of the rendered objects
+	err = patchObjects(apiPkg, pkgInstance.Objects)
+	if err == nil {
+		err = validatePatchedObjects(ctx, pkg.Manifest, pkgInstance.Objects)
+	}
+	if err != nil {
+		setInvalidCondition(apiPkg, "PatchError", err)
+		// Patches only change together with the Package spec,
+		// so retrying before the next spec change would fail again.
+		return nil
+	}
+
+	desiredDeploy, err := l.desiredObjectDeployment(
+		ctx, apiPkg, pkgInstance, mirrors, sourceImages(apiPkg, pkg.ManifestLock))
 	if err != nil {
 		return fmt.Errorf("creating desired ObjectDeployment: %w", err)
 	}
@@ -226,8 +236,22 @@ func (l *PackageDeployer) Deploy(
 	return nil
 }
 
+// apiDeprecationKubernetesVersion returns the Kubernetes version to check API deprecations against.
+// Returns an empty string to skip the check, if the version reported by the cluster can't be parsed.
+func apiDeprecationKubernetesVersion(ctx context.Context, kubernetesVersion string) string {
+	if len(kubernetesVersion) == 0 {
+		return ""
+	}
+	if _, err := version.ParseMajorMinor(kubernetesVersion); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "skipping API deprecation check")
+		return ""
+	}
+	return kubernetesVersion
+}
+
 func (l *PackageDeployer) desiredObjectDeployment(
 	_ context.Context, pkg adapters.PackageAccessor, pkgInstance *packagetypes.PackageInstance,
+	mirrors imagemirror.Overrides, sourceImages []string,
 ) (deploy adapters.ObjectDeploymentAccessor, err error) {
 	labels := map[string]string{
 		manifestsv1alpha1.PackageLabel:         pkgInstance.Manifest.Name,
@@ -238,10 +262,16 @@ func (l *PackageDeployer) desiredObjectDeployment(
 	if err != nil {
 		return nil, fmt.Errorf("marshalling config for package-config annotation: %w", err)
 	}
+	// Allows checking whether image mirror changes affect the package without pulling it.
+	imagesJSON, err := json.Marshal(sourceImages)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling images for package-images annotation: %w", err)
+	}
 
 	annotations := map[string]string{
-		manifestsv1alpha1.PackageSourceImageAnnotation: imageprefix.Replace(pkg.GetSpecImage(), l.imagePrefixOverrides),
+		manifestsv1alpha1.PackageSourceImageAnnotation: mirrors.ReplaceImagePrefix(pkg.GetSpecImage()),
 		manifestsv1alpha1.PackageConfigAnnotation:      string(configJSON),
+		manifestsv1alpha1.PackageImagesAnnotation:      string(imagesJSON),
 		constants.ChangeCauseAnnotation: fmt.Sprintf(
 			"Installing %s package.", pkgInstance.Manifest.Name),
 	}
@@ -255,6 +285,7 @@ func (l *PackageDeployer) desiredObjectDeployment(
 
 	deploy.SetSpecTemplateSpec(packagerender.RenderObjectSetTemplateSpec(pkgInstance))
 	deploy.SetSpecSelector(labels)
+	deploy.SetSpecServiceAccount(pkg.GetSpecServiceAccount())
 
 	if err := controllerutil.SetControllerReference(
 		pkg.ClientObject(), deploy.ClientObject(), l.scheme); err != nil {
@@ -265,9 +296,11 @@ func (l *PackageDeployer) desiredObjectDeployment(
 }
 
 func setInvalidConditionBasedOnLoadError(pkg adapters.PackageAccessor, err error) {
-	reason := "LoadError"
-
 	// Can not be determined more precisely
+	setInvalidCondition(pkg, "LoadError", err)
+}
+
+func setInvalidCondition(pkg adapters.PackageAccessor, reason string, err error) {
 	meta.SetStatusCondition(pkg.GetStatusConditions(), metav1.Condition{
 		Type:               corev1alpha1.PackageInvalid,
 		Status:             metav1.ConditionTrue,
@@ -187,6 +187,9 @@ func TestPackageDeployer_Deploy(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "test", Namespace: "test",
 			},
+			Spec: corev1alpha1.PackageSpec{
+				ServiceAccount: &corev1alpha1.ServiceAccountReference{Name: "tenant"},
+			},
 		},
 	}
 	rawPkg := &packagetypes.RawPackage{
@@ -197,6 +200,76 @@ func TestPackageDeployer_Deploy(t *testing.T) {
 
 	packageInvalid := meta.FindStatusCondition(apiPkg.Status.Conditions, corev1alpha1.PackageInvalid)
 	assert.Nil(t, packageInvalid, "Invalid condition should not be reported")
+
+	deploy := deploymentReconcilerMock.Calls[0].Arguments.Get(1).(adapters.ObjectDeploymentAccessor)
+	assert.Equal(t, apiPkg.Spec.ServiceAccount, deploy.GetSpecServiceAccount())
+}
+
+func TestPackageDeployer_Deploy_UnparsableKubernetesVersion(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	structuralLoaderMock := &structuralLoaderMock{}
+	deploymentReconcilerMock := &deploymentReconcilerMock{}
+
+	l := &PackageDeployer{
+		client: c,
+		scheme: testScheme,
+
+		newObjectDeployment: adapters.NewObjectDeployment,
+		structuralLoader:    structuralLoaderMock,
+
+		deploymentReconciler: deploymentReconcilerMock,
+	}
+
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	structuralLoaderMock.
+		On("LoadComponent", mock.Anything, mock.Anything, mock.Anything).
+		Return(&packagetypes.Package{
+			Manifest: &manifests.PackageManifest{
+				Spec: manifests.PackageManifestSpec{
+					Scopes: []manifests.PackageManifestScope{
+						manifests.PackageManifestScopeNamespaced,
+					},
+					Phases: []manifests.PackageManifestPhase{{Name: "phase-1"}},
+				},
+			},
+		}, nil)
+
+	deploymentReconcilerMock.
+		On("Reconcile", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	apiPkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "test",
+			},
+		},
+	}
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{},
+	}
+	// API deprecations are not checked, if the cluster reports a version that can't be parsed.
+	err := l.Deploy(ctx, apiPkg, rawPkg, manifests.PackageEnvironment{
+		Kubernetes: manifests.PackageEnvironmentKubernetes{Version: "not-a-version"},
+	})
+	require.NoError(t, err)
+
+	packageInvalid := meta.FindStatusCondition(apiPkg.Status.Conditions, corev1alpha1.PackageInvalid)
+	assert.Nil(t, packageInvalid, "Invalid condition should not be reported")
+	deploymentReconcilerMock.AssertCalled(t, "Reconcile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_apiDeprecationKubernetesVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+	assert.Equal(t, "v1.27.3", apiDeprecationKubernetesVersion(ctx, "v1.27.3"))
+	assert.Equal(t, "v1.27.3+k3s1", apiDeprecationKubernetesVersion(ctx, "v1.27.3+k3s1"))
+	assert.Empty(t, apiDeprecationKubernetesVersion(ctx, ""))
+	assert.Empty(t, apiDeprecationKubernetesVersion(ctx, "not-a-version"))
 }
 
 func TestPackageDeployer_Deploy_Error(t *testing.T) {
@@ -247,6 +320,64 @@ func TestPackageDeployer_Deploy_Error(t *testing.T) {
 	}
 }
 
+func TestPackageDeployer_Deploy_PatchError(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	structuralLoaderMock := &structuralLoaderMock{}
+	deploymentReconcilerMock := &deploymentReconcilerMock{}
+
+	l := &PackageDeployer{
+		client: c,
+		scheme: testScheme,
+
+		newObjectDeployment: adapters.NewObjectDeployment,
+		structuralLoader:    structuralLoaderMock,
+
+		deploymentReconciler: deploymentReconcilerMock,
+	}
+
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	structuralLoaderMock.
+		On("LoadComponent", mock.Anything, mock.Anything, mock.Anything).
+		Return(&packagetypes.Package{
+			Manifest: &manifests.PackageManifest{
+				Spec: manifests.PackageManifestSpec{
+					Scopes: []manifests.PackageManifestScope{
+						manifests.PackageManifestScopeNamespaced,
+					},
+				},
+			},
+		}, nil)
+
+	apiPkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "test",
+			},
+			Spec: corev1alpha1.PackageSpec{
+				Patches: []corev1alpha1.PackagePatch{
+					{Type: "Banana", Patch: "{}"},
+				},
+			},
+		},
+	}
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{},
+	}
+	err := l.Deploy(ctx, apiPkg, rawPkg, manifests.PackageEnvironment{})
+	require.NoError(t, err)
+
+	deploymentReconcilerMock.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything, mock.Anything)
+	packageInvalid := meta.FindStatusCondition(apiPkg.Status.Conditions, corev1alpha1.PackageInvalid)
+	if assert.NotNil(t, packageInvalid) {
+		assert.Equal(t, metav1.ConditionTrue, packageInvalid.Status)
+		assert.Equal(t, "PatchError", packageInvalid.Reason)
+		assert.Equal(t, "spec.patches[0]: unknown patch type: Banana", packageInvalid.Message)
+	}
+}
+
 func TestImageWithDigestOk(t *testing.T) {
 	t.Parallel()
 
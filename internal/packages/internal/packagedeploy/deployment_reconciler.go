@@ -113,6 +113,7 @@ func (r *DeploymentReconciler) Reconcile(
 		actualDeploy.ClientObject().SetLabels(labels)
 
 		actualDeploy.SetSpecTemplateSpec(templateSpec)
+		actualDeploy.SetSpecServiceAccount(desiredDeploy.GetSpecServiceAccount())
 
 		err := r.client.Update(ctx, actualDeploy.ClientObject())
 		if err == nil {
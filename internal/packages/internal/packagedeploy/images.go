@@ -0,0 +1,115 @@
+package packagedeploy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageprefix"
+)
+
+var errInvalidImageOverride = errors.New("invalid image override")
+
+// Resolves the digest pinned images and dependencies of the lock file for rendering.
+// Image overrides of the Package are applied first,
+// the image prefix overrides of the operator and mirror sets apply to their result.
+func resolveImages(
+	apiPkg adapters.PackageAccessor, lock *manifests.PackageManifestLock, mirrors imagemirror.Overrides,
+) (images, dependencies map[string]string, err error) {
+	imageOverrides, err := packageImageOverrides(apiPkg, lock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prefixOverrides := packagePrefixOverrides(apiPkg)
+
+	images = map[string]string{}
+	dependencies = map[string]string{}
+	if lock == nil {
+		return images, dependencies, nil
+	}
+
+	for _, packageImage := range lock.Spec.Images {
+		if override, ok := imageOverrides[packageImage.Name]; ok {
+			images[packageImage.Name] = imageprefix.Replace(override, mirrors.ImagePrefixOverrides)
+			continue
+		}
+
+		replacedImage := imageprefix.ReplaceInOrder(packageImage.Image, prefixOverrides, mirrors.ImagePrefixOverrides)
+		resolvedImage, err := ImageWithDigest(replacedImage, packageImage.Digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		images[packageImage.Name] = resolvedImage
+	}
+	for _, packageImage := range lock.Spec.Dependencies {
+		replacedImage := imageprefix.ReplaceInOrder(packageImage.Image, prefixOverrides, mirrors.ImagePrefixOverrides)
+		resolvedImage, err := ImageWithDigest(replacedImage, packageImage.Digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		dependencies[packageImage.Name] = resolvedImage
+	}
+	return images, dependencies, nil
+}
+
+// sourceImages lists the images used by the package with Package image overrides applied,
+// but before image prefix overrides of Package Operator and ClusterImageMirrorSets.
+func sourceImages(apiPkg adapters.PackageAccessor, lock *manifests.PackageManifestLock) []string {
+	if lock == nil {
+		return []string{}
+	}
+	imageOverrides := map[string]string{}
+	for _, o := range apiPkg.GetSpecImageOverrides() {
+		imageOverrides[o.Name] = o.Image
+	}
+	prefixOverrides := packagePrefixOverrides(apiPkg)
+
+	images := make([]string, 0, len(lock.Spec.Images)+len(lock.Spec.Dependencies))
+	for _, packageImage := range lock.Spec.Images {
+		if override, ok := imageOverrides[packageImage.Name]; ok {
+			images = append(images, override)
+			continue
+		}
+		images = append(images, imageprefix.Replace(packageImage.Image, prefixOverrides))
+	}
+	for _, packageImage := range lock.Spec.Dependencies {
+		images = append(images, imageprefix.Replace(packageImage.Image, prefixOverrides))
+	}
+	return images
+}
+
+func packagePrefixOverrides(apiPkg adapters.PackageAccessor) []imageprefix.Override {
+	var prefixOverrides []imageprefix.Override
+	for _, o := range apiPkg.GetSpecImagePrefixOverrides() {
+		prefixOverrides = append(prefixOverrides, imageprefix.Override{From: o.From, To: o.To})
+	}
+	return prefixOverrides
+}
+
+// Validates the named image overrides of a Package against the lock file.
+// Overrides must replace a locked image and stay pinned by digest, like all locked images.
+func packageImageOverrides(
+	apiPkg adapters.PackageAccessor, lock *manifests.PackageManifestLock,
+) (map[string]string, error) {
+	overrides := map[string]string{}
+	for _, o := range apiPkg.GetSpecImageOverrides() {
+		if lock == nil || !slices.ContainsFunc(lock.Spec.Images, func(i manifests.PackageManifestLockImage) bool {
+			return i.Name == o.Name
+		}) {
+			return nil, fmt.Errorf(
+				"%w: image %q is not declared in the PackageManifestLock", errInvalidImageOverride, o.Name)
+		}
+		if _, err := name.NewDigest(o.Image); err != nil {
+			return nil, fmt.Errorf(
+				"%w: image %q must be referenced by digest: %w", errInvalidImageOverride, o.Name, err)
+		}
+		overrides[o.Name] = o.Image
+	}
+	return overrides, nil
+}
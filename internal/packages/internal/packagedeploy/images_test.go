@@ -0,0 +1,184 @@
+package packagedeploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageprefix"
+)
+
+const testOverrideDgst = "sha256:0c2d2a6a1d2f0fbd3b5c5ac7c5ed1ee2b7f7d4cb8b2b5e4c8d7bd2e1a8fdb9e4"
+
+func Test_resolveImages(t *testing.T) {
+	t.Parallel()
+
+	lock := &manifests.PackageManifestLock{
+		Spec: manifests.PackageManifestLockSpec{
+			Images: []manifests.PackageManifestLockImage{
+				{Name: "app", Image: "quay.io/original/app:v1", Digest: testDgst},
+				{Name: "sidecar", Image: "quay.io/original/sidecar:v1", Digest: testDgst},
+				{Name: "other", Image: "docker.io/library/nginx:1.23.3", Digest: testDgst},
+			},
+			Dependencies: []manifests.PackageManifestLockDependency{
+				{Name: "dep", Image: "quay.io/original/dep:v1", Digest: testDgst},
+			},
+		},
+	}
+
+	mirrors := imagemirror.Overrides{
+		ImagePrefixOverrides: []imageprefix.Override{
+			{From: "quay.io/original/", To: "quay.io/mirror/"},
+		},
+	}
+	apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{
+		Spec: corev1alpha1.PackageSpec{
+			ImageOverrides: []corev1alpha1.PackageImageOverride{
+				{Name: "app", Image: "quay.io/original/app-patched@" + testOverrideDgst},
+			},
+			ImagePrefixOverrides: []corev1alpha1.PackageImagePrefixOverride{
+				{From: "quay.io/original/sidecar", To: "quay.io/team/sidecar"},
+			},
+		},
+	}}
+
+	images, dependencies, err := resolveImages(apiPkg, lock, mirrors)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		// Named overrides are still subject to operator prefix overrides.
+		"app":     "quay.io/mirror/app-patched@" + testOverrideDgst,
+		"sidecar": "quay.io/team/sidecar@" + testDgst,
+		"other":   "index.docker.io/library/nginx@" + testDgst,
+	}, images)
+	assert.Equal(t, map[string]string{
+		"dep": "quay.io/mirror/dep@" + testDgst,
+	}, dependencies)
+}
+
+func Test_resolveImages_PrefixOverrideAndMirror(t *testing.T) {
+	t.Parallel()
+
+	lock := &manifests.PackageManifestLock{
+		Spec: manifests.PackageManifestLockSpec{
+			Images: []manifests.PackageManifestLockImage{
+				{Name: "app", Image: "quay.io/original/app:v1", Digest: testDgst},
+			},
+			Dependencies: []manifests.PackageManifestLockDependency{
+				{Name: "dep", Image: "quay.io/original/dep:v1", Digest: testDgst},
+			},
+		},
+	}
+	mirrors := imagemirror.Overrides{
+		ImagePrefixOverrides: []imageprefix.Override{
+			{From: "quay.io/original/", To: "quay.io/mirror/"},
+			{From: "quay.io/team/", To: "mirror.example.com/team/"},
+		},
+	}
+	apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{
+		Spec: corev1alpha1.PackageSpec{
+			ImagePrefixOverrides: []corev1alpha1.PackageImagePrefixOverride{
+				{From: "quay.io/original/", To: "quay.io/team/"},
+			},
+		},
+	}}
+
+	images, dependencies, err := resolveImages(apiPkg, lock, mirrors)
+	require.NoError(t, err)
+	// Images redirected by the Package are still pulled through the mirror.
+	assert.Equal(t, map[string]string{
+		"app": "mirror.example.com/team/app@" + testDgst,
+	}, images)
+	assert.Equal(t, map[string]string{
+		"dep": "mirror.example.com/team/dep@" + testDgst,
+	}, dependencies)
+}
+
+func Test_sourceImages(t *testing.T) {
+	t.Parallel()
+
+	lock := &manifests.PackageManifestLock{
+		Spec: manifests.PackageManifestLockSpec{
+			Images: []manifests.PackageManifestLockImage{
+				{Name: "app", Image: "quay.io/original/app:v1", Digest: testDgst},
+				{Name: "sidecar", Image: "quay.io/original/sidecar:v1", Digest: testDgst},
+			},
+			Dependencies: []manifests.PackageManifestLockDependency{
+				{Name: "dep", Image: "quay.io/original/dep:v1", Digest: testDgst},
+			},
+		},
+	}
+	apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{
+		Spec: corev1alpha1.PackageSpec{
+			ImageOverrides: []corev1alpha1.PackageImageOverride{
+				{Name: "app", Image: "quay.io/original/app-patched@" + testOverrideDgst},
+			},
+			ImagePrefixOverrides: []corev1alpha1.PackageImagePrefixOverride{
+				{From: "quay.io/original/sidecar", To: "quay.io/team/sidecar"},
+			},
+		},
+	}}
+
+	assert.Equal(t, []string{
+		"quay.io/original/app-patched@" + testOverrideDgst,
+		"quay.io/team/sidecar:v1",
+		"quay.io/original/dep:v1",
+	}, sourceImages(apiPkg, lock))
+	assert.Empty(t, sourceImages(apiPkg, nil))
+}
+
+func Test_resolveImages_InvalidOverride(t *testing.T) {
+	t.Parallel()
+
+	lock := &manifests.PackageManifestLock{
+		Spec: manifests.PackageManifestLockSpec{
+			Images: []manifests.PackageManifestLockImage{
+				{Name: "app", Image: "quay.io/original/app:v1", Digest: testDgst},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		lock     *manifests.PackageManifestLock
+		override corev1alpha1.PackageImageOverride
+		err      string
+	}{
+		{
+			name:     "not locked",
+			lock:     lock,
+			override: corev1alpha1.PackageImageOverride{Name: "banana", Image: "quay.io/banana@" + testOverrideDgst},
+			err:      `invalid image override: image "banana" is not declared in the PackageManifestLock`,
+		},
+		{
+			name:     "no lock",
+			override: corev1alpha1.PackageImageOverride{Name: "app", Image: "quay.io/app@" + testOverrideDgst},
+			err:      `invalid image override: image "app" is not declared in the PackageManifestLock`,
+		},
+		{
+			name:     "not pinned",
+			lock:     lock,
+			override: corev1alpha1.PackageImageOverride{Name: "app", Image: "quay.io/app:v2"},
+			err:      `invalid image override: image "app" must be referenced by digest: `,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{
+				Spec: corev1alpha1.PackageSpec{
+					ImageOverrides: []corev1alpha1.PackageImageOverride{test.override},
+				},
+			}}
+
+			_, _, err := resolveImages(apiPkg, test.lock, imagemirror.Overrides{})
+			require.ErrorIs(t, err, errInvalidImageOverride)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
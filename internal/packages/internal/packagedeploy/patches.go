@@ -0,0 +1,161 @@
+package packagedeploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagevalidation"
+)
+
+var (
+	errUnknownPatchType     = errors.New("unknown patch type")
+	errPatchChangedIdentity = errors.New("patch must not change apiVersion group, kind or namespace")
+)
+
+// Applies the patches, common labels and common annotations of a Package to its rendered objects.
+// Objects are modified in place.
+func patchObjects(apiPkg adapters.PackageAccessor, objects []unstructured.Unstructured) error {
+	for i, patch := range apiPkg.GetSpecPatches() {
+		if err := applyPatch(patch, objects); err != nil {
+			return fmt.Errorf("spec.patches[%d]: %w", i, err)
+		}
+	}
+
+	commonLabels := apiPkg.GetSpecCommonLabels()
+	commonAnnotations := apiPkg.GetSpecCommonAnnotations()
+	for i := range objects {
+		obj := &objects[i]
+		if len(commonLabels) > 0 {
+			obj.SetLabels(labels.Merge(obj.GetLabels(), commonLabels))
+		}
+		if len(commonAnnotations) > 0 {
+			obj.SetAnnotations(labels.Merge(obj.GetAnnotations(), commonAnnotations))
+		}
+	}
+	return nil
+}
+
+// Runs the default object validations again on patched objects,
+// because patches and common annotations can invalidate objects that passed validation while rendering.
+func validatePatchedObjects(
+	ctx context.Context, manifest *manifests.PackageManifest, objects []unstructured.Unstructured,
+) error {
+	objectsByName := make(map[string][]unstructured.Unstructured, len(objects))
+	for _, obj := range objects {
+		name := fmt.Sprintf("%s %s", obj.GroupVersionKind().GroupKind(), client.ObjectKeyFromObject(&obj))
+		objectsByName[name] = append(objectsByName[name], obj)
+	}
+	return packagevalidation.DefaultObjectValidators.ValidateObjects(ctx, manifest, objectsByName)
+}
+
+// Applies a single patch to all objects matching its target.
+func applyPatch(patch corev1alpha1.PackagePatch, objects []unstructured.Unstructured) error {
+	matches, err := patchTargetMatcher(patch.Target)
+	if err != nil {
+		return err
+	}
+
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+
+	var apply func(original []byte, gvk schema.GroupVersionKind) ([]byte, error)
+	switch patch.Type {
+	case corev1alpha1.PackagePatchTypeStrategicMerge, "":
+		apply = func(original []byte, gvk schema.GroupVersionKind) ([]byte, error) {
+			return strategicMergePatch(original, patchJSON, gvk)
+		}
+	case corev1alpha1.PackagePatchTypeJSON6902:
+		ops, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return fmt.Errorf("invalid patch: %w", err)
+		}
+		apply = func(original []byte, _ schema.GroupVersionKind) ([]byte, error) {
+			return ops.Apply(original)
+		}
+	default:
+		return fmt.Errorf("%w: %s", errUnknownPatchType, patch.Type)
+	}
+
+	for i := range objects {
+		obj := &objects[i]
+		if !matches(obj) {
+			continue
+		}
+
+		original, err := obj.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		groupKind, namespace := obj.GroupVersionKind().GroupKind(), obj.GetNamespace()
+		patched, err := apply(original, obj.GroupVersionKind())
+		if err == nil {
+			err = obj.UnmarshalJSON(patched)
+		}
+		if err == nil && (obj.GroupVersionKind().GroupKind() != groupKind || obj.GetNamespace() != namespace) {
+			err = errPatchChangedIdentity
+		}
+		if err != nil {
+			return fmt.Errorf("patching %s %s: %w", groupKind, client.ObjectKeyFromObject(obj), err)
+		}
+	}
+	return nil
+}
+
+// Uses the strategic merge metadata of built-in Kubernetes types.
+// Types without metadata, like custom resources, are patched with a JSON merge patch instead.
+func strategicMergePatch(original, patch []byte, gvk schema.GroupVersionKind) ([]byte, error) {
+	dataStruct, err := clientgoscheme.Scheme.New(gvk)
+	if runtime.IsNotRegisteredError(err) {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+}
+
+// Returns a function checking whether an object is selected by the given patch target.
+func patchTargetMatcher(target *corev1alpha1.PackagePatchTarget) (func(obj *unstructured.Unstructured) bool, error) {
+	if target == nil {
+		return func(*unstructured.Unstructured) bool { return true }, nil
+	}
+
+	selector := labels.Everything()
+	if target.LabelSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(target.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target label selector: %w", err)
+		}
+	}
+
+	return func(obj *unstructured.Unstructured) bool {
+		gvk := obj.GroupVersionKind()
+		switch {
+		case len(target.Group) > 0 && gvk.Group != target.Group:
+			return false
+		case len(target.Kind) > 0 && gvk.Kind != target.Kind:
+			return false
+		case len(target.Name) > 0 && obj.GetName() != target.Name:
+			return false
+		}
+		return selector.Matches(labels.Set(obj.GetLabels()))
+	}, nil
+}
@@ -0,0 +1,324 @@
+package packagedeploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+)
+
+func newPatchTestObjects() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":   "web",
+				"labels": map[string]any{"app": "web"},
+			},
+			"spec": map[string]any{
+				"template": map[string]any{
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{"name": "web", "image": "nginx"},
+							map[string]any{"name": "sidecar", "image": "envoy"},
+						},
+					},
+				},
+			},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name": "config",
+			},
+			"data": map[string]any{"a": "1", "b": "2"},
+		}},
+		{Object: map[string]any{
+			"apiVersion": "example.com/v1",
+			"kind":       "Thing",
+			"metadata": map[string]any{
+				"name":   "thing",
+				"labels": map[string]any{"app": "web"},
+			},
+			"spec": map[string]any{
+				"items": []any{"a"},
+			},
+		}},
+	}
+}
+
+func TestPatchObjects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		spec   corev1alpha1.PackageSpec
+		assert func(t *testing.T, objects []unstructured.Unstructured)
+	}{
+		{
+			name: "strategic merge",
+			spec: corev1alpha1.PackageSpec{
+				Patches: []corev1alpha1.PackagePatch{{
+					Target: &corev1alpha1.PackagePatchTarget{Group: "apps", Kind: "Deployment", Name: "web"},
+					Type:   corev1alpha1.PackagePatchTypeStrategicMerge,
+					Patch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        resources:
+          limits:
+            memory: 128Mi`,
+				}},
+			},
+			assert: func(t *testing.T, objects []unstructured.Unstructured) {
+				t.Helper()
+				containers, _, _ := unstructured.NestedSlice(objects[0].Object, "spec", "template", "spec", "containers")
+				// Containers are merged by name.
+				require.Len(t, containers, 2)
+				assert.Equal(t, map[string]any{
+					"name":      "web",
+					"image":     "nginx",
+					"resources": map[string]any{"limits": map[string]any{"memory": "128Mi"}},
+				}, containers[0])
+			},
+		},
+		{
+			name: "json merge for unknown types",
+			spec: corev1alpha1.PackageSpec{
+				Patches: []corev1alpha1.PackagePatch{{
+					Target: &corev1alpha1.PackagePatchTarget{Kind: "Thing"},
+					Patch:  `{"spec": {"items": ["b"]}}`,
+				}},
+			},
+			assert: func(t *testing.T, objects []unstructured.Unstructured) {
+				t.Helper()
+				items, _, _ := unstructured.NestedStringSlice(objects[2].Object, "spec", "items")
+				assert.Equal(t, []string{"b"}, items)
+			},
+		},
+		{
+			name: "json6902",
+			spec: corev1alpha1.PackageSpec{
+				Patches: []corev1alpha1.PackagePatch{{
+					Target: &corev1alpha1.PackagePatchTarget{Kind: "ConfigMap"},
+					Type:   corev1alpha1.PackagePatchTypeJSON6902,
+					Patch: `
+- op: remove
+  path: /data/a
+- op: replace
+  path: /data/b
+  value: "3"`,
+				}},
+			},
+			assert: func(t *testing.T, objects []unstructured.Unstructured) {
+				t.Helper()
+				data, _, _ := unstructured.NestedStringMap(objects[1].Object, "data")
+				assert.Equal(t, map[string]string{"b": "3"}, data)
+			},
+		},
+		{
+			name: "label selector target",
+			spec: corev1alpha1.PackageSpec{
+				Patches: []corev1alpha1.PackagePatch{{
+					Target: &corev1alpha1.PackagePatchTarget{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					},
+					Patch: `metadata: {annotations: {patched: "true"}}`,
+				}},
+			},
+			assert: func(t *testing.T, objects []unstructured.Unstructured) {
+				t.Helper()
+				assert.Equal(t, "true", objects[0].GetAnnotations()["patched"])
+				assert.Empty(t, objects[1].GetAnnotations())
+				assert.Equal(t, "true", objects[2].GetAnnotations()["patched"])
+			},
+		},
+		{
+			name: "common labels and annotations",
+			spec: corev1alpha1.PackageSpec{
+				CommonLabels:      map[string]string{"team": "a", "app": "override"},
+				CommonAnnotations: map[string]string{"owner": "a"},
+			},
+			assert: func(t *testing.T, objects []unstructured.Unstructured) {
+				t.Helper()
+				for _, obj := range objects {
+					assert.Equal(t, "a", obj.GetLabels()["team"])
+					assert.Equal(t, "override", obj.GetLabels()["app"])
+					assert.Equal(t, map[string]string{"owner": "a"}, obj.GetAnnotations())
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{Spec: test.spec}}
+			objects := newPatchTestObjects()
+			require.NoError(t, patchObjects(apiPkg, objects))
+			test.assert(t, objects)
+		})
+	}
+}
+
+func TestPatchObjects_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		patch corev1alpha1.PackagePatch
+		err   string
+	}{
+		{
+			name:  "unknown type",
+			patch: corev1alpha1.PackagePatch{Type: "Banana", Patch: "{}"},
+			err:   "spec.patches[0]: unknown patch type: Banana",
+		},
+		{
+			name:  "invalid json6902 patch",
+			patch: corev1alpha1.PackagePatch{Type: corev1alpha1.PackagePatchTypeJSON6902, Patch: "{}"},
+			err:   "spec.patches[0]: invalid patch: ",
+		},
+		{
+			name: "failing operation",
+			patch: corev1alpha1.PackagePatch{
+				Target: &corev1alpha1.PackagePatchTarget{Kind: "ConfigMap"},
+				Type:   corev1alpha1.PackagePatchTypeJSON6902,
+				Patch:  `[{"op": "remove", "path": "/data/banana"}]`,
+			},
+			err: "spec.patches[0]: patching ConfigMap /config: ",
+		},
+		{
+			name: "invalid label selector",
+			patch: corev1alpha1.PackagePatch{
+				Target: &corev1alpha1.PackagePatchTarget{
+					LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: "Banana"},
+					}},
+				},
+				Patch: "{}",
+			},
+			err: "spec.patches[0]: invalid target label selector: ",
+		},
+		{
+			name: "namespace change",
+			patch: corev1alpha1.PackagePatch{
+				Target: &corev1alpha1.PackagePatchTarget{Kind: "ConfigMap"},
+				Patch:  `metadata: {namespace: kube-system}`,
+			},
+			err: "spec.patches[0]: patching ConfigMap /config: patch must not change apiVersion group, kind or namespace",
+		},
+		{
+			name: "kind change",
+			patch: corev1alpha1.PackagePatch{
+				Target: &corev1alpha1.PackagePatchTarget{Kind: "ConfigMap"},
+				Type:   corev1alpha1.PackagePatchTypeJSON6902,
+				Patch:  `[{"op": "replace", "path": "/kind", "value": "Secret"}]`,
+			},
+			err: "spec.patches[0]: patching ConfigMap /config: patch must not change apiVersion group, kind or namespace",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{
+				Spec: corev1alpha1.PackageSpec{Patches: []corev1alpha1.PackagePatch{test.patch}},
+			}}
+			err := patchObjects(apiPkg, newPatchTestObjects())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestValidatePatchedObjects(t *testing.T) {
+	t.Parallel()
+
+	manifest := &manifests.PackageManifest{
+		Spec: manifests.PackageManifestSpec{
+			Phases: []manifests.PackageManifestPhase{{Name: "deploy"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		spec corev1alpha1.PackageSpec
+		err  string
+	}{
+		{
+			name: "valid",
+			spec: corev1alpha1.PackageSpec{
+				CommonAnnotations: map[string]string{
+					manifests.PackageConditionMapAnnotation: "Available => my-prefix/Available",
+				},
+			},
+		},
+		{
+			name: "invalid condition map",
+			spec: corev1alpha1.PackageSpec{
+				CommonAnnotations: map[string]string{
+					manifests.PackageConditionMapAnnotation: "Available",
+				},
+			},
+			err: "The package-operator.run/condition-map annotation is invalid. in ConfigMap /config idx 0: " +
+				"expected 2 part mapping got 1 in line 1",
+		},
+		{
+			name: "phase annotation removed",
+			spec: corev1alpha1.PackageSpec{
+				Patches: []corev1alpha1.PackagePatch{{
+					Target: &corev1alpha1.PackagePatchTarget{Kind: "ConfigMap"},
+					Type:   corev1alpha1.PackagePatchTypeJSON6902,
+					Patch:  `[{"op": "remove", "path": "/metadata/annotations"}]`,
+				}},
+			},
+			err: "Missing package-operator.run/phase Annotation in ConfigMap /config idx 0",
+		},
+		{
+			name: "phase annotation changed",
+			spec: corev1alpha1.PackageSpec{
+				CommonAnnotations: map[string]string{
+					manifests.PackagePhaseAnnotation: "banana",
+				},
+			},
+			err: "Phase name not found in manifest in ConfigMap /config idx 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			apiPkg := &adapters.GenericPackage{Package: corev1alpha1.Package{Spec: test.spec}}
+			objects := []unstructured.Unstructured{{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name": "config",
+					"annotations": map[string]any{
+						manifests.PackagePhaseAnnotation: "deploy",
+					},
+				},
+			}}}
+			require.NoError(t, patchObjects(apiPkg, objects))
+
+			err := validatePatchedObjects(context.Background(), manifest, objects)
+			if len(test.err) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -64,6 +64,11 @@ func ToOCIFile(dst string, tags []string, pkg *packagetypes.RawPackage) error {
 		return err
 	}
 
+	return ImageToOCIFile(dst, tags, image)
+}
+
+// Exports the given package image to an OCI tar under the given name and tags.
+func ImageToOCIFile(dst string, tags []string, image containerregistrypkgv1.Image) error {
 	if err := os.MkdirAll(path.Dir(dst), 0o755); err != nil {
 		return fmt.Errorf("making directory tree: %w", err)
 	}
@@ -90,6 +95,15 @@ func ToPushedOCI(
 		return "", err
 	}
 
+	return ImageToPushedOCI(ctx, references, image, opts...)
+}
+
+// Exports the given package image by pushing it to an OCI registry.
+//
+// Returns the digest of the pushed package image.
+func ImageToPushedOCI(
+	ctx context.Context, references []string, image containerregistrypkgv1.Image, opts ...crane.Option,
+) (string, error) {
 	opts = append(opts, crane.WithContext(ctx))
 	verboseLogger := logr.FromContextOrDiscard(ctx).V(1)
 	for _, ref := range references {
@@ -2,12 +2,14 @@ package packageimport
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 
 	"github.com/google/go-containerregistry/pkg/crane"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/packages/internal/packageimport/kubekeychain"
 	"package-operator.run/internal/packages/internal/packagetypes"
 )
@@ -18,6 +20,33 @@ func FromRegistryInCluster(
 	ctx context.Context, uncachedClient client.Client, serviceAccount types.NamespacedName,
 	ref string, opts ...crane.Option,
 ) (*packagetypes.RawPackage, error) {
+	inClusterOpts, err := inClusterOptions(ctx, uncachedClient, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return FromRegistry(ctx, ref, append(opts, inClusterOpts...)...)
+}
+
+// Verifies the signature of a package image in a container image registry and returns the verified digest reference,
+// while supplying pull credentials which are dynamically discovered from the ServiceAccount PKO is running under.
+func VerifyInCluster(
+	ctx context.Context, uncachedClient client.Client, serviceAccount types.NamespacedName,
+	ref string, keys []crypto.PublicKey, opts ...crane.Option,
+) (string, error) {
+	inClusterOpts, err := inClusterOptions(ctx, uncachedClient, serviceAccount)
+	if err != nil {
+		return "", err
+	}
+	digest, err := imageverify.Verify(ctx, ref, keys, append(opts, inClusterOpts...)...)
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+func inClusterOptions(
+	ctx context.Context, uncachedClient client.Client, serviceAccount types.NamespacedName,
+) ([]crane.Option, error) {
 	chain, err := kubekeychain.FromServiceAccountPullSecrets(ctx, uncachedClient, serviceAccount)
 	if err != nil {
 		return nil, fmt.Errorf("creating keychain: %w", err)
@@ -26,8 +55,7 @@ func FromRegistryInCluster(
 	// pulls to *.svc.cluster.local HTTP registries (CI/kind). crane.Insecure sets
 	// name.Insecure so Scheme() is http (ping tries HTTPS first, then HTTP). The
 	// default transport also skips TLS certificate verification.
-	opts = append(opts, crane.WithAuthFromKeychain(chain), crane.Insecure)
-	return FromRegistry(ctx, ref, opts...)
+	return []crane.Option{crane.WithAuthFromKeychain(chain), crane.Insecure}, nil
 }
 
 // Imports a RawPackage from a container image registry.
@@ -2,25 +2,27 @@ package packageimport
 
 import (
 	"context"
-	"strings"
+	"crypto"
+	"fmt"
 	"sync"
 
 	"github.com/google/go-containerregistry/pkg/crane"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"package-operator.run/internal/imageprefix"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/packages/internal/packagetypes"
-	"package-operator.run/internal/utils"
 )
 
 // RequestManager de-duplicates multiple parallel container image pulls.
 // Has a (semi) in-cluster dependency because it uses `FromRegistryInCluster`.
 type RequestManager struct {
-	registryHostOverrides map[string]string
-	imagePrefixOverrides  []imageprefix.Override
+	imageMirrors         *imagemirror.Resolver
+	verificationPolicies *imageverify.PolicyResolver
 
 	pullImage    pullImageFn
+	verifyImage  verifyImageFn
 	inFlight     map[string][]chan<- response
 	inFlightLock sync.Mutex
 
@@ -39,45 +41,62 @@ type pullImageFn func(
 	ref string, opts ...crane.Option,
 ) (*packagetypes.RawPackage, error)
 
+type verifyImageFn func(
+	ctx context.Context, uncachedClient client.Client,
+	serviceAccount types.NamespacedName,
+	ref string, keys []crypto.PublicKey, opts ...crane.Option,
+) (string, error)
+
 // Creates a new request manager instance to de-duplicate parallel container image pulls.
+// Image mirrors and verification policies are resolved for every pull, so changes are picked up without restarts.
 func NewRequestManager(
-	registryHostOverrides map[string]string,
-	imagePrefixOverrides []imageprefix.Override,
+	imageMirrors *imagemirror.Resolver,
+	verificationPolicies *imageverify.PolicyResolver,
 	uncachedClient client.Client, serviceAccount types.NamespacedName,
 ) *RequestManager {
 	return &RequestManager{
-		registryHostOverrides: registryHostOverrides,
-		imagePrefixOverrides:  imagePrefixOverrides,
-		pullImage:             FromRegistryInCluster,
-		inFlight:              make(map[string][]chan<- response),
-		serviceAccount:        serviceAccount,
-		uncachedClient:        uncachedClient,
+		imageMirrors:         imageMirrors,
+		verificationPolicies: verificationPolicies,
+		pullImage:            FromRegistryInCluster,
+		verifyImage:          VerifyInCluster,
+		inFlight:             make(map[string][]chan<- response),
+		serviceAccount:       serviceAccount,
+		uncachedClient:       uncachedClient,
 	}
 }
 
+// Pull pulls the package image for a Package in the given namespace,
+// which is empty for ClusterPackages.
+// If verification policies apply to the namespace, the image is only pulled
+// by its digest after verifying its signature and errors wrap imageverify.ErrSignatureInvalid otherwise.
 func (r *RequestManager) Pull(
-	ctx context.Context, image string,
+	ctx context.Context, namespace, image string,
 ) (*packagetypes.RawPackage, error) {
-	image = imageprefix.Replace(image, r.imagePrefixOverrides)
-	image, err := r.applyOverride(image)
+	overrides, err := r.imageMirrors.Resolve(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("resolving image mirrors: %w", err)
+	}
+	image, err = overrides.PullImage(image)
 	if err != nil {
 		return nil, err
 	}
 
+	keys, err := r.verificationPolicies.Resolve(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("resolving image verification policies: %w", err)
+	}
+	if len(keys) > 0 {
+		image, err = r.verifyImage(ctx, r.uncachedClient, r.serviceAccount, image, keys)
+		if err != nil {
+			return nil, fmt.Errorf("verifying image signature: %w", err)
+		}
+	}
+
 	res := <-r.handleRequest(ctx, image)
 
 	return res.RawPackage, res.Err
 }
 
-func (r *RequestManager) applyOverride(image string) (string, error) {
-	for original, override := range r.registryHostOverrides {
-		if strings.HasPrefix(image, original) {
-			return utils.ImageURLWithOverride(image, override)
-		}
-	}
-	return image, nil
-}
-
 // handleRequest first checks if the provided image is already being pulled.
 // If it is not, a new go routine is started to pull the image and trigger
 // response handling. Then a new receiver is registered to listen for the response.
@@ -2,6 +2,12 @@ package packageimport
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"reflect"
 	"sync"
 	"testing"
@@ -10,10 +16,16 @@ import (
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	"package-operator.run/internal/imageprefix"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/imagemirror"
+	"package-operator.run/internal/imageverify"
 	"package-operator.run/internal/packages/internal/packagetypes"
 	"package-operator.run/internal/testutil"
 )
@@ -26,10 +38,11 @@ func TestRequestManager_DelayedPull(t *testing.T) {
 		Namespace: "package-operator-system",
 		Name:      "package-operator",
 	}
-	r := NewRequestManager(map[string]string{
-		"quay.io": "localhost:123",
-	},
-		[]imageprefix.Override{},
+	r := NewRequestManager(imagemirror.NewResolver(nil, imagemirror.Overrides{
+		RegistryHostOverrides: map[string]string{
+			"quay.io": "localhost:123",
+		},
+	}), nil,
 		uncachedClient, serviceAccount)
 	ipm := &imagePullerMock{}
 	r.pullImage = ipm.Pull
@@ -44,7 +57,7 @@ func TestRequestManager_DelayedPull(t *testing.T) {
 	var wg sync.WaitGroup
 	for range 5 {
 		wg.Go(func() {
-			ff, err := r.Pull(ctx, "quay.io/test123")
+			ff, err := r.Pull(ctx, "", "quay.io/test123")
 			if err != nil {
 				panic(err)
 			}
@@ -78,10 +91,11 @@ func TestRequestManager_DelayedRequests(t *testing.T) {
 		Namespace: "package-operator-system",
 		Name:      "package-operator",
 	}
-	r := NewRequestManager(map[string]string{
-		"quay.io": "localhost:123",
-	},
-		[]imageprefix.Override{},
+	r := NewRequestManager(imagemirror.NewResolver(nil, imagemirror.Overrides{
+		RegistryHostOverrides: map[string]string{
+			"quay.io": "localhost:123",
+		},
+	}), nil,
 		uncachedClient, serviceAccount)
 	r.pullImage = ipm.Pull
 
@@ -94,7 +108,7 @@ func TestRequestManager_DelayedRequests(t *testing.T) {
 	)
 	for range numRequests {
 		wg.Go(func() {
-			f, err := r.Pull(ctx, "quay.io/test123")
+			f, err := r.Pull(ctx, "", "quay.io/test123")
 			if err != nil {
 				panic(err)
 			}
@@ -129,6 +143,78 @@ func TestRequestManager_DelayedRequests(t *testing.T) {
 	}
 }
 
+func TestRequestManager_Verification(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "package-operator-system"},
+			Data: map[string][]byte{
+				imageverify.DefaultPublicKeySecretKey: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+			},
+		},
+		&corev1alpha1.ClusterImageVerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: corev1alpha1.ClusterImageVerificationPolicySpec{
+				PublicKeys: []corev1alpha1.PublicKeySecretReference{
+					{Name: "keys", Namespace: "package-operator-system"},
+				},
+			},
+		},
+	).Build()
+
+	const digestRef = "quay.io/test123@sha256:52a6b1268e32ed5b6f59da8222f7627979bfb739f32aae3fb5b5ed31b8bf80c4"
+	tests := []struct {
+		name      string
+		verifyErr error
+	}{
+		{name: "valid"},
+		{name: "invalid", verifyErr: imageverify.ErrSignatureInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewRequestManager(nil, imageverify.NewPolicyResolver(c), c, types.NamespacedName{})
+			ipm := &imagePullerMock{}
+			r.pullImage = ipm.Pull
+			ipm.
+				On("Pull", mock.Anything, mock.Anything, mock.Anything, digestRef).
+				Return(&packagetypes.RawPackage{}, nil)
+
+			var verifiedKeys []crypto.PublicKey
+			r.verifyImage = func(
+				_ context.Context, _ client.Client, _ types.NamespacedName,
+				ref string, keys []crypto.PublicKey, _ ...crane.Option,
+			) (string, error) {
+				assert.Equal(t, "quay.io/test123", ref)
+				verifiedKeys = keys
+				return digestRef, test.verifyErr
+			}
+
+			_, err := r.Pull(context.Background(), "", "quay.io/test123")
+			if assert.Len(t, verifiedKeys, 1) {
+				assert.True(t, key.PublicKey.Equal(verifiedKeys[0]))
+			}
+			if test.verifyErr != nil {
+				require.ErrorIs(t, err, imageverify.ErrSignatureInvalid)
+				ipm.AssertNotCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			// Verified images are pulled by digest.
+			ipm.AssertCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything, digestRef)
+		})
+	}
+}
+
 type imagePullerMock struct {
 	mock.Mock
 }
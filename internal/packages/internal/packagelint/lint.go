@@ -0,0 +1,196 @@
+package packagelint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagerender"
+	"package-operator.run/internal/packages/internal/packagetypes"
+	"package-operator.run/internal/packages/internal/packagevalidation"
+)
+
+// Rule checks the rendered output of a template test case for best practice violations.
+type Rule interface {
+	// Name used to enable or disable the rule in the PackageManifest.
+	Name() string
+	// Whether the rule runs without being explicitly enabled.
+	EnabledByDefault() bool
+	// Returns a ViolationError for every finding.
+	Lint(ctx context.Context, tc *TestCase) ([]packagetypes.ViolationError, error)
+}
+
+// TestCase holds a package rendered for a single template test case.
+type TestCase struct {
+	// Name of the template test case.
+	Name string
+	// Package with templates not yet rendered.
+	Package *packagetypes.Package
+	// Context the package was rendered with.
+	RenderContext packagetypes.PackageRenderContext
+	// Rendered objects by file path, including objects filtered by CEL conditions.
+	Objects map[string][]unstructured.Unstructured
+}
+
+// Calls fn for every rendered object, in order of path and index.
+func (tc *TestCase) EachObject(fn func(path string, index int, obj unstructured.Unstructured) error) error {
+	paths := make([]string, 0, len(tc.Objects))
+	for path := range tc.Objects {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		for i, obj := range tc.Objects[path] {
+			if err := fn(path, i, obj); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Name of the implicit test case used when a package does not define template test cases.
+const defaultTestCaseName = "defaults"
+
+// DefaultRules is the built-in lint rule set.
+var DefaultRules = []Rule{
+	&AvailabilityProbesRule{},
+	&DeclaredImagesRule{},
+	&ResourceRequestsRule{},
+	&PhaseAnnotationRule{},
+	&DeterministicTemplatesRule{},
+}
+
+// Linter runs lint rules against the rendered output of all template test cases of a package.
+// Rules can be enabled and disabled via .test.lint in the PackageManifest.
+type Linter struct {
+	rules []Rule
+}
+
+var _ packagetypes.PackageValidator = (*Linter)(nil)
+
+// Creates a new Linter running the given rules.
+// Uses DefaultRules if no rules are given.
+func NewLinter(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+	return &Linter{rules: rules}
+}
+
+func (l *Linter) ValidatePackage(ctx context.Context, pkg *packagetypes.Package) error {
+	return packagetypes.ValidateEachComponent(ctx, pkg, l.lintPackage)
+}
+
+func (l *Linter) lintPackage(ctx context.Context, pkg *packagetypes.Package, _ bool) error {
+	log := logr.FromContextOrDiscard(ctx).V(1)
+
+	rules, err := l.enabledRules(pkg.Manifest)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	testCases := pkg.Manifest.Test.Template
+	if len(testCases) == 0 {
+		testCases = []manifests.PackageManifestTestCaseTemplate{{Name: defaultTestCaseName}}
+	}
+
+	var (
+		violations []error
+		// the same finding is usually reported by every test case.
+		seen = map[string]struct{}{}
+	)
+	for _, testCase := range testCases {
+		log.Info("linting template test case", "name", testCase.Name)
+		tc, err := renderTestCase(ctx, pkg, testCase)
+		if err != nil {
+			return fmt.Errorf("rendering test case %q: %w", testCase.Name, err)
+		}
+
+		for _, rule := range rules {
+			vs, err := rule.Lint(ctx, tc)
+			if err != nil {
+				return fmt.Errorf("running lint rule %s: %w", rule.Name(), err)
+			}
+			for _, v := range vs {
+				v.Details = fmt.Sprintf("%s [%s]", v.Details, rule.Name())
+				if _, ok := seen[v.Error()]; ok {
+					continue
+				}
+				seen[v.Error()] = struct{}{}
+				violations = append(violations, v)
+			}
+		}
+	}
+	return errors.Join(violations...)
+}
+
+func (l *Linter) enabledRules(manifest *manifests.PackageManifest) ([]Rule, error) {
+	var enable, disable []string
+	if manifest.Test.Lint != nil {
+		enable = manifest.Test.Lint.Enable
+		disable = manifest.Test.Lint.Disable
+	}
+
+	known := map[string]struct{}{}
+	for _, rule := range l.rules {
+		known[rule.Name()] = struct{}{}
+	}
+	var violations []error
+	for _, name := range slices.Concat(enable, disable) {
+		if _, ok := known[name]; !ok {
+			violations = append(violations, packagetypes.ViolationError{
+				Reason:  packagetypes.ViolationReasonUnknownLintRule,
+				Details: fmt.Sprintf("%q in .test.lint", name),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, errors.Join(violations...)
+	}
+
+	var rules []Rule
+	for _, rule := range l.rules {
+		switch {
+		case slices.Contains(disable, rule.Name()):
+		case rule.EnabledByDefault(), slices.Contains(enable, rule.Name()):
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func renderTestCase(
+	ctx context.Context, pkg *packagetypes.Package,
+	testCase manifests.PackageManifestTestCaseTemplate,
+) (*TestCase, error) {
+	tmplCtx, err := packagevalidation.TestCaseRenderContext(ctx, pkg.Manifest, testCase)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := pkg.DeepCopy()
+	if err := packagerender.RenderTemplates(ctx, rendered, tmplCtx); err != nil {
+		return nil, err
+	}
+	objects, err := packagerender.RenderObjects(ctx, rendered, tmplCtx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestCase{
+		Name:          testCase.Name,
+		Package:       pkg,
+		RenderContext: tmplCtx,
+		Objects:       objects,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package packagelint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+const testDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        image: {{ index .images "manager" }}
+      - name: sidecar
+        image: quay.io/example/sidecar:v1
+`
+
+const testConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  annotations:
+    package-operator.run/phase: deploy
+`
+
+func testPackage() *packagetypes.Package {
+	return &packagetypes.Package{
+		Manifest: &manifests.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: manifests.PackageManifestSpec{
+				Phases: []manifests.PackageManifestPhase{{Name: "deploy"}},
+				Images: []manifests.PackageManifestImage{
+					{Name: "manager", Image: "quay.io/example/manager:v1"},
+				},
+			},
+		},
+		Files: packagetypes.Files{
+			"deployment.yaml.gotmpl": []byte(testDeployment),
+			"configmap.yaml":         []byte(testConfigMap),
+		},
+	}
+}
+
+func TestLinter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		lint *manifests.PackageManifestTestLint
+		err  string
+	}{
+		{
+			name: "defaults",
+			err: `No availability probe selects object in deployment.yaml idx 0: ` +
+				`Deployment.apps "test", add an entry to .spec.availabilityProbes [availability-probes]
+Image not declared in PackageManifest in deployment.yaml idx 0: ` +
+				`container "sidecar" uses image "quay.io/example/sidecar:v1", add it to .spec.images [declared-images]
+Missing package-operator.run/phase Annotation in deployment.yaml idx 0: Deployment.apps "test" [phase-annotation]`,
+		},
+		{
+			name: "enable and disable",
+			lint: &manifests.PackageManifestTestLint{
+				Enable:  []string{"resource-requests"},
+				Disable: []string{"availability-probes", "declared-images", "phase-annotation"},
+			},
+			err: `Missing resource requests in deployment.yaml idx 0: ` +
+				`container "manager" does not request cpu, memory [resource-requests]
+Missing resource requests in deployment.yaml idx 0: ` +
+				`container "sidecar" does not request cpu, memory [resource-requests]`,
+		},
+		{
+			name: "all disabled",
+			lint: &manifests.PackageManifestTestLint{
+				Disable: []string{
+					"availability-probes", "declared-images", "phase-annotation", "deterministic-templates",
+				},
+			},
+		},
+		{
+			name: "unknown rule",
+			lint: &manifests.PackageManifestTestLint{
+				Enable: []string{"banana"},
+			},
+			err: `Unknown lint rule: "banana" in .test.lint`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := testPackage()
+			pkg.Manifest.Test.Lint = test.lint
+
+			err := NewLinter().ValidatePackage(context.Background(), pkg)
+			if len(test.err) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestLinter_testCases(t *testing.T) {
+	t.Parallel()
+
+	pkg := testPackage()
+	pkg.Manifest.Test = manifests.PackageManifestTest{
+		Template: []manifests.PackageManifestTestCaseTemplate{
+			{Name: "t1"}, {Name: "t2"},
+		},
+		Lint: &manifests.PackageManifestTestLint{
+			Disable: []string{"availability-probes", "phase-annotation"},
+		},
+	}
+
+	// Findings of multiple test cases are only reported once.
+	err := NewLinter().ValidatePackage(context.Background(), pkg)
+	require.EqualError(t, err, `Image not declared in PackageManifest in deployment.yaml idx 0: `+
+		`container "sidecar" uses image "quay.io/example/sidecar:v1", add it to .spec.images [declared-images]`)
+}
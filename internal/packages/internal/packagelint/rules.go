@@ -0,0 +1,256 @@
+package packagelint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagekickstart/presets"
+	"package-operator.run/internal/packages/internal/packagerender"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+// Reports objects that are not selected by any availability probe of the PackageManifest.
+// Kinds that are known to not need probing, like ConfigMaps and Secrets, are ignored.
+type AvailabilityProbesRule struct{}
+
+func (r *AvailabilityProbesRule) Name() string           { return "availability-probes" }
+func (r *AvailabilityProbesRule) EnabledByDefault() bool { return true }
+
+func (r *AvailabilityProbesRule) Lint(
+	_ context.Context, tc *TestCase,
+) (violations []packagetypes.ViolationError, err error) {
+	probes := tc.Package.Manifest.Spec.AvailabilityProbes
+	err = tc.EachObject(func(path string, index int, obj unstructured.Unstructured) error {
+		gk := obj.GroupVersionKind().GroupKind()
+		if presets.NoProbe(gk) {
+			return nil
+		}
+
+		for _, probe := range probes {
+			kind := probe.Selector.Kind
+			if kind == nil || kind.Group != gk.Group || kind.Kind != gk.Kind {
+				continue
+			}
+			if probe.Selector.Selector == nil {
+				return nil
+			}
+			selector, err := metav1.LabelSelectorAsSelector(probe.Selector.Selector)
+			if err != nil {
+				return fmt.Errorf("parsing availability probe selector: %w", err)
+			}
+			if selector.Matches(labels.Set(obj.GetLabels())) {
+				return nil
+			}
+		}
+
+		violations = append(violations, packagetypes.ViolationError{
+			Reason:  packagetypes.ViolationReasonMissingAvailabilityProbe,
+			Details: fmt.Sprintf("%s %q, add an entry to .spec.availabilityProbes", gk, obj.GetName()),
+			Path:    path,
+			Index:   new(index),
+		})
+		return nil
+	})
+	return violations, err
+}
+
+// Reports containers using images that are not declared in .spec.images of the PackageManifest.
+// Declared images can be overridden and mirrored, hardcoded images can not.
+type DeclaredImagesRule struct{}
+
+func (r *DeclaredImagesRule) Name() string           { return "declared-images" }
+func (r *DeclaredImagesRule) EnabledByDefault() bool { return true }
+
+func (r *DeclaredImagesRule) Lint(
+	_ context.Context, tc *TestCase,
+) (violations []packagetypes.ViolationError, err error) {
+	declared := map[string]struct{}{}
+	for _, image := range tc.RenderContext.Images {
+		declared[image] = struct{}{}
+	}
+	for _, image := range tc.Package.Manifest.Spec.Images {
+		declared[image.Image] = struct{}{}
+	}
+
+	err = tc.EachObject(func(path string, index int, obj unstructured.Unstructured) error {
+		containers, err := podContainers(obj)
+		if err != nil {
+			return err
+		}
+		for _, c := range containers {
+			if _, ok := declared[c.Image]; ok {
+				continue
+			}
+			violations = append(violations, packagetypes.ViolationError{
+				Reason:  packagetypes.ViolationReasonUndeclaredImage,
+				Details: fmt.Sprintf("container %q uses image %q, add it to .spec.images", c.Name, c.Image),
+				Path:    path,
+				Index:   new(index),
+			})
+		}
+		return nil
+	})
+	return violations, err
+}
+
+// Resources every container should request.
+var requiredResourceRequests = []string{"cpu", "memory"}
+
+// Reports containers without cpu and memory requests.
+// Disabled by default.
+type ResourceRequestsRule struct{}
+
+func (r *ResourceRequestsRule) Name() string           { return "resource-requests" }
+func (r *ResourceRequestsRule) EnabledByDefault() bool { return false }
+
+func (r *ResourceRequestsRule) Lint(
+	_ context.Context, tc *TestCase,
+) (violations []packagetypes.ViolationError, err error) {
+	err = tc.EachObject(func(path string, index int, obj unstructured.Unstructured) error {
+		containers, err := podContainers(obj)
+		if err != nil {
+			return err
+		}
+		for _, c := range containers {
+			var missing []string
+			for _, resource := range requiredResourceRequests {
+				if _, ok := c.Requests[resource]; !ok {
+					missing = append(missing, resource)
+				}
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			violations = append(violations, packagetypes.ViolationError{
+				Reason:  packagetypes.ViolationReasonMissingResourceRequests,
+				Details: fmt.Sprintf("container %q does not request %s", c.Name, strings.Join(missing, ", ")),
+				Path:    path,
+				Index:   new(index),
+			})
+		}
+		return nil
+	})
+	return violations, err
+}
+
+// Reports objects without a phase annotation.
+// Unlike ObjectPhaseAnnotationValidator, this also covers objects that are filtered by CEL conditions.
+type PhaseAnnotationRule struct{}
+
+func (r *PhaseAnnotationRule) Name() string           { return "phase-annotation" }
+func (r *PhaseAnnotationRule) EnabledByDefault() bool { return true }
+
+func (r *PhaseAnnotationRule) Lint(
+	_ context.Context, tc *TestCase,
+) (violations []packagetypes.ViolationError, err error) {
+	err = tc.EachObject(func(path string, index int, obj unstructured.Unstructured) error {
+		if len(obj.GetAnnotations()[manifests.PackagePhaseAnnotation]) > 0 {
+			return nil
+		}
+		violations = append(violations, packagetypes.ViolationError{
+			Reason:  packagetypes.ViolationReasonMissingPhaseAnnotation,
+			Details: fmt.Sprintf("%s %q", obj.GroupVersionKind().GroupKind(), obj.GetName()),
+			Path:    path,
+			Index:   new(index),
+		})
+		return nil
+	})
+	return violations, err
+}
+
+// Renders templates a second time and reports files with differing output,
+// e.g. caused by iterating maps within go-template functions or generating random values.
+// Non-deterministic templates cause endless rollouts, as every reconciliation produces a new revision.
+type DeterministicTemplatesRule struct{}
+
+func (r *DeterministicTemplatesRule) Name() string           { return "deterministic-templates" }
+func (r *DeterministicTemplatesRule) EnabledByDefault() bool { return true }
+
+func (r *DeterministicTemplatesRule) Lint(
+	ctx context.Context, tc *TestCase,
+) (violations []packagetypes.ViolationError, err error) {
+	first, second := tc.Package.DeepCopy(), tc.Package.DeepCopy()
+	if err := packagerender.RenderTemplates(ctx, first, tc.RenderContext); err != nil {
+		return nil, err
+	}
+	if err := packagerender.RenderTemplates(ctx, second, tc.RenderContext); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for path := range tc.Package.Files {
+		if packagetypes.IsTemplateFile(path) {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		renderedPath := packagetypes.StripTemplateSuffix(path)
+		if bytes.Equal(first.Files[renderedPath], second.Files[renderedPath]) {
+			continue
+		}
+		violations = append(violations, packagetypes.ViolationError{
+			Reason:  packagetypes.ViolationReasonNonDeterministicTemplate,
+			Details: fmt.Sprintf("Testcase %q rendered different output on repeated runs", tc.Name),
+			Path:    path,
+		})
+	}
+	return violations, nil
+}
+
+// Location of the PodSpec within well known workload kinds.
+var podSpecFields = map[schema.GroupKind][]string{
+	{Kind: "Pod"}:                        {"spec"},
+	{Kind: "ReplicationController"}:      {"spec", "template", "spec"},
+	{Group: "apps", Kind: "Deployment"}:  {"spec", "template", "spec"},
+	{Group: "apps", Kind: "StatefulSet"}: {"spec", "template", "spec"},
+	{Group: "apps", Kind: "DaemonSet"}:   {"spec", "template", "spec"},
+	{Group: "apps", Kind: "ReplicaSet"}:  {"spec", "template", "spec"},
+	{Group: "batch", Kind: "Job"}:        {"spec", "template", "spec"},
+	{Group: "batch", Kind: "CronJob"}:    {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+type container struct {
+	Name     string
+	Image    string
+	Requests map[string]any
+}
+
+// Returns all init and regular containers of workload objects.
+func podContainers(obj unstructured.Unstructured) ([]container, error) {
+	podSpec, ok := podSpecFields[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return nil, nil
+	}
+
+	var containers []container
+	for _, field := range []string{"initContainers", "containers"} {
+		list, _, err := unstructured.NestedSlice(obj.Object, slices.Concat(podSpec, []string{field})...)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s of %s %q: %w", field, obj.GetKind(), obj.GetName(), err)
+		}
+		for _, item := range list {
+			c, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(c, "name")
+			image, _, _ := unstructured.NestedString(c, "image")
+			requests, _, _ := unstructured.NestedMap(c, "resources", "requests")
+			containers = append(containers, container{
+				Name: name, Image: image, Requests: requests,
+			})
+		}
+	}
+	return containers, nil
+}
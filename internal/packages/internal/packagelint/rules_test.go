@@ -0,0 +1,131 @@
+package packagelint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+func TestAvailabilityProbesRule(t *testing.T) {
+	t.Parallel()
+
+	probed := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":   "probed",
+			"labels": map[string]any{"app": "probed"},
+		},
+	}}
+	unprobed := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "unprobed"},
+	}}
+	secret := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "secret"},
+	}}
+
+	tc := &TestCase{
+		Package: &packagetypes.Package{
+			Manifest: &manifests.PackageManifest{
+				Spec: manifests.PackageManifestSpec{
+					AvailabilityProbes: []corev1alpha1.ObjectSetProbe{{
+						Selector: corev1alpha1.ProbeSelector{
+							Kind: &corev1alpha1.PackageProbeKindSpec{Group: "apps", Kind: "Deployment"},
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "probed"},
+							},
+						},
+					}},
+				},
+			},
+		},
+		Objects: map[string][]unstructured.Unstructured{
+			"deploy.yaml": {probed, unprobed, secret},
+		},
+	}
+
+	v, err := (&AvailabilityProbesRule{}).Lint(context.Background(), tc)
+	require.NoError(t, err)
+	if assert.Len(t, v, 1) {
+		assert.Equal(t, packagetypes.ViolationReasonMissingAvailabilityProbe, v[0].Reason)
+		assert.Equal(t, 1, *v[0].Index)
+	}
+}
+
+func TestDeterministicTemplatesRule(t *testing.T) {
+	t.Parallel()
+
+	tc := &TestCase{
+		Name: "test",
+		Package: &packagetypes.Package{
+			Manifest: &manifests.PackageManifest{},
+			Files: packagetypes.Files{
+				"test.yaml.gotmpl": []byte(`{{ range $k, $v := .config }}{{ $k }}: {{ $v }}{{ end }}`),
+			},
+		},
+		RenderContext: packagetypes.PackageRenderContext{
+			Config: map[string]any{"a": "1", "b": "2", "c": "3"},
+		},
+	}
+
+	v, err := (&DeterministicTemplatesRule{}).Lint(context.Background(), tc)
+	require.NoError(t, err)
+	assert.Empty(t, v)
+}
+
+func Test_podContainers(t *testing.T) {
+	t.Parallel()
+
+	cronJob := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{
+							"initContainers": []any{
+								map[string]any{"name": "init", "image": "init:v1"},
+							},
+							"containers": []any{
+								map[string]any{
+									"name":  "job",
+									"image": "job:v1",
+									"resources": map[string]any{
+										"requests": map[string]any{"cpu": "10m"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	containers, err := podContainers(cronJob)
+	require.NoError(t, err)
+	assert.Equal(t, []container{
+		{Name: "init", Image: "init:v1"},
+		{Name: "job", Image: "job:v1", Requests: map[string]any{"cpu": "10m"}},
+	}, containers)
+
+	containers, err = podContainers(unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+	}})
+	require.NoError(t, err)
+	assert.Empty(t, containers)
+}
@@ -0,0 +1,252 @@
+package packagerender
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"sigs.k8s.io/yaml"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+// Runs the Jsonnet engine on all .jsonnet files.
+// Top-level keys of the template context are available as external variables,
+// e.g. std.extVar('config'), and libraries are imported from the package files.
+func renderJsonnet(files packagetypes.Files, tctx map[string]any) error {
+	vm, errs := newJsonnetVM(&filesImporter{files: files})
+	for key, value := range tctx {
+		code, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		vm.ExtCode(key, string(code))
+	}
+
+	paths, err := jsonnetPaths(files)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		out, err := vm.EvaluateFile(path)
+		if err != nil {
+			return jsonnetViolation(files, path, errs.err, err)
+		}
+		documents, err := jsonnetDocuments(out)
+		if err != nil {
+			return packagetypes.ViolationError{
+				Reason:  packagetypes.ViolationReasonJsonnetEvaluation,
+				Details: err.Error(),
+				Path:    path,
+			}
+		}
+
+		// save back to file map as YAML without the Jsonnet suffix
+		files[packagetypes.JsonnetOutputPath(path)] = documents
+	}
+	return nil
+}
+
+// Returns the sorted paths of all .jsonnet files.
+// Outputs must neither replace existing files, e.g. a plain YAML file of the same name,
+// nor the output of another Jsonnet file, as one of them would be silently dropped.
+func jsonnetPaths(files packagetypes.Files) ([]string, error) {
+	var paths []string
+	for path := range files {
+		if packagetypes.IsJsonnetFile(path) {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	outputs := map[string]string{}
+	for _, path := range paths {
+		outPath := packagetypes.JsonnetOutputPath(path)
+		if _, ok := files[outPath]; ok {
+			return nil, packagetypes.ViolationError{
+				Reason:  packagetypes.ViolationReasonJsonnetOutputConflict,
+				Details: fmt.Sprintf("renders into existing file %s", outPath),
+				Path:    path,
+			}
+		}
+		if other, ok := outputs[outPath]; ok {
+			return nil, packagetypes.ViolationError{
+				Reason:  packagetypes.ViolationReasonJsonnetOutputConflict,
+				Details: fmt.Sprintf("renders into %s, like %s", outPath, other),
+				Path:    path,
+			}
+		}
+		outputs[outPath] = path
+	}
+	return paths, nil
+}
+
+// Evaluates a Jsonnet file from disk into YAML documents.
+// Imports are resolved relative to the importing file.
+// External variables are not set, as there is no package context.
+func EvaluateJsonnetFile(filePath string) ([]byte, error) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{})
+
+	out, err := vm.EvaluateFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return jsonnetDocuments(out)
+}
+
+func newJsonnetVM(importer jsonnet.Importer) (*jsonnet.VM, *jsonnetErrorRecorder) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(importer)
+	errs := &jsonnetErrorRecorder{}
+	vm.ErrorFormatter = errs
+	return vm, errs
+}
+
+// Converts the JSON output of a Jsonnet program into YAML documents.
+// Supports a single object, a list of objects
+// or an object mapping names to objects, as commonly produced by Jsonnet libraries.
+func jsonnetDocuments(out string) ([]byte, error) {
+	var result any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		return nil, err
+	}
+
+	var objects []any
+	switch r := result.(type) {
+	case []any:
+		objects = r
+	case map[string]any:
+		if _, ok := r["kind"]; ok || len(r) == 0 {
+			objects = []any{r}
+			break
+		}
+		keys := make([]string, 0, len(r))
+		for k := range r {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			objects = append(objects, r[k])
+		}
+	default:
+		return nil, fmt.Errorf("%w: got %T", errJsonnetOutputNotObject, result)
+	}
+
+	documents := make([][]byte, 0, len(objects))
+	for _, obj := range objects {
+		if _, ok := obj.(map[string]any); !ok {
+			return nil, fmt.Errorf("%w: got %T", errJsonnetOutputNotObject, obj)
+		}
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, bytes.TrimSpace(doc))
+	}
+	return packagetypes.JoinYAMLDocuments(documents), nil
+}
+
+// Matches the location prefix of Jsonnet errors, e.g.:
+// lib.libsonnet:2:6-7 Unexpected: "," while parsing terminal.
+var jsonnetLocationRegEx = regexp.MustCompile(`(?s)^([^:\s]+):(\d+):(\d+)(?:-\d+)? (.*)$`)
+
+var errJsonnetOutputNotObject = errors.New("jsonnet must evaluate to an object, a list of objects or a map of objects")
+
+// Converts a failed Jsonnet evaluation into a ViolationError
+// pointing at the file, line and column the error originates from.
+func jsonnetViolation(files packagetypes.Files, path string, evalErr, formattedErr error) error {
+	v := packagetypes.ViolationError{
+		Reason:  packagetypes.ViolationReasonJsonnetEvaluation,
+		Details: formattedErr.Error(),
+		Path:    path,
+	}
+	if evalErr == nil {
+		return v
+	}
+
+	var (
+		file         string
+		line, column int
+	)
+	switch err := evalErr.(type) {
+	case jsonnet.RuntimeError:
+		v.Details = err.Msg
+		// The innermost frame with a location is where the error was raised.
+		for _, frame := range slices.Backward(err.StackTrace) {
+			if frame.Loc.IsSet() {
+				file, line, column = frame.Loc.FileName, frame.Loc.Begin.Line, frame.Loc.Begin.Column
+				break
+			}
+		}
+	case interface{ Loc() ast.LocationRange }:
+		loc := err.Loc()
+		v.Details = evalErr.Error()
+		file, line, column = loc.FileName, loc.Begin.Line, loc.Begin.Column
+	default:
+		return v
+	}
+	// Static errors carry their location as message prefix,
+	// which is also the only location of static errors in imported files.
+	if m := jsonnetLocationRegEx.FindStringSubmatch(v.Details); m != nil {
+		file, v.Details = m[1], m[4]
+		line, _ = strconv.Atoi(m[2])
+		column, _ = strconv.Atoi(m[3])
+	}
+
+	// Errors may originate from imported libraries.
+	if _, ok := files[file]; ok {
+		v.Path = file
+	}
+	v.Line, v.Column = line, column
+	v.Subject = sourceExcerpt(files[v.Path], v.Line)
+	return v
+}
+
+// Records the structured error of the last evaluation,
+// as the Jsonnet VM only returns formatted messages.
+type jsonnetErrorRecorder struct {
+	err error
+}
+
+func (r *jsonnetErrorRecorder) Format(err error) string {
+	r.err = err
+	return err.Error()
+}
+
+func (r *jsonnetErrorRecorder) SetMaxStackTraceSize(int) {}
+
+func (r *jsonnetErrorRecorder) SetColorFormatter(jsonnet.ColorFormatter) {}
+
+// Resolves Jsonnet imports from package files, relative to the importing file.
+type filesImporter struct {
+	files    packagetypes.Files
+	contents map[string]jsonnet.Contents
+}
+
+func (i *filesImporter) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	foundAt := path.Clean(path.Join(path.Dir(importedFrom), importedPath))
+	if c, ok := i.contents[foundAt]; ok {
+		return c, foundAt, nil
+	}
+
+	content, ok := i.files[foundAt]
+	if !ok {
+		return jsonnet.Contents{}, "", fmt.Errorf("%w: %s", os.ErrNotExist, filepath.FromSlash(foundAt))
+	}
+	if i.contents == nil {
+		i.contents = map[string]jsonnet.Contents{}
+	}
+	c := jsonnet.MakeContentsRaw(content)
+	i.contents[foundAt] = c
+	return c, foundAt, nil
+}
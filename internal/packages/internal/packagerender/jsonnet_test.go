@@ -0,0 +1,167 @@
+package packagerender
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+func TestRenderTemplates_Jsonnet(t *testing.T) {
+	t.Parallel()
+
+	tmplCtx := packagetypes.PackageRenderContext{
+		Package: manifests.TemplateContextPackage{
+			TemplateContextObjectMeta: manifests.TemplateContextObjectMeta{Name: "test"},
+		},
+		Config: map[string]any{"replicas": 3},
+		Images: map[string]string{"app": "quay.io/example/app:v1"},
+	}
+
+	pkg := &packagetypes.Package{
+		Manifest: &manifests.PackageManifest{},
+		Files: packagetypes.Files{
+			"lib/names.libsonnet": []byte(`{ name(pkg):: pkg.metadata.name + '-app' }`),
+			"app/deployment.jsonnet": []byte(`local names = import '../lib/names.libsonnet';
+local pkg = std.extVar('package');
+{
+  apiVersion: 'apps/v1',
+  kind: 'Deployment',
+  metadata: { name: names.name(pkg) },
+  spec: {
+    replicas: std.extVar('config').replicas,
+    template: { spec: { containers: [{ name: 'app', image: std.extVar('images').app }] } },
+  },
+}
+`),
+			"configmaps.yaml.jsonnet": []byte(`[
+  { apiVersion: 'v1', kind: 'ConfigMap', metadata: { name: 'a' } },
+  { apiVersion: 'v1', kind: 'ConfigMap', metadata: { name: 'b' } },
+]`),
+		},
+	}
+
+	ctx := context.Background()
+	require.NoError(t, RenderTemplates(ctx, pkg, tmplCtx))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-app
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - image: quay.io/example/app:v1
+        name: app
+`, string(pkg.Files["app/deployment.yaml"]))
+	assert.NotContains(t, pkg.Files, "lib/names.yaml")
+
+	objects, err := RenderObjects(ctx, pkg, tmplCtx, nil)
+	require.NoError(t, err)
+	require.Len(t, objects["configmaps.yaml"], 2)
+	assert.Equal(t, "b", objects["configmaps.yaml"][1].GetName())
+}
+
+func TestRenderTemplates_JsonnetError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files packagetypes.Files
+		err   string
+	}{
+		{
+			name: "runtime",
+			files: packagetypes.Files{
+				"test.jsonnet": []byte("{\n  a: error 'banana',\n}\n"),
+			},
+			err: "Jsonnet could not be evaluated in test.jsonnet:2:6: banana\n" +
+				"1 | {\n2 >   a: error 'banana',\n3 | }",
+		},
+		{
+			name: "static in library",
+			files: packagetypes.Files{
+				"test.jsonnet":  []byte("import 'lib.libsonnet'\n"),
+				"lib.libsonnet": []byte("{\n  a: ,\n}\n"),
+			},
+			err: "Jsonnet could not be evaluated in lib.libsonnet:2:6: " +
+				"Unexpected: \",\" while parsing terminal\n" +
+				"1 | {\n2 >   a: ,\n3 | }",
+		},
+		{
+			name: "runtime in library",
+			files: packagetypes.Files{
+				"test.jsonnet":      []byte("local lib = import 'lib/lib.libsonnet';\nlib.f(1)\n"),
+				"lib/lib.libsonnet": []byte("{\n  f(x):: error 'in lib',\n}\n"),
+			},
+			err: "Jsonnet could not be evaluated in lib/lib.libsonnet:2:10: in lib\n" +
+				"1 | {\n2 >   f(x):: error 'in lib',\n3 | }",
+		},
+		{
+			name: "not an object",
+			files: packagetypes.Files{
+				"test.jsonnet": []byte("42"),
+			},
+			err: "Jsonnet could not be evaluated in test.jsonnet: " +
+				"jsonnet must evaluate to an object, a list of objects or a map of objects: got float64",
+		},
+		{
+			name: "overwrites YAML file",
+			files: packagetypes.Files{
+				"test.jsonnet": []byte("{}"),
+				"test.yaml":    []byte("apiVersion: v1\nkind: ConfigMap\n"),
+			},
+			err: "Jsonnet output conflicts with another file in test.jsonnet: renders into existing file test.yaml",
+		},
+		{
+			name: "overwrites other Jsonnet output",
+			files: packagetypes.Files{
+				"test.jsonnet":      []byte("{}"),
+				"test.yaml.jsonnet": []byte("{}"),
+			},
+			err: "Jsonnet output conflicts with another file in test.yaml.jsonnet: renders into test.yaml, like test.jsonnet",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := &packagetypes.Package{
+				Manifest: &manifests.PackageManifest{},
+				Files:    test.files,
+			}
+			err := RenderTemplates(context.Background(), pkg, packagetypes.PackageRenderContext{})
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestEvaluateJsonnetFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.libsonnet"), []byte(
+		`{ cm(name):: { apiVersion: 'v1', kind: 'ConfigMap', metadata: { name: name } } }`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.jsonnet"), []byte(
+		`local lib = import 'lib.libsonnet'; { b: lib.cm('b'), a: lib.cm('a') }`), 0o600))
+
+	out, err := EvaluateJsonnetFile(filepath.Join(dir, "main.jsonnet"))
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`, string(out))
+}
@@ -39,7 +39,7 @@ func RenderObjects(
 		default:
 			objects, err := parseObjects(pkg.Manifest, tmplCtx, path, content)
 			if err != nil {
-				return nil, err
+				return nil, mapYAMLViolation(pkg.Files, err)
 			}
 			if len(objects) != 0 {
 				pathObject[path] = objects
@@ -17,7 +17,7 @@ import (
 
 var errConstructingCelContext = errors.New("constructing CEL context")
 
-// Runs a go-template transformer on all .gotmpl files.
+// Runs a go-template transformer on all .gotmpl files and the Jsonnet engine on all .jsonnet files.
 func RenderTemplates(_ context.Context, pkg *packagetypes.Package, tmplCtx packagetypes.PackageRenderContext) error {
 	tctx, err := templateContext(tmplCtx)
 	if err != nil {
@@ -43,7 +43,7 @@ func RenderTemplates(_ context.Context, pkg *packagetypes.Package, tmplCtx packa
 
 		_, err := templ.New(path).Parse(string(content))
 		if err != nil {
-			return fmt.Errorf("parsing template from %s: %w", path, err)
+			return templateViolation(packagetypes.ViolationReasonTemplateParse, pkg.Files, path, err)
 		}
 	}
 
@@ -55,14 +55,15 @@ func RenderTemplates(_ context.Context, pkg *packagetypes.Package, tmplCtx packa
 
 		var buf bytes.Buffer
 		if err := templ.ExecuteTemplate(&buf, path, tctx); err != nil {
-			return fmt.Errorf("executing template from %s with context %+v: %w", path, tctx, err)
+			return templateViolation(packagetypes.ViolationReasonTemplateExecution, pkg.Files, path, err)
 		}
 
 		// save back to file map without the template suffix
 		pkg.Files[packagetypes.StripTemplateSuffix(path)] = buf.Bytes()
 	}
 
-	return nil
+	// Jsonnet files are evaluated after go templates, so they may be templated themselves.
+	return renderJsonnet(pkg.Files, tctx)
 }
 
 func templateContext(tmplCtx packagetypes.PackageRenderContext) (map[string]any, error) {
@@ -0,0 +1,165 @@
+package packagerender
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+// Number of lines shown before and after the offending line in source excerpts.
+const excerptContextLines = 2
+
+var (
+	// Matches errors of text/template, e.g.:
+	// template: test.yaml.gotmpl:3:12: executing "test.yaml.gotmpl" at <.config.banana>: map has no entry for key "banana".
+	templateErrorRegEx = regexp.MustCompile(`(?s)^template: ([^:]+):(\d+)(?::(\d+))?: (.*)$`)
+	// Matches the redundant prefix of execution errors.
+	templateExecPrefixRegEx = regexp.MustCompile(`^executing "[^"]*" `)
+	// Matches the line of YAML parsing errors, e.g.:
+	// error converting YAML to JSON: yaml: line 3: mapping values are not allowed in this context.
+	yamlErrorLineRegEx = regexp.MustCompile(`yaml: line (\d+):`)
+	// Matches go template actions.
+	templateActionRegEx = regexp.MustCompile(`\{\{.*?\}\}`)
+)
+
+// Converts an error returned by text/template into a ViolationError
+// pointing at the template file, line and column the error originates from.
+func templateViolation(
+	reason packagetypes.ViolationReason, files packagetypes.Files, path string, err error,
+) error {
+	v := packagetypes.ViolationError{
+		Reason:  reason,
+		Details: err.Error(),
+		Path:    path,
+	}
+
+	m := templateErrorRegEx.FindStringSubmatch(err.Error())
+	if m == nil {
+		return v
+	}
+	// Errors may originate from other template files, e.g. helpers.
+	if _, ok := files[m[1]]; ok {
+		v.Path = m[1]
+	}
+	v.Line, _ = strconv.Atoi(m[2])
+	if len(m[3]) > 0 {
+		v.Column, _ = strconv.Atoi(m[3])
+	}
+	v.Details = templateExecPrefixRegEx.ReplaceAllString(m[4], "")
+	v.Subject = sourceExcerpt(files[v.Path], v.Line)
+	return v
+}
+
+// Maps an invalid YAML error of a rendered file back to the line of the template it was rendered from.
+// The template line is found on a best effort basis, by matching the offending rendered line
+// against the literal text of template lines.
+func mapYAMLViolation(files packagetypes.Files, err error) error {
+	var v packagetypes.ViolationError
+	if !errors.As(err, &v) || v.Reason != packagetypes.ViolationReasonInvalidYAML || v.Index == nil {
+		return err
+	}
+
+	m := yamlErrorLineRegEx.FindStringSubmatch(v.Details)
+	if m == nil {
+		return err
+	}
+	docLine, _ := strconv.Atoi(m[1])
+	docStart, ok := packagetypes.YAMLDocumentLine(files[v.Path], *v.Index)
+	if !ok {
+		return err
+	}
+	line := docStart + docLine - 1
+
+	template, ok := files[v.Path+packagetypes.TemplateFileSuffix]
+	if !ok {
+		v.Line = line
+		return v
+	}
+
+	v.Line = templateSourceLine(template, files[v.Path], line)
+	v.Path += packagetypes.TemplateFileSuffix
+	if v.Line > 0 {
+		v.Subject = sourceExcerpt(template, v.Line)
+	}
+	return v
+}
+
+// Returns the line of the template most likely producing the given line of rendered output or 0.
+func templateSourceLine(template, rendered []byte, line int) int {
+	renderedLines := strings.Split(string(rendered), "\n")
+	if line < 1 || line > len(renderedLines) {
+		return 0
+	}
+	target := strings.TrimSpace(renderedLines[line-1])
+
+	var best int
+	for i, tmplLine := range strings.Split(string(template), "\n") {
+		pattern, ok := templateLinePattern(strings.TrimSpace(tmplLine))
+		if !ok || !pattern.MatchString(target) {
+			continue
+		}
+		// Prefer matches closest to the rendered line.
+		if best == 0 || abs(i+1-line) < abs(best-line) {
+			best = i + 1
+		}
+	}
+	return best
+}
+
+// Builds a pattern matching the rendered output of a single template line.
+// Returns false for lines without literal text, as they would match any output.
+func templateLinePattern(tmplLine string) (*regexp.Regexp, bool) {
+	if strings.Count(tmplLine, "{{") != strings.Count(tmplLine, "}}") {
+		// Action spanning multiple lines.
+		return nil, false
+	}
+
+	literals := templateActionRegEx.Split(tmplLine, -1)
+	var hasText bool
+	for i := range literals {
+		if len(strings.TrimSpace(literals[i])) > 0 {
+			hasText = true
+		}
+		literals[i] = regexp.QuoteMeta(literals[i])
+	}
+	if !hasText {
+		return nil, false
+	}
+	pattern, err := regexp.Compile(`^\s*` + strings.Join(literals, `.*`) + `\s*$`)
+	if err != nil {
+		return nil, false
+	}
+	return pattern, true
+}
+
+// Returns a few numbered lines of content around line, highlighting line itself.
+func sourceExcerpt(content []byte, line int) string {
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	first := max(line-excerptContextLines, 1)
+	last := min(line+excerptContextLines, len(lines))
+	width := len(strconv.Itoa(last))
+
+	var b strings.Builder
+	for i := first; i <= last; i++ {
+		marker := "|"
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%-*d %s %s\n", width, i, marker, lines[i-1])
+	}
+	return b.String()
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,127 @@
+package packagerender
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+func TestRenderTemplates_errorPosition(t *testing.T) {
+	t.Parallel()
+
+	tmplCtx := packagetypes.PackageRenderContext{
+		Package: manifests.TemplateContextPackage{
+			TemplateContextObjectMeta: manifests.TemplateContextObjectMeta{Name: "test"},
+		},
+		Config: map[string]any{"replicas": 1},
+	}
+
+	tests := []struct {
+		name  string
+		files packagetypes.Files
+		err   string
+	}{
+		{
+			name: "parse",
+			files: packagetypes.Files{
+				"test.yaml.gotmpl": []byte("a: 1\nb: {{ .config.replicas }\nc: 3\n"),
+			},
+			err: `Template could not be parsed in test.yaml.gotmpl:2: unexpected "}" in operand
+1 | a: 1
+2 > b: {{ .config.replicas }
+3 | c: 3`,
+		},
+		{
+			name: "execution",
+			files: packagetypes.Files{
+				"test.yaml.gotmpl": []byte("a: 1\nb: {{ .config.banana }}\n"),
+			},
+			err: `Template could not be executed in test.yaml.gotmpl:2:13: ` +
+				`at <.config.banana>: map has no entry for key "banana"
+1 | a: 1
+2 > b: {{ .config.banana }}`,
+		},
+		{
+			name: "execution in helper",
+			files: packagetypes.Files{
+				"_helpers.gotmpl":  []byte(`{{ define "b" }}{{ .config.banana }}{{ end }}`),
+				"test.yaml.gotmpl": []byte(`b: {{ template "b" . }}`),
+			},
+			err: `Template could not be executed in _helpers.gotmpl:1:26: ` +
+				`at <.config.banana>: map has no entry for key "banana"
+1 > {{ define "b" }}{{ .config.banana }}{{ end }}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := &packagetypes.Package{
+				Files:    test.files,
+				Manifest: &manifests.PackageManifest{},
+			}
+			err := RenderTemplates(context.Background(), pkg, tmplCtx)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestRenderObjects_templateYAMLErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	tmplCtx := packagetypes.PackageRenderContext{
+		Package: manifests.TemplateContextPackage{
+			TemplateContextObjectMeta: manifests.TemplateContextObjectMeta{Name: "test"},
+		},
+		Config: map[string]any{"name": "@test"},
+	}
+
+	pkg := &packagetypes.Package{
+		Files: packagetypes.Files{
+			"test.yaml.gotmpl": []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+{{- if true }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .config.name }}
+{{- end }}
+`),
+		},
+		Manifest: &manifests.PackageManifest{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+	}
+
+	ctx := context.Background()
+	require.NoError(t, RenderTemplates(ctx, pkg, tmplCtx))
+	_, err := RenderObjects(ctx, pkg, tmplCtx, nil)
+
+	var v packagetypes.ViolationError
+	require.True(t, errors.As(err, &v))
+	assert.Equal(t, packagetypes.ViolationReasonInvalidYAML, v.Reason)
+	assert.Equal(t, "test.yaml.gotmpl", v.Path)
+	assert.Equal(t, 10, v.Line)
+	assert.Equal(t, 1, *v.Index)
+	assert.Contains(t, v.Subject, "10 >   name: {{ .config.name }}")
+}
+
+func Test_templateSourceLine(t *testing.T) {
+	t.Parallel()
+
+	template := []byte("a: {{ .a }}\n{{- range .items }}\n- item: {{ . }}\n{{- end }}\nb: c\n")
+	rendered := []byte("a: 1\n- item: x\n- item: y\nb: c\n")
+
+	assert.Equal(t, 1, templateSourceLine(template, rendered, 1))
+	assert.Equal(t, 3, templateSourceLine(template, rendered, 3))
+	assert.Equal(t, 5, templateSourceLine(template, rendered, 4))
+	assert.Equal(t, 0, templateSourceLine(template, rendered, 10))
+}
@@ -0,0 +1,139 @@
+package packagesbom
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Subset of the CycloneDX 1.5 JSON schema.
+// https://cyclonedx.org/docs/1.5/json/
+type cdxDocument struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components,omitempty"`
+	Dependencies []cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Hashes     []cdxHash     `json:"hashes,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+const (
+	cdxPackageRef = "package"
+	// Property marking components as Package Operator packages.
+	cdxKindProperty = "package-operator.run/kind"
+)
+
+func encodeCycloneDX(bom *BillOfMaterials, created time.Time) ([]byte, error) {
+	doc := cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{{
+				Type: "application",
+				Name: toolName,
+			}}},
+			Component: cdxComponent{
+				Type:   "application",
+				BOMRef: cdxPackageRef,
+				Name:   bom.Name,
+			},
+		},
+	}
+
+	dependsOn := []string{}
+	for _, img := range bom.Images {
+		ref := "image:" + img.Name
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:   "container",
+			BOMRef: ref,
+			Name:   img.Name,
+			Hashes: cdxHashes(img.Digest),
+			PURL:   purl(img.Image, img.Digest),
+		})
+		dependsOn = append(dependsOn, ref)
+	}
+
+	for _, dep := range bom.Dependencies {
+		ref := "dependency:" + dep.Name
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:    "container",
+			BOMRef:  ref,
+			Name:    dep.Name,
+			Version: dep.Version,
+			Hashes:  cdxHashes(dep.Digest),
+			PURL:    purl(dep.Image, dep.Digest),
+			Properties: []cdxProperty{{
+				Name: cdxKindProperty, Value: "Package",
+			}},
+		})
+		dependsOn = append(dependsOn, ref)
+	}
+
+	for _, f := range bom.Files {
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:   "file",
+			BOMRef: "file:" + f.Path,
+			Name:   f.Path,
+			Hashes: cdxHashes(f.Digest),
+		})
+	}
+
+	doc.Dependencies = []cdxDependency{{
+		Ref:       cdxPackageRef,
+		DependsOn: dependsOn,
+	}}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func cdxHashes(digest string) []cdxHash {
+	algorithm, value, ok := strings.Cut(digest, ":")
+	if !ok {
+		return nil
+	}
+
+	// CycloneDX spells algorithms like SHA-256.
+	alg := strings.ToUpper(algorithm)
+	if i := strings.IndexAny(alg, "0123456789"); i > 0 {
+		alg = alg[:i] + "-" + alg[i:]
+	}
+
+	return []cdxHash{{Alg: alg, Content: value}}
+}
@@ -0,0 +1,266 @@
+// Package packagesbom generates software bills of materials (SBOM) for package images
+// and attaches them to the image as an additional layer.
+package packagesbom
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+const (
+	// SPDX 2.3 JSON documents.
+	FormatSPDX = "spdx"
+	// CycloneDX 1.5 JSON documents.
+	FormatCycloneDX = "cyclonedx"
+
+	// Folder in the package image holding the SBOM.
+	// Lies outside of the package folder, so it is not imported as part of the package.
+	OCIPath = "sbom"
+
+	// Creator of SBOM documents.
+	toolName = "kubectl-package"
+)
+
+// Formats lists all supported SBOM formats.
+var Formats = []string{FormatSPDX, FormatCycloneDX}
+
+var (
+	// ErrUnknownFormat is returned for SBOM formats other than the ones listed in Formats.
+	ErrUnknownFormat = errors.New("unknown SBOM format")
+	// ErrNoSBOM is returned when a package image has no SBOM attached.
+	ErrNoSBOM = errors.New("package image has no SBOM attached")
+)
+
+// Filenames of the SBOM document in the OCIPath folder by format.
+var filenames = map[string]string{
+	FormatSPDX:      "package.spdx.json",
+	FormatCycloneDX: "package.cdx.json",
+}
+
+// BillOfMaterials lists everything that went into a package image.
+type BillOfMaterials struct {
+	// Name of the package.
+	Name string
+	// Container images locked in the manifest locks of the package and its components.
+	Images []Image
+	// Package images of locked dependencies.
+	Dependencies []Dependency
+	// Digests of all files in the package.
+	Files []File
+}
+
+// Image is a container image used by the package.
+type Image struct {
+	Name   string
+	Image  string
+	Digest string
+}
+
+// Dependency is a package the package depends on.
+type Dependency struct {
+	Name    string
+	Image   string
+	Digest  string
+	Version string
+}
+
+// File is a file contained in the package.
+type File struct {
+	Path string
+	// sha256 digest of the file content.
+	Digest string
+}
+
+// SBOM is a bill of materials document attached to a package image.
+type SBOM struct {
+	// Format of the document, one of Formats.
+	Format string
+	// Encoded document.
+	Document []byte
+}
+
+// NewBillOfMaterials collects the images, dependencies and files of the given package.
+// rawPkg must be the RawPackage pkg was loaded from.
+func NewBillOfMaterials(pkg *packagetypes.Package, rawPkg *packagetypes.RawPackage) *BillOfMaterials {
+	bom := &BillOfMaterials{
+		Name: pkg.Manifest.Name,
+	}
+
+	seenImages := map[Image]bool{}
+	seenDeps := map[Dependency]bool{}
+	for _, p := range append([]packagetypes.Package{*pkg}, pkg.Components...) {
+		if p.ManifestLock == nil {
+			continue
+		}
+		for _, img := range p.ManifestLock.Spec.Images {
+			i := Image{Name: img.Name, Image: img.Image, Digest: img.Digest}
+			if !seenImages[i] {
+				seenImages[i] = true
+				bom.Images = append(bom.Images, i)
+			}
+		}
+		for _, dep := range p.ManifestLock.Spec.Dependencies {
+			d := Dependency{Name: dep.Name, Image: dep.Image, Digest: dep.Digest, Version: dep.Version}
+			if !seenDeps[d] {
+				seenDeps[d] = true
+				bom.Dependencies = append(bom.Dependencies, d)
+			}
+		}
+	}
+
+	for filePath, data := range rawPkg.Files {
+		sum := sha256.Sum256(data)
+		bom.Files = append(bom.Files, File{
+			Path:   filePath,
+			Digest: "sha256:" + hex.EncodeToString(sum[:]),
+		})
+	}
+	slices.SortFunc(bom.Files, func(a, b File) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return bom
+}
+
+// Encode encodes the bill of materials as document of the given format.
+func Encode(bom *BillOfMaterials, format string, created time.Time) (*SBOM, error) {
+	var (
+		doc []byte
+		err error
+	)
+	switch format {
+	case FormatSPDX:
+		doc, err = encodeSPDX(bom, created)
+	case FormatCycloneDX:
+		doc, err = encodeCycloneDX(bom, created)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s document: %w", format, err)
+	}
+
+	return &SBOM{Format: format, Document: doc}, nil
+}
+
+// Attach adds the SBOM to the package image as additional layer.
+func Attach(image containerregistrypkgv1.Image, sbom *SBOM) (containerregistrypkgv1.Image, error) {
+	filename, ok := filenames[sbom.Format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, sbom.Format)
+	}
+
+	layer, err := crane.Layer(map[string][]byte{
+		path.Join(OCIPath, filename): sbom.Document,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	image, err = mutate.AppendLayers(image, layer)
+	if err != nil {
+		return nil, fmt.Errorf("appending SBOM layer: %w", err)
+	}
+
+	// Drop the timestamps of the appended layer, to keep the image reproducible.
+	image, err = mutate.Canonical(image)
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// FromOCI reads the SBOM attached to the given package image.
+// Returns ErrNoSBOM if the image has no SBOM attached.
+func FromOCI(image containerregistrypkgv1.Image) (*SBOM, error) {
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("read image layers: %w", err)
+	}
+
+	// The SBOM is attached last, so start searching from the top most layer.
+	for _, layer := range slices.Backward(layers) {
+		sbom, found, err := sbomFromLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return sbom, nil
+		}
+	}
+
+	return nil, ErrNoSBOM
+}
+
+func sbomFromLayer(layer containerregistrypkgv1.Layer) (sbom *SBOM, found bool, err error) {
+	reader, err := layer.Uncompressed()
+	if err != nil {
+		return nil, false, fmt.Errorf("read layer contents: %w", err)
+	}
+	defer func() {
+		if cErr := reader.Close(); err == nil && cErr != nil {
+			err = cErr
+		}
+	}()
+
+	tarReader := tar.NewReader(reader)
+	for {
+		hdr, nextErr := tarReader.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil, false, nil
+		}
+		if nextErr != nil {
+			return nil, false, fmt.Errorf("read file header from layer: %w", nextErr)
+		}
+
+		for format, filename := range filenames {
+			if path.Clean(hdr.Name) != path.Join(OCIPath, filename) {
+				continue
+			}
+			doc, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, false, fmt.Errorf("read SBOM from layer: %w", err)
+			}
+			return &SBOM{Format: format, Document: doc}, true, nil
+		}
+	}
+}
+
+// Returns a single digest over the paths and digests of all files.
+func filesDigest(files []File) string {
+	h := sha256.New()
+	for _, f := range files {
+		_, _ = io.WriteString(h, f.Path+"\x00"+f.Digest+"\x00")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Returns the package URL of the image or an empty string if the image can't be parsed.
+// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#oci
+func purl(image, digest string) string {
+	ref, err := name.ParseReference(image)
+	if err != nil || digest == "" {
+		return ""
+	}
+	repo := ref.Context()
+
+	return "pkg:oci/" + path.Base(repo.RepositoryStr()) +
+		"@" + strings.Replace(digest, ":", "%3A", 1) +
+		"?repository_url=" + repo.Name()
+}
@@ -0,0 +1,173 @@
+package packagesbom
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packageexport"
+	"package-operator.run/internal/packages/internal/packageimport"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+const (
+	testDigest    = "sha256:00e48c32b3cdcf9e2c66467f2beb0ef33b43b54e2b56415db4ee431512c406ea"
+	testDepDigest = "sha256:11e48c32b3cdcf9e2c66467f2beb0ef33b43b54e2b56415db4ee431512c406ea"
+)
+
+var testCreated = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func testPackage() (*packagetypes.Package, *packagetypes.RawPackage) {
+	lock := &manifests.PackageManifestLock{
+		Spec: manifests.PackageManifestLockSpec{
+			Images: []manifests.PackageManifestLockImage{
+				{Name: "nginx", Image: "quay.io/example/nginx:1.25", Digest: testDigest},
+			},
+			Dependencies: []manifests.PackageManifestLockDependency{
+				{Name: "dep", Image: "quay.io/example/dep-pkg", Digest: testDepDigest, Version: "v1.2.3"},
+			},
+		},
+	}
+	pkg := &packagetypes.Package{
+		Manifest: &manifests.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		},
+		ManifestLock: lock,
+		Components: []packagetypes.Package{
+			{
+				Manifest: &manifests.PackageManifest{
+					ObjectMeta: metav1.ObjectMeta{Name: "component"},
+				},
+				// Same image locked again must not be listed twice.
+				ManifestLock: lock.DeepCopy(),
+			},
+			{
+				Manifest: &manifests.PackageManifest{
+					ObjectMeta: metav1.ObjectMeta{Name: "no-lock"},
+				},
+			},
+		},
+	}
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{
+			"manifest.yaml":        []byte("test"),
+			"deployment.yaml":      []byte("test2"),
+			"components/a/x.yaml":  []byte("test3"),
+			"manifest.lock.yaml":   []byte("test4"),
+			"subdir/configmap.yml": []byte("test5"),
+		},
+	}
+	return pkg, rawPkg
+}
+
+func TestNewBillOfMaterials(t *testing.T) {
+	t.Parallel()
+
+	bom := NewBillOfMaterials(testPackage())
+
+	assert.Equal(t, "test", bom.Name)
+	assert.Equal(t, []Image{
+		{Name: "nginx", Image: "quay.io/example/nginx:1.25", Digest: testDigest},
+	}, bom.Images)
+	assert.Equal(t, []Dependency{
+		{Name: "dep", Image: "quay.io/example/dep-pkg", Digest: testDepDigest, Version: "v1.2.3"},
+	}, bom.Dependencies)
+	if assert.Len(t, bom.Files, 5) {
+		assert.Equal(t, "components/a/x.yaml", bom.Files[0].Path)
+		// sha256 of "test"
+		assert.Equal(t, File{
+			Path:   "manifest.yaml",
+			Digest: "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		}, bom.Files[3])
+	}
+}
+
+func TestEncode_SPDX(t *testing.T) {
+	t.Parallel()
+
+	sbom, err := Encode(NewBillOfMaterials(testPackage()), FormatSPDX, testCreated)
+	require.NoError(t, err)
+	assert.Equal(t, FormatSPDX, sbom.Format)
+
+	var doc spdxDocument
+	require.NoError(t, json.Unmarshal(sbom.Document, &doc))
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "2026-01-02T03:04:05Z", doc.CreationInfo.Created)
+	// package, image and dependency
+	if assert.Len(t, doc.Packages, 3) {
+		img := doc.Packages[1]
+		assert.Equal(t, "nginx", img.Name)
+		assert.Equal(t, []spdxChecksum{{
+			Algorithm:     "SHA256",
+			ChecksumValue: "00e48c32b3cdcf9e2c66467f2beb0ef33b43b54e2b56415db4ee431512c406ea",
+		}}, img.Checksums)
+		assert.Equal(t,
+			"pkg:oci/nginx@sha256%3A00e48c32b3cdcf9e2c66467f2beb0ef33b43b54e2b56415db4ee431512c406ea"+
+				"?repository_url=quay.io/example/nginx",
+			img.ExternalRefs[0].ReferenceLocator)
+		assert.Equal(t, "v1.2.3", doc.Packages[2].VersionInfo)
+	}
+	assert.Len(t, doc.Files, 5)
+	// describes + 2 depends on + 5 contains
+	assert.Len(t, doc.Relationships, 8)
+}
+
+func TestEncode_CycloneDX(t *testing.T) {
+	t.Parallel()
+
+	sbom, err := Encode(NewBillOfMaterials(testPackage()), FormatCycloneDX, testCreated)
+	require.NoError(t, err)
+	assert.Equal(t, FormatCycloneDX, sbom.Format)
+
+	var doc cdxDocument
+	require.NoError(t, json.Unmarshal(sbom.Document, &doc))
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "test", doc.Metadata.Component.Name)
+	// image, dependency and 5 files
+	if assert.Len(t, doc.Components, 7) {
+		assert.Equal(t, []cdxHash{{
+			Alg:     "SHA-256",
+			Content: "00e48c32b3cdcf9e2c66467f2beb0ef33b43b54e2b56415db4ee431512c406ea",
+		}}, doc.Components[0].Hashes)
+	}
+	assert.Equal(t, []cdxDependency{{
+		Ref: "package", DependsOn: []string{"image:nginx", "dependency:dep"},
+	}}, doc.Dependencies)
+}
+
+func TestEncode_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := Encode(NewBillOfMaterials(testPackage()), "banana", testCreated)
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestAttach(t *testing.T) {
+	t.Parallel()
+
+	_, rawPkg := testPackage()
+	image, err := packageexport.ToOCI(rawPkg)
+	require.NoError(t, err)
+
+	_, err = FromOCI(image)
+	require.ErrorIs(t, err, ErrNoSBOM)
+
+	sbom := &SBOM{Format: FormatCycloneDX, Document: []byte("{}")}
+	image, err = Attach(image, sbom)
+	require.NoError(t, err)
+
+	read, err := FromOCI(image)
+	require.NoError(t, err)
+	assert.Equal(t, sbom, read)
+
+	// The SBOM must not end up in the imported package.
+	imported, err := packageimport.FromOCI(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, rawPkg.Files, imported.Files)
+}
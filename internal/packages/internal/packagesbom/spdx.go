@@ -0,0 +1,171 @@
+package packagesbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Subset of the SPDX 2.3 JSON schema.
+// https://spdx.github.io/spdx-spec/v2.3/
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+}
+
+type spdxFile struct {
+	SPDXID    string         `json:"SPDXID"`
+	FileName  string         `json:"fileName"`
+	Checksums []spdxChecksum `json:"checksums"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const (
+	spdxNoAssertion = "NOASSERTION"
+	spdxPackageID   = "SPDXRef-Package"
+)
+
+func encodeSPDX(bom *BillOfMaterials, created time.Time) ([]byte, error) {
+	doc := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        bom.Name,
+		// Must be unique per document, the digest of all files identifies the package content.
+		DocumentNamespace: "https://package-operator.run/spdx/" + bom.Name + "-" + filesDigest(bom.Files),
+		CreationInfo: spdxCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []spdxPackage{{
+			SPDXID:                spdxPackageID,
+			Name:                  bom.Name,
+			DownloadLocation:      spdxNoAssertion,
+			FilesAnalyzed:         true,
+			PrimaryPackagePurpose: "APPLICATION",
+		}},
+		Relationships: []spdxRelationship{{
+			SPDXElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: spdxPackageID,
+		}},
+	}
+
+	for i, img := range bom.Images {
+		id := fmt.Sprintf("SPDXRef-Image-%d", i)
+		doc.Packages = append(doc.Packages, spdxPackage{
+			SPDXID:                id,
+			Name:                  img.Name,
+			DownloadLocation:      spdxNoAssertion,
+			Checksums:             spdxChecksums(img.Digest),
+			ExternalRefs:          spdxPURLRefs(img.Image, img.Digest),
+			PrimaryPackagePurpose: "CONTAINER",
+		})
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      spdxPackageID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+
+	for i, dep := range bom.Dependencies {
+		id := fmt.Sprintf("SPDXRef-Dependency-%d", i)
+		doc.Packages = append(doc.Packages, spdxPackage{
+			SPDXID:                id,
+			Name:                  dep.Name,
+			VersionInfo:           dep.Version,
+			DownloadLocation:      spdxNoAssertion,
+			Checksums:             spdxChecksums(dep.Digest),
+			ExternalRefs:          spdxPURLRefs(dep.Image, dep.Digest),
+			PrimaryPackagePurpose: "CONTAINER",
+			Comment:               "Package Operator package",
+		})
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      spdxPackageID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+
+	for i, f := range bom.Files {
+		id := fmt.Sprintf("SPDXRef-File-%d", i)
+		doc.Files = append(doc.Files, spdxFile{
+			SPDXID:    id,
+			FileName:  "./" + f.Path,
+			Checksums: spdxChecksums(f.Digest),
+		})
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      spdxPackageID,
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: id,
+		})
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func spdxChecksums(digest string) []spdxChecksum {
+	algorithm, value, ok := strings.Cut(digest, ":")
+	if !ok {
+		return nil
+	}
+
+	return []spdxChecksum{{
+		Algorithm:     strings.ToUpper(algorithm),
+		ChecksumValue: value,
+	}}
+}
+
+func spdxPURLRefs(image, digest string) []spdxExternalRef {
+	p := purl(image, digest)
+	if p == "" {
+		return nil
+	}
+
+	return []spdxExternalRef{{
+		ReferenceCategory: "PACKAGE-MANAGER",
+		ReferenceType:     "purl",
+		ReferenceLocator:  p,
+	}}
+}
@@ -7,16 +7,33 @@ import (
 	"strings"
 )
 
-// templateFilenameSuffix is the files suffix for all go template files that need pre-processing.
+// TemplateFileSuffix is the files suffix for all go template files that need pre-processing.
 // .gotmpl is the suffix that is being used by the go language server gopls.
 // https://go-review.googlesource.com/c/tools/+/363360/7/gopls/doc/features.md#29
-const templateFilenameSuffix = ".gotmpl"
+const TemplateFileSuffix = ".gotmpl"
 
 // Is path suffixed by [TemplateFileSuffix].
-func IsTemplateFile(path string) bool { return strings.HasSuffix(path, templateFilenameSuffix) }
+func IsTemplateFile(path string) bool { return strings.HasSuffix(path, TemplateFileSuffix) }
 
 // StripTemplateSuffix removes a [TemplateFileSuffix] suffix from a string if present.
-func StripTemplateSuffix(path string) string { return strings.TrimSuffix(path, templateFilenameSuffix) }
+func StripTemplateSuffix(path string) string { return strings.TrimSuffix(path, TemplateFileSuffix) }
+
+// JsonnetFileSuffix is the file suffix of Jsonnet programs rendered into YAML files.
+// Jsonnet libraries, which are only imported, use the .libsonnet suffix instead.
+const JsonnetFileSuffix = ".jsonnet"
+
+// Is path suffixed by [JsonnetFileSuffix].
+func IsJsonnetFile(path string) bool { return strings.HasSuffix(path, JsonnetFileSuffix) }
+
+// JsonnetOutputPath returns the path of the YAML file a Jsonnet program at path is rendered into.
+// e.g. deployment.jsonnet and deployment.yaml.jsonnet both render into deployment.yaml.
+func JsonnetOutputPath(path string) string {
+	path = strings.TrimSuffix(path, JsonnetFileSuffix)
+	if IsYAMLFile(path) {
+		return path
+	}
+	return path + ".yaml"
+}
 
 // IsYAMLFile return true if the given fileName is suffixed by .yml or .yaml.
 func IsYAMLFile(fileName string) bool {
@@ -38,6 +55,43 @@ func SplitYAMLDocuments(file []byte) (docs [][]byte) {
 	return docs
 }
 
+// Returns the 1-based line number the YAML document at index starts at,
+// counting documents the same way as SplitYAMLDocuments.
+func YAMLDocumentLine(file []byte, index int) (line int, ok bool) {
+	trimmed := bytes.TrimLeft(file, "-\n")
+	line = 1 + bytes.Count(file[:len(file)-len(trimmed)], []byte("\n"))
+
+	var start int
+	if index > 0 {
+		separators := splitYAMLDocumentsRegEx.FindAllIndex(trimmed, -1)
+		if index > len(separators) {
+			return 0, false
+		}
+		start = separators[index-1][1]
+	}
+
+	// skip whitespace in front of the document, like SplitYAMLDocuments does.
+	doc := trimmed[start:]
+	leading := doc[:len(doc)-len(bytes.TrimLeft(doc, " \t\r\n"))]
+	return line + bytes.Count(trimmed[:start], []byte("\n")) + bytes.Count(leading, []byte("\n")), true
+}
+
+// Locates the source file and line of the YAML document at index within the file at path.
+// Documents rendered from templates are attributed to the template file, without line information.
+func SourceLocation(files Files, path string, index *int) (sourcePath string, line int) {
+	content, ok := files[path]
+	if !ok {
+		if _, ok := files[path+TemplateFileSuffix]; ok {
+			return path + TemplateFileSuffix, 0
+		}
+		return path, 0
+	}
+	if index != nil {
+		line, _ = YAMLDocumentLine(content, *index)
+	}
+	return path, line
+}
+
 // Joins multiple YAML documents together.
 func JoinYAMLDocuments(documents [][]byte) []byte {
 	return append(bytes.Join(documents, []byte("\n---\n")), []byte("\n")...)
@@ -54,3 +54,56 @@ func TestIsTemplateFile(t *testing.T) {
 		})
 	}
 }
+
+func TestYAMLDocumentLine(t *testing.T) {
+	t.Parallel()
+
+	file := []byte("---\na: 1\n---\n\nb: 2\nc: 3\n---\nd: 4\n")
+	docs := SplitYAMLDocuments(file)
+	assert.Len(t, docs, 3)
+
+	tests := []struct {
+		index int
+		line  int
+		ok    bool
+	}{
+		{index: 0, line: 2, ok: true},
+		{index: 1, line: 5, ok: true},
+		{index: 2, line: 8, ok: true},
+		{index: 3, ok: false},
+	}
+	for _, test := range tests {
+		line, ok := YAMLDocumentLine(file, test.index)
+		assert.Equal(t, test.ok, ok, "index %d", test.index)
+		assert.Equal(t, test.line, line, "index %d", test.index)
+	}
+}
+
+func TestSourceLocation(t *testing.T) {
+	t.Parallel()
+
+	files := Files{
+		"a.yaml":        []byte("a: 1\n---\nb: 2\n"),
+		"b.yaml.gotmpl": []byte("b: {{.config.b}}\n"),
+	}
+
+	path, line := SourceLocation(files, "a.yaml", new(1))
+	assert.Equal(t, "a.yaml", path)
+	assert.Equal(t, 3, line)
+
+	path, line = SourceLocation(files, "b.yaml", new(0))
+	assert.Equal(t, "b.yaml.gotmpl", path)
+	assert.Equal(t, 0, line)
+
+	path, line = SourceLocation(files, "c.yaml", nil)
+	assert.Equal(t, "c.yaml", path)
+	assert.Equal(t, 0, line)
+}
+
+func TestJsonnetOutputPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "deploy/app.yaml", JsonnetOutputPath("deploy/app.jsonnet"))
+	assert.Equal(t, "deploy/app.yml", JsonnetOutputPath("deploy/app.yml.jsonnet"))
+	assert.False(t, IsJsonnetFile("lib/app.libsonnet"))
+}
@@ -21,13 +21,28 @@ func ValidateEachComponent(
 	for _, component := range pkg.Components {
 		componentName := component.Manifest.Name
 		if err := validateFn(ctx, &component, true); err != nil {
-			return fmt.Errorf("component \"%s\": %w", componentName, err)
+			return &ComponentError{Component: componentName, Err: err}
 		}
 	}
 
 	return validateFn(ctx, pkg, false)
 }
 
+// ComponentError wraps errors returned while validating a component of a multi-component package.
+// Paths of wrapped ViolationErrors are relative to the component folder.
+type ComponentError struct {
+	Component string
+	Err       error
+}
+
+func (e *ComponentError) Error() string {
+	return fmt.Sprintf("component \"%s\": %s", e.Component, e.Err)
+}
+
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
 // ObjectValidator knows how to validate objects within a Package.
 type ObjectValidator interface {
 	ValidateObjects(
@@ -14,6 +14,8 @@ type ViolationError struct {
 	Path      string          // Path shows which file path in the package is responsible for this error.
 	Component string          // Component indicates which component the error is associated with
 	Index     *int            // Index is the index of the YAML document within Path.
+	Line      int             // Line within Path the violation was found at, 0 if unknown.
+	Column    int             // Column within Line the violation was found at, 0 if unknown.
 	Subject   string          // Complete subject producing the error, may be the whole yaml file, a single document, etc.
 }
 
@@ -29,6 +31,12 @@ func (v ViolationError) Error() string {
 	// Attach path to message if set.
 	if v.Path != "" {
 		msg += " in " + v.Path
+		if v.Line > 0 {
+			msg += fmt.Sprintf(":%d", v.Line)
+			if v.Column > 0 {
+				msg += fmt.Sprintf(":%d", v.Column)
+			}
+		}
 		if v.Index != nil {
 			msg += fmt.Sprintf(" idx %d", *v.Index)
 		}
@@ -81,8 +89,70 @@ const (
 	ViolationReasonImageMissingInLockfile        ViolationReason = "Image specified in manifest but missing from lockfile. Try running: kubectl package update"                      //nolint: lll
 	ViolationReasonImageDifferentToLockfile      ViolationReason = "Image specified in manifest does not match with lockfile. Try running: kubectl package update"                   //nolint: lll
 	ViolationReasonInvalidCELExpression          ViolationReason = "The CEL expression in " + manifests.PackageCELConditionAnnotation + " annotation is invalid."                    //nolint: lll
+	ViolationReasonInvalidConditionMap           ViolationReason = "The " + manifests.PackageConditionMapAnnotation + " annotation is invalid."
+	ViolationReasonAPIRemoved                    ViolationReason = "API removed in target Kubernetes version"
+	ViolationReasonAPIDeprecated                 ViolationReason = "API deprecated in target Kubernetes version"
+	ViolationReasonUnknownLintRule               ViolationReason = "Unknown lint rule"
+	ViolationReasonMissingAvailabilityProbe      ViolationReason = "No availability probe selects object"
+	ViolationReasonUndeclaredImage               ViolationReason = "Image not declared in PackageManifest"
+	ViolationReasonMissingResourceRequests       ViolationReason = "Missing resource requests"
+	ViolationReasonNonDeterministicTemplate      ViolationReason = "Template output is not deterministic"
+	ViolationReasonTemplateParse                 ViolationReason = "Template could not be parsed"
+	ViolationReasonTemplateExecution             ViolationReason = "Template could not be executed"
+	ViolationReasonJsonnetEvaluation             ViolationReason = "Jsonnet could not be evaluated"
+	ViolationReasonJsonnetOutputConflict         ViolationReason = "Jsonnet output conflicts with another file"
 )
 
+// Stable identifiers of ViolationReasons, used by machine readable reports.
+var violationReasonIDs = map[ViolationReason]string{
+	ViolationReasonEmptyPackage:                  "empty-package",
+	ViolationReasonPackageManifestNotFound:       "package-manifest-not-found",
+	ViolationReasonUnknownGVK:                    "unknown-gvk",
+	ViolationReasonPackageManifestInvalid:        "package-manifest-invalid",
+	ViolationReasonPackageManifestDuplicated:     "package-manifest-duplicated",
+	ViolationReasonPackageManifestLockInvalid:    "package-manifest-lock-invalid",
+	ViolationReasonPackageManifestLockDuplicated: "package-manifest-lock-duplicated",
+	ViolationReasonInvalidYAML:                   "invalid-yaml",
+	ViolationReasonMissingPhaseAnnotation:        "missing-phase-annotation",
+	ViolationReasonPhaseNotFound:                 "phase-not-found",
+	ViolationReasonMissingGVK:                    "missing-gvk",
+	ViolationReasonDuplicateObject:               "duplicate-object",
+	ViolationReasonLabelsInvalid:                 "labels-invalid",
+	ViolationReasonUnsupportedScope:              "unsupported-scope",
+	ViolationReasonFixtureMismatch:               "fixture-mismatch",
+	ViolationReasonComponentsNotEnabled:          "components-not-enabled",
+	ViolationReasonComponentNotFound:             "component-not-found",
+	ViolationReasonInvalidComponentPath:          "invalid-component-path",
+	ViolationReasonUnknown:                       "unknown",
+	ViolationReasonNestedMultiComponentPkg:       "nested-multi-component-package",
+	ViolationReasonInvalidFileInComponentsDir:    "invalid-file-in-components-dir",
+	ViolationReasonKubeconform:                   "kubeconform",
+	ViolationReasonLockfileMissing:               "lockfile-missing",
+	ViolationReasonImageMissingInLockfile:        "image-missing-in-lockfile",
+	ViolationReasonImageDifferentToLockfile:      "image-different-to-lockfile",
+	ViolationReasonInvalidCELExpression:          "invalid-cel-expression",
+	ViolationReasonInvalidConditionMap:           "invalid-condition-map",
+	ViolationReasonAPIRemoved:                    "api-removed",
+	ViolationReasonAPIDeprecated:                 "api-deprecated",
+	ViolationReasonUnknownLintRule:               "unknown-lint-rule",
+	ViolationReasonMissingAvailabilityProbe:      "missing-availability-probe",
+	ViolationReasonUndeclaredImage:               "undeclared-image",
+	ViolationReasonMissingResourceRequests:       "missing-resource-requests",
+	ViolationReasonNonDeterministicTemplate:      "non-deterministic-template",
+	ViolationReasonTemplateParse:                 "template-parse",
+	ViolationReasonTemplateExecution:             "template-execution",
+	ViolationReasonJsonnetEvaluation:             "jsonnet-evaluation",
+	ViolationReasonJsonnetOutputConflict:         "jsonnet-output-conflict",
+}
+
+// ID returns a stable machine readable identifier of the reason, e.g. "missing-phase-annotation".
+func (r ViolationReason) ID() string {
+	if id, ok := violationReasonIDs[r]; ok {
+		return id
+	}
+	return violationReasonIDs[ViolationReasonUnknown]
+}
+
 var ErrEmptyPackage = ViolationError{
 	Reason: ViolationReasonEmptyPackage,
 }
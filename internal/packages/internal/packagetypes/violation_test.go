@@ -34,6 +34,16 @@ func TestViolationErrorPath(t *testing.T) {
 	require.EqualError(t, v, "cheese reason in a/b idx 4")
 }
 
+func TestViolationErrorPathLine(t *testing.T) {
+	t.Parallel()
+
+	v := ViolationError{Reason: ViolationReason("cheese reason"), Path: "a/b", Line: 3, Column: 7, Index: new(4)}
+	require.EqualError(t, v, "cheese reason in a/b:3:7 idx 4")
+
+	v = ViolationError{Reason: ViolationReason("cheese reason"), Path: "a/b", Line: 3}
+	require.EqualError(t, v, "cheese reason in a/b:3")
+}
+
 func TestViolationErrorDetailPath(t *testing.T) {
 	t.Parallel()
 
@@ -51,3 +61,11 @@ func TestViolationErrorDetailPathSubject(t *testing.T) {
 	}
 	require.EqualError(t, v, "cheese reason in a/b: zoom 200x\nyaml: test")
 }
+
+func TestViolationReasonID(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "missing-phase-annotation", ViolationReasonMissingPhaseAnnotation.ID())
+	require.Equal(t, "invalid-condition-map", ViolationReasonInvalidConditionMap.ID())
+	require.Equal(t, "unknown", ViolationReason("cheese reason").ID())
+}
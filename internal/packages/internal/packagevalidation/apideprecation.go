@@ -0,0 +1,67 @@
+package packagevalidation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"package-operator.run/internal/apideprecation"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+// Validates that objects are not using APIs that are deprecated or removed in the given Kubernetes version.
+type ObjectAPIDeprecationValidator struct {
+	// Kubernetes version to validate against, e.g. "v1.27.3".
+	// Validation is skipped if empty.
+	KubernetesVersion string
+	// Also reports APIs that are deprecated, but still served in KubernetesVersion.
+	RejectDeprecated bool
+}
+
+var _ packagetypes.ObjectValidator = (*ObjectAPIDeprecationValidator)(nil)
+
+func (v *ObjectAPIDeprecationValidator) ValidateObjects(
+	ctx context.Context,
+	manifest *manifests.PackageManifest,
+	objects map[string][]unstructured.Unstructured,
+) error {
+	if len(v.KubernetesVersion) == 0 {
+		return nil
+	}
+
+	kubeVersion, err := version.ParseMajorMinor(v.KubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("parsing Kubernetes version: %w", err)
+	}
+
+	return ValidateEachObject(ctx, manifest, objects, func(
+		_ context.Context, path string, index int,
+		obj unstructured.Unstructured, _ *manifests.PackageManifest,
+	) error {
+		gvk := obj.GroupVersionKind()
+		api, ok := apideprecation.APIs[gvk]
+		if !ok {
+			return nil
+		}
+
+		var reason packagetypes.ViolationReason
+		switch {
+		case api.IsRemovedIn(kubeVersion):
+			reason = packagetypes.ViolationReasonAPIRemoved
+		case v.RejectDeprecated && kubeVersion.AtLeast(api.DeprecatedIn):
+			reason = packagetypes.ViolationReasonAPIDeprecated
+		default:
+			return nil
+		}
+
+		return packagetypes.ViolationError{
+			Reason:  reason,
+			Details: api.Describe(gvk),
+			Path:    path,
+			Index:   new(index),
+		}
+	})
+}
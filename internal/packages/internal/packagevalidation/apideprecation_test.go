@@ -0,0 +1,83 @@
+package packagevalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"package-operator.run/internal/apis/manifests"
+)
+
+func TestObjectAPIDeprecationValidator(t *testing.T) {
+	t.Parallel()
+
+	cronJob := unstructured.Unstructured{}
+	cronJob.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: "batch", Version: "v1beta1", Kind: "CronJob",
+	})
+	psp := unstructured.Unstructured{}
+	psp.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy",
+	})
+	deployment := unstructured.Unstructured{}
+	deployment.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: "apps", Version: "v1", Kind: "Deployment",
+	})
+	objects := map[string][]unstructured.Unstructured{
+		"test.yaml": {cronJob, psp, deployment},
+	}
+
+	tests := []struct {
+		name      string
+		validator *ObjectAPIDeprecationValidator
+		err       string
+	}{
+		{
+			name:      "no version",
+			validator: &ObjectAPIDeprecationValidator{},
+		},
+		{
+			name:      "still served",
+			validator: &ObjectAPIDeprecationValidator{KubernetesVersion: "v1.22.4"},
+		},
+		{
+			name: "deprecated",
+			validator: &ObjectAPIDeprecationValidator{
+				KubernetesVersion: "v1.22.4", RejectDeprecated: true,
+			},
+			err: "API deprecated in target Kubernetes version in test.yaml idx 0: " +
+				"batch/v1beta1 CronJob deprecated in v1.21, removed in v1.25, migrate to batch/v1\n" +
+				"API deprecated in target Kubernetes version in test.yaml idx 1: " +
+				"policy/v1beta1 PodSecurityPolicy deprecated in v1.21, removed in v1.25, no replacement available",
+		},
+		{
+			name:      "removed",
+			validator: &ObjectAPIDeprecationValidator{KubernetesVersion: "1.27.0+k3s1"},
+			err: "API removed in target Kubernetes version in test.yaml idx 0: " +
+				"batch/v1beta1 CronJob deprecated in v1.21, removed in v1.25, migrate to batch/v1\n" +
+				"API removed in target Kubernetes version in test.yaml idx 1: " +
+				"policy/v1beta1 PodSecurityPolicy deprecated in v1.21, removed in v1.25, no replacement available",
+		},
+		{
+			name:      "invalid version",
+			validator: &ObjectAPIDeprecationValidator{KubernetesVersion: "v11111"},
+			err:       `parsing Kubernetes version: illegal version string "v11111"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.validator.ValidateObjects(
+				context.Background(), &manifests.PackageManifest{}, objects)
+			if len(test.err) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagerender"
 	"package-operator.run/internal/packages/internal/packagetypes"
 )
 
@@ -18,6 +19,7 @@ import (
 var DefaultObjectValidators = ObjectValidatorList{
 	&ObjectDuplicateValidator{}, &ObjectGVKValidator{},
 	&ObjectLabelsValidator{}, &ObjectPhaseAnnotationValidator{},
+	&ObjectConditionMapValidator{},
 }
 
 // ObjectValidatorList runs a list of validators and joins all errors.
@@ -191,3 +193,33 @@ func (*ObjectLabelsValidator) validate(
 	}
 	return nil
 }
+
+// Validates that the condition map annotation can be parsed.
+// Part of DefaultObjectValidators: rendering a Package with an unparsable condition map
+// already failed before, so this reports the same Packages as violation instead.
+type ObjectConditionMapValidator struct{}
+
+var _ packagetypes.ObjectValidator = (*ObjectConditionMapValidator)(nil)
+
+func (v *ObjectConditionMapValidator) ValidateObjects(
+	ctx context.Context,
+	manifest *manifests.PackageManifest,
+	objects map[string][]unstructured.Unstructured,
+) error {
+	return ValidateEachObject(ctx, manifest, objects, v.validate)
+}
+
+func (*ObjectConditionMapValidator) validate(
+	_ context.Context, path string, index int,
+	obj unstructured.Unstructured, _ *manifests.PackageManifest,
+) error {
+	if err := packagerender.ValidateConditionMapAnnotation(&obj); err != nil {
+		return packagetypes.ViolationError{
+			Reason:  packagetypes.ViolationReasonInvalidConditionMap,
+			Details: err.Error(),
+			Path:    path,
+			Index:   new(index),
+		}
+	}
+	return nil
+}
@@ -101,3 +101,29 @@ func TestObjectLabelsValidator(t *testing.T) {
 	errString := `Labels invalid in test.yaml idx 1: metadata.labels: Invalid value: "/123": prefix part must be non-empty`
 	require.EqualError(t, err, errString)
 }
+
+func TestObjectConditionMapValidator(t *testing.T) {
+	t.Parallel()
+
+	cmv := &ObjectConditionMapValidator{}
+
+	failObj := unstructured.Unstructured{}
+	failObj.SetAnnotations(map[string]string{
+		manifests.PackageConditionMapAnnotation: "Available",
+	})
+
+	okObj := unstructured.Unstructured{}
+	okObj.SetAnnotations(map[string]string{
+		manifests.PackageConditionMapAnnotation: "Available => my-prefix/Available",
+	})
+
+	err := cmv.ValidateObjects(
+		context.Background(), &manifests.PackageManifest{},
+		map[string][]unstructured.Unstructured{
+			"test.yaml": {{}, failObj, okObj},
+		})
+
+	require.EqualError(t, err,
+		"The package-operator.run/condition-map annotation is invalid. in test.yaml idx 1: "+
+			"expected 2 part mapping got 1 in line 1")
+}
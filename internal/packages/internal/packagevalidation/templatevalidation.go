@@ -25,6 +25,12 @@ const testFixturesFolderName = ".test-fixtures"
 type TemplateTestValidator struct {
 	// Path to a folder containing the test fixtures for the package.
 	packageBaseFolderPath string
+	// Kubernetes version to check rendered objects for deprecated and removed APIs.
+	// Defaults to the Kubernetes version of each test case environment.
+	KubernetesVersion string
+	// Also rejects APIs that are deprecated, but still served in the Kubernetes version.
+	// By default only removed APIs are rejected.
+	RejectDeprecatedAPIs bool
 }
 
 // Creates a new TemplateTestValidator instance.
@@ -82,29 +88,25 @@ func (v TemplateTestValidator) runTestCase(
 	log := logr.FromContextOrDiscard(ctx)
 	pkg = pkg.DeepCopy()
 
-	configuration := map[string]any{}
-	if testCase.Context.Config != nil {
-		if err := json.Unmarshal(testCase.Context.Config.Raw, &configuration); err != nil {
-			return err
-		}
-	}
-
-	if _, err := packagemanifestvalidation.AdmitPackageConfiguration(ctx, configuration, pkg.Manifest, nil); err != nil {
+	tmplCtx, err := TestCaseRenderContext(ctx, pkg.Manifest, testCase)
+	if err != nil {
 		return err
 	}
-
-	tmplCtx := packagetypes.PackageRenderContext{
-		Package:      testCase.Context.Package,
-		Config:       configuration,
-		Images:       generateStaticImages(pkg.Manifest),
-		Dependencies: generateStaticDependencies(pkg.Manifest),
-		Environment:  testCase.Context.Environment,
-	}
 	if err := packagerender.RenderTemplates(ctx, pkg, tmplCtx); err != nil {
 		return err
 	}
+	kubernetesVersion := v.KubernetesVersion
+	if len(kubernetesVersion) == 0 {
+		kubernetesVersion = testCase.Context.Environment.Kubernetes.Version
+	}
 	pathObjects, pathFilteredIndex, err := packagerender.RenderObjectsWithFilterInfo(
-		ctx, pkg, tmplCtx, DefaultObjectValidators)
+		ctx, pkg, tmplCtx, ObjectValidatorList{
+			DefaultObjectValidators,
+			&ObjectAPIDeprecationValidator{
+				KubernetesVersion: kubernetesVersion,
+				RejectDeprecated:  v.RejectDeprecatedAPIs,
+			},
+		})
 	if err != nil {
 		return err
 	}
@@ -179,6 +181,32 @@ func (v TemplateTestValidator) runTestCase(
 	return errors.Join(violations...)
 }
 
+// Creates the PackageRenderContext of a template test case.
+// Images and dependencies are replaced with a static placeholder image.
+func TestCaseRenderContext(
+	ctx context.Context, manifest *manifests.PackageManifest,
+	testCase manifests.PackageManifestTestCaseTemplate,
+) (packagetypes.PackageRenderContext, error) {
+	configuration := map[string]any{}
+	if testCase.Context.Config != nil {
+		if err := json.Unmarshal(testCase.Context.Config.Raw, &configuration); err != nil {
+			return packagetypes.PackageRenderContext{}, err
+		}
+	}
+
+	if _, err := packagemanifestvalidation.AdmitPackageConfiguration(ctx, configuration, manifest, nil); err != nil {
+		return packagetypes.PackageRenderContext{}, err
+	}
+
+	return packagetypes.PackageRenderContext{
+		Package:      testCase.Context.Package,
+		Config:       configuration,
+		Images:       generateStaticImages(manifest),
+		Dependencies: generateStaticDependencies(manifest),
+		Environment:  testCase.Context.Environment,
+	}, nil
+}
+
 func renderTemplateFiles(
 	folder string,
 	fileMap packagetypes.Files,
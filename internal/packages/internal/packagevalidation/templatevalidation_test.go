@@ -181,6 +181,59 @@ File mismatch against fixture in file.yaml: Testcase "t1"
 	require.Equal(t, expectedErr, err.Error())
 }
 
+func TestTemplateTestValidator_deprecatedAPIs(t *testing.T) {
+	t.Parallel()
+
+	packageManifest := &manifests.PackageManifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-pkg",
+		},
+		Spec: manifests.PackageManifestSpec{
+			Phases: []manifests.PackageManifestPhase{
+				{Name: "tesxx"},
+			},
+		},
+		Test: manifests.PackageManifestTest{
+			Template: []manifests.PackageManifestTestCaseTemplate{
+				{Name: "t1"},
+			},
+		},
+	}
+	pkg := &packagetypes.Package{
+		Manifest: packageManifest,
+		Files: packagetypes.Files{
+			// Deprecated in v1.19, removed in v1.22.
+			"ingress.yaml": []byte(`apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: test
+  annotations:
+    package-operator.run/phase: tesxx
+`),
+		},
+	}
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	// Deprecated APIs are accepted by default.
+	ttv := NewTemplateTestValidator(t.TempDir())
+	ttv.KubernetesVersion = "v1.20.0"
+	require.NoError(t, ttv.ValidatePackage(ctx, pkg))
+
+	ttv = NewTemplateTestValidator(t.TempDir())
+	ttv.KubernetesVersion = "v1.20.0"
+	ttv.RejectDeprecatedAPIs = true
+	err := ttv.ValidatePackage(ctx, pkg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), string(packagetypes.ViolationReasonAPIDeprecated))
+
+	// Removed APIs are always rejected.
+	ttv = NewTemplateTestValidator(t.TempDir())
+	ttv.KubernetesVersion = "v1.22.0"
+	err = ttv.ValidatePackage(ctx, pkg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), string(packagetypes.ViolationReasonAPIRemoved))
+}
+
 func Test_generateStaticImages(t *testing.T) {
 	t.Parallel()
 	manifest := &manifests.PackageManifest{
@@ -0,0 +1,68 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/version"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/apideprecation"
+	"package-operator.run/internal/apis/manifests"
+)
+
+// Ensures that objects are not using APIs that are no longer served by the Kubernetes version of the cluster.
+// Other than the APIExistence check, the violation names the API to migrate to.
+// The Kubernetes version is taken from the environment, no checks are done until it is known.
+type APIDeprecation struct {
+	lock              sync.RWMutex
+	kubernetesVersion *version.Version
+}
+
+var _ phasesChecker = (*APIDeprecation)(nil)
+
+func NewAPIDeprecation() *APIDeprecation {
+	return &APIDeprecation{}
+}
+
+func (p *APIDeprecation) SetEnvironment(env *manifests.PackageEnvironment) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Unparsable versions disable the check instead of blocking every ObjectSet.
+	p.kubernetesVersion, _ = version.ParseMajorMinor(env.Kubernetes.Version)
+}
+
+func (p *APIDeprecation) Check(
+	ctx context.Context, phases []corev1alpha1.ObjectSetTemplatePhase,
+) (violations []Violation, err error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.kubernetesVersion == nil {
+		return nil, nil
+	}
+
+	for _, phase := range phases {
+		if len(phase.Class) > 0 {
+			// Objects are reconciled by a remote-phase-manager, maybe on another cluster.
+			continue
+		}
+
+		for _, objectSetObject := range phase.Objects {
+			obj := &objectSetObject.Object
+			gvk := obj.GroupVersionKind()
+			api, ok := apideprecation.APIs[gvk]
+			if !ok || !api.IsRemovedIn(p.kubernetesVersion) {
+				continue
+			}
+
+			v := []Violation{{
+				Error: fmt.Sprintf("API not served by Kubernetes v%s: %s.", p.kubernetesVersion, api.Describe(gvk)),
+			}}
+			addPositionToViolations(NewContextWithPhase(ctx, phase), obj, &v)
+			violations = append(violations, v...)
+		}
+	}
+	return
+}
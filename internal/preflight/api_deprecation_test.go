@@ -0,0 +1,72 @@
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/apis/manifests"
+)
+
+func TestAPIDeprecation(t *testing.T) {
+	t.Parallel()
+
+	cronJob := corev1alpha1.ObjectSetObject{}
+	cronJob.Object.SetAPIVersion("batch/v1beta1")
+	cronJob.Object.SetKind("CronJob")
+	cronJob.Object.SetName("test")
+
+	remoteCronJob := corev1alpha1.ObjectSetObject{}
+	remoteCronJob.Object.SetAPIVersion("batch/v1beta1")
+	remoteCronJob.Object.SetKind("CronJob")
+	remoteCronJob.Object.SetName("remote")
+
+	deployment := corev1alpha1.ObjectSetObject{}
+	deployment.Object.SetAPIVersion("apps/v1")
+	deployment.Object.SetKind("Deployment")
+	deployment.Object.SetName("test")
+
+	phases := []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "phase1", Objects: []corev1alpha1.ObjectSetObject{cronJob, deployment}},
+		{Name: "phase2", Class: "remote", Objects: []corev1alpha1.ObjectSetObject{remoteCronJob}},
+	}
+
+	tests := []struct {
+		name       string
+		version    string
+		violations []Violation
+	}{
+		{
+			name: "unknown version",
+		},
+		{
+			name:    "still served",
+			version: "v1.24.3",
+		},
+		{
+			name:    "removed",
+			version: "v1.27.3",
+			violations: []Violation{{
+				Position: `Phase "phase1", CronJob /test`,
+				Error: "API not served by Kubernetes v1.27: " +
+					"batch/v1beta1 CronJob deprecated in v1.21, removed in v1.25, migrate to batch/v1.",
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := NewAPIDeprecation()
+			p.SetEnvironment(&manifests.PackageEnvironment{
+				Kubernetes: manifests.PackageEnvironmentKubernetes{Version: test.version},
+			})
+			v, err := p.Check(context.Background(), phases)
+			require.NoError(t, err)
+			assert.Equal(t, test.violations, v)
+		})
+	}
+}
@@ -38,7 +38,10 @@ func (v *Violation) String() string {
 
 type contextKey string
 
-const phaseContextKey contextKey = "_phase"
+const (
+	phaseContextKey contextKey = "_phase"
+	ownerContextKey contextKey = "_owner"
+)
 
 func NewContextWithPhase(ctx context.Context, phase corev1alpha1.ObjectSetTemplatePhase) context.Context {
 	return context.WithValue(ctx, phaseContextKey, phase)
@@ -54,6 +57,17 @@ func phaseFromContext(ctx context.Context) (
 	return phaseI.(corev1alpha1.ObjectSetTemplatePhase), true
 }
 
+// NewContextWithOwner stores the owner of the checked objects,
+// so phase checkers can default namespaces.
+func NewContextWithOwner(ctx context.Context, owner client.Object) context.Context {
+	return context.WithValue(ctx, ownerContextKey, owner)
+}
+
+func ownerFromContext(ctx context.Context) (owner client.Object, found bool) {
+	owner, found = ctx.Value(ownerContextKey).(client.Object)
+	return
+}
+
 func addPositionToViolations(
 	ctx context.Context, obj client.Object, vs *[]Violation,
 ) {
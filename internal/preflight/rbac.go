@@ -0,0 +1,165 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/autoimpersonation"
+)
+
+// RBACVerbs are the verbs required to manage an object throughout its lifecycle.
+var RBACVerbs = []string{"create", "update", "patch", "delete"}
+
+// Ensures that the identity behind the given client is allowed to manage all objects
+// before the first phase is applied, so a rollout does not stall halfway on Forbidden errors.
+// When the client impersonates another identity, permissions of the impersonated identity are checked.
+// When objects of the owner are reconciled as a ServiceAccount, permissions of the ServiceAccount are checked.
+type RBAC struct {
+	client                 client.Writer
+	restMapper             meta.RESTMapper
+	serviceAccountResolver ServiceAccountResolver
+}
+
+// ServiceAccountResolver looks up the ServiceAccount objects of an owner are reconciled as.
+type ServiceAccountResolver interface {
+	ServiceAccountFor(ctx context.Context, owner client.Object) (*types.NamespacedName, error)
+}
+
+var _ phasesChecker = (*RBAC)(nil)
+
+func NewRBAC(
+	client client.Writer, restMapper meta.RESTMapper,
+	serviceAccountResolver ServiceAccountResolver,
+) *RBAC {
+	return &RBAC{
+		client:                 client,
+		restMapper:             restMapper,
+		serviceAccountResolver: serviceAccountResolver,
+	}
+}
+
+func (p *RBAC) Check(
+	ctx context.Context, phases []corev1alpha1.ObjectSetTemplatePhase,
+) (violations []Violation, err error) {
+	var (
+		defaultNamespace string
+		serviceAccount   *types.NamespacedName
+	)
+	if owner, ok := ownerFromContext(ctx); ok {
+		defaultNamespace = owner.GetNamespace()
+		if p.serviceAccountResolver != nil {
+			serviceAccount, err = p.serviceAccountResolver.ServiceAccountFor(ctx, owner)
+			if err != nil {
+				return nil, fmt.Errorf("resolving ServiceAccount: %w", err)
+			}
+		}
+	}
+
+	for _, phase := range phases {
+		if len(phase.Class) > 0 {
+			// Objects are reconciled by a remote-phase-manager with its own identity.
+			continue
+		}
+
+		for _, objectSetObject := range phase.Objects {
+			obj := &objectSetObject.Object
+			missing, err := p.missingVerbs(ctx, obj, defaultNamespace, serviceAccount)
+			if err != nil {
+				return nil, err
+			}
+			if len(missing) == 0 {
+				continue
+			}
+
+			v := []Violation{{
+				Error: fmt.Sprintf("Missing permissions to %s.", strings.Join(missing, ", ")),
+			}}
+			addPositionToViolations(NewContextWithPhase(ctx, phase), obj, &v)
+			violations = append(violations, v...)
+		}
+	}
+	return
+}
+
+func (p *RBAC) missingVerbs(
+	ctx context.Context, obj client.Object, defaultNamespace string,
+	serviceAccount *types.NamespacedName,
+) (missing []string, err error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	mapping, err := p.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// covered by APIsExistence check
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var namespace string
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = obj.GetNamespace()
+		if len(namespace) == 0 {
+			namespace = defaultNamespace
+		}
+	}
+
+	for _, verb := range RBACVerbs {
+		attrs := &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      verb,
+			Group:     mapping.Resource.Group,
+			Version:   mapping.Resource.Version,
+			Resource:  mapping.Resource.Resource,
+		}
+		if verb != "create" {
+			// Object names are not known to the authorizer on create.
+			attrs.Name = obj.GetName()
+		}
+
+		allowed, err := p.allowed(ctx, attrs, serviceAccount)
+		if err != nil {
+			return nil, fmt.Errorf("reviewing access to %s %s: %w",
+				gvk.Kind, types.NamespacedName{Namespace: namespace, Name: obj.GetName()}, err)
+		}
+		if !allowed {
+			missing = append(missing, verb)
+		}
+	}
+	return missing, nil
+}
+
+func (p *RBAC) allowed(
+	ctx context.Context, attrs *authorizationv1.ResourceAttributes,
+	serviceAccount *types.NamespacedName,
+) (bool, error) {
+	if serviceAccount == nil {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: attrs,
+			},
+		}
+		if err := p.client.Create(ctx, review); err != nil {
+			return false, fmt.Errorf("creating SelfSubjectAccessReview: %w", err)
+		}
+		return review.Status.Allowed, nil
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attrs,
+			User:               autoimpersonation.Username(*serviceAccount),
+			Groups:             autoimpersonation.Groups(*serviceAccount),
+		},
+	}
+	if err := p.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("creating SubjectAccessReview: %w", err)
+	}
+	return review.Status.Allowed, nil
+}
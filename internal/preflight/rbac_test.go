@@ -0,0 +1,158 @@
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/testutil"
+	"package-operator.run/internal/testutil/restmappermock"
+)
+
+func TestRBAC(t *testing.T) {
+	t.Parallel()
+
+	obj := corev1alpha1.ObjectSetObject{}
+	obj.Object.SetName("test")
+	obj.Object.SetKind("Hans")
+
+	remoteObj := corev1alpha1.ObjectSetObject{}
+	remoteObj.Object.SetName("remote")
+	remoteObj.Object.SetKind("Hans")
+
+	phases := []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "phase1", Objects: []corev1alpha1.ObjectSetObject{obj}},
+		{Name: "phase2", Class: "remote", Objects: []corev1alpha1.ObjectSetObject{remoteObj}},
+	}
+
+	owner := &unstructured.Unstructured{}
+	owner.SetNamespace("test-ns")
+
+	rm := &restmappermock.RestMapperMock{}
+	rm.On("RESTMapping").Return(&meta.RESTMapping{
+		Resource: schema.GroupVersionResource{Version: "v1", Resource: "hanses"},
+		Scope:    meta.RESTScopeNamespace,
+	}, nil)
+
+	c := testutil.NewClient()
+	var reviewed []authorizationv1.ResourceAttributes
+	c.
+		On("Create", mock.Anything, mock.AnythingOfType("*v1.SelfSubjectAccessReview"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			review := args.Get(1).(*authorizationv1.SelfSubjectAccessReview)
+			reviewed = append(reviewed, *review.Spec.ResourceAttributes)
+			review.Status.Allowed = review.Spec.ResourceAttributes.Verb == "create" ||
+				review.Spec.ResourceAttributes.Verb == "update"
+		}).
+		Return(nil)
+
+	ctx := NewContextWithOwner(context.Background(), owner)
+	v, err := NewRBAC(c, rm, nil).Check(ctx, phases)
+	require.NoError(t, err)
+	if assert.Len(t, v, 1) {
+		assert.Equal(t, `Phase "phase1", Hans /test: Missing permissions to patch, delete.`, v[0].String())
+	}
+
+	// Remote phases are skipped.
+	require.Len(t, reviewed, len(RBACVerbs))
+	for _, attrs := range reviewed {
+		assert.Equal(t, "test-ns", attrs.Namespace)
+		assert.Equal(t, "hanses", attrs.Resource)
+		if attrs.Verb == "create" {
+			assert.Empty(t, attrs.Name)
+		} else {
+			assert.Equal(t, "test", attrs.Name)
+		}
+	}
+}
+
+func TestRBAC_noMatch(t *testing.T) {
+	t.Parallel()
+
+	obj := corev1alpha1.ObjectSetObject{}
+	obj.Object.SetName("test")
+	obj.Object.SetKind("Hans")
+
+	phases := []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "phase1", Objects: []corev1alpha1.ObjectSetObject{obj}},
+	}
+
+	rm := &restmappermock.RestMapperMock{}
+	rm.On("RESTMapping").Return((*meta.RESTMapping)(nil), &meta.NoKindMatchError{})
+
+	c := testutil.NewClient()
+
+	v, err := NewRBAC(c, rm, nil).Check(context.Background(), phases)
+	require.NoError(t, err)
+	assert.Empty(t, v)
+	c.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+type serviceAccountResolverStub struct{ sa *types.NamespacedName }
+
+func (s serviceAccountResolverStub) ServiceAccountFor(
+	context.Context, client.Object,
+) (*types.NamespacedName, error) {
+	return s.sa, nil
+}
+
+func TestRBAC_serviceAccount(t *testing.T) {
+	t.Parallel()
+
+	obj := corev1alpha1.ObjectSetObject{}
+	obj.Object.SetName("test")
+	obj.Object.SetKind("Hans")
+
+	phases := []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "phase1", Objects: []corev1alpha1.ObjectSetObject{obj}},
+	}
+
+	owner := &unstructured.Unstructured{}
+	owner.SetNamespace("test-ns")
+
+	rm := &restmappermock.RestMapperMock{}
+	rm.On("RESTMapping").Return(&meta.RESTMapping{
+		Resource: schema.GroupVersionResource{Version: "v1", Resource: "hanses"},
+		Scope:    meta.RESTScopeNamespace,
+	}, nil)
+
+	c := testutil.NewClient()
+	var reviewed []authorizationv1.SubjectAccessReviewSpec
+	c.
+		On("Create", mock.Anything, mock.AnythingOfType("*v1.SubjectAccessReview"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			review := args.Get(1).(*authorizationv1.SubjectAccessReview)
+			reviewed = append(reviewed, review.Spec)
+			review.Status.Allowed = review.Spec.ResourceAttributes.Verb != "delete"
+		}).
+		Return(nil)
+
+	ctx := NewContextWithOwner(context.Background(), owner)
+	v, err := NewRBAC(c, rm, serviceAccountResolverStub{
+		sa: &types.NamespacedName{Namespace: "test-ns", Name: "tenant"},
+	}).Check(ctx, phases)
+	require.NoError(t, err)
+	if assert.Len(t, v, 1) {
+		assert.Equal(t, `Phase "phase1", Hans /test: Missing permissions to delete.`, v[0].String())
+	}
+
+	require.Len(t, reviewed, len(RBACVerbs))
+	for _, spec := range reviewed {
+		assert.Equal(t, "system:serviceaccount:test-ns:tenant", spec.User)
+		assert.Equal(t, []string{
+			"system:serviceaccounts", "system:serviceaccounts:test-ns", "system:authenticated",
+		}, spec.Groups)
+	}
+	c.AssertNotCalled(t, "Create", mock.Anything,
+		mock.AnythingOfType("*v1.SelfSubjectAccessReview"), mock.Anything)
+}
@@ -27,6 +27,16 @@ func (o *ObjectDeploymentMock) SetSpecPaused(paused bool) {
 	o.Called(paused)
 }
 
+func (o *ObjectDeploymentMock) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	args := o.Called()
+	res, _ := args.Get(0).(*corev1alpha1.ServiceAccountReference)
+	return res
+}
+
+func (o *ObjectDeploymentMock) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	o.Called(serviceAccount)
+}
+
 func (o *ObjectDeploymentMock) SetStatusRevision(r int64) {
 	o.Called(r)
 }
@@ -141,6 +151,16 @@ func (o *ObjectSetDeploymentMock) SetSpecPaused(paused bool) {
 	o.Called(paused)
 }
 
+func (o *ObjectSetDeploymentMock) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	args := o.Called()
+	res, _ := args.Get(0).(*corev1alpha1.ServiceAccountReference)
+	return res
+}
+
+func (o *ObjectSetDeploymentMock) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	o.Called(serviceAccount)
+}
+
 func (o *ObjectSetDeploymentMock) SetStatusRevision(r int64) {
 	o.Called(r)
 }
@@ -143,6 +143,16 @@ func (o *ObjectSetMock) GetSpecRevision() int64 {
 	return args.Get(0).(int64)
 }
 
+func (o *ObjectSetMock) GetSpecServiceAccount() *corev1alpha1.ServiceAccountReference {
+	args := o.Called()
+	res, _ := args.Get(0).(*corev1alpha1.ServiceAccountReference)
+	return res
+}
+
+func (o *ObjectSetMock) SetSpecServiceAccount(serviceAccount *corev1alpha1.ServiceAccountReference) {
+	o.Called(serviceAccount)
+}
+
 func (o *ObjectSetMock) SetSpecRevision(revision int64) {
 	o.Called(revision)
 }
@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/crane"
@@ -24,6 +25,7 @@ type InMemoryRegistry struct {
 type (
 	inMemoryRegistryWriter struct {
 		resp *http.Response
+		body bytes.Buffer
 	}
 	inMemoryRegistryRoundTripper struct {
 		handler http.Handler
@@ -42,7 +44,7 @@ func NewInMemoryRegistry() *InMemoryRegistry {
 func (w *inMemoryRegistryWriter) Header() http.Header { return w.resp.Header }
 
 func (w *inMemoryRegistryWriter) Write(data []byte) (int, error) {
-	w.resp.Body = io.NopCloser(bytes.NewBuffer(data))
+	w.body.Write(data)
 
 	return len(data), nil
 }
@@ -54,8 +56,13 @@ func (t inMemoryRegistryRoundTripper) RoundTrip(req *http.Request) (*http.Respon
 		req.Body = io.NopCloser(&bytes.Buffer{})
 	}
 	resp := &http.Response{Status: "ok", StatusCode: http.StatusOK, Header: http.Header{}, Request: req}
-	w := &inMemoryRegistryWriter{resp}
+	w := &inMemoryRegistryWriter{resp: resp}
 	t.handler.ServeHTTP(w, req)
+	resp.Body = io.NopCloser(&w.body)
+	// Clients verify the size of blobs they read.
+	if cl, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		resp.ContentLength = cl
+	}
 
 	return resp, nil
 }